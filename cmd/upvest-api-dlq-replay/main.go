@@ -0,0 +1,78 @@
+// Command upvest-api-dlq-replay re-publishes the messages of a dead-letter topic
+// to the topics they came from, once the cause of their failure is fixed.
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/event"
+	"github.com/segmentio/kafka-go"
+	log "github.com/sirupsen/logrus"
+)
+
+type Config struct {
+	Broker      string
+	Topic       string
+	GroupID     string
+	IdleTimeout time.Duration
+}
+
+func main() {
+	// Setup Logging
+	log.SetFormatter(&log.JSONFormatter{})
+	log.SetLevel(log.InfoLevel)
+
+	// Parse configuration. The connection is configured like the services',
+	// from KAFKA_CONFIG_FILE and the KAFKA_* variables; the flags override it.
+	config := Config{}
+	flag.StringVar(&config.Broker, "broker", "", "Kafka broker address, or comma-separated addresses (default KAFKA_BROKERS)")
+	flag.StringVar(&config.Topic, "topic", "", "dead-letter topic to replay (default KAFKA_TOPIC"+event.DeadLetterTopicSuffix+")")
+	flag.StringVar(&config.GroupID, "group", "upvest-api-dlq-replay", "consumer group that tracks the replayed messages")
+	flag.DurationVar(&config.IdleTimeout, "idle-timeout", 10*time.Second, "stop once no message arrived for this long")
+	flag.Parse()
+
+	if config.IdleTimeout <= 0 {
+		log.Fatalf("idle-timeout must be positive, got %s", config.IdleTimeout)
+	}
+
+	lookupEnv := os.LookupEnv
+	if config.Broker != "" {
+		lookupEnv = func(name string) (string, bool) {
+			if name == "KAFKA_BROKERS" {
+				return config.Broker, true
+			}
+			return os.LookupEnv(name)
+		}
+	}
+	kafkaConfig, err := event.LoadKafkaConfig(os.Getenv("KAFKA_CONFIG_FILE"), lookupEnv)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if config.Topic == "" {
+		config.Topic = kafkaConfig.Topic + event.DeadLetterTopicSuffix
+	}
+
+	reader, err := kafkaConfig.NewReader(config.Topic, config.GroupID)
+	if err != nil {
+		log.Fatalf("failed to initialize Kafka reader: %v", err)
+	}
+	defer reader.Close()
+
+	// The writer has no topic, so that every message goes back to its own.
+	writer, err := kafkaConfig.NewWriter("", kafka.RequireAll)
+	if err != nil {
+		log.Fatalf("failed to initialize Kafka writer: %v", err)
+	}
+	defer writer.Close()
+
+	log.Infof("replaying %s on %s", config.Topic, strings.Join(kafkaConfig.Brokers, ","))
+	replayed, err := event.ReplayDeadLetters(context.Background(), reader, writer, config.IdleTimeout)
+	if err != nil {
+		log.Fatalf("failed to replay %s after %d messages: %v", config.Topic, replayed, err)
+	}
+	log.Infof("replayed %d messages from %s", replayed, config.Topic)
+}
@@ -0,0 +1,105 @@
+// Command upvest-api-instruments-loader bulk-loads instrument reference data
+// from a CSV file, so that sandbox environments can be seeded offline.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/referencedata"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/repository"
+	log "github.com/sirupsen/logrus"
+)
+
+type Config struct {
+	DbDSN       string
+	File        string
+	BatchSize   int
+	SkipInvalid bool
+	DryRun      bool
+}
+
+func main() {
+	// Setup Logging
+	log.SetFormatter(&log.JSONFormatter{})
+	log.SetLevel(log.InfoLevel)
+
+	// Parse configuration
+	config := Config{}
+	flag.StringVar(&config.File, "file", "schema/seeds/instruments.csv", "CSV file with instrument reference data")
+	flag.StringVar(&config.DbDSN, "dsn", os.Getenv("DB_DSN"), "Postgres connection string (defaults to $DB_DSN)")
+	flag.IntVar(&config.BatchSize, "batch-size", 500, "number of instruments written per transaction")
+	flag.BoolVar(&config.SkipInvalid, "skip-invalid", false, "load the valid rows even if some rows are invalid")
+	flag.BoolVar(&config.DryRun, "dry-run", false, "validate the file without writing to the database")
+	flag.Parse()
+
+	if config.BatchSize <= 0 {
+		log.Fatalf("batch-size must be positive, got %d", config.BatchSize)
+	}
+
+	instruments, err := readInstruments(config.File, config.SkipInvalid)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Infof("read %d valid instruments from %s", len(instruments), config.File)
+
+	if config.DryRun {
+		return
+	}
+
+	// Init Database
+	db, err := initDatabase(config.DbDSN)
+	if err != nil {
+		log.Fatalf("failed to initialize database: %v", err)
+	}
+	defer db.Close()
+
+	changed, err := load(context.Background(), repository.NewInstrumentRepository(db), instruments, config.BatchSize)
+	if err != nil {
+		log.Fatalf("failed to load instruments: %v", err)
+	}
+	log.Infof("loaded %d instruments, %d new or changed", len(instruments), changed)
+}
+
+// readInstruments reads and validates the file. Invalid rows are logged; they
+// abort the load unless skipInvalid is set.
+func readInstruments(path string, skipInvalid bool) ([]domain.Instrument, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	instruments, rowErrors, err := referencedata.ReadInstruments(file)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rowErr := range rowErrors {
+		log.Warnf("%s: %v", path, rowErr)
+	}
+	if len(rowErrors) > 0 && !skipInvalid {
+		return nil, fmt.Errorf("%s has %d invalid rows; fix them or pass -skip-invalid", path, len(rowErrors))
+	}
+
+	return instruments, nil
+}
+
+// load upserts the instruments in batches and returns how many were new or changed.
+func load(ctx context.Context, repo repository.InstrumentRepository, instruments []domain.Instrument, batchSize int) (int, error) {
+	changed := 0
+	for start := 0; start < len(instruments); start += batchSize {
+		end := min(start+batchSize, len(instruments))
+
+		n, err := repo.UpsertInstruments(ctx, instruments[start:end])
+		if err != nil {
+			return changed, err
+		}
+		changed += n
+		log.Infof("loaded instruments %d-%d", start+1, end)
+	}
+	return changed, nil
+}
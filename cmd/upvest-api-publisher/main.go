@@ -1,16 +1,50 @@
 package main
 
 import (
+	"context"
+	"crypto/rand"
 	"net/http"
 	"os"
+	"time"
 
+	"github.com/ashwingopalsamy/upvest-api/internal/event"
+	"github.com/ashwingopalsamy/upvest-api/internal/middleware"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/documents"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/screening"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/sepa"
+	"github.com/ashwingopalsamy/upvest-api/internal/util/shutdown"
 	log "github.com/sirupsen/logrus"
 )
 
-const publisherPortAddr = ":8080"
+const (
+	publisherPortAddr = ":8080"
+	// backOfficePortAddr serves the operations API. It is not authenticated and
+	// must not be exposed outside the internal network.
+	backOfficePortAddr = ":8081"
+
+	// drainTimeout bounds how long in-flight requests may take on shutdown.
+	drainTimeout = 20 * time.Second
+
+	// Slow clients are cut off, leaving room for 10 MiB document uploads. A
+	// request carrying an Idempotency-Key holds the key for longer than it may
+	// take, and renews it while it runs.
+	readHeaderTimeout = 10 * time.Second
+	readTimeout       = time.Minute
+	writeTimeout      = 2 * time.Minute
+	idempotencyLease  = 2 * writeTimeout
+
+	defaultDocumentsDir = "/var/lib/upvest-api/documents"
+)
 
 type Config struct {
-	DbDSN string
+	DbDSN          string
+	CursorSecret   string
+	PayoutDebtor   sepa.Debtor
+	DocumentsDir   string
+	ScreeningLists string
+	EventEncoding  string
+	// KafkaConfigFile is read before the KAFKA_* variables, which override it.
+	KafkaConfigFile string
 }
 
 func main() {
@@ -21,26 +55,142 @@ func main() {
 
 	// Parse configuration
 	config := Config{
-		DbDSN: os.Getenv("DB_DSN"),
+		DbDSN:        os.Getenv("DB_DSN"),
+		CursorSecret: os.Getenv("CURSOR_SECRET"),
+		PayoutDebtor: sepa.Debtor{
+			Name: os.Getenv("PAYOUT_DEBTOR_NAME"),
+			IBAN: os.Getenv("PAYOUT_DEBTOR_IBAN"),
+			BIC:  os.Getenv("PAYOUT_DEBTOR_BIC"),
+		},
+		DocumentsDir:    os.Getenv("DOCUMENTS_DIR"),
+		ScreeningLists:  os.Getenv("SCREENING_LISTS"),
+		EventEncoding:   os.Getenv("EVENT_ENCODING"),
+		KafkaConfigFile: os.Getenv("KAFKA_CONFIG_FILE"),
+	}
+	if err := config.PayoutDebtor.Validate(); err != nil {
+		log.Warnf("withdrawal export is disabled: %v", err)
+	}
+	kafkaConfig, err := event.LoadKafkaConfig(config.KafkaConfigFile, os.LookupEnv)
+	if err != nil {
+		log.Fatal(err)
 	}
 
+	// Stop on SIGINT or SIGTERM
+	ctx, stop := shutdown.OnSignal()
+	defer stop()
+
 	// Init Database
 	db, err := initDatabase(config.DbDSN)
 	if err != nil {
 		log.Fatalf("failed to initialize database: %v", err)
 	}
-	defer db.Close()
 
 	// Init Kafka Publisher
-	initKafkaPublisher()
-	defer publisher.Close()
+	initKafkaPublisher(kafkaConfig, config.EventEncoding)
+
+	// Init Outbox Relay
+	relayCtx, stopRelay := context.WithCancel(context.Background())
+	relayDone := initOutboxRelay(relayCtx, db)
+
+	// Init Document Storage
+	storage := initDocumentStorage(config.DocumentsDir)
+	log.Warn("no virus scanner is configured, uploaded documents are not scanned")
+
+	// Init Sanctions and PEP Screening
+	screener := initScreener(config.ScreeningLists)
 
 	// Create and start the HTTP server
-	server := NewServer(db, publisher)
+	server := &http.Server{
+		Addr: publisherPortAddr,
+		Handler: NewServer(db, middleware.NewCursorCodec(cursorSecret(config.CursorSecret)), storage, documents.NoScan,
+			screener),
+		ReadHeaderTimeout: readHeaderTimeout,
+		ReadTimeout:       readTimeout,
+		WriteTimeout:      writeTimeout,
+	}
+
+	backOfficeServer := &http.Server{
+		Addr:              backOfficePortAddr,
+		Handler:           NewBackOfficeServer(db, config.PayoutDebtor),
+		ReadHeaderTimeout: readHeaderTimeout,
+		ReadTimeout:       readTimeout,
+		WriteTimeout:      writeTimeout,
+	}
+
+	// Init HTTP Servers. Either server failing stops the other one as well.
+	log.Infof("starting back-office server on %s", backOfficePortAddr)
+	backOfficeDone := make(chan struct{})
+	go func() {
+		defer close(backOfficeDone)
+		if err := shutdown.Serve(ctx, backOfficeServer, drainTimeout); err != nil {
+			log.Errorf("back-office HTTP server stopped: %v", err)
+		}
+		stop()
+	}()
 
-	// Init HTTP Server
 	log.Infof("starting server on %s", publisherPortAddr)
-	if err := http.ListenAndServe(publisherPortAddr, server); err != nil {
-		log.Fatalf("failed to start server: %v", err)
+	if err := shutdown.Serve(ctx, server, drainTimeout); err != nil {
+		log.Errorf("HTTP server stopped: %v", err)
+	}
+	stop()
+	<-backOfficeDone
+
+	// Tear down in reverse order. The relay keeps running until the requests
+	// are drained, so that it picks up the events they committed; its last
+	// batch is published before the writer is closed.
+	log.Info("shutting down Upvest API service")
+	stopRelay()
+	<-relayDone
+	if err := publisher.Close(); err != nil {
+		log.Errorf("failed to close Kafka publisher: %v", err)
+	}
+	if err := db.Close(); err != nil {
+		log.Errorf("failed to close database: %v", err)
+	}
+	log.Info("Upvest API service stopped")
+}
+
+// cursorSecret returns the key used to sign pagination cursors. Without a
+// configured secret a random one is used, so cursors do not survive restarts
+// and are not portable between instances.
+func cursorSecret(configured string) []byte {
+	if configured != "" {
+		return []byte(configured)
+	}
+
+	log.Warn("CURSOR_SECRET is not set, using a random secret for pagination cursors")
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		log.Fatalf("failed to generate cursor secret: %v", err)
+	}
+	return secret
+}
+
+// initDocumentStorage opens the local storage for document files, by default
+// below /var/lib/upvest-api/documents.
+func initDocumentStorage(dir string) documents.Storage {
+	if dir == "" {
+		dir = defaultDocumentsDir
+	}
+
+	storage, err := documents.NewLocalStorage(dir)
+	if err != nil {
+		log.Fatalf("failed to initialize document storage: %v", err)
+	}
+	return storage
+}
+
+// initScreener loads the sanctions and PEP lists users are screened against on
+// creation and on changes of their name or birth date.
+func initScreener(paths string) *screening.Screener {
+	screener, err := screening.LoadScreener(paths)
+	if err != nil {
+		log.Fatalf("failed to load screening lists: %v", err)
+	}
+	if len(screener.Lists()) == 0 {
+		log.Warn("SCREENING_LISTS is not set, users are not screened against sanctions and PEP lists")
+	} else {
+		log.Infof("screening users against %v", screener.Lists())
 	}
+	return screener
 }
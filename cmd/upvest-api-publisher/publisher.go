@@ -1,13 +1,39 @@
 package main
 
 import (
+	"context"
+	"database/sql"
+
 	"github.com/ashwingopalsamy/upvest-api/internal/event"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/repository"
 	log "github.com/sirupsen/logrus"
 )
 
 var publisher *event.Publisher
 
-func initKafkaPublisher() {
-	publisher = event.NewPublisher("kafka:9092", "user-events")
-	log.Info("Kafka publisher initialized")
+// initKafkaPublisher writes events to the configured topic in the configured
+// encoding, by default as JSON envelopes.
+func initKafkaPublisher(kafkaConfig event.KafkaConfig, encoding string) {
+	eventEncoding, err := event.ParseEncoding(encoding)
+	if err != nil {
+		log.Fatalf("invalid EVENT_ENCODING %q", encoding)
+	}
+	publisher, err = event.NewPublisher(kafkaConfig, eventEncoding)
+	if err != nil {
+		log.Fatalf("failed to initialize Kafka publisher: %v", err)
+	}
+	log.Infof("Kafka publisher initialized for %s on %v", kafkaConfig.Topic, kafkaConfig.Brokers)
+}
+
+// initOutboxRelay starts draining the outbox table to Kafka in the background
+// until ctx is cancelled. The returned channel is closed once the relay stopped.
+func initOutboxRelay(ctx context.Context, db *sql.DB) <-chan struct{} {
+	relay := event.NewRelay(repository.NewOutboxRepository(db), publisher, event.DefaultRelayConfig())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		relay.Run(ctx)
+	}()
+	log.Info("outbox relay started")
+	return done
 }
@@ -4,26 +4,167 @@ import (
 	"database/sql"
 	"net/http"
 
-	"github.com/ashwingopalsamy/upvest-api/internal/event"
 	"github.com/ashwingopalsamy/upvest-api/internal/middleware"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/documents"
 	"github.com/ashwingopalsamy/upvest-api/internal/pkg/handler"
 	"github.com/ashwingopalsamy/upvest-api/internal/pkg/repository"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/screening"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/sepa"
 	"github.com/gorilla/mux"
 )
 
-func NewServer(db *sql.DB, publisher *event.Publisher) http.Handler {
+// NewServer returns the handler of the client API.
+func NewServer(db *sql.DB, cursors *middleware.CursorCodec, storage documents.Storage, scanner documents.Scanner,
+	screener *screening.Screener) http.Handler {
 	router := mux.NewRouter()
 
 	userRepo := repository.NewUserRepository(db)
-	userHandler := handler.NewUserHandler(userRepo, publisher)
+	userHandler := handler.NewUserHandler(userRepo, cursors, screener)
+
+	accountRepo := repository.NewAccountRepository(db)
+	accountHandler := handler.NewAccountHandler(accountRepo, cursors)
+
+	instrumentRepo := repository.NewInstrumentRepository(db)
+	instrumentHandler := handler.NewInstrumentHandler(instrumentRepo, cursors)
+
+	orderRepo := repository.NewOrderRepository(db)
+	orderHandler := handler.NewOrderHandler(orderRepo, cursors)
+
+	ledgerRepo := repository.NewLedgerRepository(db)
+	ledgerHandler := handler.NewLedgerHandler(ledgerRepo)
+
+	referenceAccountRepo := repository.NewReferenceAccountRepository(db)
+	referenceAccountHandler := handler.NewReferenceAccountHandler(referenceAccountRepo)
+
+	// Withdrawals are exported on the back office, so no payout debtor is needed.
+	withdrawalRepo := repository.NewWithdrawalRepository(db)
+	withdrawalHandler := handler.NewWithdrawalHandler(withdrawalRepo, sepa.Debtor{})
+
+	savingsPlanRepo := repository.NewSavingsPlanRepository(db)
+	savingsPlanHandler := handler.NewSavingsPlanHandler(savingsPlanRepo)
+
+	feeRepo := repository.NewFeeRepository(db)
+	feeHandler := handler.NewFeeHandler(feeRepo)
+
+	appropriatenessRepo := repository.NewAppropriatenessRepository(db)
+	appropriatenessHandler := handler.NewAppropriatenessHandler(appropriatenessRepo)
+
+	businessRepo := repository.NewBusinessRepository(db)
+	businessHandler := handler.NewBusinessHandler(businessRepo)
+
+	guardianRepo := repository.NewGuardianRepository(db)
+	guardianHandler := handler.NewGuardianHandler(guardianRepo)
+
+	documentRepo := repository.NewDocumentRepository(db)
+	documentHandler := handler.NewDocumentHandler(documentRepo, storage, scanner)
+
+	screeningRepo := repository.NewScreeningRepository(db)
+	screeningHandler := handler.NewScreeningHandler(screeningRepo)
+
+	// Events recorded while serving a request carry its correlation ID.
+	router.Use(middleware.CorrelationID)
+
+	// Every mutating route honours the Idempotency-Key header.
+	router.Use(middleware.Idempotency(repository.NewIdempotencyRepository(db), idempotencyLease))
 
 	router.HandleFunc("/health", pingHTTP).Methods("GET")
 
 	router.HandleFunc("/users", userHandler.CreateUser).Methods(http.MethodPost)
 	router.Handle("/users",
-		middleware.ExtractPagingParams(http.HandlerFunc(userHandler.GetAllUsers))).Methods(http.MethodGet)
+		middleware.ExtractPagingParams(cursors)(http.HandlerFunc(userHandler.GetAllUsers))).Methods(http.MethodGet)
 	router.HandleFunc("/users/{user_id}", userHandler.GetUserByID).Methods(http.MethodGet)
+	router.HandleFunc("/users/{user_id}", userHandler.UpdateUser).Methods(http.MethodPatch)
 	router.HandleFunc("/users/{user_id}", userHandler.DeleteUser).Methods(http.MethodDelete)
+	router.HandleFunc("/users/{user_id}/balances", ledgerHandler.GetUserBalances).Methods(http.MethodGet)
+	router.HandleFunc("/users/{user_id}/reference_accounts",
+		referenceAccountHandler.CreateReferenceAccount).Methods(http.MethodPost)
+	router.HandleFunc("/users/{user_id}/reference_accounts",
+		referenceAccountHandler.GetReferenceAccounts).Methods(http.MethodGet)
+	router.HandleFunc("/users/{user_id}/withdrawals", withdrawalHandler.CreateWithdrawal).Methods(http.MethodPost)
+	router.HandleFunc("/users/{user_id}/savings_plans", savingsPlanHandler.CreateSavingsPlan).Methods(http.MethodPost)
+	router.HandleFunc("/users/{user_id}/savings_plans", savingsPlanHandler.GetSavingsPlans).Methods(http.MethodGet)
+	router.HandleFunc("/users/{user_id}/fees", feeHandler.GetUserFees).Methods(http.MethodGet)
+	router.HandleFunc("/users/{user_id}/appropriateness", appropriatenessHandler.SubmitAnswers).Methods(http.MethodPost)
+	router.HandleFunc("/users/{user_id}/appropriateness", appropriatenessHandler.GetAssessment).Methods(http.MethodGet)
+	router.HandleFunc("/users/{user_id}/guardians", guardianHandler.AddGuardian).Methods(http.MethodPost)
+	router.HandleFunc("/users/{user_id}/guardians", guardianHandler.GetGuardians).Methods(http.MethodGet)
+	router.HandleFunc("/users/{user_id}/documents", documentHandler.UploadDocument).Methods(http.MethodPost)
+	router.HandleFunc("/users/{user_id}/documents", documentHandler.GetDocuments).Methods(http.MethodGet)
+	router.HandleFunc("/users/{user_id}/documents/{document_id}/file",
+		documentHandler.DownloadDocument).Methods(http.MethodGet)
+	router.HandleFunc("/users/{user_id}/screening_hits", screeningHandler.GetScreeningHits).Methods(http.MethodGet)
+
+	router.HandleFunc("/accounts", accountHandler.CreateAccount).Methods(http.MethodPost)
+	router.Handle("/accounts",
+		middleware.ExtractPagingParams(cursors)(http.HandlerFunc(accountHandler.GetAllAccounts))).Methods(http.MethodGet)
+	router.HandleFunc("/accounts/{account_id}", accountHandler.GetAccountByID).Methods(http.MethodGet)
+	router.HandleFunc("/accounts/{account_id}", accountHandler.DeleteAccount).Methods(http.MethodDelete)
+
+	router.Handle("/instruments",
+		middleware.ExtractPagingParams(cursors)(http.HandlerFunc(instrumentHandler.GetAllInstruments))).Methods(http.MethodGet)
+	router.HandleFunc("/instruments/{isin}", instrumentHandler.GetInstrumentByISIN).Methods(http.MethodGet)
+
+	router.HandleFunc("/orders", orderHandler.CreateOrder).Methods(http.MethodPost)
+	router.Handle("/orders",
+		middleware.ExtractPagingParams(cursors)(http.HandlerFunc(orderHandler.GetAllOrders))).Methods(http.MethodGet)
+	router.HandleFunc("/orders/{order_id}", orderHandler.GetOrderByID).Methods(http.MethodGet)
+	router.HandleFunc("/orders/{order_id}/cancel", orderHandler.CancelOrder).Methods(http.MethodPost)
+
+	router.HandleFunc("/withdrawals/{withdrawal_id}", withdrawalHandler.GetWithdrawalByID).Methods(http.MethodGet)
+
+	router.HandleFunc("/savings_plans/{savings_plan_id}", savingsPlanHandler.GetSavingsPlanByID).Methods(http.MethodGet)
+	router.HandleFunc("/savings_plans/{savings_plan_id}/executions",
+		savingsPlanHandler.GetSavingsPlanExecutions).Methods(http.MethodGet)
+	router.HandleFunc("/savings_plans/{savings_plan_id}/pause", savingsPlanHandler.PauseSavingsPlan).Methods(http.MethodPost)
+	router.HandleFunc("/savings_plans/{savings_plan_id}/resume", savingsPlanHandler.ResumeSavingsPlan).Methods(http.MethodPost)
+	router.HandleFunc("/savings_plans/{savings_plan_id}/cancel", savingsPlanHandler.CancelSavingsPlan).Methods(http.MethodPost)
+
+	router.HandleFunc("/fee_schedules", feeHandler.GetFeeSchedules).Methods(http.MethodGet)
+
+	router.HandleFunc("/businesses", businessHandler.CreateBusiness).Methods(http.MethodPost)
+	router.HandleFunc("/businesses/{business_id}", businessHandler.GetBusinessByID).Methods(http.MethodGet)
+
+	router.HandleFunc("/appropriateness/questionnaire", appropriatenessHandler.GetQuestionnaire).Methods(http.MethodGet)
+
+	return router
+}
+
+// NewBackOfficeServer returns the handler of the operations and compliance API,
+// which books and pays out money and releases held users. It must only be
+// reachable from the internal network.
+func NewBackOfficeServer(db *sql.DB, debtor sepa.Debtor) http.Handler {
+	router := mux.NewRouter()
+
+	ledgerRepo := repository.NewLedgerRepository(db)
+	ledgerHandler := handler.NewLedgerHandler(ledgerRepo)
+
+	withdrawalRepo := repository.NewWithdrawalRepository(db)
+	withdrawalHandler := handler.NewWithdrawalHandler(withdrawalRepo, debtor)
+
+	feeRepo := repository.NewFeeRepository(db)
+	feeHandler := handler.NewFeeHandler(feeRepo)
+
+	screeningRepo := repository.NewScreeningRepository(db)
+	screeningHandler := handler.NewScreeningHandler(screeningRepo)
+
+	router.Use(middleware.CorrelationID)
+	router.Use(middleware.Idempotency(repository.NewIdempotencyRepository(db), idempotencyLease))
+
+	router.HandleFunc("/health", pingHTTP).Methods("GET")
+
+	router.HandleFunc("/journal-entries", ledgerHandler.CreateJournalEntry).Methods(http.MethodPost)
+
+	router.HandleFunc("/withdrawals/exports", withdrawalHandler.ExportPendingWithdrawals).Methods(http.MethodPost)
+	router.HandleFunc("/withdrawals/exports/{export_id}", withdrawalHandler.GetWithdrawalExport).Methods(http.MethodGet)
+	router.HandleFunc("/withdrawals/{withdrawal_id}/book", withdrawalHandler.BookWithdrawal).Methods(http.MethodPost)
+	router.HandleFunc("/withdrawals/{withdrawal_id}/fail", withdrawalHandler.FailWithdrawal).Methods(http.MethodPost)
+
+	router.HandleFunc("/fee_schedules", feeHandler.SetFeeSchedule).Methods(http.MethodPut)
+	router.HandleFunc("/users/{user_id}/fee_segment", feeHandler.AssignFeeSegment).Methods(http.MethodPut)
+	router.HandleFunc("/users/{user_id}/fees", feeHandler.ChargeFee).Methods(http.MethodPost)
+
+	router.HandleFunc("/screening_hits/{screening_hit_id}/review",
+		screeningHandler.ReviewScreeningHit).Methods(http.MethodPost)
 
 	return router
 }
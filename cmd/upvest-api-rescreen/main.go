@@ -0,0 +1,95 @@
+// Command upvest-api-rescreen screens all users that are not offboarded against
+// the sanctions and PEP lists, so that a newly loaded list catches existing
+// users. New hits are stored for review and hold the user, as on creation.
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/repository"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/screening"
+	log "github.com/sirupsen/logrus"
+)
+
+// firstUserID sorts before every user ID.
+const firstUserID = "00000000-0000-0000-0000-000000000000"
+
+type Config struct {
+	DbDSN     string
+	Lists     string
+	BatchSize int
+}
+
+func main() {
+	// Setup Logging
+	log.SetFormatter(&log.JSONFormatter{})
+	log.SetLevel(log.InfoLevel)
+
+	// Parse configuration
+	config := Config{}
+	flag.StringVar(&config.Lists, "lists", os.Getenv("SCREENING_LISTS"), "comma-separated list files (defaults to $SCREENING_LISTS)")
+	flag.StringVar(&config.DbDSN, "dsn", os.Getenv("DB_DSN"), "Postgres connection string (defaults to $DB_DSN)")
+	flag.IntVar(&config.BatchSize, "batch-size", 500, "number of users read per query")
+	flag.Parse()
+
+	if config.BatchSize <= 0 {
+		log.Fatalf("batch-size must be positive, got %d", config.BatchSize)
+	}
+
+	screener, err := screening.LoadScreener(config.Lists)
+	if err != nil {
+		log.Fatalf("failed to load screening lists: %v", err)
+	}
+	if len(screener.Lists()) == 0 {
+		log.Fatal("no screening lists given; pass -lists or set SCREENING_LISTS")
+	}
+	log.Infof("screening users against %v", screener.Lists())
+
+	// Init Database
+	db, err := initDatabase(config.DbDSN)
+	if err != nil {
+		log.Fatalf("failed to initialize database: %v", err)
+	}
+	defer db.Close()
+
+	screened, hits, err := rescreen(context.Background(), repository.NewScreeningRepository(db), screener, config.BatchSize)
+	if err != nil {
+		log.Fatalf("failed to re-screen users: %v", err)
+	}
+	log.Infof("screened %d users, %d new hits", screened, hits)
+}
+
+// rescreen pages through the users by ID and records the hits of every user
+// that matches a list. It returns the number of users screened and of new hits.
+func rescreen(ctx context.Context, repo repository.ScreeningRepository, screener *screening.Screener, batchSize int) (int, int, error) {
+	screened, created := 0, 0
+	afterID := firstUserID
+	for {
+		users, err := repo.GetUsersToScreen(ctx, afterID, batchSize)
+		if err != nil {
+			return screened, created, err
+		}
+		if len(users) == 0 {
+			return screened, created, nil
+		}
+
+		for _, user := range users {
+			hits := screener.Screen(&user)
+			if len(hits) == 0 {
+				continue
+			}
+			n, err := repo.RecordHits(ctx, user.ID, hits)
+			if err != nil {
+				return screened, created, err
+			}
+			if n > 0 {
+				log.Infof("user %s has %d new screening hits", user.ID, n)
+			}
+			created += n
+		}
+		screened += len(users)
+		afterID = users[len(users)-1].ID
+	}
+}
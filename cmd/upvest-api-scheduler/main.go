@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/repository"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/scheduler"
+	"github.com/ashwingopalsamy/upvest-api/internal/util/shutdown"
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	schedulerPortAddr = ":8082"
+
+	defaultSchedulerInterval = time.Hour
+	dailyJobInterval         = 24 * time.Hour
+
+	// drainTimeout bounds how long in-flight requests may take on shutdown.
+	drainTimeout = 5 * time.Second
+)
+
+type Config struct {
+	DbDSN               string
+	HolidayCalendarFile string
+	Interval            string
+}
+
+func main() {
+	// Setup Logging
+	log.SetFormatter(&log.JSONFormatter{})
+	log.SetLevel(log.InfoLevel)
+	log.Info("starting Upvest API Scheduler service")
+
+	// Parse configuration
+	config := Config{
+		DbDSN:               os.Getenv("DB_DSN"),
+		HolidayCalendarFile: os.Getenv("HOLIDAY_CALENDAR_FILE"),
+		Interval:            os.Getenv("SCHEDULER_INTERVAL"),
+	}
+
+	// Stop on SIGINT or SIGTERM
+	ctx, stop := shutdown.OnSignal()
+	defer stop()
+
+	// Init Database
+	db, err := initDatabase(config.DbDSN)
+	if err != nil {
+		log.Fatalf("failed to initialize database: %v", err)
+	}
+
+	// Jobs run until shutdown
+	var jobs sync.WaitGroup
+	run := func(job func(context.Context)) {
+		jobs.Add(1)
+		go func() {
+			defer jobs.Done()
+			job(ctx)
+		}()
+	}
+
+	// Init Savings Plan Scheduler
+	savingsPlans := scheduler.New(repository.NewSavingsPlanRepository(db),
+		loadCalendar(config.HolidayCalendarFile), schedulerInterval(config.Interval))
+	run(savingsPlans.Run)
+	log.Info("savings plan scheduler started")
+
+	// Init Daily Jobs
+	comingOfAge := scheduler.NewDailyJob("coming of age job",
+		repository.NewGuardianRepository(db).RecordComingOfAge, dailyJobInterval)
+	run(comingOfAge.Run)
+	documentExpiry := scheduler.NewDailyJob("document expiry job",
+		repository.NewDocumentRepository(db).ExpireDocuments, dailyJobInterval)
+	run(documentExpiry.Run)
+	idempotencyKeyCleanup := scheduler.NewDailyJob("idempotency key cleanup",
+		repository.NewIdempotencyRepository(db).DeleteExpired, dailyJobInterval)
+	run(idempotencyKeyCleanup.Run)
+	log.Info("daily jobs started")
+
+	// Setup Router
+	router := mux.NewRouter()
+
+	// Setup Routes
+	router.HandleFunc("/health", pingHTTP).Methods("GET")
+
+	// Init HTTP Server
+	log.Infof("starting server on %s", schedulerPortAddr)
+	if err := shutdown.Serve(ctx, &http.Server{Addr: schedulerPortAddr, Handler: router}, drainTimeout); err != nil {
+		log.Errorf("HTTP server stopped: %v", err)
+	}
+
+	// Tear down in reverse order. The jobs finish the run or the savings plan in
+	// hand before the database is closed.
+	log.Info("shutting down Upvest API Scheduler service")
+	stop()
+	jobs.Wait()
+	if err := db.Close(); err != nil {
+		log.Errorf("failed to close database: %v", err)
+	}
+	log.Info("Upvest API Scheduler service stopped")
+}
+
+// loadCalendar reads the holiday calendar. Without a configured file only
+// weekends are skipped.
+func loadCalendar(path string) *domain.Calendar {
+	if path == "" {
+		log.Warn("HOLIDAY_CALENDAR_FILE is not set, savings plans only skip weekends")
+		return domain.NewCalendar()
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		log.Fatalf("failed to open holiday calendar: %v", err)
+	}
+	defer file.Close()
+
+	calendar, err := scheduler.LoadCalendar(file)
+	if err != nil {
+		log.Fatalf("failed to load holiday calendar %s: %v", path, err)
+	}
+	log.Infof("holiday calendar %s covers the dates until %s", path, calendar.LastDay().Format(domain.DateLayout))
+	return calendar
+}
+
+func schedulerInterval(configured string) time.Duration {
+	if configured == "" {
+		return defaultSchedulerInterval
+	}
+
+	interval, err := time.ParseDuration(configured)
+	if err != nil || interval <= 0 {
+		log.Fatalf("invalid SCHEDULER_INTERVAL %q", configured)
+	}
+	return interval
+}
@@ -1,18 +1,34 @@
 package main
 
 import (
-	"context"
 	"net/http"
 	"os"
+	"time"
 
+	"github.com/ashwingopalsamy/upvest-api/internal/event"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/repository"
+	"github.com/ashwingopalsamy/upvest-api/internal/util/shutdown"
 	"github.com/gorilla/mux"
 	log "github.com/sirupsen/logrus"
 )
 
-const subscriberPortAddr = ":8081"
+const (
+	subscriberPortAddr = ":8081"
+
+	// drainTimeout bounds how long in-flight requests may take on shutdown.
+	drainTimeout = 5 * time.Second
+)
 
 type Config struct {
-	DbDSN string
+	DbDSN           string
+	MaxAttempts     string
+	MinBackoff      string
+	MaxBackoff      string
+	CommitBatchSize string
+	CommitInterval  string
+	DeadLetterTopic string
+	// KafkaConfigFile is read before the KAFKA_* variables, which override it.
+	KafkaConfigFile string
 }
 
 func main() {
@@ -23,22 +39,43 @@ func main() {
 
 	// Parse configuration
 	config := Config{
-		DbDSN: os.Getenv("DB_DSN"),
+		DbDSN:           os.Getenv("DB_DSN"),
+		MaxAttempts:     os.Getenv("CONSUMER_MAX_ATTEMPTS"),
+		MinBackoff:      os.Getenv("CONSUMER_MIN_BACKOFF"),
+		MaxBackoff:      os.Getenv("CONSUMER_MAX_BACKOFF"),
+		CommitBatchSize: os.Getenv("CONSUMER_COMMIT_BATCH_SIZE"),
+		CommitInterval:  os.Getenv("CONSUMER_COMMIT_INTERVAL"),
+		DeadLetterTopic: os.Getenv("DEAD_LETTER_TOPIC"),
+		KafkaConfigFile: os.Getenv("KAFKA_CONFIG_FILE"),
 	}
+	kafkaConfig, err := event.LoadKafkaConfig(config.KafkaConfigFile, os.LookupEnv)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Stop on SIGINT or SIGTERM
+	ctx, stop := shutdown.OnSignal()
+	defer stop()
 
 	// Init Database
 	db, err := initDatabase(config.DbDSN)
 	if err != nil {
 		log.Fatalf("failed to initialize database: %v", err)
 	}
-	defer db.Close()
 
 	// Init Subscriber
-	initKafkaSubscriber()
-	defer subscriber.Close()
+	initKafkaSubscriber(config, kafkaConfig, db)
 
+	listener := newUserEventListener(repository.NewUserRepository(db),
+		cancelSavingsPlans(repository.NewSavingsPlanRepository(db)),
+		cancelOrders(repository.NewOrderRepository(db)),
+		closeAccounts(repository.NewAccountRepository(db)),
+	)
+
+	consumed := make(chan struct{})
 	go func() {
-		subscriber.Consume(context.TODO(), kafkaListener)
+		defer close(consumed)
+		subscriber.Consume(ctx, listener.kafkaListener)
 	}()
 
 	// Setup Router
@@ -48,8 +85,21 @@ func main() {
 	router.HandleFunc("/health", pingHTTP).Methods("GET")
 
 	// Init HTTP Server
-	log.Info("starting server on %d", subscriberPortAddr)
-	if err := http.ListenAndServe(subscriberPortAddr, router); err != nil {
-		log.Fatalf("failed to start server: %v", err)
+	log.Infof("starting server on %s", subscriberPortAddr)
+	if err := shutdown.Serve(ctx, &http.Server{Addr: subscriberPortAddr, Handler: router}, drainTimeout); err != nil {
+		log.Errorf("HTTP server stopped: %v", err)
+	}
+
+	// Tear down in reverse order. The consumer finishes the message in hand and
+	// commits the processed offsets before the reader leaves the group.
+	log.Info("shutting down Upvest API Subscriber service")
+	stop()
+	<-consumed
+	if err := subscriber.Close(); err != nil {
+		log.Errorf("failed to close Kafka subscriber: %v", err)
+	}
+	if err := db.Close(); err != nil {
+		log.Errorf("failed to close database: %v", err)
 	}
+	log.Info("Upvest API Subscriber service stopped")
 }
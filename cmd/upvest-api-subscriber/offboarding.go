@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/repository"
+	log "github.com/sirupsen/logrus"
+)
+
+// offboardingStep closes a resource that depends on a user being offboarded.
+// Steps must be idempotent, since an event may be delivered more than once.
+type offboardingStep func(ctx context.Context, userID string) error
+
+// cancelSavingsPlans cancels the user's savings plans, so that no further
+// executions are scheduled for the user.
+func cancelSavingsPlans(plans repository.SavingsPlanRepository) offboardingStep {
+	return plans.CancelUserSavingsPlans
+}
+
+// cancelOrders cancels the user's open orders. It runs before closeAccounts so
+// that no order is left pending against a closed account.
+func cancelOrders(orders repository.OrderRepository) offboardingStep {
+	return orders.CancelUserOrders
+}
+
+// closeAccounts closes the user's accounts and account group.
+func closeAccounts(accounts repository.AccountRepository) offboardingStep {
+	return accounts.CloseUserAccounts
+}
+
+// completeOffboarding closes the user's dependent resources and then moves the
+// user from OFFBOARDING to OFFBOARDED.
+func (l *userEventListener) completeOffboarding(ctx context.Context, userID string) error {
+	for _, step := range l.offboardingSteps {
+		if err := step(ctx, userID); err != nil {
+			return fmt.Errorf("failed to offboard user %s: %w", userID, err)
+		}
+	}
+
+	err := l.users.CompleteOffboarding(ctx, userID)
+	if errors.Is(err, domain.ErrIllegalStatusTransition) {
+		// A redelivered event for a user whose offboarding already completed.
+		log.Warnf("skipping offboarding of user %s: %v", userID, err)
+		return nil
+	}
+	return err
+}
@@ -1,30 +1,124 @@
 package main
 
 import (
-	"encoding/json"
-	"fmt"
+	"context"
+	"database/sql"
+	"strconv"
+	"time"
 
 	"github.com/ashwingopalsamy/upvest-api/internal/event"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/repository"
+	"github.com/segmentio/kafka-go"
 	log "github.com/sirupsen/logrus"
 )
 
 var subscriber *event.Subscriber
 
-func initKafkaSubscriber() {
-	subscriber = event.NewSubscriber("kafka:9092", "user-events", "user-subscriber-group")
-	log.Info("Kafka subscriber initialized")
+// initKafkaSubscriber joins the configured consumer group. Processed messages
+// are recorded in Postgres, so that messages delivered again are skipped.
+func initKafkaSubscriber(config Config, kafkaConfig event.KafkaConfig, db *sql.DB) {
+	var err error
+	subscriber, err = event.NewSubscriber(kafkaConfig, subscriberConfig(config, kafkaConfig.Topic),
+		repository.NewProcessedMessageRepository(db))
+	if err != nil {
+		log.Fatalf("failed to initialize Kafka subscriber: %v", err)
+	}
+	log.Infof("Kafka subscriber initialized for %s in group %s", kafkaConfig.Topic, kafkaConfig.GroupID)
+}
+
+// subscriberConfig applies the configured retry policy, offset commits and
+// dead-letter topic over the defaults. DEAD_LETTER_TOPIC=off drops failed
+// messages instead.
+func subscriberConfig(config Config, topic string) event.SubscriberConfig {
+	subscriberConfig := event.DefaultSubscriberConfig(topic)
+
+	subscriberConfig.Retry.MaxAttempts = intSetting("CONSUMER_MAX_ATTEMPTS", config.MaxAttempts, subscriberConfig.Retry.MaxAttempts)
+	subscriberConfig.Retry.MinBackoff = durationSetting("CONSUMER_MIN_BACKOFF", config.MinBackoff, subscriberConfig.Retry.MinBackoff)
+	subscriberConfig.Retry.MaxBackoff = durationSetting("CONSUMER_MAX_BACKOFF", config.MaxBackoff, subscriberConfig.Retry.MaxBackoff)
+	if subscriberConfig.Retry.MinBackoff > subscriberConfig.Retry.MaxBackoff {
+		log.Fatal("CONSUMER_MIN_BACKOFF must not exceed CONSUMER_MAX_BACKOFF")
+	}
+
+	subscriberConfig.CommitBatchSize = intSetting("CONSUMER_COMMIT_BATCH_SIZE", config.CommitBatchSize, subscriberConfig.CommitBatchSize)
+	subscriberConfig.CommitInterval = durationSetting("CONSUMER_COMMIT_INTERVAL", config.CommitInterval, subscriberConfig.CommitInterval)
+
+	switch config.DeadLetterTopic {
+	case "":
+	case "off":
+		log.Warn("DEAD_LETTER_TOPIC is off, messages that fail every attempt are dropped")
+		subscriberConfig.DeadLetterTopic = ""
+	default:
+		subscriberConfig.DeadLetterTopic = config.DeadLetterTopic
+	}
+	return subscriberConfig
 }
 
-func kafkaListener(key, value []byte) error {
-	var event map[string]interface{}
-	if err := json.Unmarshal(value, &event); err != nil {
-		log.Errorf("failed to unmarshal message: %v", err)
+func intSetting(name, configured string, fallback int) int {
+	if configured == "" {
+		return fallback
+	}
+
+	value, err := strconv.Atoi(configured)
+	if err != nil || value <= 0 {
+		log.Fatalf("invalid %s %q", name, configured)
+	}
+	return value
+}
+
+func durationSetting(name, configured string, fallback time.Duration) time.Duration {
+	if configured == "" {
+		return fallback
+	}
+
+	duration, err := time.ParseDuration(configured)
+	if err != nil || duration <= 0 {
+		log.Fatalf("invalid %s %q", name, configured)
+	}
+	return duration
+}
+
+// userEventListener reacts to the user events emitted by the publisher service.
+type userEventListener struct {
+	users            repository.UserRepository
+	offboardingSteps []offboardingStep
+}
+
+func newUserEventListener(users repository.UserRepository, steps ...offboardingStep) *userEventListener {
+	return &userEventListener{
+		users:            users,
+		offboardingSteps: steps,
+	}
+}
+
+// kafkaListener completes the offboarding of users. Events of other types are
+// skipped by their header, without parsing them.
+func (l *userEventListener) kafkaListener(msg kafka.Message) error {
+	if eventType, ok := event.MessageType(msg); ok && eventType != event.TypeUserOffboarding {
+		return nil
+	}
+
+	envelope, err := event.DecodeMessage(msg)
+	if err != nil {
+		log.Errorf("failed to decode message: %v", err)
 		return err
 	}
+	if envelope.Type != event.TypeUserOffboarding {
+		return nil
+	}
 
-	action := event["action"]
-	log.Infof("Processing event: %v", action)
+	log.Infof("Processing event %s %s (version %d)", envelope.Type, envelope.ID, envelope.SchemaVersion)
+	payload, err := event.UserEvents.Decode(envelope)
+	if err != nil {
+		log.Errorf("failed to decode %s event: %v", envelope.Type, err)
+		return err
+	}
+
+	// Events recorded while offboarding carry on the correlation ID.
+	ctx := event.WithCorrelationID(context.Background(), envelope.CorrelationID)
+	if err := l.completeOffboarding(ctx, payload.(*event.UserOffboarding).UserID); err != nil {
+		return err
+	}
 
-	fmt.Printf("Processed event: %v\n", event)
+	log.Infof("Processed event %s %s", envelope.Type, envelope.ID)
 	return nil
 }
@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+)
+
+const (
+	AccountTypeTrading    = "TRADING"
+	AccountTypeRetirement = "RETIREMENT"
+
+	AccountStatusActive = "ACTIVE"
+	AccountStatusClosed = "CLOSED"
+)
+
+var (
+	ErrUserNotActive    = errors.New("user is not active")
+	ErrAccountNotActive = errors.New("account is not active")
+)
+
+// AccountGroup bundles the accounts of a user. Each user has at most one open
+// account group, created together with the user's first account.
+type AccountGroup struct {
+	ID        string `json:"id"`
+	CreatedAt string `json:"created_at,omitempty"`
+	UpdatedAt string `json:"updated_at,omitempty"`
+	UserID    string `json:"user_id"`
+	Status    string `json:"status,omitempty"`
+}
+
+type Account struct {
+	ID             string `json:"id"`
+	CreatedAt      string `json:"created_at,omitempty"`
+	UpdatedAt      string `json:"updated_at,omitempty"`
+	UserID         string `json:"user_id"`
+	AccountGroupID string `json:"account_group_id,omitempty"`
+	Type           string `json:"type"`
+	Name           string `json:"name,omitempty"`
+	Status         string `json:"status,omitempty"`
+	ClosedAt       string `json:"closed_at,omitempty"`
+}
+
+var (
+	validAccountTypes = map[string]struct{}{
+		AccountTypeTrading:    {},
+		AccountTypeRetirement: {},
+	}
+	validAccountStatuses = map[string]struct{}{
+		AccountStatusActive: {},
+		AccountStatusClosed: {},
+	}
+	// Regex for UUID validation.
+	uuidRegex = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+)
+
+// IsValidUUID reports whether id is a UUID in its canonical textual form.
+func IsValidUUID(id string) bool {
+	return uuidRegex.MatchString(id)
+}
+
+// IsValidAccountStatus reports whether status is a known account status.
+func IsValidAccountStatus(status string) bool {
+	_, valid := validAccountStatuses[status]
+	return valid
+}
+
+// Validate checks if the account object adheres to the spec.
+func (a *Account) Validate() error {
+	if !IsValidUUID(a.UserID) {
+		return errors.New("user_id must be a valid UUID")
+	}
+	if _, valid := validAccountTypes[a.Type]; !valid {
+		return errors.New("invalid account type")
+	}
+	if len(a.Name) > 100 {
+		return errors.New("name must be at most 100 characters")
+	}
+	return nil
+}
@@ -0,0 +1,203 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+)
+
+// CurrentQuestionnaireVersion is the version of the appropriateness
+// questionnaire new answers must be given to. Assessments keep the version they
+// were answered in, so older versions stay defined.
+const CurrentQuestionnaireVersion = 1
+
+// Questionnaire is a version of the MiFID II appropriateness questionnaire. An
+// instrument category is allowed when the points of the chosen options of the
+// questions counting towards it reach PassingScore.
+type Questionnaire struct {
+	Version      int        `json:"version"`
+	PassingScore int        `json:"passing_score"`
+	Questions    []Question `json:"questions"`
+}
+
+// Question asks for knowledge or experience relevant to Categories.
+type Question struct {
+	ID         string   `json:"id"`
+	Text       string   `json:"text"`
+	Categories []string `json:"categories"`
+	Options    []Option `json:"options"`
+}
+
+type Option struct {
+	ID     string `json:"id"`
+	Text   string `json:"text"`
+	Points int    `json:"points"`
+}
+
+// AppropriatenessSubmission holds a user's answers, by question ID, to a
+// questionnaire version.
+type AppropriatenessSubmission struct {
+	QuestionnaireVersion int               `json:"questionnaire_version"`
+	Answers              map[string]string `json:"answers"`
+}
+
+// AppropriatenessAssessment is the scored result of a submission. The latest
+// assessment of a user is the current one.
+type AppropriatenessAssessment struct {
+	ID                   string            `json:"id"`
+	CreatedAt            string            `json:"created_at,omitempty"`
+	UserID               string            `json:"user_id"`
+	QuestionnaireVersion int               `json:"questionnaire_version"`
+	Answers              map[string]string `json:"answers"`
+	Scores               map[string]int    `json:"scores"`
+	AllowedCategories    []string          `json:"allowed_categories"`
+}
+
+var experienceOptions = []Option{
+	{ID: "NONE", Text: "None", Points: 0},
+	{ID: "1_TO_5", Text: "1 to 5 transactions", Points: 1},
+	{ID: "MORE_THAN_5", Text: "More than 5 transactions", Points: 2},
+}
+
+// questionnaires holds every questionnaire version ever published. Versions
+// are never changed once published; a change is a new version.
+var questionnaires = map[int]Questionnaire{
+	1: {
+		Version:      1,
+		PassingScore: 3,
+		Questions: []Question{
+			{
+				ID:         "PROFESSION",
+				Text:       "Have you worked in a profession that requires knowledge of securities for at least one year?",
+				Categories: []string{InstrumentTypeEquity, InstrumentTypeETF, InstrumentTypeFund, InstrumentTypeBond},
+				Options: []Option{
+					{ID: "YES", Text: "Yes", Points: 1},
+					{ID: "NO", Text: "No", Points: 0},
+				},
+			},
+			{
+				ID:         "EQUITY_KNOWLEDGE",
+				Text:       "Can you lose the whole amount invested in a share?",
+				Categories: []string{InstrumentTypeEquity},
+				Options: []Option{
+					{ID: "YES", Text: "Yes, if the company becomes insolvent", Points: 2},
+					{ID: "NO", Text: "No, shares are protected by a deposit guarantee", Points: 0},
+					{ID: "DONT_KNOW", Text: "I don't know", Points: 0},
+				},
+			},
+			{
+				ID:         "EQUITY_EXPERIENCE",
+				Text:       "How many share transactions have you made in the last three years?",
+				Categories: []string{InstrumentTypeEquity},
+				Options:    experienceOptions,
+			},
+			{
+				ID:         "ETF_KNOWLEDGE",
+				Text:       "What happens to the price of an ETF when the index it tracks falls?",
+				Categories: []string{InstrumentTypeETF},
+				Options: []Option{
+					{ID: "FALLS", Text: "It falls as well", Points: 2},
+					{ID: "RISES", Text: "It rises", Points: 0},
+					{ID: "DONT_KNOW", Text: "I don't know", Points: 0},
+				},
+			},
+			{
+				ID:         "ETF_EXPERIENCE",
+				Text:       "How many ETF transactions have you made in the last three years?",
+				Categories: []string{InstrumentTypeETF},
+				Options:    experienceOptions,
+			},
+			{
+				ID:         "FUND_KNOWLEDGE",
+				Text:       "Who decides which securities an actively managed fund holds?",
+				Categories: []string{InstrumentTypeFund},
+				Options: []Option{
+					{ID: "FUND_MANAGER", Text: "The fund manager", Points: 2},
+					{ID: "INVESTOR", Text: "Each investor", Points: 0},
+					{ID: "DONT_KNOW", Text: "I don't know", Points: 0},
+				},
+			},
+			{
+				ID:         "FUND_EXPERIENCE",
+				Text:       "How many fund transactions have you made in the last three years?",
+				Categories: []string{InstrumentTypeFund},
+				Options:    experienceOptions,
+			},
+			{
+				ID:         "BOND_KNOWLEDGE",
+				Text:       "What usually happens to the price of a bond when interest rates rise?",
+				Categories: []string{InstrumentTypeBond},
+				Options: []Option{
+					{ID: "FALLS", Text: "It falls", Points: 2},
+					{ID: "RISES", Text: "It rises", Points: 0},
+					{ID: "DONT_KNOW", Text: "I don't know", Points: 0},
+				},
+			},
+			{
+				ID:         "BOND_EXPERIENCE",
+				Text:       "How many bond transactions have you made in the last three years?",
+				Categories: []string{InstrumentTypeBond},
+				Options:    experienceOptions,
+			},
+		},
+	},
+}
+
+// GetQuestionnaire returns the questionnaire of the given version.
+func GetQuestionnaire(version int) (Questionnaire, bool) {
+	questionnaire, ok := questionnaires[version]
+	return questionnaire, ok
+}
+
+// Categories returns the sorted instrument categories the questionnaire
+// assesses.
+func (q *Questionnaire) Categories() []string {
+	seen := map[string]struct{}{}
+	for _, question := range q.Questions {
+		for _, category := range question.Categories {
+			seen[category] = struct{}{}
+		}
+	}
+	categories := make([]string, 0, len(seen))
+	for category := range seen {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+	return categories
+}
+
+// Validate checks that the submission answers every question of the current
+// questionnaire with one of its options, and nothing else.
+func (s *AppropriatenessSubmission) Validate() error {
+	if s.QuestionnaireVersion != CurrentQuestionnaireVersion {
+		return fmt.Errorf("questionnaire_version must be the current version %d", CurrentQuestionnaireVersion)
+	}
+	questionnaire, _ := GetQuestionnaire(s.QuestionnaireVersion)
+
+	known := make(map[string]struct{}, len(questionnaire.Questions))
+	for _, question := range questionnaire.Questions {
+		known[question.ID] = struct{}{}
+		answer, ok := s.Answers[question.ID]
+		if !ok {
+			return fmt.Errorf("question %s must be answered", question.ID)
+		}
+		if _, ok := question.Option(answer); !ok {
+			return fmt.Errorf("%q is not an option of question %s", answer, question.ID)
+		}
+	}
+	for questionID := range s.Answers {
+		if _, ok := known[questionID]; !ok {
+			return fmt.Errorf("unknown question %s", questionID)
+		}
+	}
+	return nil
+}
+
+// Option returns the option of q with the given ID.
+func (q *Question) Option(id string) (Option, bool) {
+	for _, option := range q.Options {
+		if option.ID == id {
+			return option, true
+		}
+	}
+	return Option{}, false
+}
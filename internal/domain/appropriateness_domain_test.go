@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AppropriatenessSubmission_Validate(t *testing.T) {
+	questionnaire, ok := GetQuestionnaire(CurrentQuestionnaireVersion)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"BOND", "EQUITY", "ETF", "FUND"}, questionnaire.Categories())
+
+	answers := map[string]string{}
+	for _, question := range questionnaire.Questions {
+		answers[question.ID] = question.Options[0].ID
+	}
+	submission := AppropriatenessSubmission{QuestionnaireVersion: CurrentQuestionnaireVersion, Answers: answers}
+	assert.NoError(t, submission.Validate())
+
+	answers["ETF_KNOWLEDGE"] = "MAYBE"
+	assert.EqualError(t, submission.Validate(), `"MAYBE" is not an option of question ETF_KNOWLEDGE`)
+
+	delete(answers, "ETF_KNOWLEDGE")
+	assert.EqualError(t, submission.Validate(), "question ETF_KNOWLEDGE must be answered")
+
+	answers["ETF_KNOWLEDGE"] = "FALLS"
+	answers["CRYPTO_KNOWLEDGE"] = "YES"
+	assert.EqualError(t, submission.Validate(), "unknown question CRYPTO_KNOWLEDGE")
+
+	submission.QuestionnaireVersion = 0
+	assert.EqualError(t, submission.Validate(), "questionnaire_version must be the current version 1")
+}
@@ -0,0 +1,148 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	BusinessRoleUBO                 = "UBO"
+	BusinessRoleLegalRepresentative = "LEGAL_REPRESENTATIVE"
+)
+
+var (
+	// ErrBusinessNotAccepted is wrapped with the reason an otherwise valid
+	// business cannot be onboarded.
+	ErrBusinessNotAccepted = errors.New("business not accepted")
+	// ErrLEIAlreadyRegistered is returned for a business whose LEI belongs to
+	// a business onboarded before.
+	ErrLEIAlreadyRegistered = errors.New("a business with this LEI already exists")
+)
+
+// Business is a legal entity client. It acts through its legal
+// representatives, and its ultimate beneficial owners (UBOs) are disclosed
+// with their share of ownership; both are natural-person users.
+type Business struct {
+	ID                 string           `json:"id"`
+	CreatedAt          string           `json:"created_at,omitempty"`
+	UpdatedAt          string           `json:"updated_at,omitempty"`
+	Name               string           `json:"name"`
+	RegistrationNumber string           `json:"registration_number"`
+	LegalForm          string           `json:"legal_form"`
+	RegisteredAddress  Address          `json:"registered_address"`
+	LEI                string           `json:"lei"`
+	Persons            []BusinessPerson `json:"persons"`
+}
+
+// BusinessPerson links a user to a business in a role. OwnershipPercentage is
+// a decimal with at most two decimal places, given for UBOs only.
+type BusinessPerson struct {
+	UserID              string `json:"user_id"`
+	Role                string `json:"role"`
+	OwnershipPercentage string `json:"ownership_percentage,omitempty"`
+}
+
+var (
+	validLegalForms = map[string]struct{}{
+		"AG": {}, "GMBH": {}, "UG": {}, "SE": {}, "KG": {}, "GMBH_CO_KG": {}, "OHG": {}, "GBR": {},
+		"EK": {}, "EG": {}, "EV": {}, "LTD": {}, "PLC": {}, "LLC": {}, "SA": {}, "SARL": {}, "BV": {}, "NV": {},
+		"OTHER": {},
+	}
+	// Regex for LEI validation: 18 character LOU and entity part, 2 check digits.
+	leiRegex = regexp.MustCompile(`^[A-Z0-9]{18}[0-9]{2}$`)
+)
+
+// IsValidLEI reports whether lei is a well-formed ISO 17442 legal entity
+// identifier with valid check digits.
+func IsValidLEI(lei string) bool {
+	if !leiRegex.MatchString(lei) {
+		return false
+	}
+
+	// ISO 7064 MOD 97-10 over the whole identifier, letters replaced with 10..35.
+	remainder := 0
+	for _, r := range lei {
+		if r >= 'A' && r <= 'Z' {
+			remainder = (remainder*100 + int(r-'A') + 10) % 97
+		} else {
+			remainder = (remainder*10 + int(r-'0')) % 97
+		}
+	}
+	return remainder == 1
+}
+
+// Validate checks if the business object adheres to the spec. Whether its
+// persons are existing users is checked when it is stored.
+func (b *Business) Validate() error {
+	if len(b.Name) < 2 || len(b.Name) > 200 {
+		return errors.New("name must be between 2 and 200 characters")
+	}
+	if len(b.RegistrationNumber) < 1 || len(b.RegistrationNumber) > 50 {
+		return errors.New("registration_number must be between 1 and 50 characters")
+	}
+	if _, valid := validLegalForms[b.LegalForm]; !valid {
+		return errors.New("invalid legal_form")
+	}
+	if err := b.RegisteredAddress.Validate(); err != nil {
+		return fmt.Errorf("registered_address: %w", err)
+	}
+	if !IsValidLEI(b.LEI) {
+		return errors.New("lei must be a valid ISO 17442 LEI")
+	}
+	return b.validatePersons()
+}
+
+func (b *Business) validatePersons() error {
+	var (
+		representatives int
+		ownership       int
+		seen            = make(map[BusinessPerson]struct{}, len(b.Persons))
+	)
+	for _, person := range b.Persons {
+		if !IsValidUUID(person.UserID) {
+			return errors.New("user_id of every person must be a valid UUID")
+		}
+		if _, duplicate := seen[BusinessPerson{UserID: person.UserID, Role: person.Role}]; duplicate {
+			return fmt.Errorf("user %s is listed twice as %s", person.UserID, person.Role)
+		}
+		seen[BusinessPerson{UserID: person.UserID, Role: person.Role}] = struct{}{}
+
+		switch person.Role {
+		case BusinessRoleLegalRepresentative:
+			if person.OwnershipPercentage != "" {
+				return errors.New("ownership_percentage is only allowed for UBOs")
+			}
+			representatives++
+		case BusinessRoleUBO:
+			hundredths, ok := percentageHundredths(person.OwnershipPercentage)
+			if !ok || hundredths > 100_00 {
+				return errors.New("ownership_percentage of a UBO must be a positive decimal of at most 100 with at most 2 decimal places")
+			}
+			ownership += hundredths
+		default:
+			return errors.New("role must be UBO or LEGAL_REPRESENTATIVE")
+		}
+	}
+	if representatives == 0 {
+		return errors.New("at least one legal representative is required")
+	}
+	if ownership > 100_00 {
+		return errors.New("ownership_percentage of all UBOs must not add up to more than 100")
+	}
+	return nil
+}
+
+// percentageHundredths converts a positive percentage with at most two decimal
+// places to hundredths of a percent.
+func percentageHundredths(percentage string) (int, bool) {
+	places, ok := positiveDecimalPlaces(percentage)
+	if !ok || places > 2 {
+		return 0, false
+	}
+	whole, fraction, _ := strings.Cut(percentage, ".")
+	value, err := strconv.Atoi(whole + fraction + strings.Repeat("0", 2-places))
+	return value, err == nil
+}
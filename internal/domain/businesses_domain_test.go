@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testBusinessOwner          = "5b3c1f5e-2d4a-4c43-9d7e-8a6b1f0e2c11"
+	testBusinessRepresentative = "0d9f6c2a-7e1b-4f58-b3a4-6c2e9d8f1a22"
+)
+
+func newTestBusiness() Business {
+	return Business{
+		Name:               "Muster GmbH",
+		RegistrationNumber: "HRB 123456",
+		LegalForm:          "GMBH",
+		RegisteredAddress:  Address{AddressLine1: "Hauptstr. 1", Postcode: "10115", City: "Berlin", Country: "DE"},
+		LEI:                "529900T8BM49AURSDO55",
+		Persons: []BusinessPerson{
+			{UserID: testBusinessRepresentative, Role: BusinessRoleLegalRepresentative},
+			{UserID: testBusinessOwner, Role: BusinessRoleUBO, OwnershipPercentage: "75.5"},
+			{UserID: testBusinessRepresentative, Role: BusinessRoleUBO, OwnershipPercentage: "24.5"},
+		},
+	}
+}
+
+func Test_IsValidLEI(t *testing.T) {
+	for _, lei := range []string{"529900T8BM49AURSDO55", "7LTWFZYICNSX8D621K86", "5493001KJTIIGC8Y1R12"} {
+		assert.True(t, IsValidLEI(lei), lei)
+	}
+	for _, lei := range []string{"5493001KJTIIGC8Y1R17", "5493001kjtiigc8y1r12", "529900T8BM49AURSDO5", "529900T8BM49AURSDOAA"} {
+		assert.False(t, IsValidLEI(lei), lei)
+	}
+}
+
+func Test_Business_Validate(t *testing.T) {
+	business := newTestBusiness()
+	assert.NoError(t, business.Validate())
+
+	tests := []struct {
+		name   string
+		modify func(b *Business)
+		err    string
+	}{
+		{"unknown legal form", func(b *Business) { b.LegalForm = "INC" }, "invalid legal_form"},
+		{"invalid registered address", func(b *Business) { b.RegisteredAddress.Country = "XX" }, "registered_address: invalid country code"},
+		{"invalid lei", func(b *Business) { b.LEI = "529900T8BM49AURSDO56" }, "lei must be a valid ISO 17442 LEI"},
+		{"no legal representative", func(b *Business) { b.Persons = b.Persons[1:] }, "at least one legal representative is required"},
+		{"unknown role", func(b *Business) { b.Persons[0].Role = "DIRECTOR" }, "role must be UBO or LEGAL_REPRESENTATIVE"},
+		{"ownership of a representative", func(b *Business) { b.Persons[0].OwnershipPercentage = "10" }, "ownership_percentage is only allowed for UBOs"},
+		{"ubo without ownership", func(b *Business) { b.Persons[1].OwnershipPercentage = "" },
+			"ownership_percentage of a UBO must be a positive decimal of at most 100 with at most 2 decimal places"},
+		{"ownership with three decimal places", func(b *Business) { b.Persons[1].OwnershipPercentage = "75.125" },
+			"ownership_percentage of a UBO must be a positive decimal of at most 100 with at most 2 decimal places"},
+		{"ownership above 100 in total", func(b *Business) { b.Persons[1].OwnershipPercentage = "75.51" },
+			"ownership_percentage of all UBOs must not add up to more than 100"},
+		{"person listed twice", func(b *Business) { b.Persons = append(b.Persons, b.Persons[0]) },
+			"user " + testBusinessRepresentative + " is listed twice as LEGAL_REPRESENTATIVE"},
+	}
+
+	for _, tt := range tests {
+		business := newTestBusiness()
+		tt.modify(&business)
+		assert.EqualError(t, business.Validate(), tt.err, tt.name)
+	}
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	DocumentTypeIDCard         = "ID_CARD"
+	DocumentTypePassport       = "PASSPORT"
+	DocumentTypeProofOfAddress = "PROOF_OF_ADDRESS"
+
+	DocumentStatusValid   = "VALID"
+	DocumentStatusExpired = "EXPIRED"
+
+	// MaxDocumentSize is the largest file accepted as a document, in bytes.
+	MaxDocumentSize = 10 << 20
+)
+
+// ErrDocumentInfected is returned for an upload the virus scanner rejected.
+var ErrDocumentInfected = errors.New("document failed the virus scan")
+
+// Document is a KYC document uploaded for a user. The file itself is kept in
+// blob storage under StorageKey; SHA256 is the hex digest of its content.
+type Document struct {
+	ID             string `json:"id"`
+	CreatedAt      string `json:"created_at,omitempty"`
+	UserID         string `json:"user_id"`
+	Type           string `json:"type"`
+	IssuingCountry string `json:"issuing_country"`
+	ExpiryDate     string `json:"expiry_date,omitempty"`
+	FileName       string `json:"file_name"`
+	ContentType    string `json:"content_type"`
+	Size           int64  `json:"size"`
+	SHA256         string `json:"sha256"`
+	Status         string `json:"status,omitempty"`
+	StorageKey     string `json:"-"`
+}
+
+var (
+	validDocumentTypes = map[string]struct{}{
+		DocumentTypeIDCard: {}, DocumentTypePassport: {}, DocumentTypeProofOfAddress: {},
+	}
+	// Content types accepted for uploads, as detected from the file content.
+	validDocumentContentTypes = map[string]struct{}{
+		"application/pdf": {}, "image/jpeg": {}, "image/png": {},
+	}
+)
+
+// IsValidDocumentContentType reports whether documents of the given content
+// type are accepted.
+func IsValidDocumentContentType(contentType string) bool {
+	_, valid := validDocumentContentTypes[contentType]
+	return valid
+}
+
+// Validate checks if the document metadata adheres to the spec. Identity
+// documents need an expiry date, which must not have passed.
+func (d *Document) Validate() error {
+	return d.validate(time.Now().UTC())
+}
+
+func (d *Document) validate(today time.Time) error {
+	if _, valid := validDocumentTypes[d.Type]; !valid {
+		return errors.New("type must be ID_CARD, PASSPORT or PROOF_OF_ADDRESS")
+	}
+	if !IsValidCountry(d.IssuingCountry) {
+		return errors.New("invalid issuing_country code")
+	}
+	if d.ExpiryDate == "" {
+		if d.Type != DocumentTypeProofOfAddress {
+			return fmt.Errorf("expiry_date is required for %s", d.Type)
+		}
+	} else {
+		expiryDate, err := time.Parse(DateLayout, d.ExpiryDate)
+		if err != nil {
+			return errors.New("expiry_date must be a date in YYYY-MM-DD format")
+		}
+		if expiryDate.Before(today.Truncate(24 * time.Hour)) {
+			return errors.New("expiry_date must not be in the past")
+		}
+	}
+	if len(d.FileName) < 1 || len(d.FileName) > 255 {
+		return errors.New("file name must be between 1 and 255 characters")
+	}
+	if !IsValidDocumentContentType(d.ContentType) {
+		return errors.New("file must be a PDF, JPEG or PNG")
+	}
+	if d.Size < 1 || d.Size > MaxDocumentSize {
+		return fmt.Errorf("file must not be empty or larger than %d bytes", MaxDocumentSize)
+	}
+	return nil
+}
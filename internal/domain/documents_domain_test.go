@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Document_Validate(t *testing.T) {
+	today := date("2025-04-20")
+	valid := func() Document {
+		return Document{
+			Type:           DocumentTypePassport,
+			IssuingCountry: "DE",
+			ExpiryDate:     "2025-04-20",
+			FileName:       "passport.pdf",
+			ContentType:    "application/pdf",
+			Size:           2048,
+		}
+	}
+
+	document := valid()
+	assert.NoError(t, document.validate(today))
+
+	document = valid()
+	document.Type, document.ExpiryDate = DocumentTypeProofOfAddress, ""
+	assert.NoError(t, document.validate(today))
+
+	tests := []struct {
+		name   string
+		modify func(*Document)
+		err    string
+	}{
+		{"unknown type", func(d *Document) { d.Type = "DRIVING_LICENCE" }, "type must be ID_CARD, PASSPORT or PROOF_OF_ADDRESS"},
+		{"unknown country", func(d *Document) { d.IssuingCountry = "XX" }, "invalid issuing_country code"},
+		{"identity document without expiry", func(d *Document) { d.ExpiryDate = "" }, "expiry_date is required for PASSPORT"},
+		{"malformed expiry", func(d *Document) { d.ExpiryDate = "20.04.2025" }, "expiry_date must be a date in YYYY-MM-DD format"},
+		{"expired", func(d *Document) { d.ExpiryDate = "2025-04-19" }, "expiry_date must not be in the past"},
+		{"unsupported content", func(d *Document) { d.ContentType = "text/plain; charset=utf-8" }, "file must be a PDF, JPEG or PNG"},
+		{"too large", func(d *Document) { d.Size = MaxDocumentSize + 1 }, "file must not be empty or larger than 10485760 bytes"},
+	}
+
+	for _, tt := range tests {
+		document := valid()
+		tt.modify(&document)
+		assert.EqualError(t, document.validate(today), tt.err, tt.name)
+	}
+}
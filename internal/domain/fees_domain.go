@@ -0,0 +1,183 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+)
+
+const (
+	FeeTypeTrade   = "TRADE"
+	FeeTypeCustody = "CUSTODY"
+
+	// FeeMethodFlat charges a fixed amount per trade.
+	FeeMethodFlat = "FLAT"
+	// FeeMethodPercentage charges a percentage of the trade's notional, bounded
+	// by an optional minimum and maximum.
+	FeeMethodPercentage = "PERCENTAGE"
+	// FeeMethodBasisPoints charges an annual rate in basis points of the
+	// custody value, pro rata for the days of the charged period.
+	FeeMethodBasisPoints = "BASIS_POINTS"
+
+	// FeeSegmentDefault applies to users without a segment, and to segments
+	// without a schedule of their own.
+	FeeSegmentDefault = "DEFAULT"
+
+	maxFeeRateDecimals = 6
+	maxFeeReference    = 100
+)
+
+var (
+	// ErrNoFeeSchedule is returned when neither the user's segment nor the
+	// default segment has a schedule for a fee.
+	ErrNoFeeSchedule     = errors.New("no fee schedule applies")
+	ErrFeeAlreadyCharged = errors.New("fee already charged")
+)
+
+// FeeSchedule defines how one type of fee is calculated for the users of a
+// segment in a currency. Amounts are in minor units; a MaxAmount of zero means
+// the fee is not capped.
+type FeeSchedule struct {
+	ID         string `json:"id"`
+	CreatedAt  string `json:"created_at,omitempty"`
+	UpdatedAt  string `json:"updated_at,omitempty"`
+	Segment    string `json:"segment"`
+	FeeType    string `json:"fee_type"`
+	Method     string `json:"method"`
+	Currency   string `json:"currency"`
+	FlatAmount int64  `json:"flat_amount,omitempty"`
+	// Rate is a percentage for PERCENTAGE schedules and basis points per annum
+	// for BASIS_POINTS schedules.
+	Rate      string `json:"rate,omitempty"`
+	MinAmount int64  `json:"min_amount,omitempty"`
+	MaxAmount int64  `json:"max_amount,omitempty"`
+}
+
+// FeeCharge asks for a fee to be calculated and charged to a user. The
+// reference identifies what is charged, e.g. an order ID or a custody period,
+// and a fee is charged only once per reference.
+type FeeCharge struct {
+	FeeType   string `json:"fee_type"`
+	Reference string `json:"reference"`
+	Currency  string `json:"currency"`
+	// BasisAmount is the trade's notional for TRADE fees and the average
+	// custody value of the period for CUSTODY fees, in minor units.
+	BasisAmount int64 `json:"basis_amount"`
+	// PeriodStart and the exclusive PeriodEnd bound the custody period.
+	PeriodStart string `json:"period_start,omitempty"`
+	PeriodEnd   string `json:"period_end,omitempty"`
+}
+
+// Fee is a fee charged to a user's available cash.
+type Fee struct {
+	ID             string `json:"id"`
+	CreatedAt      string `json:"created_at,omitempty"`
+	UserID         string `json:"user_id"`
+	FeeScheduleID  string `json:"fee_schedule_id"`
+	FeeType        string `json:"fee_type"`
+	Reference      string `json:"reference"`
+	BasisAmount    int64  `json:"basis_amount"`
+	Amount         int64  `json:"amount"`
+	Currency       string `json:"currency"`
+	JournalEntryID string `json:"journal_entry_id,omitempty"`
+}
+
+// feeMethods lists the calculation methods allowed per fee type.
+var feeMethods = map[string][]string{
+	FeeTypeTrade:   {FeeMethodFlat, FeeMethodPercentage},
+	FeeTypeCustody: {FeeMethodBasisPoints},
+}
+
+var segmentRegex = regexp.MustCompile(`^[A-Z][A-Z0-9_]{0,29}$`)
+
+// IsValidSegment reports whether segment is a valid user segment name.
+func IsValidSegment(segment string) bool {
+	return segmentRegex.MatchString(segment)
+}
+
+func (s *FeeSchedule) Validate() error {
+	if !IsValidSegment(s.Segment) {
+		return errors.New("segment must be 1 to 30 upper case letters, digits or underscores")
+	}
+	methods, valid := feeMethods[s.FeeType]
+	if !valid {
+		return errors.New("fee_type must be TRADE or CUSTODY")
+	}
+	allowed := false
+	for _, method := range methods {
+		allowed = allowed || method == s.Method
+	}
+	if !allowed {
+		return fmt.Errorf("method %q is not allowed for %s fees", s.Method, s.FeeType)
+	}
+	if !IsValidCurrency(s.Currency) {
+		return errors.New("invalid currency code")
+	}
+
+	if s.Method == FeeMethodFlat {
+		if s.FlatAmount <= 0 {
+			return errors.New("flat_amount must be positive")
+		}
+		if s.Rate != "" || s.MinAmount != 0 || s.MaxAmount != 0 {
+			return errors.New("flat fees take no rate, min_amount or max_amount")
+		}
+		return nil
+	}
+
+	if s.FlatAmount != 0 {
+		return errors.New("flat_amount is only allowed for flat fees")
+	}
+	if places, ok := positiveDecimalPlaces(s.Rate); !ok || places > maxFeeRateDecimals {
+		return fmt.Errorf("rate must be a positive decimal with at most %d decimal places", maxFeeRateDecimals)
+	}
+	if s.MinAmount < 0 {
+		return errors.New("min_amount must not be negative")
+	}
+	if s.MaxAmount != 0 && s.MaxAmount < s.MinAmount {
+		return errors.New("max_amount must not be less than min_amount")
+	}
+	return nil
+}
+
+func (c *FeeCharge) Validate() error {
+	if _, valid := feeMethods[c.FeeType]; !valid {
+		return errors.New("fee_type must be TRADE or CUSTODY")
+	}
+	if c.Reference == "" || len(c.Reference) > maxFeeReference {
+		return fmt.Errorf("reference is required and must be at most %d characters", maxFeeReference)
+	}
+	if !IsValidCurrency(c.Currency) {
+		return errors.New("invalid currency code")
+	}
+	if c.BasisAmount < 0 {
+		return errors.New("basis_amount must not be negative")
+	}
+
+	if c.FeeType == FeeTypeTrade {
+		if c.PeriodStart != "" || c.PeriodEnd != "" {
+			return errors.New("period_start and period_end are only allowed for custody fees")
+		}
+		return nil
+	}
+	if _, err := c.Days(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Days returns the number of days of a custody charge's period.
+func (c *FeeCharge) Days() (int, error) {
+	start, err := time.Parse(DateLayout, c.PeriodStart)
+	if err != nil {
+		return 0, errors.New("period_start must be in YYYY-MM-DD format")
+	}
+	end, err := time.Parse(DateLayout, c.PeriodEnd)
+	if err != nil {
+		return 0, errors.New("period_end must be in YYYY-MM-DD format")
+	}
+	if !end.After(start) {
+		return 0, errors.New("period_end must be after period_start")
+	}
+	return int(end.Sub(start).Hours() / 24), nil
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FeeSchedule_Validate(t *testing.T) {
+	valid := []FeeSchedule{
+		{Segment: "DEFAULT", FeeType: FeeTypeTrade, Method: FeeMethodFlat, Currency: "EUR", FlatAmount: 100},
+		{Segment: "PREMIUM", FeeType: FeeTypeTrade, Method: FeeMethodPercentage, Currency: "EUR", Rate: "0.25", MinAmount: 99, MaxAmount: 2500},
+		{Segment: "PREMIUM", FeeType: FeeTypeCustody, Method: FeeMethodBasisPoints, Currency: "EUR", Rate: "12.5"},
+	}
+	for _, schedule := range valid {
+		assert.NoError(t, schedule.Validate(), schedule.Method)
+	}
+
+	tests := []struct {
+		name     string
+		schedule FeeSchedule
+		err      string
+	}{
+		{"lower case segment", FeeSchedule{Segment: "premium"}, "segment must be 1 to 30 upper case letters, digits or underscores"},
+		{"unknown fee type", FeeSchedule{Segment: "DEFAULT", FeeType: "DEPOSIT"}, "fee_type must be TRADE or CUSTODY"},
+		{"flat custody fee", FeeSchedule{Segment: "DEFAULT", FeeType: FeeTypeCustody, Method: FeeMethodFlat}, `method "FLAT" is not allowed for CUSTODY fees`},
+		{"flat fee with rate", FeeSchedule{Segment: "DEFAULT", FeeType: FeeTypeTrade, Method: FeeMethodFlat, Currency: "EUR", FlatAmount: 100, Rate: "1"}, "flat fees take no rate, min_amount or max_amount"},
+		{"zero rate", FeeSchedule{Segment: "DEFAULT", FeeType: FeeTypeTrade, Method: FeeMethodPercentage, Currency: "EUR", Rate: "0"}, "rate must be a positive decimal with at most 6 decimal places"},
+		{"max below min", FeeSchedule{Segment: "DEFAULT", FeeType: FeeTypeTrade, Method: FeeMethodPercentage, Currency: "EUR", Rate: "0.25", MinAmount: 100, MaxAmount: 99}, "max_amount must not be less than min_amount"},
+	}
+
+	for _, tt := range tests {
+		assert.EqualError(t, tt.schedule.Validate(), tt.err, tt.name)
+	}
+}
+
+func Test_FeeCharge_Validate(t *testing.T) {
+	trade := FeeCharge{FeeType: FeeTypeTrade, Reference: "order-1", Currency: "EUR", BasisAmount: 10000}
+	assert.NoError(t, trade.Validate())
+
+	custody := FeeCharge{FeeType: FeeTypeCustody, Reference: "2025-04", Currency: "EUR", BasisAmount: 10000,
+		PeriodStart: "2025-04-01", PeriodEnd: "2025-05-01"}
+	assert.NoError(t, custody.Validate())
+	days, _ := custody.Days()
+	assert.Equal(t, 30, days)
+
+	trade.PeriodStart = "2025-04-01"
+	assert.EqualError(t, trade.Validate(), "period_start and period_end are only allowed for custody fees")
+
+	custody.PeriodEnd = "2025-04-01"
+	assert.EqualError(t, custody.Validate(), "period_end must be after period_start")
+
+	custody.Reference = ""
+	assert.EqualError(t, custody.Validate(), "reference is required and must be at most 100 characters")
+}
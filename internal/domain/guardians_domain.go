@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// AgeOfMajority is the age from which users act for themselves.
+	AgeOfMajority = 18
+	// MaxGuardians is the number of guardians a minor can have at a time.
+	MaxGuardians = 2
+	// maxPlausibleAge bounds the birth dates users are accepted with.
+	maxPlausibleAge = 125
+)
+
+var (
+	// ErrGuardianNotAccepted is wrapped with the reason a user cannot become
+	// the guardian of another.
+	ErrGuardianNotAccepted = errors.New("guardian not accepted")
+	// ErrTooManyGuardians is returned when a minor already has MaxGuardians.
+	ErrTooManyGuardians = errors.New("minor already has the maximum number of guardians")
+	// ErrGuardianRequired is returned when a minor without a guardian acts on
+	// their own.
+	ErrGuardianRequired = errors.New("minor has no guardian")
+)
+
+// Guardianship links a minor to a guardian. It ends when the minor comes of
+// age.
+type Guardianship struct {
+	UserID     string `json:"user_id"`
+	GuardianID string `json:"guardian_id"`
+	CreatedAt  string `json:"created_at,omitempty"`
+	EndedAt    string `json:"ended_at,omitempty"`
+}
+
+// AgeOn returns the age in completed years of someone born on birthDate. A
+// person born on 29 February turns a year older on 1 March in common years.
+func AgeOn(birthDate, on time.Time) int {
+	age := on.Year() - birthDate.Year()
+	if on.Month() < birthDate.Month() || (on.Month() == birthDate.Month() && on.Day() < birthDate.Day()) {
+		age--
+	}
+	return age
+}
+
+// IsMinorOn reports whether the user is younger than AgeOfMajority on the
+// given day. Users with an unparseable birth date are not minors.
+func (u *User) IsMinorOn(on time.Time) bool {
+	birthDate, err := time.Parse(DateLayout, u.BirthDate)
+	if err != nil {
+		return false
+	}
+	return AgeOn(birthDate, on) < AgeOfMajority
+}
+
+// ComingOfAgeCutoff returns the latest birth date of users who are of age on
+// the given day, consistent with AgeOn.
+func ComingOfAgeCutoff(on time.Time) time.Time {
+	cutoff := on.AddDate(-AgeOfMajority, 0, 0)
+	if cutoff.Day() != on.Day() {
+		// 29 February of a common year normalised to 1 March.
+		cutoff = cutoff.AddDate(0, 0, -cutoff.Day())
+	}
+	return cutoff
+}
+
+func validateBirthDate(birthDate, today time.Time) error {
+	if birthDate.After(today) {
+		return errors.New("birth_date must not be in the future")
+	}
+	if birthDate.Before(today.AddDate(-maxPlausibleAge, 0, 0)) {
+		return errors.New("birth_date must not be more than 125 years ago")
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AgeOn(t *testing.T) {
+	tests := []struct {
+		birthDate, on string
+		expected      int
+	}{
+		{"2008-05-10", "2026-05-09", 17},
+		{"2008-05-10", "2026-05-10", 18},
+		{"2008-02-29", "2026-02-28", 17},
+		{"2008-02-29", "2026-03-01", 18},
+		{"2006-03-01", "2024-02-29", 17},
+		{"2024-01-01", "2024-01-01", 0},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, AgeOn(date(tt.birthDate), date(tt.on)), tt.birthDate+" on "+tt.on)
+	}
+}
+
+func Test_ComingOfAgeCutoff(t *testing.T) {
+	for _, on := range []string{"2026-05-10", "2026-02-28", "2026-03-01", "2024-02-29", "2026-12-31"} {
+		cutoff := ComingOfAgeCutoff(date(on))
+
+		assert.Equal(t, AgeOfMajority, AgeOn(cutoff, date(on)), on)
+		assert.Equal(t, AgeOfMajority-1, AgeOn(cutoff.AddDate(0, 0, 1), date(on)), on)
+	}
+}
+
+func Test_User_IsMinorOn(t *testing.T) {
+	user := User{BirthDate: "2008-05-10"}
+
+	assert.True(t, user.IsMinorOn(date("2026-05-09")))
+	assert.False(t, user.IsMinorOn(date("2026-05-10")))
+}
+
+func Test_ValidateBirthDate(t *testing.T) {
+	today := date("2026-05-10")
+
+	assert.NoError(t, validateBirthDate(date("2026-05-10"), today))
+	assert.NoError(t, validateBirthDate(date("1901-05-10"), today))
+	assert.EqualError(t, validateBirthDate(date("2026-05-11"), today), "birth_date must not be in the future")
+	assert.EqualError(t, validateBirthDate(date("1901-05-09"), today), "birth_date must not be more than 125 years ago")
+}
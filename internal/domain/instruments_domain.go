@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+)
+
+const (
+	InstrumentTypeEquity = "EQUITY"
+	InstrumentTypeETF    = "ETF"
+	InstrumentTypeFund   = "FUND"
+	InstrumentTypeBond   = "BOND"
+
+	TradingStatusActive    = "ACTIVE"
+	TradingStatusSuspended = "SUSPENDED"
+	TradingStatusDelisted  = "DELISTED"
+)
+
+type Instrument struct {
+	ISIN          string `json:"isin"`
+	CreatedAt     string `json:"created_at,omitempty"`
+	UpdatedAt     string `json:"updated_at,omitempty"`
+	WKN           string `json:"wkn,omitempty"`
+	Name          string `json:"name"`
+	Type          string `json:"type"`
+	Currency      string `json:"currency"`
+	TradingStatus string `json:"trading_status"`
+}
+
+var (
+	validInstrumentTypes = map[string]struct{}{
+		InstrumentTypeEquity: {},
+		InstrumentTypeETF:    {},
+		InstrumentTypeFund:   {},
+		InstrumentTypeBond:   {},
+	}
+	validTradingStatuses = map[string]struct{}{
+		TradingStatusActive:    {},
+		TradingStatusSuspended: {},
+		TradingStatusDelisted:  {},
+	}
+	// Regex for ISIN validation: country prefix, national code, check digit.
+	isinRegex = regexp.MustCompile(`^[A-Z]{2}[A-Z0-9]{9}[0-9]$`)
+	// Regex for WKN validation. The letters I and O are not used.
+	wknRegex = regexp.MustCompile(`^[A-HJ-NP-Z0-9]{6}$`)
+)
+
+// IsValidInstrumentType reports whether t is a known instrument type.
+func IsValidInstrumentType(t string) bool {
+	_, valid := validInstrumentTypes[t]
+	return valid
+}
+
+// IsValidTradingStatus reports whether status is a known trading status.
+func IsValidTradingStatus(status string) bool {
+	_, valid := validTradingStatuses[status]
+	return valid
+}
+
+// IsValidISIN reports whether isin is a well-formed ISIN (ISO 6166) with a
+// correct check digit.
+func IsValidISIN(isin string) bool {
+	if !isinRegex.MatchString(isin) {
+		return false
+	}
+
+	// Letters expand to two digits (A=10 ... Z=35); the resulting digit string,
+	// check digit included, must pass the Luhn algorithm.
+	digits := make([]int, 0, 2*len(isin))
+	for _, c := range isin {
+		if c >= 'A' && c <= 'Z' {
+			value := int(c-'A') + 10
+			digits = append(digits, value/10, value%10)
+		} else {
+			digits = append(digits, int(c-'0'))
+		}
+	}
+
+	sum := 0
+	for i := len(digits) - 1; i >= 0; i-- {
+		digit := digits[i]
+		if (len(digits)-1-i)%2 == 1 {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+	}
+	return sum%10 == 0
+}
+
+// IsValidWKN reports whether wkn is a well-formed German securities
+// identification number.
+func IsValidWKN(wkn string) bool {
+	return wknRegex.MatchString(wkn)
+}
+
+// Validate checks if the instrument object adheres to the spec.
+func (i *Instrument) Validate() error {
+	if !IsValidISIN(i.ISIN) {
+		return errors.New("isin must be a valid ISIN")
+	}
+	if i.WKN != "" && !IsValidWKN(i.WKN) {
+		return errors.New("wkn must be a valid WKN")
+	}
+	if len(i.Name) < 1 || len(i.Name) > 255 {
+		return errors.New("name must be between 1 and 255 characters")
+	}
+	if !IsValidInstrumentType(i.Type) {
+		return errors.New("invalid instrument type")
+	}
+	if !IsValidCurrency(i.Currency) {
+		return errors.New("invalid currency code")
+	}
+	if !IsValidTradingStatus(i.TradingStatus) {
+		return errors.New("invalid trading_status")
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_IsValidISIN(t *testing.T) {
+	tests := []struct {
+		isin  string
+		valid bool
+	}{
+		{"US0378331005", true},
+		{"DE0007164600", true},
+		{"IE00B4L5Y983", true},
+		{"GB0002634946", true},
+		{"XS2314659447", true},
+		{"US0378331006", false},
+		{"DE0007164601", false},
+		{"IE00B4L5Y984", false},
+		{"us0378331005", false},
+		{"US037833100", false},
+		{"US03783310055", false},
+		{"1S0378331005", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.valid, IsValidISIN(tt.isin), tt.isin)
+	}
+}
+
+func Test_IsValidWKN(t *testing.T) {
+	assert.True(t, IsValidWKN("716460"))
+	assert.True(t, IsValidWKN("A0RPWH"))
+	assert.False(t, IsValidWKN("A0RPWO"))
+	assert.False(t, IsValidWKN("71646"))
+	assert.False(t, IsValidWKN("a0rpwh"))
+}
+
+func Test_Instrument_Validate(t *testing.T) {
+	instrument := Instrument{
+		ISIN:          "IE00B4L5Y983",
+		WKN:           "A0RPWH",
+		Name:          "iShares Core MSCI World UCITS ETF",
+		Type:          InstrumentTypeETF,
+		Currency:      "USD",
+		TradingStatus: TradingStatusActive,
+	}
+	assert.NoError(t, instrument.Validate())
+
+	invalid := instrument
+	invalid.Currency = "XXX"
+	assert.EqualError(t, invalid.Validate(), "invalid currency code")
+
+	invalid = instrument
+	invalid.Type = "CRYPTO"
+	assert.EqualError(t, invalid.Validate(), "invalid instrument type")
+}
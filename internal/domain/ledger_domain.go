@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Ledger accounts. USER_* accounts hold a user's cash and carry the user's ID;
+// the others are accounts of the platform.
+const (
+	// LedgerAccountUserAvailable holds settled cash the user can dispose of.
+	LedgerAccountUserAvailable = "USER_AVAILABLE"
+	// LedgerAccountUserReserved holds settled cash earmarked for pending
+	// orders or payouts.
+	LedgerAccountUserReserved = "USER_RESERVED"
+	// LedgerAccountClearing is the platform's cash at its settlement banks.
+	LedgerAccountClearing = "CLEARING"
+	// LedgerAccountFeeIncome collects the fees charged to users.
+	LedgerAccountFeeIncome = "FEE_INCOME"
+
+	maxJournalEntryDescriptionLength = 200
+)
+
+var (
+	ErrUnbalancedJournalEntry = errors.New("journal entry is not balanced")
+	ErrNonZeroBalance         = errors.New("user has a non-zero balance")
+)
+
+var validLedgerAccounts = map[string]struct{}{
+	LedgerAccountUserAvailable: {},
+	LedgerAccountUserReserved:  {},
+	LedgerAccountClearing:      {},
+	LedgerAccountFeeIncome:     {},
+}
+
+// JournalEntry is a set of postings booked together. Its postings sum to zero
+// in every currency, so money only ever moves between ledger accounts.
+type JournalEntry struct {
+	ID          string    `json:"id"`
+	CreatedAt   string    `json:"created_at,omitempty"`
+	Description string    `json:"description"`
+	Reference   string    `json:"reference,omitempty"`
+	Postings    []Posting `json:"postings"`
+}
+
+// Posting moves Amount minor units of Currency into a ledger account; negative
+// amounts move money out of it. Postings are never changed or deleted.
+type Posting struct {
+	ID             string `json:"id"`
+	JournalEntryID string `json:"journal_entry_id,omitempty"`
+	CreatedAt      string `json:"created_at,omitempty"`
+	LedgerAccount  string `json:"ledger_account"`
+	UserID         string `json:"user_id,omitempty"`
+	Currency       string `json:"currency"`
+	Amount         int64  `json:"amount"`
+}
+
+// Balance is a user's cash in one currency, in minor units. Settled cash is
+// the sum of available and reserved cash.
+type Balance struct {
+	Currency  string `json:"currency"`
+	Available int64  `json:"available"`
+	Reserved  int64  `json:"reserved"`
+	Settled   int64  `json:"settled"`
+}
+
+// IsUserLedgerAccount reports whether account holds the cash of a user.
+func IsUserLedgerAccount(account string) bool {
+	return strings.HasPrefix(account, "USER_")
+}
+
+func (e *JournalEntry) Validate() error {
+	if strings.TrimSpace(e.Description) == "" {
+		return errors.New("description is required")
+	}
+	if len(e.Description) > maxJournalEntryDescriptionLength {
+		return fmt.Errorf("description must be at most %d characters", maxJournalEntryDescriptionLength)
+	}
+	if len(e.Postings) < 2 {
+		return fmt.Errorf("%w: at least two postings are required", ErrUnbalancedJournalEntry)
+	}
+
+	sums := make(map[string]int64)
+	for i, posting := range e.Postings {
+		if _, valid := validLedgerAccounts[posting.LedgerAccount]; !valid {
+			return fmt.Errorf("postings[%d]: invalid ledger account %q", i, posting.LedgerAccount)
+		}
+		if IsUserLedgerAccount(posting.LedgerAccount) {
+			if !IsValidUUID(posting.UserID) {
+				return fmt.Errorf("postings[%d]: user_id must be a valid UUID", i)
+			}
+		} else if posting.UserID != "" {
+			return fmt.Errorf("postings[%d]: user_id is only allowed on user ledger accounts", i)
+		}
+		if !IsValidCurrency(posting.Currency) {
+			return fmt.Errorf("postings[%d]: invalid currency code", i)
+		}
+		if posting.Amount == 0 {
+			return fmt.Errorf("postings[%d]: amount must not be zero", i)
+		}
+		sums[posting.Currency] += posting.Amount
+	}
+
+	for currency, sum := range sums {
+		if sum != 0 {
+			return fmt.Errorf("%w: %s postings sum to %d", ErrUnbalancedJournalEntry, currency, sum)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_JournalEntry_Validate(t *testing.T) {
+	const userID = "0b6f6b6e-3c1f-4a7e-9d6a-2f1c8e4b5a77"
+
+	deposit := func() JournalEntry {
+		return JournalEntry{
+			Description: "Deposit",
+			Postings: []Posting{
+				{LedgerAccount: LedgerAccountClearing, Currency: "EUR", Amount: -10000},
+				{LedgerAccount: LedgerAccountUserAvailable, UserID: userID, Currency: "EUR", Amount: 10000},
+			},
+		}
+	}
+	valid := deposit()
+	assert.NoError(t, valid.Validate())
+
+	unbalanced := deposit()
+	unbalanced.Postings[0].Amount = -9999
+	assert.True(t, errors.Is(unbalanced.Validate(), ErrUnbalancedJournalEntry))
+
+	// Each currency must balance on its own.
+	mixed := deposit()
+	mixed.Postings[0].Currency = "USD"
+	assert.True(t, errors.Is(mixed.Validate(), ErrUnbalancedJournalEntry))
+
+	single := deposit()
+	single.Postings = single.Postings[:1]
+	assert.True(t, errors.Is(single.Validate(), ErrUnbalancedJournalEntry))
+
+	tests := []struct {
+		name   string
+		mutate func(e *JournalEntry)
+	}{
+		{"missing description", func(e *JournalEntry) { e.Description = " " }},
+		{"unknown ledger account", func(e *JournalEntry) { e.Postings[0].LedgerAccount = "SUSPENSE" }},
+		{"user account without user", func(e *JournalEntry) { e.Postings[1].UserID = "" }},
+		{"platform account with user", func(e *JournalEntry) { e.Postings[0].UserID = userID }},
+		{"invalid currency", func(e *JournalEntry) { e.Postings[0].Currency = "EURO" }},
+		{"zero amount", func(e *JournalEntry) { e.Postings[0].Amount, e.Postings[1].Amount = 0, 0 }},
+	}
+	for _, tt := range tests {
+		entry := deposit()
+		tt.mutate(&entry)
+		assert.Error(t, entry.Validate(), tt.name)
+	}
+}
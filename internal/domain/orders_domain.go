@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	OrderSideBuy  = "BUY"
+	OrderSideSell = "SELL"
+
+	OrderTypeMarket = "MARKET"
+	OrderTypeLimit  = "LIMIT"
+
+	OrderStatusNew             = "NEW"
+	OrderStatusProcessing      = "PROCESSING"
+	OrderStatusPartiallyFilled = "PARTIALLY_FILLED"
+	OrderStatusFilled          = "FILLED"
+	OrderStatusCancelled       = "CANCELLED"
+	OrderStatusRejected        = "REJECTED"
+
+	// Fractional trading allows quantities down to a millionth of a share.
+	maxQuantityDecimals   = 6
+	maxLimitPriceDecimals = 4
+)
+
+var (
+	ErrIllegalOrderTransition = errors.New("illegal order status transition")
+	// ErrOrderNotAccepted is wrapped with the reason an otherwise valid order
+	// cannot be placed, e.g. an instrument that is not tradable.
+	ErrOrderNotAccepted = errors.New("order not accepted")
+)
+
+type Order struct {
+	ID             string `json:"id"`
+	CreatedAt      string `json:"created_at,omitempty"`
+	UpdatedAt      string `json:"updated_at,omitempty"`
+	UserID         string `json:"user_id"`
+	AccountID      string `json:"account_id"`
+	ISIN           string `json:"isin"`
+	Side           string `json:"side"`
+	Type           string `json:"type"`
+	Quantity       string `json:"quantity,omitempty"`
+	CashAmount     string `json:"cash_amount,omitempty"`
+	LimitPrice     string `json:"limit_price,omitempty"`
+	Currency       string `json:"currency"`
+	FilledQuantity string `json:"filled_quantity,omitempty"`
+	Status         string `json:"status,omitempty"`
+}
+
+// orderStatusTransitions lists the statuses an order may move to from each
+// status. FILLED, CANCELLED and REJECTED are terminal.
+var orderStatusTransitions = map[string][]string{
+	OrderStatusNew:             {OrderStatusProcessing, OrderStatusCancelled, OrderStatusRejected},
+	OrderStatusProcessing:      {OrderStatusPartiallyFilled, OrderStatusFilled, OrderStatusCancelled, OrderStatusRejected},
+	OrderStatusPartiallyFilled: {OrderStatusPartiallyFilled, OrderStatusFilled, OrderStatusCancelled},
+	OrderStatusFilled:          {},
+	OrderStatusCancelled:       {},
+	OrderStatusRejected:        {},
+}
+
+// ValidateOrderStatusTransition returns an error wrapping ErrIllegalOrderTransition
+// if an order in status from may not move to status to.
+func ValidateOrderStatusTransition(from, to string) error {
+	for _, allowed := range orderStatusTransitions[from] {
+		if allowed == to {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s to %s", ErrIllegalOrderTransition, from, to)
+}
+
+// IsValidOrderStatus reports whether status is a known order status.
+func IsValidOrderStatus(status string) bool {
+	_, valid := orderStatusTransitions[status]
+	return valid
+}
+
+// Regex for unsigned decimal numbers without exponent or superfluous leading zeros.
+var decimalRegex = regexp.MustCompile(`^(0|[1-9][0-9]{0,14})(\.[0-9]+)?$`)
+
+// positiveDecimalPlaces returns the number of decimal places of s if s is a
+// positive decimal number.
+func positiveDecimalPlaces(s string) (int, bool) {
+	if !decimalRegex.MatchString(s) || strings.Trim(s, "0.") == "" {
+		return 0, false
+	}
+	_, fraction, _ := strings.Cut(s, ".")
+	return len(fraction), true
+}
+
+// Validate checks if the order object adheres to the spec. Orders are placed
+// either by quantity or, for fractional trading of market orders, by cash
+// amount.
+func (o *Order) Validate() error {
+	if !IsValidUUID(o.UserID) {
+		return errors.New("user_id must be a valid UUID")
+	}
+	if !IsValidUUID(o.AccountID) {
+		return errors.New("account_id must be a valid UUID")
+	}
+	if !IsValidISIN(o.ISIN) {
+		return errors.New("isin must be a valid ISIN")
+	}
+	if o.Side != OrderSideBuy && o.Side != OrderSideSell {
+		return errors.New("side must be BUY or SELL")
+	}
+	if o.Type != OrderTypeMarket && o.Type != OrderTypeLimit {
+		return errors.New("type must be MARKET or LIMIT")
+	}
+	minorUnits, valid := CurrencyMinorUnits(o.Currency)
+	if !valid {
+		return errors.New("invalid currency code")
+	}
+
+	if (o.Quantity == "") == (o.CashAmount == "") {
+		return errors.New("exactly one of quantity and cash_amount is required")
+	}
+	if o.Quantity != "" {
+		if places, ok := positiveDecimalPlaces(o.Quantity); !ok || places > maxQuantityDecimals {
+			return fmt.Errorf("quantity must be a positive decimal with at most %d decimal places", maxQuantityDecimals)
+		}
+	}
+	if o.CashAmount != "" {
+		if o.Type == OrderTypeLimit {
+			return errors.New("limit orders must be placed by quantity")
+		}
+		if places, ok := positiveDecimalPlaces(o.CashAmount); !ok || places > minorUnits {
+			return fmt.Errorf("cash_amount must be a positive decimal with at most %d decimal places", minorUnits)
+		}
+	}
+
+	if o.Type == OrderTypeLimit {
+		if places, ok := positiveDecimalPlaces(o.LimitPrice); !ok || places > maxLimitPriceDecimals {
+			return fmt.Errorf("limit_price must be a positive decimal with at most %d decimal places", maxLimitPriceDecimals)
+		}
+	} else if o.LimitPrice != "" {
+		return errors.New("limit_price is only allowed for limit orders")
+	}
+	return nil
+}
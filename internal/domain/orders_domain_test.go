@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ValidateOrderStatusTransition(t *testing.T) {
+	tests := []struct {
+		from, to string
+		allowed  bool
+	}{
+		{OrderStatusNew, OrderStatusProcessing, true},
+		{OrderStatusNew, OrderStatusCancelled, true},
+		{OrderStatusNew, OrderStatusRejected, true},
+		{OrderStatusProcessing, OrderStatusPartiallyFilled, true},
+		{OrderStatusProcessing, OrderStatusFilled, true},
+		{OrderStatusPartiallyFilled, OrderStatusPartiallyFilled, true},
+		{OrderStatusPartiallyFilled, OrderStatusFilled, true},
+		{OrderStatusPartiallyFilled, OrderStatusCancelled, true},
+		{OrderStatusNew, OrderStatusFilled, false},
+		{OrderStatusPartiallyFilled, OrderStatusRejected, false},
+		{OrderStatusFilled, OrderStatusCancelled, false},
+		{OrderStatusCancelled, OrderStatusCancelled, false},
+		{OrderStatusRejected, OrderStatusProcessing, false},
+	}
+
+	for _, tt := range tests {
+		err := ValidateOrderStatusTransition(tt.from, tt.to)
+		if tt.allowed {
+			assert.NoError(t, err, "%s -> %s", tt.from, tt.to)
+		} else {
+			assert.True(t, errors.Is(err, ErrIllegalOrderTransition), "%s -> %s", tt.from, tt.to)
+		}
+	}
+}
+
+func Test_Order_Validate(t *testing.T) {
+	valid := Order{
+		UserID:    "0b6f6b6e-3c1f-4a7e-9d6a-2f1c8e4b5a77",
+		AccountID: "4c3e1d2b-8a7f-4e6d-9c5b-1a2b3c4d5e6f",
+		ISIN:      "DE0007164600",
+		Side:      OrderSideBuy,
+		Type:      OrderTypeMarket,
+		Quantity:  "0.125",
+		Currency:  "EUR",
+	}
+	assert.NoError(t, valid.Validate())
+
+	tests := []struct {
+		name   string
+		modify func(o *Order)
+		err    string
+	}{
+		{"both quantity and amount", func(o *Order) { o.CashAmount = "100" }, "exactly one of quantity and cash_amount is required"},
+		{"neither quantity nor amount", func(o *Order) { o.Quantity = "" }, "exactly one of quantity and cash_amount is required"},
+		{"zero quantity", func(o *Order) { o.Quantity = "0.000" }, "quantity must be a positive decimal with at most 6 decimal places"},
+		{"too precise quantity", func(o *Order) { o.Quantity = "1.0000001" }, "quantity must be a positive decimal with at most 6 decimal places"},
+		{"negative quantity", func(o *Order) { o.Quantity = "-1" }, "quantity must be a positive decimal with at most 6 decimal places"},
+		{"amount below minor unit", func(o *Order) { o.Quantity, o.CashAmount = "", "10.001" }, "cash_amount must be a positive decimal with at most 2 decimal places"},
+		{"limit by amount", func(o *Order) { o.Type, o.Quantity, o.CashAmount, o.LimitPrice = OrderTypeLimit, "", "100", "10" }, "limit orders must be placed by quantity"},
+		{"limit without price", func(o *Order) { o.Type = OrderTypeLimit }, "limit_price must be a positive decimal with at most 4 decimal places"},
+		{"market with price", func(o *Order) { o.LimitPrice = "10" }, "limit_price is only allowed for limit orders"},
+		{"invalid isin", func(o *Order) { o.ISIN = "DE0007164601" }, "isin must be a valid ISIN"},
+		{"invalid side", func(o *Order) { o.Side = "SHORT" }, "side must be BUY or SELL"},
+	}
+
+	for _, tt := range tests {
+		order := valid
+		tt.modify(&order)
+		assert.EqualError(t, order.Validate(), tt.err, tt.name)
+	}
+
+	fractional := valid
+	fractional.Quantity, fractional.CashAmount = "", "25.50"
+	assert.NoError(t, fractional.Validate())
+
+	limit := valid
+	limit.Type, limit.LimitPrice = OrderTypeLimit, "151.2500"
+	assert.NoError(t, limit.Validate())
+}
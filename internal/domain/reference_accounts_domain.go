@@ -0,0 +1,138 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+const maxAccountHolderLength = 70
+
+var ErrHolderNameMismatch = errors.New("account holder does not match the user's name")
+
+// ReferenceAccount is a user's own bank account that withdrawals are paid out
+// to. Its holder must carry the user's name.
+type ReferenceAccount struct {
+	ID            string `json:"id"`
+	CreatedAt     string `json:"created_at,omitempty"`
+	UpdatedAt     string `json:"updated_at,omitempty"`
+	UserID        string `json:"user_id"`
+	IBAN          string `json:"iban"`
+	BIC           string `json:"bic"`
+	AccountHolder string `json:"account_holder"`
+}
+
+var (
+	// IBAN lengths per country as published in the SWIFT IBAN registry.
+	ibanLengths = map[string]int{
+		"AD": 24, "AE": 23, "AL": 28, "AT": 20, "AZ": 28, "BA": 20, "BE": 16, "BG": 22, "BH": 22, "BI": 27,
+		"BR": 29, "BY": 28, "CH": 21, "CR": 22, "CY": 28, "CZ": 24, "DE": 22, "DJ": 27, "DK": 18, "DO": 28,
+		"EE": 20, "EG": 29, "ES": 24, "FI": 18, "FK": 18, "FO": 18, "FR": 27, "GB": 22, "GE": 22, "GI": 23,
+		"GL": 18, "GR": 27, "GT": 28, "HR": 21, "HU": 28, "IE": 22, "IL": 23, "IQ": 23, "IS": 26, "IT": 27,
+		"JO": 30, "KW": 30, "KZ": 20, "LB": 28, "LC": 32, "LI": 21, "LT": 20, "LU": 20, "LV": 21, "LY": 25,
+		"MC": 27, "MD": 24, "ME": 22, "MK": 19, "MN": 20, "MR": 27, "MT": 31, "MU": 30, "NI": 28, "NL": 18,
+		"NO": 15, "OM": 23, "PK": 24, "PL": 28, "PS": 29, "PT": 25, "QA": 29, "RO": 24, "RS": 22, "RU": 33,
+		"SA": 24, "SC": 31, "SD": 18, "SE": 24, "SI": 19, "SK": 24, "SM": 27, "SO": 23, "ST": 25, "SV": 28,
+		"TL": 23, "TN": 24, "TR": 26, "UA": 29, "VA": 22, "VG": 24, "XK": 20, "YE": 30,
+	}
+	// Regex for the IBAN structure: country code, check digits and BBAN.
+	ibanRegex = regexp.MustCompile(`^[A-Z]{2}[0-9]{2}[A-Z0-9]{11,30}$`)
+	// Regex for ISO 9362 BICs: institution, country, location and optional branch.
+	bicRegex = regexp.MustCompile(`^[A-Z0-9]{4}[A-Z]{2}[A-Z0-9]{2}([A-Z0-9]{3})?$`)
+	// Transliterations applied before comparing names.
+	nameFolds = strings.NewReplacer(
+		"ä", "ae", "ö", "oe", "ü", "ue", "ß", "ss", "æ", "ae", "ø", "oe", "å", "aa",
+		"á", "a", "à", "a", "â", "a", "ã", "a", "ç", "c", "é", "e", "è", "e", "ê", "e", "ë", "e",
+		"í", "i", "ì", "i", "î", "i", "ï", "i", "ñ", "n", "ó", "o", "ò", "o", "ô", "o", "õ", "o",
+		"ú", "u", "ù", "u", "û", "u", "ý", "y", "ÿ", "y", "ł", "l", "š", "s", "ž", "z", "č", "c",
+	)
+)
+
+// NormalizeIBAN removes the spaces of the printed IBAN form and upper-cases it.
+func NormalizeIBAN(iban string) string {
+	return strings.ToUpper(strings.ReplaceAll(iban, " ", ""))
+}
+
+// IsValidIBAN reports whether iban is a well-formed IBAN in electronic format
+// with the registered length for its country and a valid mod-97 checksum.
+func IsValidIBAN(iban string) bool {
+	if !ibanRegex.MatchString(iban) {
+		return false
+	}
+	country := iban[:2]
+	if _, valid := validCountries[country]; !valid {
+		return false
+	}
+	if length, registered := ibanLengths[country]; !registered || len(iban) != length {
+		return false
+	}
+
+	// ISO 7064 MOD 97-10: move the first four characters to the end, replace
+	// letters with 10..35 and reduce the resulting number modulo 97.
+	remainder := 0
+	for _, r := range iban[4:] + iban[:4] {
+		if r >= 'A' && r <= 'Z' {
+			remainder = (remainder*100 + int(r-'A') + 10) % 97
+		} else {
+			remainder = (remainder*10 + int(r-'0')) % 97
+		}
+	}
+	return remainder == 1
+}
+
+// IsValidBIC reports whether bic is an 8 or 11 character ISO 9362 business
+// identifier code of a known country.
+func IsValidBIC(bic string) bool {
+	if !bicRegex.MatchString(bic) {
+		return false
+	}
+	_, valid := validCountries[bic[4:6]]
+	return valid
+}
+
+// HolderNameMatches reports whether the account holder names the user: every
+// part of the user's first and last name has to appear in it, in any order and
+// regardless of case, accents and punctuation.
+func HolderNameMatches(holder, firstName, lastName string) bool {
+	holderParts := make(map[string]struct{})
+	for _, part := range NameParts(holder) {
+		holderParts[part] = struct{}{}
+	}
+
+	userParts := append(NameParts(firstName), NameParts(lastName)...)
+	if len(userParts) == 0 {
+		return false
+	}
+	for _, part := range userParts {
+		if _, found := holderParts[part]; !found {
+			return false
+		}
+	}
+	return true
+}
+
+// NameParts splits a name into its lower-case parts, with accents transliterated
+// and punctuation removed, for comparing names.
+func NameParts(name string) []string {
+	folded := nameFolds.Replace(strings.ToLower(name))
+	return strings.FieldsFunc(folded, func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+}
+
+// Validate checks if the reference account adheres to the spec. IBAN and BIC
+// are expected in normalized form.
+func (a *ReferenceAccount) Validate() error {
+	if !IsValidIBAN(a.IBAN) {
+		return errors.New("iban must be a valid IBAN")
+	}
+	if !IsValidBIC(a.BIC) {
+		return errors.New("bic must be a valid BIC")
+	}
+	holder := strings.TrimSpace(a.AccountHolder)
+	if len(holder) < 2 || len(holder) > maxAccountHolderLength {
+		return errors.New("account_holder must be between 2 and 70 characters")
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_IsValidIBAN(t *testing.T) {
+	for _, iban := range []string{
+		"DE89370400440532013000",
+		"GB82WEST12345698765432",
+		"FR1420041010050500013M02606",
+		"NO9386011117947",
+		"AT611904300234573201",
+	} {
+		assert.True(t, IsValidIBAN(iban), iban)
+	}
+
+	for _, iban := range []string{
+		"DE89370400440532013001", // checksum
+		"DE8937040044053201300",  // length
+		"XX89370400440532013000", // country
+		"US64SVBKUS6S3300958879", // no IBAN country
+		"de89370400440532013000", // not normalized
+		"DE89 3704 0044 0532 0130 00",
+	} {
+		assert.False(t, IsValidIBAN(iban), iban)
+	}
+
+	assert.True(t, IsValidIBAN(NormalizeIBAN("de89 3704 0044 0532 0130 00")))
+}
+
+func Test_IsValidBIC(t *testing.T) {
+	for _, bic := range []string{"DEUTDEFF", "DEUTDEFF500", "COBADEFFXXX"} {
+		assert.True(t, IsValidBIC(bic), bic)
+	}
+	for _, bic := range []string{"DEUTDEF", "DEUTXXFF", "deutdeff", "DEUTDEFF50"} {
+		assert.False(t, IsValidBIC(bic), bic)
+	}
+}
+
+func Test_HolderNameMatches(t *testing.T) {
+	tests := []struct {
+		holder string
+		match  bool
+	}{
+		{"Jürgen Müller-Lüdenscheidt", true},
+		{"MUELLER-LUEDENSCHEIDT, JUERGEN", true},
+		{"Dr. Jürgen Müller-Lüdenscheidt", true},
+		{"Jürgen Müller", false},
+		{"Anna Müller-Lüdenscheidt", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.match, HolderNameMatches(tt.holder, "Jürgen", "Müller-Lüdenscheidt"), tt.holder)
+	}
+}
@@ -0,0 +1,218 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	SavingsPlanCadenceMonthly    = "MONTHLY"
+	SavingsPlanCadenceQuarterly  = "QUARTERLY"
+	SavingsPlanCadenceHalfYearly = "HALF_YEARLY"
+	SavingsPlanCadenceYearly     = "YEARLY"
+
+	SavingsPlanStatusActive    = "ACTIVE"
+	SavingsPlanStatusPaused    = "PAUSED"
+	SavingsPlanStatusCancelled = "CANCELLED"
+
+	// DateLayout is the format of calendar dates such as a plan's start date.
+	DateLayout = "2006-01-02"
+)
+
+var (
+	ErrIllegalSavingsPlanTransition = errors.New("illegal savings plan status transition")
+	// ErrSavingsPlanNotAccepted is wrapped with the reason an otherwise valid
+	// savings plan cannot be set up, e.g. an instrument that is not tradable.
+	ErrSavingsPlanNotAccepted = errors.New("savings plan not accepted")
+	// ErrSavingsPlanNotDue is returned when a plan is no longer due for the
+	// scheduled date, because it ran already or was paused or cancelled.
+	ErrSavingsPlanNotDue = errors.New("savings plan is not due")
+)
+
+// SavingsPlan invests a fixed cash amount into an instrument on a recurring
+// schedule. Execution dates are derived from the start date, so a plan started
+// on the 31st runs on the last day of shorter months.
+type SavingsPlan struct {
+	ID                string `json:"id"`
+	CreatedAt         string `json:"created_at,omitempty"`
+	UpdatedAt         string `json:"updated_at,omitempty"`
+	UserID            string `json:"user_id"`
+	AccountID         string `json:"account_id"`
+	ISIN              string `json:"isin"`
+	Amount            string `json:"amount"`
+	Currency          string `json:"currency"`
+	Cadence           string `json:"cadence"`
+	StartDate         string `json:"start_date"`
+	NextExecutionDate string `json:"next_execution_date,omitempty"`
+	Status            string `json:"status,omitempty"`
+}
+
+// SavingsPlanExecution records one run of a savings plan. The scheduled date
+// follows from the plan's cadence; the execution date is the business day the
+// plan actually ran on.
+type SavingsPlanExecution struct {
+	ID            string `json:"id"`
+	CreatedAt     string `json:"created_at,omitempty"`
+	SavingsPlanID string `json:"savings_plan_id"`
+	ScheduledDate string `json:"scheduled_date"`
+	ExecutionDate string `json:"execution_date"`
+}
+
+// cadenceMonths is the number of months between two executions per cadence.
+var cadenceMonths = map[string]int{
+	SavingsPlanCadenceMonthly:    1,
+	SavingsPlanCadenceQuarterly:  3,
+	SavingsPlanCadenceHalfYearly: 6,
+	SavingsPlanCadenceYearly:     12,
+}
+
+// savingsPlanStatusTransitions lists the statuses a savings plan may move to
+// from each status. CANCELLED is terminal.
+var savingsPlanStatusTransitions = map[string][]string{
+	SavingsPlanStatusActive:    {SavingsPlanStatusPaused, SavingsPlanStatusCancelled},
+	SavingsPlanStatusPaused:    {SavingsPlanStatusActive, SavingsPlanStatusCancelled},
+	SavingsPlanStatusCancelled: {},
+}
+
+// ValidateSavingsPlanStatusTransition returns an error wrapping
+// ErrIllegalSavingsPlanTransition if a plan in status from may not move to
+// status to.
+func ValidateSavingsPlanStatusTransition(from, to string) error {
+	for _, allowed := range savingsPlanStatusTransitions[from] {
+		if allowed == to {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s to %s", ErrIllegalSavingsPlanTransition, from, to)
+}
+
+// Validate checks if the savings plan object adheres to the spec. The start
+// date may lie in the past; the plan then first runs on the next date of its
+// schedule.
+func (p *SavingsPlan) Validate() error {
+	if !IsValidUUID(p.UserID) {
+		return errors.New("user_id must be a valid UUID")
+	}
+	if !IsValidUUID(p.AccountID) {
+		return errors.New("account_id must be a valid UUID")
+	}
+	if !IsValidISIN(p.ISIN) {
+		return errors.New("isin must be a valid ISIN")
+	}
+	minorUnits, valid := CurrencyMinorUnits(p.Currency)
+	if !valid {
+		return errors.New("invalid currency code")
+	}
+	if places, ok := positiveDecimalPlaces(p.Amount); !ok || places > minorUnits {
+		return fmt.Errorf("amount must be a positive decimal with at most %d decimal places", minorUnits)
+	}
+	if _, valid := cadenceMonths[p.Cadence]; !valid {
+		return errors.New("cadence must be MONTHLY, QUARTERLY, HALF_YEARLY or YEARLY")
+	}
+	if _, err := time.Parse(DateLayout, p.StartDate); err != nil {
+		return errors.New("start_date must be in YYYY-MM-DD format")
+	}
+	return nil
+}
+
+// NextScheduledDate returns the first date of the plan's schedule on or after
+// from, ignoring business days. Use Calendar.NextBusinessDay to get the date
+// the plan executes on.
+func (p *SavingsPlan) NextScheduledDate(from time.Time) (time.Time, error) {
+	start, err := time.Parse(DateLayout, p.StartDate)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid start date %q: %w", p.StartDate, err)
+	}
+	months, valid := cadenceMonths[p.Cadence]
+	if !valid {
+		return time.Time{}, fmt.Errorf("invalid cadence %q", p.Cadence)
+	}
+
+	from = truncateToDate(from)
+	if !start.Before(from) {
+		return start, nil
+	}
+
+	// Skip the whole periods between start and from, then step forward.
+	n := ((from.Year()-start.Year())*12 + int(from.Month()) - int(start.Month())) / months
+	for {
+		date := addMonthsClamped(start, n*months)
+		if !date.Before(from) {
+			return date, nil
+		}
+		n++
+	}
+}
+
+// addMonthsClamped adds months to date and clamps the day to the length of the
+// resulting month, so that January 31st plus one month is February 28th (or
+// 29th) rather than early March.
+func addMonthsClamped(date time.Time, months int) time.Time {
+	firstOfMonth := time.Date(date.Year(), date.Month()+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+	day := date.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return time.Date(firstOfMonth.Year(), firstOfMonth.Month(), day, 0, 0, 0, 0, time.UTC)
+}
+
+func truncateToDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// Calendar knows the days on which savings plans are not executed: weekends
+// and the configured holidays.
+type Calendar struct {
+	holidays map[string]struct{}
+	// lastDay is the last day the holidays are known for; zero if unbounded.
+	lastDay time.Time
+}
+
+// NewCalendar returns a calendar of holidays that is taken to cover every date.
+func NewCalendar(holidays ...time.Time) *Calendar {
+	c := &Calendar{holidays: make(map[string]struct{}, len(holidays))}
+	for _, holiday := range holidays {
+		c.holidays[holiday.Format(DateLayout)] = struct{}{}
+	}
+	return c
+}
+
+// NewCalendarUntil returns a calendar of holidays that covers the dates up to
+// and including lastDay.
+func NewCalendarUntil(lastDay time.Time, holidays ...time.Time) *Calendar {
+	c := NewCalendar(holidays...)
+	c.lastDay = truncateToDate(lastDay)
+	return c
+}
+
+// Covers reports whether the holidays on date are known.
+func (c *Calendar) Covers(date time.Time) bool {
+	return c.lastDay.IsZero() || !truncateToDate(date).After(c.lastDay)
+}
+
+// LastDay returns the last day the calendar covers, or the zero time if it
+// covers every date.
+func (c *Calendar) LastDay() time.Time {
+	return c.lastDay
+}
+
+// IsBusinessDay reports whether date is neither a weekend day nor a holiday.
+func (c *Calendar) IsBusinessDay(date time.Time) bool {
+	if weekday := date.Weekday(); weekday == time.Saturday || weekday == time.Sunday {
+		return false
+	}
+	_, holiday := c.holidays[date.Format(DateLayout)]
+	return !holiday
+}
+
+// NextBusinessDay returns date if it is a business day, and the first business
+// day after it otherwise.
+func (c *Calendar) NextBusinessDay(date time.Time) time.Time {
+	date = truncateToDate(date)
+	for !c.IsBusinessDay(date) {
+		date = date.AddDate(0, 0, 1)
+	}
+	return date
+}
@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func date(s string) time.Time {
+	d, _ := time.Parse(DateLayout, s)
+	return d
+}
+
+func Test_ValidateSavingsPlanStatusTransition(t *testing.T) {
+	tests := []struct {
+		from, to string
+		allowed  bool
+	}{
+		{SavingsPlanStatusActive, SavingsPlanStatusPaused, true},
+		{SavingsPlanStatusActive, SavingsPlanStatusCancelled, true},
+		{SavingsPlanStatusPaused, SavingsPlanStatusActive, true},
+		{SavingsPlanStatusPaused, SavingsPlanStatusCancelled, true},
+		{SavingsPlanStatusActive, SavingsPlanStatusActive, false},
+		{SavingsPlanStatusPaused, SavingsPlanStatusPaused, false},
+		{SavingsPlanStatusCancelled, SavingsPlanStatusActive, false},
+	}
+
+	for _, tt := range tests {
+		err := ValidateSavingsPlanStatusTransition(tt.from, tt.to)
+		if tt.allowed {
+			assert.NoError(t, err, "%s -> %s", tt.from, tt.to)
+		} else {
+			assert.True(t, errors.Is(err, ErrIllegalSavingsPlanTransition), "%s -> %s", tt.from, tt.to)
+		}
+	}
+}
+
+func Test_SavingsPlan_Validate(t *testing.T) {
+	valid := SavingsPlan{
+		UserID:    "0b6f6b6e-3c1f-4a7e-9d6a-2f1c8e4b5a77",
+		AccountID: "4c3e1d2b-8a7f-4e6d-9c5b-1a2b3c4d5e6f",
+		ISIN:      "IE00B4L5Y983",
+		Amount:    "50.00",
+		Currency:  "EUR",
+		Cadence:   SavingsPlanCadenceMonthly,
+		StartDate: "2025-03-01",
+	}
+	assert.NoError(t, valid.Validate())
+
+	tests := []struct {
+		name   string
+		modify func(p *SavingsPlan)
+		err    string
+	}{
+		{"zero amount", func(p *SavingsPlan) { p.Amount = "0" }, "amount must be a positive decimal with at most 2 decimal places"},
+		{"too precise amount", func(p *SavingsPlan) { p.Amount = "10.001" }, "amount must be a positive decimal with at most 2 decimal places"},
+		{"unknown cadence", func(p *SavingsPlan) { p.Cadence = "DAILY" }, "cadence must be MONTHLY, QUARTERLY, HALF_YEARLY or YEARLY"},
+		{"malformed start date", func(p *SavingsPlan) { p.StartDate = "01.03.2025" }, "start_date must be in YYYY-MM-DD format"},
+		{"invalid isin", func(p *SavingsPlan) { p.ISIN = "IE00B4L5Y984" }, "isin must be a valid ISIN"},
+	}
+
+	for _, tt := range tests {
+		plan := valid
+		tt.modify(&plan)
+		assert.EqualError(t, plan.Validate(), tt.err, tt.name)
+	}
+}
+
+func Test_SavingsPlan_NextScheduledDate(t *testing.T) {
+	tests := []struct {
+		start, cadence, from, expected string
+	}{
+		{"2025-03-15", SavingsPlanCadenceMonthly, "2025-01-10", "2025-03-15"},
+		{"2025-03-15", SavingsPlanCadenceMonthly, "2025-03-15", "2025-03-15"},
+		{"2025-03-15", SavingsPlanCadenceMonthly, "2025-03-16", "2025-04-15"},
+		{"2025-01-31", SavingsPlanCadenceMonthly, "2025-02-01", "2025-02-28"},
+		{"2025-01-31", SavingsPlanCadenceMonthly, "2025-03-01", "2025-03-31"},
+		{"2024-01-31", SavingsPlanCadenceMonthly, "2024-02-01", "2024-02-29"},
+		{"2025-01-15", SavingsPlanCadenceQuarterly, "2025-02-01", "2025-04-15"},
+		{"2025-01-15", SavingsPlanCadenceHalfYearly, "2025-07-16", "2026-01-15"},
+		{"2024-02-29", SavingsPlanCadenceYearly, "2024-03-01", "2025-02-28"},
+	}
+
+	for _, tt := range tests {
+		plan := SavingsPlan{StartDate: tt.start, Cadence: tt.cadence}
+		next, err := plan.NextScheduledDate(date(tt.from))
+
+		assert.NoError(t, err)
+		assert.Equal(t, tt.expected, next.Format(DateLayout), "%s %s from %s", tt.start, tt.cadence, tt.from)
+	}
+}
+
+func Test_Calendar_NextBusinessDay(t *testing.T) {
+	calendar := NewCalendar(date("2025-04-18"), date("2025-04-21"))
+
+	tests := []struct {
+		day, expected string
+	}{
+		{"2025-03-14", "2025-03-14"}, // Friday
+		{"2025-03-15", "2025-03-17"}, // Saturday
+		{"2025-03-16", "2025-03-17"}, // Sunday
+		{"2025-04-18", "2025-04-22"}, // Good Friday, then the weekend and Easter Monday
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, calendar.NextBusinessDay(date(tt.day)).Format(DateLayout), tt.day)
+	}
+}
+
+func Test_Calendar_Covers(t *testing.T) {
+	calendar := NewCalendarUntil(date("2026-12-31"), date("2026-12-25"))
+
+	assert.True(t, calendar.Covers(date("2026-12-31").Add(23*time.Hour)))
+	assert.False(t, calendar.Covers(date("2027-01-01")))
+	assert.True(t, NewCalendar().Covers(date("2099-01-01")))
+}
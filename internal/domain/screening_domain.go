@@ -0,0 +1,47 @@
+package domain
+
+import "errors"
+
+const (
+	ScreeningCategorySanctions = "SANCTIONS"
+	ScreeningCategoryPEP       = "PEP"
+
+	ScreeningHitStatusPending   = "PENDING"
+	ScreeningHitStatusConfirmed = "CONFIRMED"
+	ScreeningHitStatusDismissed = "DISMISSED"
+)
+
+// ErrScreeningHitReviewed is returned when a hit that was reviewed already is
+// reviewed again.
+var ErrScreeningHitReviewed = errors.New("screening hit was already reviewed")
+
+// ScreeningHit records that a user's name, and birth date where the list gives
+// one, matched an entry of a sanctions or PEP list. Hits are kept once per
+// user and list entry and wait for review by compliance.
+type ScreeningHit struct {
+	ID               string  `json:"id"`
+	CreatedAt        string  `json:"created_at,omitempty"`
+	UserID           string  `json:"user_id"`
+	ListName         string  `json:"list_name"`
+	EntryID          string  `json:"entry_id"`
+	EntryName        string  `json:"entry_name"`
+	Category         string  `json:"category"`
+	MatchedName      string  `json:"matched_name"`
+	Score            float64 `json:"score"`
+	BirthDateMatched bool    `json:"birth_date_matched"`
+	Status           string  `json:"status"`
+	ReviewedAt       string  `json:"reviewed_at,omitempty"`
+}
+
+// ScreeningReview is the decision of compliance on a pending hit.
+type ScreeningReview struct {
+	Decision string `json:"decision"`
+}
+
+// Validate checks that the decision confirms or dismisses the hit.
+func (r *ScreeningReview) Validate() error {
+	if r.Decision != ScreeningHitStatusConfirmed && r.Decision != ScreeningHitStatusDismissed {
+		return errors.New("decision must be CONFIRMED or DISMISSED")
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ScreeningReview_Validate(t *testing.T) {
+	tests := []struct {
+		decision string
+		valid    bool
+	}{
+		{ScreeningHitStatusConfirmed, true},
+		{ScreeningHitStatusDismissed, true},
+		{ScreeningHitStatusPending, false}, // a review must resolve the hit
+		{"dismissed", false},
+		{"REJECTED", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		review := ScreeningReview{Decision: tt.decision}
+		if tt.valid {
+			assert.NoError(t, review.Validate(), tt.decision)
+		} else {
+			assert.EqualError(t, review.Validate(), "decision must be CONFIRMED or DISMISSED", tt.decision)
+		}
+	}
+}
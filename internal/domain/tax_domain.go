@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// CountryUS is the country whose nationals and tax residents are reportable
+// under FATCA.
+const CountryUS = "US"
+
+// TaxResidency is a country the user is liable to tax in, together with the
+// tax identification number (TIN) issued there.
+type TaxResidency struct {
+	Country string `json:"country"`
+	TIN     string `json:"tin"`
+}
+
+var (
+	// Fallback for countries without a dedicated TIN format.
+	genericTINRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9 ./-]{0,29}$`)
+	// US SSNs and ITINs, with or without the dashes after the area and group numbers.
+	usTINRegex = regexp.MustCompile(`^(\d{3})-?(\d{2})-?(\d{4})$`)
+	// German tax identification number (Steuerliche Identifikationsnummer).
+	deTINRegex = regexp.MustCompile(`^[1-9]\d{10}$`)
+
+	tinValidators = map[string]func(string) error{
+		"DE":      validateGermanTIN,
+		CountryUS: validateUSTIN,
+	}
+)
+
+// Validate checks the country and the format of the TIN issued in it.
+func (t *TaxResidency) Validate() error {
+	if _, valid := validCountries[t.Country]; !valid {
+		return errors.New("invalid tax residency country code")
+	}
+	if t.TIN == "" {
+		return fmt.Errorf("tin is required for tax residency %s", t.Country)
+	}
+	if validate, ok := tinValidators[t.Country]; ok {
+		return validate(t.TIN)
+	}
+	if !genericTINRegex.MatchString(t.TIN) {
+		return fmt.Errorf("invalid tin for tax residency %s", t.Country)
+	}
+	return nil
+}
+
+// validateGermanTIN checks the 11 digit Steuer-ID: no leading zero, exactly one
+// of the first ten digits repeated (twice, or three times but not in a row),
+// and an ISO 7064 MOD 11,10 check digit.
+func validateGermanTIN(tin string) error {
+	if !deTINRegex.MatchString(tin) {
+		return errors.New("tin for tax residency DE must be 11 digits not starting with 0")
+	}
+
+	var counts [10]int
+	for _, digit := range tin[:10] {
+		counts[digit-'0']++
+	}
+	repeated := -1
+	for digit, count := range counts {
+		if count < 2 {
+			continue
+		}
+		if repeated >= 0 || count > 3 {
+			return errors.New("tin for tax residency DE repeats its digits in an invalid way")
+		}
+		repeated = digit
+	}
+	if repeated < 0 || (counts[repeated] == 3 && strings.Contains(tin[:10], strings.Repeat(string(rune('0'+repeated)), 3))) {
+		return errors.New("tin for tax residency DE repeats its digits in an invalid way")
+	}
+
+	product := 10
+	for _, digit := range tin[:10] {
+		sum := (int(digit-'0') + product) % 10
+		if sum == 0 {
+			sum = 10
+		}
+		product = (sum * 2) % 11
+	}
+	check := 11 - product
+	if check == 10 {
+		check = 0
+	}
+	if check != int(tin[10]-'0') {
+		return errors.New("tin for tax residency DE has an invalid check digit")
+	}
+	return nil
+}
+
+// validateUSTIN checks the format of a Social Security Number or, for numbers
+// starting with 9, an Individual Taxpayer Identification Number.
+func validateUSTIN(tin string) error {
+	parts := usTINRegex.FindStringSubmatch(tin)
+	if parts == nil {
+		return errors.New("tin for tax residency US must be 9 digits, optionally formatted as AAA-GG-SSSS")
+	}
+	area, group, serial := parts[1], parts[2], parts[3]
+
+	if area[0] == '9' {
+		// ITIN group numbers are 50-65, 70-88, 90-92 and 94-99.
+		g := int(group[0]-'0')*10 + int(group[1]-'0')
+		if (g >= 50 && g <= 65) || (g >= 70 && g <= 88) || (g >= 90 && g <= 92) || g >= 94 {
+			return nil
+		}
+		return errors.New("tin for tax residency US is not a valid ITIN")
+	}
+
+	if area == "000" || area == "666" || group == "00" || serial == "0000" {
+		return errors.New("tin for tax residency US is not a valid SSN")
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TaxResidency_Validate(t *testing.T) {
+	valid := []TaxResidency{
+		{Country: "DE", TIN: "86095742719"},
+		{Country: "DE", TIN: "47036892816"},
+		{Country: "US", TIN: "123-45-6789"},
+		{Country: "US", TIN: "123456789"},
+		{Country: "US", TIN: "912-70-1234"},
+		{Country: "FR", TIN: "30 23 217 600 053"},
+	}
+	for _, residency := range valid {
+		assert.NoError(t, residency.Validate(), residency.TIN)
+	}
+
+	tests := []struct {
+		name      string
+		residency TaxResidency
+		err       string
+	}{
+		{"unknown country", TaxResidency{Country: "XX", TIN: "1"}, "invalid tax residency country code"},
+		{"missing tin", TaxResidency{Country: "FR"}, "tin is required for tax residency FR"},
+		{"generic tin with symbols", TaxResidency{Country: "FR", TIN: "#123"}, "invalid tin for tax residency FR"},
+		{"german tin too short", TaxResidency{Country: "DE", TIN: "8609574271"}, "tin for tax residency DE must be 11 digits not starting with 0"},
+		{"german tin with leading zero", TaxResidency{Country: "DE", TIN: "06095742719"}, "tin for tax residency DE must be 11 digits not starting with 0"},
+		{"german tin check digit", TaxResidency{Country: "DE", TIN: "86095742718"}, "tin for tax residency DE has an invalid check digit"},
+		{"german tin without repeated digit", TaxResidency{Country: "DE", TIN: "12345678903"}, "tin for tax residency DE repeats its digits in an invalid way"},
+		{"german tin with two repeated digits", TaxResidency{Country: "DE", TIN: "11223456789"}, "tin for tax residency DE repeats its digits in an invalid way"},
+		{"german tin with a digit three times in a row", TaxResidency{Country: "DE", TIN: "11134567890"}, "tin for tax residency DE repeats its digits in an invalid way"},
+		{"us tin with letters", TaxResidency{Country: "US", TIN: "12A-45-6789"}, "tin for tax residency US must be 9 digits, optionally formatted as AAA-GG-SSSS"},
+		{"ssn with area 666", TaxResidency{Country: "US", TIN: "666-45-6789"}, "tin for tax residency US is not a valid SSN"},
+		{"ssn with group 00", TaxResidency{Country: "US", TIN: "123-00-6789"}, "tin for tax residency US is not a valid SSN"},
+		{"itin with group 93", TaxResidency{Country: "US", TIN: "912-93-1234"}, "tin for tax residency US is not a valid ITIN"},
+	}
+
+	for _, tt := range tests {
+		assert.EqualError(t, tt.residency.Validate(), tt.err, tt.name)
+	}
+}
+
+func Test_User_TaxResidencies(t *testing.T) {
+	user := User{
+		FirstName:     "Rob",
+		LastName:      "Smith",
+		BirthDate:     "1990-01-01",
+		BirthCity:     "Berlin",
+		BirthCountry:  "DE",
+		Nationalities: []string{"DE"},
+		Address:       Address{AddressLine1: "123 Main St", Postcode: "12345", City: "Berlin", Country: "DE"},
+		TaxResidencies: []TaxResidency{
+			{Country: "DE", TIN: "86095742719"},
+		},
+	}
+	assert.NoError(t, user.Validate())
+	assert.False(t, user.IsFATCAReportable())
+
+	user.TaxResidencies = append(user.TaxResidencies, TaxResidency{Country: "US", TIN: "123-45-6789"})
+	assert.NoError(t, user.Validate())
+	assert.True(t, user.IsFATCAReportable())
+
+	user.TaxResidencies = user.TaxResidencies[:1]
+	user.Nationalities = append(user.Nationalities, "US")
+	assert.True(t, user.IsFATCAReportable())
+
+	user.TaxResidencies = append(user.TaxResidencies, TaxResidency{Country: "DE", TIN: "47036892816"})
+	assert.EqualError(t, user.Validate(), "tax_residencies must list each country at most once")
+}
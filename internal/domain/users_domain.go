@@ -1,11 +1,18 @@
 package domain
 
 import (
+	"encoding/json"
 	"errors"
+	"reflect"
 	"regexp"
+	"sort"
 	"time"
 )
 
+// ErrUserModified is returned when a user changed after it was read for an
+// update.
+var ErrUserModified = errors.New("user was modified concurrently")
+
 type User struct {
 	ID            string   `json:"id"`
 	CreatedAt     string   `json:"created_at,omitempty"`
@@ -22,6 +29,11 @@ type User struct {
 	PostalAddress *Address `json:"postal_address,omitempty"`
 	Address       Address  `json:"address"`
 	Status        string   `json:"status,omitempty"`
+
+	TaxResidencies []TaxResidency `json:"tax_residencies,omitempty"`
+	// FATCA is derived from the nationalities and tax residencies by the
+	// repository; see IsFATCAReportable.
+	FATCA bool `json:"fatca"`
 }
 
 type Address struct {
@@ -82,6 +94,12 @@ var (
 	}
 )
 
+// IsValidCountry reports whether code is a supported ISO 3166-1 alpha-2 country code.
+func IsValidCountry(code string) bool {
+	_, valid := validCountries[code]
+	return valid
+}
+
 // Validate checks if the user object adheres to the spec.
 func (u *User) Validate() error {
 	if len(u.FirstName) < 2 || len(u.FirstName) > 100 {
@@ -96,9 +114,13 @@ func (u *User) Validate() error {
 	if _, valid := validTitles[u.Title]; !valid {
 		return errors.New("invalid title")
 	}
-	if _, err := time.Parse("2006-01-02", u.BirthDate); err != nil {
+	birthDate, err := time.Parse(DateLayout, u.BirthDate)
+	if err != nil {
 		return errors.New("birth_date must be in YYYY-MM-DD format")
 	}
+	if err := validateBirthDate(birthDate, time.Now().UTC()); err != nil {
+		return err
+	}
 	if len(u.BirthCity) < 1 || len(u.BirthCity) > 85 {
 		return errors.New("birth_city must be between 1 and 85 characters")
 	}
@@ -116,6 +138,16 @@ func (u *User) Validate() error {
 			return errors.New("invalid nationality code")
 		}
 	}
+	seen := make(map[string]struct{}, len(u.TaxResidencies))
+	for i := range u.TaxResidencies {
+		if err := u.TaxResidencies[i].Validate(); err != nil {
+			return err
+		}
+		if _, duplicate := seen[u.TaxResidencies[i].Country]; duplicate {
+			return errors.New("tax_residencies must list each country at most once")
+		}
+		seen[u.TaxResidencies[i].Country] = struct{}{}
+	}
 	if err := u.Address.Validate(); err != nil {
 		return err
 	}
@@ -127,6 +159,49 @@ func (u *User) Validate() error {
 	return nil
 }
 
+// IsFATCAReportable reports whether the user has US indicia, i.e. US appears in
+// the nationalities or the tax residencies.
+func (u *User) IsFATCAReportable() bool {
+	for _, nationality := range u.Nationalities {
+		if nationality == CountryUS {
+			return true
+		}
+	}
+	for _, residency := range u.TaxResidencies {
+		if residency.Country == CountryUS {
+			return true
+		}
+	}
+	return false
+}
+
+// ChangedFields returns the sorted JSON names of the fields whose values differ
+// between u and other.
+func (u *User) ChangedFields(other *User) []string {
+	before, after := toFieldMap(u), toFieldMap(other)
+
+	var changed []string
+	for field := range before {
+		if !reflect.DeepEqual(before[field], after[field]) {
+			changed = append(changed, field)
+		}
+	}
+	for field := range after {
+		if _, seen := before[field]; !seen {
+			changed = append(changed, field)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+func toFieldMap(u *User) map[string]interface{} {
+	fields := map[string]interface{}{}
+	raw, _ := json.Marshal(u)
+	_ = json.Unmarshal(raw, &fields)
+	return fields
+}
+
 // Validate checks if the address object adheres to the spec.
 func (a *Address) Validate() error {
 	if len(a.AddressLine1) == 0 || len(a.AddressLine1) > 100 {
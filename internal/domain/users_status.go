@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	UserStatusActive      = "ACTIVE"
+	UserStatusInactive    = "INACTIVE"
+	UserStatusOffboarding = "OFFBOARDING"
+	UserStatusOffboarded  = "OFFBOARDED"
+	// UserStatusHeld is given to users with screening hits pending review.
+	UserStatusHeld = "HELD"
+)
+
+var ErrIllegalStatusTransition = errors.New("illegal user status transition")
+
+// userStatusTransitions lists the statuses a user may move to from each status.
+// OFFBOARDED is terminal. A HELD user is released to ACTIVE once all screening
+// hits are dismissed, or offboarded.
+var userStatusTransitions = map[string][]string{
+	UserStatusActive:      {UserStatusInactive, UserStatusOffboarding, UserStatusHeld},
+	UserStatusInactive:    {UserStatusActive, UserStatusOffboarding, UserStatusHeld},
+	UserStatusHeld:        {UserStatusActive, UserStatusOffboarding},
+	UserStatusOffboarding: {UserStatusOffboarded},
+	UserStatusOffboarded:  {},
+}
+
+// ValidateUserStatusTransition returns an error wrapping ErrIllegalStatusTransition
+// if a user in status from may not move to status to.
+func ValidateUserStatusTransition(from, to string) error {
+	for _, allowed := range userStatusTransitions[from] {
+		if allowed == to {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s to %s", ErrIllegalStatusTransition, from, to)
+}
+
+// IsValidUserStatus reports whether status is a known user status.
+func IsValidUserStatus(status string) bool {
+	_, valid := userStatusTransitions[status]
+	return valid
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ValidateUserStatusTransition(t *testing.T) {
+	tests := []struct {
+		from, to string
+		allowed  bool
+	}{
+		{UserStatusActive, UserStatusInactive, true},
+		{UserStatusActive, UserStatusOffboarding, true},
+		{UserStatusInactive, UserStatusActive, true},
+		{UserStatusInactive, UserStatusOffboarding, true},
+		{UserStatusOffboarding, UserStatusOffboarded, true},
+		{UserStatusActive, UserStatusHeld, true},
+		{UserStatusHeld, UserStatusActive, true},
+		{UserStatusHeld, UserStatusOffboarding, true},
+		{UserStatusHeld, UserStatusInactive, false},
+		{UserStatusOffboarding, UserStatusHeld, false},
+		{UserStatusActive, UserStatusOffboarded, false},
+		{UserStatusOffboarding, UserStatusActive, false},
+		{UserStatusOffboarded, UserStatusOffboarding, false},
+		{UserStatusOffboarded, UserStatusOffboarded, false},
+		{"UNKNOWN", UserStatusActive, false},
+	}
+
+	for _, tt := range tests {
+		err := ValidateUserStatusTransition(tt.from, tt.to)
+		if tt.allowed {
+			assert.NoError(t, err, "%s -> %s", tt.from, tt.to)
+		} else {
+			assert.True(t, errors.Is(err, ErrIllegalStatusTransition), "%s -> %s", tt.from, tt.to)
+		}
+	}
+}
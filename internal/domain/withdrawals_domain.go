@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	WithdrawalStatusPending = "PENDING"
+	WithdrawalStatusBooked  = "BOOKED"
+	WithdrawalStatusFailed  = "FAILED"
+
+	// Withdrawals are paid out by SEPA credit transfer.
+	withdrawalCurrency      = "EUR"
+	maxFailureReasonLength  = 200
+	maxWithdrawalAmountEuro = 1_000_000
+)
+
+var (
+	ErrIllegalWithdrawalTransition = errors.New("illegal withdrawal status transition")
+	ErrInsufficientFunds           = errors.New("insufficient funds")
+	// ErrWithdrawalNotAccepted is wrapped with the reason an otherwise valid
+	// withdrawal cannot be made, e.g. a reference account of another user.
+	ErrWithdrawalNotAccepted = errors.New("withdrawal not accepted")
+	// ErrWithdrawalExportConflict reports that withdrawals of an export were
+	// exported or completed since they were read, or that another export took
+	// the same message ID.
+	ErrWithdrawalExportConflict = errors.New("withdrawal export conflict")
+)
+
+// Withdrawal pays Amount minor units of the user's available cash out to one
+// of the user's reference accounts. The amount is reserved while the
+// withdrawal is PENDING.
+type Withdrawal struct {
+	ID                 string `json:"id"`
+	CreatedAt          string `json:"created_at,omitempty"`
+	UpdatedAt          string `json:"updated_at,omitempty"`
+	UserID             string `json:"user_id"`
+	ReferenceAccountID string `json:"reference_account_id"`
+	Amount             int64  `json:"amount"`
+	Currency           string `json:"currency"`
+	Status             string `json:"status,omitempty"`
+	FailureReason      string `json:"failure_reason,omitempty"`
+}
+
+// PaymentInstruction is a pending withdrawal together with the account it is
+// paid out to.
+type PaymentInstruction struct {
+	Withdrawal Withdrawal
+	Creditor   ReferenceAccount
+}
+
+// WithdrawalExport is a pain.001 file handed to the bank. Its withdrawals stay
+// PENDING until booked or failed, but are not exported again.
+type WithdrawalExport struct {
+	ID            string
+	CreatedAt     string
+	MessageID     string
+	WithdrawalIDs []string
+	File          []byte
+}
+
+// withdrawalStatusTransitions lists the statuses a withdrawal may move to from
+// each status. BOOKED and FAILED are terminal.
+var withdrawalStatusTransitions = map[string][]string{
+	WithdrawalStatusPending: {WithdrawalStatusBooked, WithdrawalStatusFailed},
+	WithdrawalStatusBooked:  {},
+	WithdrawalStatusFailed:  {},
+}
+
+// ValidateWithdrawalStatusTransition returns an error wrapping
+// ErrIllegalWithdrawalTransition if a withdrawal in status from may not move to
+// status to.
+func ValidateWithdrawalStatusTransition(from, to string) error {
+	for _, allowed := range withdrawalStatusTransitions[from] {
+		if allowed == to {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s to %s", ErrIllegalWithdrawalTransition, from, to)
+}
+
+// Validate checks if the withdrawal object adheres to the spec.
+func (w *Withdrawal) Validate() error {
+	if !IsValidUUID(w.UserID) {
+		return errors.New("user_id must be a valid UUID")
+	}
+	if !IsValidUUID(w.ReferenceAccountID) {
+		return errors.New("reference_account_id must be a valid UUID")
+	}
+	if w.Currency != withdrawalCurrency {
+		return fmt.Errorf("currency must be %s", withdrawalCurrency)
+	}
+	if w.Amount <= 0 || w.Amount > maxWithdrawalAmountEuro*100 {
+		return fmt.Errorf("amount must be between 1 and %d minor units", maxWithdrawalAmountEuro*100)
+	}
+	return nil
+}
+
+// ValidateFailureReason checks the reason given for a failed withdrawal.
+func ValidateFailureReason(reason string) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" || len(reason) > maxFailureReasonLength {
+		return fmt.Errorf("reason must be between 1 and %d characters", maxFailureReasonLength)
+	}
+	return nil
+}
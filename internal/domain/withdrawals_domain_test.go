@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ValidateWithdrawalStatusTransition(t *testing.T) {
+	assert.NoError(t, ValidateWithdrawalStatusTransition(WithdrawalStatusPending, WithdrawalStatusBooked))
+	assert.NoError(t, ValidateWithdrawalStatusTransition(WithdrawalStatusPending, WithdrawalStatusFailed))
+	assert.True(t, errors.Is(ValidateWithdrawalStatusTransition(WithdrawalStatusBooked, WithdrawalStatusFailed),
+		ErrIllegalWithdrawalTransition))
+	assert.True(t, errors.Is(ValidateWithdrawalStatusTransition(WithdrawalStatusFailed, WithdrawalStatusBooked),
+		ErrIllegalWithdrawalTransition))
+}
+
+func Test_FormatMinorUnits(t *testing.T) {
+	assert.Equal(t, "10.50", FormatMinorUnits(1050, "EUR"))
+	assert.Equal(t, "0.05", FormatMinorUnits(5, "EUR"))
+	assert.Equal(t, "-1.000", FormatMinorUnits(-1000, "KWD"))
+	assert.Equal(t, "500", FormatMinorUnits(500, "JPY"))
+}
+
+func Test_Withdrawal_Validate(t *testing.T) {
+	valid := Withdrawal{
+		UserID:             "0b6f6b6e-3c1f-4a7e-9d6a-2f1c8e4b5a77",
+		ReferenceAccountID: "4c3e1d2b-8a7f-4e6d-9c5b-1a2b3c4d5e6f",
+		Amount:             2500,
+		Currency:           "EUR",
+	}
+	assert.NoError(t, valid.Validate())
+
+	usd := valid
+	usd.Currency = "USD"
+	assert.Error(t, usd.Validate())
+
+	zero := valid
+	zero.Amount = 0
+	assert.Error(t, zero.Validate())
+}
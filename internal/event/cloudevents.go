@@ -0,0 +1,381 @@
+package event
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// CloudEvents 1.0 and its Kafka protocol binding, see
+// https://github.com/cloudevents/spec/blob/v1.0.2/cloudevents/spec.md and
+// https://github.com/cloudevents/spec/blob/v1.0.2/cloudevents/bindings/kafka-protocol-binding.md
+const (
+	CloudEventsSpecVersion     = "1.0"
+	CloudEventsJSONContentType = "application/cloudevents+json; charset=UTF-8"
+
+	cloudEventsHeaderPrefix = "ce_"
+	contentTypeHeader       = "content-type"
+)
+
+// Extension attributes that carry the envelope fields CloudEvents has no
+// attribute for.
+const (
+	CloudEventSchemaVersion = "schemaversion"
+	CloudEventCorrelationID = "correlationid"
+)
+
+// cloudEventAttributes are the names of the context attributes and data members
+// of the spec, which extensions must not reuse.
+var cloudEventAttributes = map[string]bool{
+	"id": true, "source": true, "specversion": true, "type": true, "datacontenttype": true,
+	"dataschema": true, "subject": true, "time": true, "data": true, "data_base64": true,
+}
+
+var cloudEventExtensionName = regexp.MustCompile(`^[a-z0-9]+$`)
+
+// CloudEvent is an event in the CloudEvents 1.0 format. Extension attributes
+// are kept in their canonical string encoding, as the binary mode carries them.
+type CloudEvent struct {
+	ID              string
+	Source          string
+	SpecVersion     string
+	Type            string
+	DataContentType string
+	DataSchema      string
+	Subject         string
+	Time            time.Time
+	Extensions      map[string]string
+	Data            []byte
+}
+
+// Validate checks the required attributes and the names of the extensions.
+func (ce CloudEvent) Validate() error {
+	switch {
+	case ce.SpecVersion != CloudEventsSpecVersion:
+		return fmt.Errorf("unsupported CloudEvents specversion %q", ce.SpecVersion)
+	case ce.ID == "":
+		return errors.New("CloudEvent without id")
+	case ce.Source == "":
+		return errors.New("CloudEvent without source")
+	case ce.Type == "":
+		return errors.New("CloudEvent without type")
+	}
+	for name := range ce.Extensions {
+		if cloudEventAttributes[name] || !cloudEventExtensionName.MatchString(name) {
+			return fmt.Errorf("invalid CloudEvent extension name %q", name)
+		}
+	}
+	return nil
+}
+
+// BinaryMessage encodes ce in the binary content mode: the data is the value of
+// the message and the attributes are ce_ headers, except for the content type.
+func (ce CloudEvent) BinaryMessage(key []byte) (kafka.Message, error) {
+	if err := ce.Validate(); err != nil {
+		return kafka.Message{}, err
+	}
+
+	headers := []kafka.Header{
+		ceHeader("specversion", ce.SpecVersion),
+		ceHeader("id", ce.ID),
+		ceHeader("source", ce.Source),
+		ceHeader("type", ce.Type),
+	}
+	if ce.DataContentType != "" {
+		headers = append(headers, kafka.Header{Key: contentTypeHeader, Value: []byte(ce.DataContentType)})
+	}
+	attributes := ce.optionalAttributes()
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		headers = append(headers, ceHeader(name, attributes[name]))
+	}
+
+	return kafka.Message{Key: key, Value: ce.Data, Headers: headers}, nil
+}
+
+// StructuredMessage encodes ce in the structured content mode, as a JSON
+// document in the value of the message.
+func (ce CloudEvent) StructuredMessage(key []byte) (kafka.Message, error) {
+	value, err := json.Marshal(ce)
+	if err != nil {
+		return kafka.Message{}, err
+	}
+
+	return kafka.Message{
+		Key:     key,
+		Value:   value,
+		Headers: []kafka.Header{{Key: contentTypeHeader, Value: []byte(CloudEventsJSONContentType)}},
+	}, nil
+}
+
+// MarshalJSON encodes ce in the JSON event format. JSON data is embedded as is,
+// text as a string and anything else in data_base64.
+func (ce CloudEvent) MarshalJSON() ([]byte, error) {
+	if err := ce.Validate(); err != nil {
+		return nil, err
+	}
+
+	doc := map[string]interface{}{
+		"specversion": ce.SpecVersion,
+		"id":          ce.ID,
+		"source":      ce.Source,
+		"type":        ce.Type,
+	}
+	if ce.DataContentType != "" {
+		doc["datacontenttype"] = ce.DataContentType
+	}
+	for name, value := range ce.optionalAttributes() {
+		doc[name] = value
+	}
+
+	switch {
+	case ce.Data == nil:
+	case isJSONContentType(ce.DataContentType):
+		if !json.Valid(ce.Data) {
+			return nil, fmt.Errorf("CloudEvent %s has invalid JSON data", ce.ID)
+		}
+		doc["data"] = json.RawMessage(ce.Data)
+	case isTextContentType(ce.DataContentType):
+		doc["data"] = string(ce.Data)
+	default:
+		doc["data_base64"] = base64.StdEncoding.EncodeToString(ce.Data)
+	}
+	return json.Marshal(doc)
+}
+
+// UnmarshalJSON decodes ce from the JSON event format. Null attributes are
+// treated as absent.
+func (ce *CloudEvent) UnmarshalJSON(value []byte) error {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(value, &doc); err != nil {
+		return fmt.Errorf("failed to unmarshal CloudEvent: %w", err)
+	}
+
+	var decoded CloudEvent
+	var data, dataBase64 json.RawMessage
+	for name, raw := range doc {
+		if string(raw) == "null" {
+			continue
+		}
+		switch name {
+		case "data":
+			data = raw
+			continue
+		case "data_base64":
+			dataBase64 = raw
+			continue
+		}
+
+		value, err := canonicalString(raw)
+		if err != nil {
+			return fmt.Errorf("CloudEvent attribute %s: %w", name, err)
+		}
+		if err := decoded.setAttribute(name, value); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case data != nil && dataBase64 != nil:
+		return errors.New("CloudEvent with both data and data_base64")
+	case dataBase64 != nil:
+		var encoded string
+		if err := json.Unmarshal(dataBase64, &encoded); err != nil {
+			return fmt.Errorf("CloudEvent data_base64 is not a string: %w", err)
+		}
+		var err error
+		if decoded.Data, err = base64.StdEncoding.DecodeString(encoded); err != nil {
+			return fmt.Errorf("failed to decode CloudEvent data_base64: %w", err)
+		}
+	case data != nil && !isJSONContentType(decoded.DataContentType):
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return fmt.Errorf("CloudEvent data of type %s is not a string: %w", decoded.DataContentType, err)
+		}
+		decoded.Data = []byte(text)
+	case data != nil:
+		decoded.Data = []byte(data)
+	}
+
+	if err := decoded.Validate(); err != nil {
+		return err
+	}
+	*ce = decoded
+	return nil
+}
+
+// DecodeCloudEvent reads the CloudEvent in msg, in either content mode. It
+// reports false if msg is not a CloudEvent.
+func DecodeCloudEvent(msg kafka.Message) (CloudEvent, bool, error) {
+	contentType, hasContentType := header(msg, contentTypeHeader)
+	if hasContentType && strings.HasPrefix(strings.ToLower(contentType), "application/cloudevents") {
+		var ce CloudEvent
+		if err := json.Unmarshal(msg.Value, &ce); err != nil {
+			return CloudEvent{}, true, err
+		}
+		return ce, true, nil
+	}
+	if _, ok := header(msg, cloudEventsHeaderPrefix+"specversion"); !ok {
+		return CloudEvent{}, false, nil
+	}
+
+	ce := CloudEvent{DataContentType: contentType, Data: msg.Value}
+	for _, h := range msg.Headers {
+		if name, ok := strings.CutPrefix(h.Key, cloudEventsHeaderPrefix); ok {
+			if err := ce.setAttribute(name, string(h.Value)); err != nil {
+				return CloudEvent{}, true, err
+			}
+		}
+	}
+	if err := ce.Validate(); err != nil {
+		return CloudEvent{}, true, err
+	}
+	return ce, true, nil
+}
+
+// optionalAttributes returns the optional attributes and extensions that are
+// set, in their string encoding.
+func (ce CloudEvent) optionalAttributes() map[string]string {
+	attributes := make(map[string]string, len(ce.Extensions)+3)
+	for name, value := range ce.Extensions {
+		attributes[name] = value
+	}
+	if ce.DataSchema != "" {
+		attributes["dataschema"] = ce.DataSchema
+	}
+	if ce.Subject != "" {
+		attributes["subject"] = ce.Subject
+	}
+	if !ce.Time.IsZero() {
+		attributes["time"] = ce.Time.Format(time.RFC3339Nano)
+	}
+	return attributes
+}
+
+func (ce *CloudEvent) setAttribute(name, value string) error {
+	switch name {
+	case "specversion":
+		ce.SpecVersion = value
+	case "id":
+		ce.ID = value
+	case "source":
+		ce.Source = value
+	case "type":
+		ce.Type = value
+	case "datacontenttype":
+		ce.DataContentType = value
+	case "dataschema":
+		ce.DataSchema = value
+	case "subject":
+		ce.Subject = value
+	case "time":
+		t, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return fmt.Errorf("invalid CloudEvent time %q", value)
+		}
+		ce.Time = t
+	default:
+		if ce.Extensions == nil {
+			ce.Extensions = map[string]string{}
+		}
+		ce.Extensions[name] = value
+	}
+	return nil
+}
+
+// canonicalString returns the string encoding of a JSON attribute value.
+func canonicalString(raw json.RawMessage) (string, error) {
+	var value interface{}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return "", err
+	}
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case bool, float64:
+		return string(raw), nil
+	default:
+		return "", errors.New("not a string, number or boolean")
+	}
+}
+
+func ceHeader(name, value string) kafka.Header {
+	return kafka.Header{Key: cloudEventsHeaderPrefix + name, Value: []byte(value)}
+}
+
+// isJSONContentType reports whether data of contentType is JSON, which it is
+// when no content type is given.
+func isJSONContentType(contentType string) bool {
+	mediaType := parseMediaType(contentType)
+	return mediaType == "" || mediaType == "application/json" || mediaType == "text/json" ||
+		strings.HasSuffix(mediaType, "+json")
+}
+
+func isTextContentType(contentType string) bool {
+	mediaType := parseMediaType(contentType)
+	return strings.HasPrefix(mediaType, "text/") || mediaType == "application/xml" ||
+		strings.HasSuffix(mediaType, "+xml")
+}
+
+func parseMediaType(contentType string) string {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	return strings.ToLower(strings.TrimSpace(mediaType))
+}
+
+// CloudEvent maps e to a CloudEvent with JSON data. The producer is the source,
+// and the schema version and correlation ID are extension attributes.
+func (e Envelope) CloudEvent() CloudEvent {
+	extensions := map[string]string{CloudEventSchemaVersion: strconv.Itoa(e.SchemaVersion)}
+	if e.CorrelationID != "" {
+		extensions[CloudEventCorrelationID] = e.CorrelationID
+	}
+
+	return CloudEvent{
+		ID:              e.ID,
+		Source:          e.Producer,
+		SpecVersion:     CloudEventsSpecVersion,
+		Type:            e.Type,
+		DataContentType: "application/json",
+		Time:            e.OccurredAt,
+		Extensions:      extensions,
+		Data:            e.Payload,
+	}
+}
+
+// envelopeFromCloudEvent reverses Envelope.CloudEvent. CloudEvents from other
+// producers, without a schema version, are taken as version 1 of their type.
+func envelopeFromCloudEvent(ce CloudEvent) (Envelope, error) {
+	if !isJSONContentType(ce.DataContentType) {
+		return Envelope{}, fmt.Errorf("CloudEvent %s has data of type %s, not JSON", ce.ID, ce.DataContentType)
+	}
+
+	version := 1
+	if value, ok := ce.Extensions[CloudEventSchemaVersion]; ok {
+		var err error
+		if version, err = strconv.Atoi(value); err != nil || version < 1 {
+			return Envelope{}, fmt.Errorf("CloudEvent %s has invalid schema version %q", ce.ID, value)
+		}
+	}
+
+	return Envelope{
+		ID:            ce.ID,
+		Type:          ce.Type,
+		SchemaVersion: version,
+		OccurredAt:    ce.Time,
+		Producer:      ce.Source,
+		CorrelationID: ce.Extensions[CloudEventCorrelationID],
+		Payload:       ce.Data,
+	}, nil
+}
@@ -0,0 +1,346 @@
+package event_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/event"
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+)
+
+// The examples of the JSON event format,
+// https://github.com/cloudevents/spec/blob/v1.0.2/cloudevents/formats/json-format.md
+var specJSONExamples = map[string]struct {
+	json     string
+	expected event.CloudEvent
+}{
+	"text data": {
+		json: `{
+			"specversion" : "1.0",
+			"type" : "com.example.someevent",
+			"source" : "/mycontext",
+			"id" : "A234-1234-1234",
+			"time" : "2018-04-05T17:31:00Z",
+			"comexampleextension1" : "value",
+			"comexampleothervalue" : 5,
+			"datacontenttype" : "text/xml",
+			"data" : "<much wow=\"xml\"/>"
+		}`,
+		expected: event.CloudEvent{
+			SpecVersion:     "1.0",
+			Type:            "com.example.someevent",
+			Source:          "/mycontext",
+			ID:              "A234-1234-1234",
+			Time:            time.Date(2018, 4, 5, 17, 31, 0, 0, time.UTC),
+			Extensions:      map[string]string{"comexampleextension1": "value", "comexampleothervalue": "5"},
+			DataContentType: "text/xml",
+			Data:            []byte(`<much wow="xml"/>`),
+		},
+	},
+	"binary data": {
+		json: `{
+			"specversion" : "1.0",
+			"type" : "com.example.someevent",
+			"source" : "/mycontext",
+			"id" : "B234-1234-1234",
+			"time" : "2018-04-05T17:31:00Z",
+			"comexampleextension1" : "value",
+			"comexampleothervalue" : 5,
+			"datacontenttype" : "application/vnd.apache.thrift.binary",
+			"data_base64" : "CwABAAAAA3dvdwA="
+		}`,
+		expected: event.CloudEvent{
+			SpecVersion:     "1.0",
+			Type:            "com.example.someevent",
+			Source:          "/mycontext",
+			ID:              "B234-1234-1234",
+			Time:            time.Date(2018, 4, 5, 17, 31, 0, 0, time.UTC),
+			Extensions:      map[string]string{"comexampleextension1": "value", "comexampleothervalue": "5"},
+			DataContentType: "application/vnd.apache.thrift.binary",
+			Data:            []byte{0x0b, 0x00, 0x01, 0x00, 0x00, 0x00, 0x03, 'w', 'o', 'w', 0x00},
+		},
+	},
+	"JSON data": {
+		json: `{
+			"specversion" : "1.0",
+			"type" : "com.example.someevent",
+			"source" : "/mycontext",
+			"subject": null,
+			"id" : "C234-1234-1234",
+			"time" : "2018-04-05T17:31:00Z",
+			"comexampleextension1" : "value",
+			"comexampleothervalue" : 5,
+			"datacontenttype" : "application/json",
+			"data" : {"appinfoA":"abc","appinfoB":123,"appinfoC":true}
+		}`,
+		expected: event.CloudEvent{
+			SpecVersion:     "1.0",
+			Type:            "com.example.someevent",
+			Source:          "/mycontext",
+			ID:              "C234-1234-1234",
+			Time:            time.Date(2018, 4, 5, 17, 31, 0, 0, time.UTC),
+			Extensions:      map[string]string{"comexampleextension1": "value", "comexampleothervalue": "5"},
+			DataContentType: "application/json",
+			Data:            []byte(`{"appinfoA":"abc","appinfoB":123,"appinfoC":true}`),
+		},
+	},
+}
+
+func Test_CloudEvent_DecodesSpecJSONExamples(t *testing.T) {
+	for name, example := range specJSONExamples {
+		t.Run(name, func(t *testing.T) {
+			msg := kafka.Message{
+				Value:   []byte(example.json),
+				Headers: []kafka.Header{{Key: "content-type", Value: []byte("application/cloudevents+json; charset=UTF-8")}},
+			}
+
+			ce, ok, err := event.DecodeCloudEvent(msg)
+
+			assert.NoError(t, err)
+			assert.True(t, ok)
+			assert.Equal(t, example.expected, ce)
+		})
+	}
+}
+
+func Test_CloudEvent_StructuredModeRoundTrips(t *testing.T) {
+	for name, example := range specJSONExamples {
+		t.Run(name, func(t *testing.T) {
+			msg, err := example.expected.StructuredMessage([]byte("mykey"))
+			assert.NoError(t, err)
+			assert.Equal(t, []kafka.Header{{Key: "content-type", Value: []byte(event.CloudEventsJSONContentType)}}, msg.Headers)
+
+			ce, ok, err := event.DecodeCloudEvent(msg)
+
+			assert.NoError(t, err)
+			assert.True(t, ok)
+			assert.Equal(t, example.expected, ce)
+		})
+	}
+}
+
+func Test_CloudEvent_BinaryModeRoundTrips(t *testing.T) {
+	for name, example := range specJSONExamples {
+		t.Run(name, func(t *testing.T) {
+			msg, err := example.expected.BinaryMessage([]byte("mykey"))
+			assert.NoError(t, err)
+			assert.Equal(t, example.expected.Data, msg.Value)
+
+			ce, ok, err := event.DecodeCloudEvent(msg)
+
+			assert.NoError(t, err)
+			assert.True(t, ok)
+			assert.Equal(t, example.expected, ce)
+		})
+	}
+}
+
+// The binary content mode example of
+// https://github.com/cloudevents/spec/blob/v1.0.2/cloudevents/bindings/kafka-protocol-binding.md
+func Test_CloudEvent_KafkaBinaryModeExample(t *testing.T) {
+	avro := []byte{0x06, 'a', 'b', 'c'}
+	specMessage := kafka.Message{
+		Topic: "mytopic",
+		Key:   []byte("mykey"),
+		Headers: []kafka.Header{
+			{Key: "ce_specversion", Value: []byte("1.0")},
+			{Key: "ce_type", Value: []byte("com.example.someevent")},
+			{Key: "ce_source", Value: []byte("/mycontext/subcontext")},
+			{Key: "ce_id", Value: []byte("1234-1234-1234")},
+			{Key: "ce_time", Value: []byte("2018-04-05T03:56:24Z")},
+			{Key: "content-type", Value: []byte("application/avro")},
+		},
+		Value: avro,
+	}
+	expected := event.CloudEvent{
+		SpecVersion:     "1.0",
+		Type:            "com.example.someevent",
+		Source:          "/mycontext/subcontext",
+		ID:              "1234-1234-1234",
+		Time:            time.Date(2018, 4, 5, 3, 56, 24, 0, time.UTC),
+		DataContentType: "application/avro",
+		Data:            avro,
+	}
+
+	ce, ok, err := event.DecodeCloudEvent(specMessage)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, expected, ce)
+
+	msg, err := expected.BinaryMessage([]byte("mykey"))
+	assert.NoError(t, err)
+	assert.Equal(t, specMessage.Key, msg.Key)
+	assert.Equal(t, specMessage.Value, msg.Value)
+	assert.ElementsMatch(t, specMessage.Headers, msg.Headers)
+}
+
+// The structured content mode example of
+// https://github.com/cloudevents/spec/blob/v1.0.2/cloudevents/bindings/kafka-protocol-binding.md
+func Test_CloudEvent_KafkaStructuredModeExample(t *testing.T) {
+	specMessage := kafka.Message{
+		Topic:   "mytopic",
+		Key:     []byte("mykey"),
+		Headers: []kafka.Header{{Key: "content-type", Value: []byte("application/cloudevents+json; charset=UTF-8")}},
+		Value: []byte(`{
+			"specversion" : "1.0",
+			"type" : "com.example.someevent",
+			"source" : "/mycontext/subcontext",
+			"id" : "1234-1234-1234",
+			"time" : "2018-04-05T03:56:24Z",
+			"datacontenttype" : "application/xml",
+			"data" : "<much wow=\"xml\"/>"
+		}`),
+	}
+
+	ce, ok, err := event.DecodeCloudEvent(specMessage)
+
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, event.CloudEvent{
+		SpecVersion:     "1.0",
+		Type:            "com.example.someevent",
+		Source:          "/mycontext/subcontext",
+		ID:              "1234-1234-1234",
+		Time:            time.Date(2018, 4, 5, 3, 56, 24, 0, time.UTC),
+		DataContentType: "application/xml",
+		Data:            []byte(`<much wow="xml"/>`),
+	}, ce)
+}
+
+func Test_CloudEvent_RejectsInvalidEvents(t *testing.T) {
+	structured := []kafka.Header{{Key: "content-type", Value: []byte("application/cloudevents+json")}}
+	tests := map[string]kafka.Message{
+		"missing id": {
+			Headers: structured,
+			Value:   []byte(`{"specversion":"1.0","type":"t","source":"/s"}`),
+		},
+		"unsupported specversion": {
+			Headers: structured,
+			Value:   []byte(`{"specversion":"0.3","id":"1","type":"t","source":"/s"}`),
+		},
+		"data and data_base64": {
+			Headers: structured,
+			Value:   []byte(`{"specversion":"1.0","id":"1","type":"t","source":"/s","data":{},"data_base64":"e30="}`),
+		},
+		"invalid time": {
+			Headers: structured,
+			Value:   []byte(`{"specversion":"1.0","id":"1","type":"t","source":"/s","time":"yesterday"}`),
+		},
+		"object extension": {
+			Headers: structured,
+			Value:   []byte(`{"specversion":"1.0","id":"1","type":"t","source":"/s","comexample":{}}`),
+		},
+		"invalid extension name": {
+			Headers: []kafka.Header{
+				{Key: "ce_specversion", Value: []byte("1.0")},
+				{Key: "ce_id", Value: []byte("1")},
+				{Key: "ce_type", Value: []byte("t")},
+				{Key: "ce_source", Value: []byte("/s")},
+				{Key: "ce_Com-Example", Value: []byte("value")},
+			},
+		},
+	}
+
+	for name, msg := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, ok, err := event.DecodeCloudEvent(msg)
+			assert.True(t, ok)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_DecodeCloudEvent_IgnoresOtherMessages(t *testing.T) {
+	msg, err := event.Envelope{ID: "o1", Type: event.TypeUserCreated, SchemaVersion: 2, Payload: []byte(`{}`)}.Message(nil)
+	assert.NoError(t, err)
+
+	_, ok, err := event.DecodeCloudEvent(msg)
+
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func Test_Encoding_RoundTripsEnvelopes(t *testing.T) {
+	envelope := event.Envelope{
+		ID:            "o1",
+		Type:          event.TypeUserOffboarding,
+		SchemaVersion: 2,
+		OccurredAt:    time.Date(2025, 5, 11, 9, 0, 0, 0, time.UTC),
+		Producer:      "upvest-api",
+		CorrelationID: "corr-1",
+		Payload:       []byte(`{"user_id":"123"}`),
+	}
+
+	for _, encoding := range []event.Encoding{
+		event.EncodingEnvelope, event.EncodingCloudEventsBinary, event.EncodingCloudEventsStructured,
+	} {
+		t.Run(string(encoding), func(t *testing.T) {
+			msg, err := encoding.Message([]byte("123"), envelope)
+			assert.NoError(t, err)
+
+			eventType, ok := event.MessageType(msg)
+			assert.True(t, ok)
+			assert.Equal(t, event.TypeUserOffboarding, eventType)
+
+			decoded, err := event.DecodeMessage(msg)
+			assert.NoError(t, err)
+			assert.Equal(t, envelope, decoded)
+		})
+	}
+}
+
+func Test_Envelope_CloudEventAttributes(t *testing.T) {
+	envelope := event.Envelope{
+		ID:            "o1",
+		Type:          event.TypeUserCreated,
+		SchemaVersion: 2,
+		OccurredAt:    time.Date(2025, 5, 11, 9, 0, 0, 0, time.UTC),
+		Producer:      "upvest-api",
+		Payload:       []byte(`{}`),
+	}
+
+	msg, err := event.EncodingCloudEventsBinary.Message([]byte("123"), envelope)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []kafka.Header{
+		{Key: "ce_specversion", Value: []byte("1.0")},
+		{Key: "ce_id", Value: []byte("o1")},
+		{Key: "ce_source", Value: []byte("upvest-api")},
+		{Key: "ce_type", Value: []byte("USER_CREATED")},
+		{Key: "content-type", Value: []byte("application/json")},
+		{Key: "ce_schemaversion", Value: []byte("2")},
+		{Key: "ce_time", Value: []byte("2025-05-11T09:00:00Z")},
+	}, msg.Headers)
+	assert.Equal(t, []byte(`{}`), msg.Value)
+}
+
+func Test_DecodeMessage_TakesForeignCloudEventsAsVersion1(t *testing.T) {
+	msg, err := event.CloudEvent{
+		SpecVersion: "1.0",
+		ID:          "e1",
+		Source:      "/partner",
+		Type:        event.TypeUserOffboarding,
+		Data:        []byte(`{"user_id":"123"}`),
+	}.BinaryMessage(nil)
+	assert.NoError(t, err)
+
+	envelope, err := event.DecodeMessage(msg)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, envelope.SchemaVersion)
+	assert.Equal(t, "/partner", envelope.Producer)
+}
+
+func Test_ParseEncoding(t *testing.T) {
+	encoding, err := event.ParseEncoding("")
+	assert.NoError(t, err)
+	assert.Equal(t, event.EncodingEnvelope, encoding)
+
+	encoding, err = event.ParseEncoding("cloudevents-binary")
+	assert.NoError(t, err)
+	assert.Equal(t, event.EncodingCloudEventsBinary, encoding)
+
+	_, err = event.ParseEncoding("avro")
+	assert.Error(t, err)
+}
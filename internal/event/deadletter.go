@@ -0,0 +1,99 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	log "github.com/sirupsen/logrus"
+)
+
+// DeadLetterTopicSuffix names the dead-letter topic after its source topic.
+const DeadLetterTopicSuffix = ".dlq"
+
+// Headers the subscriber adds to a dead-lettered message, next to the headers
+// of the original message.
+const (
+	HeaderDeadLetterPrefix   = "dlq-"
+	HeaderOriginalTopic      = HeaderDeadLetterPrefix + "original-topic"
+	HeaderOriginalPartition  = HeaderDeadLetterPrefix + "original-partition"
+	HeaderOriginalOffset     = HeaderDeadLetterPrefix + "original-offset"
+	HeaderDeadLetterError    = HeaderDeadLetterPrefix + "error"
+	HeaderDeadLetterAttempts = HeaderDeadLetterPrefix + "attempts"
+	HeaderDeadLetterFailedAt = HeaderDeadLetterPrefix + "failed-at"
+)
+
+// DeadLetterMessage copies msg for the dead-letter topic and records where it
+// came from and why it failed in headers.
+func DeadLetterMessage(msg kafka.Message, cause error, attempts int, failedAt time.Time) kafka.Message {
+	headers := make([]kafka.Header, 0, len(msg.Headers)+6)
+	headers = append(headers, msg.Headers...)
+	headers = append(headers,
+		kafka.Header{Key: HeaderOriginalTopic, Value: []byte(msg.Topic)},
+		kafka.Header{Key: HeaderOriginalPartition, Value: []byte(strconv.Itoa(msg.Partition))},
+		kafka.Header{Key: HeaderOriginalOffset, Value: []byte(strconv.FormatInt(msg.Offset, 10))},
+		kafka.Header{Key: HeaderDeadLetterError, Value: []byte(cause.Error())},
+		kafka.Header{Key: HeaderDeadLetterAttempts, Value: []byte(strconv.Itoa(attempts))},
+		kafka.Header{Key: HeaderDeadLetterFailedAt, Value: []byte(failedAt.UTC().Format(time.RFC3339))},
+	)
+
+	return kafka.Message{
+		Key:     msg.Key,
+		Value:   msg.Value,
+		Headers: headers,
+	}
+}
+
+// ReplayDeadLetters re-publishes dead-lettered messages to the topic they came
+// from, without the dead-letter headers, through a writer that has no topic of
+// its own. A message is committed only once it was re-published. Replay stops
+// when no message arrives for idle, and returns the number of messages replayed.
+func ReplayDeadLetters(ctx context.Context, reader MessageReader, writer MessageWriter, idle time.Duration) (int, error) {
+	replayed := 0
+	for {
+		fetchCtx, cancel := context.WithTimeout(ctx, idle)
+		msg, err := reader.FetchMessage(fetchCtx)
+		cancel()
+		if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+			return replayed, nil
+		} else if err != nil {
+			return replayed, fmt.Errorf("failed to fetch dead letter: %w", err)
+		}
+
+		original, err := originalMessage(msg)
+		if err != nil {
+			return replayed, fmt.Errorf("dead letter %d/%d: %w", msg.Partition, msg.Offset, err)
+		}
+		if err := writer.WriteMessages(ctx, original); err != nil {
+			return replayed, fmt.Errorf("failed to replay dead letter %d/%d: %w", msg.Partition, msg.Offset, err)
+		}
+		if err := reader.CommitMessages(ctx, msg); err != nil {
+			return replayed, fmt.Errorf("failed to commit dead letter %d/%d: %w", msg.Partition, msg.Offset, err)
+		}
+
+		replayed++
+		log.Infof("replayed dead letter %d/%d to %s", msg.Partition, msg.Offset, original.Topic)
+	}
+}
+
+// originalMessage restores the message that was dead-lettered.
+func originalMessage(msg kafka.Message) (kafka.Message, error) {
+	original := kafka.Message{Key: msg.Key, Value: msg.Value}
+	for _, header := range msg.Headers {
+		switch {
+		case header.Key == HeaderOriginalTopic:
+			original.Topic = string(header.Value)
+		case !strings.HasPrefix(header.Key, HeaderDeadLetterPrefix):
+			original.Headers = append(original.Headers, header)
+		}
+	}
+
+	if original.Topic == "" {
+		return kafka.Message{}, fmt.Errorf("missing %s header", HeaderOriginalTopic)
+	}
+	return original, nil
+}
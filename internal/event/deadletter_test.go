@@ -0,0 +1,87 @@
+package event_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/event"
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeDeadLetterReader hands out its messages and then blocks until the fetch
+// times out, as an idle topic does.
+type fakeDeadLetterReader struct {
+	messages  []kafka.Message
+	committed []int64
+}
+
+func (r *fakeDeadLetterReader) FetchMessage(ctx context.Context) (kafka.Message, error) {
+	if len(r.messages) == 0 {
+		<-ctx.Done()
+		return kafka.Message{}, ctx.Err()
+	}
+	msg := r.messages[0]
+	r.messages = r.messages[1:]
+	return msg, nil
+}
+
+func (r *fakeDeadLetterReader) CommitMessages(_ context.Context, msgs ...kafka.Message) error {
+	for _, msg := range msgs {
+		r.committed = append(r.committed, msg.Offset)
+	}
+	return nil
+}
+
+func (r *fakeDeadLetterReader) Close() error {
+	return nil
+}
+
+func Test_ReplayDeadLetters_RestoresOriginalMessages(t *testing.T) {
+	original := kafka.Message{
+		Topic:   "user-events",
+		Offset:  42,
+		Key:     []byte("user-1"),
+		Value:   []byte(`{"action":"USER_OFFBOARDING"}`),
+		Headers: []kafka.Header{{Key: "trace-id", Value: []byte("abc")}},
+	}
+	dead := event.DeadLetterMessage(original, errors.New("user not found"), 5, time.Date(2025, 4, 28, 9, 0, 0, 0, time.UTC))
+	dead.Offset = 7
+	reader := &fakeDeadLetterReader{messages: []kafka.Message{dead}}
+	writer := &fakeWriter{}
+
+	replayed, err := event.ReplayDeadLetters(context.Background(), reader, writer, 10*time.Millisecond)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, replayed)
+	assert.Equal(t, []kafka.Message{{
+		Topic:   "user-events",
+		Key:     original.Key,
+		Value:   original.Value,
+		Headers: original.Headers,
+	}}, writer.written)
+	assert.Equal(t, []int64{7}, reader.committed)
+}
+
+func Test_ReplayDeadLetters_DoesNotCommitOnWriteFailure(t *testing.T) {
+	dead := event.DeadLetterMessage(kafka.Message{Topic: "user-events"}, errors.New("invalid event"), 5, time.Now())
+	reader := &fakeDeadLetterReader{messages: []kafka.Message{dead}}
+	writer := &fakeWriter{err: errors.New("broker unavailable")}
+
+	replayed, err := event.ReplayDeadLetters(context.Background(), reader, writer, 10*time.Millisecond)
+
+	assert.Error(t, err)
+	assert.Zero(t, replayed)
+	assert.Empty(t, reader.committed)
+}
+
+func Test_ReplayDeadLetters_RequiresOriginalTopic(t *testing.T) {
+	reader := &fakeDeadLetterReader{messages: []kafka.Message{{Key: []byte("user-1")}}}
+
+	_, err := event.ReplayDeadLetters(context.Background(), reader, &fakeWriter{}, 10*time.Millisecond)
+
+	assert.ErrorContains(t, err, "missing dlq-original-topic header")
+	assert.Empty(t, reader.committed)
+}
@@ -0,0 +1,134 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// Headers that carry the type and schema version of an event, so that consumers
+// can route a message without parsing its body.
+const (
+	HeaderEventType    = "event-type"
+	HeaderEventVersion = "event-version"
+)
+
+// Envelope wraps every event published to Kafka.
+type Envelope struct {
+	ID            string          `json:"id"`
+	Type          string          `json:"type"`
+	SchemaVersion int             `json:"schema_version"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	Producer      string          `json:"producer"`
+	CorrelationID string          `json:"correlation_id,omitempty"`
+	Payload       json.RawMessage `json:"payload"`
+}
+
+// Payload is the typed body of an event of a given type and schema version.
+type Payload interface {
+	EventType() string
+	SchemaVersion() int
+}
+
+// Message encodes e as a Kafka message with the event headers.
+func (e Envelope) Message(key []byte) (kafka.Message, error) {
+	value, err := json.Marshal(e)
+	if err != nil {
+		return kafka.Message{}, fmt.Errorf("failed to marshal %s event: %w", e.Type, err)
+	}
+
+	return kafka.Message{Key: key, Value: value, Headers: e.headers()}, nil
+}
+
+func (e Envelope) headers() []kafka.Header {
+	return []kafka.Header{
+		{Key: HeaderEventType, Value: []byte(e.Type)},
+		{Key: HeaderEventVersion, Value: []byte(strconv.Itoa(e.SchemaVersion))},
+	}
+}
+
+// MessageType returns the event type from the headers of msg, if it has one.
+// CloudEvents in the binary content mode carry it in ce_type.
+func MessageType(msg kafka.Message) (string, bool) {
+	if eventType, ok := header(msg, HeaderEventType); ok {
+		return eventType, true
+	}
+	return header(msg, cloudEventsHeaderPrefix+"type")
+}
+
+// DecodeMessage reads the envelope of msg, whether it was published as an
+// envelope or as a CloudEvent in either content mode. Messages published before
+// envelopes were introduced carry the bare payload with its type in the action
+// field; they are returned as version 1 of that type.
+func DecodeMessage(msg kafka.Message) (Envelope, error) {
+	ce, ok, err := DecodeCloudEvent(msg)
+	if err != nil {
+		return Envelope{}, err
+	} else if ok {
+		return envelopeFromCloudEvent(ce)
+	}
+
+	if _, ok := header(msg, HeaderEventType); !ok {
+		return decodeLegacyMessage(msg)
+	}
+
+	var envelope Envelope
+	if err := json.Unmarshal(msg.Value, &envelope); err != nil {
+		return Envelope{}, fmt.Errorf("failed to unmarshal event envelope: %w", err)
+	}
+	if envelope.Type == "" || envelope.SchemaVersion < 1 {
+		return Envelope{}, errors.New("event envelope without type or schema version")
+	}
+	return envelope, nil
+}
+
+func decodeLegacyMessage(msg kafka.Message) (Envelope, error) {
+	var legacy struct {
+		Action string `json:"action"`
+	}
+	if err := json.Unmarshal(msg.Value, &legacy); err != nil {
+		return Envelope{}, fmt.Errorf("failed to unmarshal event: %w", err)
+	}
+	if legacy.Action == "" {
+		return Envelope{}, errors.New("event without type")
+	}
+
+	return Envelope{
+		Type:          legacy.Action,
+		SchemaVersion: 1,
+		OccurredAt:    msg.Time,
+		Payload:       msg.Value,
+	}, nil
+}
+
+type correlationIDKey struct{}
+
+// WithCorrelationID returns a copy of ctx carrying the correlation ID that
+// events recorded within it are tagged with.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, correlationIDKey{}, id)
+}
+
+// CorrelationID returns the correlation ID carried by ctx, if any.
+func CorrelationID(ctx context.Context) string {
+	id, _ := ctx.Value(correlationIDKey{}).(string)
+	return id
+}
+
+// header returns the value of the first header of msg named key.
+func header(msg kafka.Message, key string) (string, bool) {
+	for _, h := range msg.Headers {
+		if h.Key == key {
+			return string(h.Value), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,78 @@
+package event_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/event"
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Envelope_RoundTripsThroughMessage(t *testing.T) {
+	envelope := event.Envelope{
+		ID:            "o1",
+		Type:          event.TypeUserOffboarding,
+		SchemaVersion: 2,
+		OccurredAt:    time.Date(2025, 5, 11, 9, 0, 0, 0, time.UTC),
+		Producer:      "upvest-api",
+		CorrelationID: "corr-1",
+		Payload:       []byte(`{"user_id":"123"}`),
+	}
+
+	msg, err := envelope.Message([]byte("123"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("123"), msg.Key)
+	assert.Equal(t, "USER_OFFBOARDING", header(msg, event.HeaderEventType))
+	assert.Equal(t, "2", header(msg, event.HeaderEventVersion))
+
+	eventType, ok := event.MessageType(msg)
+	assert.True(t, ok)
+	assert.Equal(t, event.TypeUserOffboarding, eventType)
+
+	decoded, err := event.DecodeMessage(msg)
+	assert.NoError(t, err)
+	assert.Equal(t, envelope, decoded)
+}
+
+func Test_DecodeMessage_WrapsLegacyPayloads(t *testing.T) {
+	publishedAt := time.Date(2025, 1, 12, 9, 0, 0, 0, time.UTC)
+	msg := kafka.Message{Value: []byte(`{"action":"USER_OFFBOARDING","user_id":"123"}`), Time: publishedAt}
+
+	_, ok := event.MessageType(msg)
+	assert.False(t, ok)
+
+	envelope, err := event.DecodeMessage(msg)
+	assert.NoError(t, err)
+	assert.Equal(t, event.Envelope{
+		Type:          event.TypeUserOffboarding,
+		SchemaVersion: 1,
+		OccurredAt:    publishedAt,
+		Payload:       msg.Value,
+	}, envelope)
+}
+
+func Test_DecodeMessage_RejectsMessagesWithoutType(t *testing.T) {
+	tests := map[string]kafka.Message{
+		"legacy without action": {Value: []byte(`{"user_id":"123"}`)},
+		"not JSON":              {Value: []byte(`user 123`)},
+		"envelope without type": {
+			Value:   []byte(`{"id":"o1","schema_version":2}`),
+			Headers: []kafka.Header{{Key: event.HeaderEventType, Value: []byte("USER_CREATED")}},
+		},
+	}
+
+	for name, msg := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := event.DecodeMessage(msg)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_CorrelationID(t *testing.T) {
+	assert.Empty(t, event.CorrelationID(context.Background()))
+	assert.Empty(t, event.CorrelationID(event.WithCorrelationID(context.Background(), "")))
+	assert.Equal(t, "corr-1", event.CorrelationID(event.WithCorrelationID(context.Background(), "corr-1")))
+}
@@ -0,0 +1,368 @@
+package event
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+)
+
+// SASL mechanisms supported by KafkaConfig.
+const (
+	SASLPlain       = "PLAIN"
+	SASLScramSHA256 = "SCRAM-SHA-256"
+	SASLScramSHA512 = "SCRAM-SHA-512"
+)
+
+// KafkaConfig is the connection to Kafka shared by publishers and subscribers.
+type KafkaConfig struct {
+	Brokers  []string        `json:"brokers"`
+	Topic    string          `json:"topic"`
+	GroupID  string          `json:"group_id"`
+	ClientID string          `json:"client_id"`
+	TLS      KafkaTLSConfig  `json:"tls"`
+	SASL     KafkaSASLConfig `json:"sasl"`
+	// Compression is one of none, gzip, snappy, lz4 or zstd.
+	Compression  string        `json:"compression"`
+	BatchSize    int           `json:"batch_size"`
+	BatchTimeout time.Duration `json:"-"`
+	// RequiredAcks is one of none, one or all. Empty leaves every writer at its
+	// own default: one for events, all for dead letters.
+	RequiredAcks string `json:"required_acks"`
+}
+
+// KafkaTLSConfig enables TLS, implied by any of the files. Without a CA file
+// the system roots are trusted; the certificate and key authenticate the client.
+type KafkaTLSConfig struct {
+	Enabled  bool   `json:"enabled"`
+	CAFile   string `json:"ca_file"`
+	CertFile string `json:"cert_file"`
+	KeyFile  string `json:"key_file"`
+}
+
+type KafkaSASLConfig struct {
+	Mechanism string `json:"mechanism"`
+	Username  string `json:"username"`
+	Password  string `json:"password"`
+}
+
+// DefaultKafkaConfig is the single broker of the docker-compose setup. Batching
+// follows the defaults of kafka.Writer.
+func DefaultKafkaConfig() KafkaConfig {
+	return KafkaConfig{
+		Brokers:      []string{"kafka:9092"},
+		Topic:        "user-events",
+		GroupID:      "user-subscriber-group",
+		BatchSize:    100,
+		BatchTimeout: time.Second,
+	}
+}
+
+// LoadKafkaConfig reads the JSON config file at path, if any, over the defaults
+// and then the KAFKA_* environment variables of lookupEnv over the file. The
+// result is validated, including that the TLS files can be loaded.
+func LoadKafkaConfig(path string, lookupEnv func(string) (string, bool)) (KafkaConfig, error) {
+	config := DefaultKafkaConfig()
+	if path != "" {
+		if err := config.readFile(path); err != nil {
+			return KafkaConfig{}, err
+		}
+	}
+	if err := config.readEnv(lookupEnv); err != nil {
+		return KafkaConfig{}, err
+	}
+	if err := config.Validate(); err != nil {
+		return KafkaConfig{}, err
+	}
+	return config, nil
+}
+
+func (c *KafkaConfig) readFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open Kafka config file: %w", err)
+	}
+	defer file.Close()
+
+	// The batch timeout is written as a duration string, e.g. "10ms".
+	doc := struct {
+		*KafkaConfig
+		BatchTimeout string `json:"batch_timeout"`
+	}{KafkaConfig: c}
+	decoder := json.NewDecoder(file)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&doc); err != nil {
+		return fmt.Errorf("invalid Kafka config file %s: %w", path, err)
+	}
+
+	if doc.BatchTimeout != "" {
+		if c.BatchTimeout, err = time.ParseDuration(doc.BatchTimeout); err != nil {
+			return fmt.Errorf("invalid Kafka config file %s: invalid batch_timeout %q", path, doc.BatchTimeout)
+		}
+	}
+	return nil
+}
+
+func (c *KafkaConfig) readEnv(lookupEnv func(string) (string, bool)) error {
+	setString := func(field *string) func(string) error {
+		return func(value string) error {
+			*field = value
+			return nil
+		}
+	}
+
+	settings := []struct {
+		name  string
+		apply func(value string) error
+	}{
+		{"KAFKA_BROKERS", func(value string) error {
+			c.Brokers = nil
+			for _, broker := range strings.Split(value, ",") {
+				if broker = strings.TrimSpace(broker); broker != "" {
+					c.Brokers = append(c.Brokers, broker)
+				}
+			}
+			return nil
+		}},
+		{"KAFKA_TOPIC", setString(&c.Topic)},
+		{"KAFKA_GROUP_ID", setString(&c.GroupID)},
+		{"KAFKA_CLIENT_ID", setString(&c.ClientID)},
+		{"KAFKA_TLS", func(value string) (err error) {
+			c.TLS.Enabled, err = strconv.ParseBool(value)
+			return err
+		}},
+		{"KAFKA_TLS_CA_FILE", setString(&c.TLS.CAFile)},
+		{"KAFKA_TLS_CERT_FILE", setString(&c.TLS.CertFile)},
+		{"KAFKA_TLS_KEY_FILE", setString(&c.TLS.KeyFile)},
+		{"KAFKA_SASL_MECHANISM", setString(&c.SASL.Mechanism)},
+		{"KAFKA_SASL_USERNAME", setString(&c.SASL.Username)},
+		{"KAFKA_SASL_PASSWORD", setString(&c.SASL.Password)},
+		{"KAFKA_COMPRESSION", setString(&c.Compression)},
+		{"KAFKA_BATCH_SIZE", func(value string) (err error) {
+			c.BatchSize, err = strconv.Atoi(value)
+			return err
+		}},
+		{"KAFKA_BATCH_TIMEOUT", func(value string) (err error) {
+			c.BatchTimeout, err = time.ParseDuration(value)
+			return err
+		}},
+		{"KAFKA_REQUIRED_ACKS", setString(&c.RequiredAcks)},
+	}
+
+	for _, setting := range settings {
+		value, ok := lookupEnv(setting.name)
+		if !ok || value == "" {
+			continue
+		}
+		if err := setting.apply(value); err != nil {
+			return fmt.Errorf("invalid %s %q", setting.name, value)
+		}
+	}
+	return nil
+}
+
+// Validate reports every problem of the configuration at once.
+func (c KafkaConfig) Validate() error {
+	var problems []string
+	if len(c.Brokers) == 0 {
+		problems = append(problems, "at least one broker is required")
+	}
+	for _, broker := range c.Brokers {
+		if _, _, err := net.SplitHostPort(broker); err != nil {
+			problems = append(problems, fmt.Sprintf("broker %q is not host:port", broker))
+		}
+	}
+	if c.Topic == "" {
+		problems = append(problems, "topic is required")
+	}
+	if c.GroupID == "" {
+		problems = append(problems, "group ID is required")
+	}
+	if _, err := c.tlsConfig(); err != nil {
+		problems = append(problems, err.Error())
+	}
+	if _, err := c.saslMechanism(); err != nil {
+		problems = append(problems, err.Error())
+	}
+	if _, err := c.compression(); err != nil {
+		problems = append(problems, err.Error())
+	}
+	if c.BatchSize <= 0 {
+		problems = append(problems, fmt.Sprintf("batch size must be positive, got %d", c.BatchSize))
+	}
+	if c.BatchTimeout <= 0 {
+		problems = append(problems, fmt.Sprintf("batch timeout must be positive, got %s", c.BatchTimeout))
+	}
+	if _, err := c.requiredAcks(kafka.RequireOne); err != nil {
+		problems = append(problems, err.Error())
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid Kafka configuration: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// NewWriter writes to topic, or to the topic of each message if topic is empty.
+// defaultAcks applies unless RequiredAcks is set. Messages are partitioned by
+// key, so that the events of an aggregate reach consumers in the order the
+// relay publishes them.
+func (c KafkaConfig) NewWriter(topic string, defaultAcks kafka.RequiredAcks) (*kafka.Writer, error) {
+	tlsConfig, err := c.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+	mechanism, err := c.saslMechanism()
+	if err != nil {
+		return nil, err
+	}
+	compression, err := c.compression()
+	if err != nil {
+		return nil, err
+	}
+	acks, err := c.requiredAcks(defaultAcks)
+	if err != nil {
+		return nil, err
+	}
+
+	return &kafka.Writer{
+		Addr:         kafka.TCP(c.Brokers...),
+		Topic:        topic,
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: acks,
+		Compression:  compression,
+		BatchSize:    c.BatchSize,
+		BatchTimeout: c.BatchTimeout,
+		Transport: &kafka.Transport{
+			ClientID: c.ClientID,
+			TLS:      tlsConfig,
+			SASL:     mechanism,
+		},
+	}, nil
+}
+
+// NewReader consumes topic as a member of groupID. Without a CommitInterval
+// the reader only commits when asked to.
+func (c KafkaConfig) NewReader(topic, groupID string) (*kafka.Reader, error) {
+	tlsConfig, err := c.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+	mechanism, err := c.saslMechanism()
+	if err != nil {
+		return nil, err
+	}
+
+	return kafka.NewReader(kafka.ReaderConfig{
+		Brokers: c.Brokers,
+		Topic:   topic,
+		GroupID: groupID,
+		Dialer: &kafka.Dialer{
+			ClientID:      c.ClientID,
+			Timeout:       10 * time.Second,
+			DualStack:     true,
+			TLS:           tlsConfig,
+			SASLMechanism: mechanism,
+		},
+	}), nil
+}
+
+func (c KafkaConfig) tlsConfig() (*tls.Config, error) {
+	t := c.TLS
+	if !t.Enabled && t.CAFile == "" && t.CertFile == "" && t.KeyFile == "" {
+		return nil, nil
+	}
+
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if t.CAFile != "" {
+		pem, err := os.ReadFile(t.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read TLS CA file: %w", err)
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("TLS CA file %s contains no PEM certificate", t.CAFile)
+		}
+	}
+
+	switch {
+	case t.CertFile == "" && t.KeyFile == "":
+	case t.CertFile == "" || t.KeyFile == "":
+		return nil, errors.New("TLS cert file and key file must be set together")
+	default:
+		certificate, err := tls.LoadX509KeyPair(t.CertFile, t.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+	return config, nil
+}
+
+func (c KafkaConfig) saslMechanism() (sasl.Mechanism, error) {
+	s := c.SASL
+	if s.Mechanism == "" {
+		if s.Username != "" || s.Password != "" {
+			return nil, errors.New("SASL username and password require a SASL mechanism")
+		}
+		return nil, nil
+	}
+	if s.Username == "" || s.Password == "" {
+		return nil, fmt.Errorf("SASL %s requires a username and a password", s.Mechanism)
+	}
+
+	switch strings.ToUpper(s.Mechanism) {
+	case SASLPlain:
+		return plain.Mechanism{Username: s.Username, Password: s.Password}, nil
+	case SASLScramSHA256:
+		return scram.Mechanism(scram.SHA256, s.Username, s.Password)
+	case SASLScramSHA512:
+		return scram.Mechanism(scram.SHA512, s.Username, s.Password)
+	default:
+		return nil, fmt.Errorf("unknown SASL mechanism %q, expected %s, %s or %s",
+			s.Mechanism, SASLPlain, SASLScramSHA256, SASLScramSHA512)
+	}
+}
+
+func (c KafkaConfig) compression() (kafka.Compression, error) {
+	switch strings.ToLower(c.Compression) {
+	case "", "none":
+		return 0, nil
+	case "gzip":
+		return kafka.Gzip, nil
+	case "snappy":
+		return kafka.Snappy, nil
+	case "lz4":
+		return kafka.Lz4, nil
+	case "zstd":
+		return kafka.Zstd, nil
+	default:
+		return 0, fmt.Errorf("unknown compression %q, expected none, gzip, snappy, lz4 or zstd", c.Compression)
+	}
+}
+
+func (c KafkaConfig) requiredAcks(fallback kafka.RequiredAcks) (kafka.RequiredAcks, error) {
+	switch strings.ToLower(c.RequiredAcks) {
+	case "":
+		return fallback, nil
+	case "none":
+		return kafka.RequireNone, nil
+	case "one":
+		return kafka.RequireOne, nil
+	case "all":
+		return kafka.RequireAll, nil
+	default:
+		return 0, fmt.Errorf("unknown required acks %q, expected none, one or all", c.RequiredAcks)
+	}
+}
@@ -0,0 +1,207 @@
+package event_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/event"
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func env(values map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		value, ok := values[name]
+		return value, ok
+	}
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+// writeCertificate writes a self-signed certificate and its key as PEM files.
+func writeCertificate(t *testing.T) (certFile, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "upvest-api"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+
+	certFile = writeFile(t, "client.crt", string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})))
+	keyFile = writeFile(t, "client.key", string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})))
+	return certFile, keyFile
+}
+
+func Test_LoadKafkaConfig_Defaults(t *testing.T) {
+	config, err := event.LoadKafkaConfig("", env(nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, event.DefaultKafkaConfig(), config)
+	assert.Equal(t, []string{"kafka:9092"}, config.Brokers)
+	assert.Equal(t, "user-events", config.Topic)
+	assert.Equal(t, "user-subscriber-group", config.GroupID)
+}
+
+func Test_LoadKafkaConfig_EnvironmentOverridesFile(t *testing.T) {
+	path := writeFile(t, "kafka.json", `{
+		"brokers": ["kafka-1:9092", "kafka-2:9092"],
+		"topic": "users",
+		"client_id": "upvest-api",
+		"sasl": {"mechanism": "SCRAM-SHA-512", "username": "api", "password": "file-secret"},
+		"compression": "zstd",
+		"batch_size": 50,
+		"batch_timeout": "20ms",
+		"required_acks": "all"
+	}`)
+
+	config, err := event.LoadKafkaConfig(path, env(map[string]string{
+		"KAFKA_BROKERS":       "kafka-3:9093, kafka-4:9093",
+		"KAFKA_GROUP_ID":      "users-group",
+		"KAFKA_SASL_PASSWORD": "env-secret",
+		"KAFKA_BATCH_TIMEOUT": "5ms",
+		"KAFKA_COMPRESSION":   "",
+	}))
+
+	assert.NoError(t, err)
+	assert.Equal(t, event.KafkaConfig{
+		Brokers:      []string{"kafka-3:9093", "kafka-4:9093"},
+		Topic:        "users",
+		GroupID:      "users-group",
+		ClientID:     "upvest-api",
+		SASL:         event.KafkaSASLConfig{Mechanism: event.SASLScramSHA512, Username: "api", Password: "env-secret"},
+		Compression:  "zstd",
+		BatchSize:    50,
+		BatchTimeout: 5 * time.Millisecond,
+		RequiredAcks: "all",
+	}, config)
+}
+
+func Test_LoadKafkaConfig_RejectsUnknownFileFields(t *testing.T) {
+	path := writeFile(t, "kafka.json", `{"broker": "kafka:9092"}`)
+
+	_, err := event.LoadKafkaConfig(path, env(nil))
+
+	assert.ErrorContains(t, err, `unknown field "broker"`)
+}
+
+func Test_LoadKafkaConfig_RejectsMalformedEnvironment(t *testing.T) {
+	tests := map[string]string{
+		"KAFKA_BATCH_SIZE":    "many",
+		"KAFKA_BATCH_TIMEOUT": "10",
+		"KAFKA_TLS":           "maybe",
+	}
+	for name, value := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := event.LoadKafkaConfig("", env(map[string]string{name: value}))
+
+			assert.EqualError(t, err, "invalid "+name+" \""+value+"\"")
+		})
+	}
+}
+
+func Test_LoadKafkaConfig_ReportsEveryProblem(t *testing.T) {
+	_, err := event.LoadKafkaConfig("", env(map[string]string{
+		"KAFKA_BROKERS":        "kafka",
+		"KAFKA_SASL_MECHANISM": "SCRAM-SHA-1",
+		"KAFKA_SASL_USERNAME":  "api",
+		"KAFKA_SASL_PASSWORD":  "secret",
+		"KAFKA_COMPRESSION":    "brotli",
+		"KAFKA_BATCH_SIZE":     "0",
+		"KAFKA_REQUIRED_ACKS":  "two",
+		"KAFKA_TLS_CERT_FILE":  "client.crt",
+	}))
+
+	assert.EqualError(t, err, `invalid Kafka configuration: `+
+		`broker "kafka" is not host:port; `+
+		`TLS cert file and key file must be set together; `+
+		`unknown SASL mechanism "SCRAM-SHA-1", expected PLAIN, SCRAM-SHA-256 or SCRAM-SHA-512; `+
+		`unknown compression "brotli", expected none, gzip, snappy, lz4 or zstd; `+
+		`batch size must be positive, got 0; `+
+		`unknown required acks "two", expected none, one or all`)
+}
+
+func Test_LoadKafkaConfig_RequiresSASLCredentials(t *testing.T) {
+	_, err := event.LoadKafkaConfig("", env(map[string]string{
+		"KAFKA_SASL_MECHANISM": "PLAIN",
+		"KAFKA_SASL_USERNAME":  "api",
+	}))
+
+	assert.EqualError(t, err, "invalid Kafka configuration: SASL PLAIN requires a username and a password")
+}
+
+func Test_LoadKafkaConfig_LoadsTLSFiles(t *testing.T) {
+	certFile, keyFile := writeCertificate(t)
+
+	config, err := event.LoadKafkaConfig("", env(map[string]string{
+		"KAFKA_TLS_CA_FILE":   certFile,
+		"KAFKA_TLS_CERT_FILE": certFile,
+		"KAFKA_TLS_KEY_FILE":  keyFile,
+	}))
+	assert.NoError(t, err)
+
+	writer, err := config.NewWriter(config.Topic, kafka.RequireOne)
+	assert.NoError(t, err)
+	tlsConfig := writer.Transport.(*kafka.Transport).TLS
+	if assert.NotNil(t, tlsConfig) {
+		assert.Len(t, tlsConfig.Certificates, 1)
+		assert.NotNil(t, tlsConfig.RootCAs)
+	}
+}
+
+func Test_LoadKafkaConfig_RejectsUnreadableTLSFiles(t *testing.T) {
+	_, err := event.LoadKafkaConfig("", env(map[string]string{
+		"KAFKA_TLS_CA_FILE": writeFile(t, "ca.crt", "not a certificate"),
+	}))
+
+	assert.ErrorContains(t, err, "contains no PEM certificate")
+}
+
+func Test_KafkaConfig_NewWriter(t *testing.T) {
+	config := event.DefaultKafkaConfig()
+	config.Brokers = []string{"kafka-1:9092", "kafka-2:9092"}
+	config.ClientID = "upvest-api"
+	config.SASL = event.KafkaSASLConfig{Mechanism: event.SASLPlain, Username: "api", Password: "secret"}
+	config.Compression = "gzip"
+	config.BatchSize = 10
+
+	writer, err := config.NewWriter("user-events", kafka.RequireAll)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "kafka-1:9092,kafka-2:9092", writer.Addr.String())
+	assert.Equal(t, "user-events", writer.Topic)
+	assert.IsType(t, &kafka.Hash{}, writer.Balancer)
+	assert.Equal(t, kafka.RequireAll, writer.RequiredAcks)
+	assert.Equal(t, kafka.Gzip, writer.Compression)
+	assert.Equal(t, 10, writer.BatchSize)
+	assert.Equal(t, time.Second, writer.BatchTimeout)
+	transport := writer.Transport.(*kafka.Transport)
+	assert.Equal(t, "upvest-api", transport.ClientID)
+	assert.Nil(t, transport.TLS)
+	assert.Equal(t, "PLAIN", transport.SASL.Name())
+
+	config.RequiredAcks = "one"
+	writer, err = config.NewWriter("user-events", kafka.RequireAll)
+	assert.NoError(t, err)
+	assert.Equal(t, kafka.RequireOne, writer.RequiredAcks)
+}
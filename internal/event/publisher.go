@@ -3,38 +3,79 @@ package event
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/segmentio/kafka-go"
 )
 
 type PublisherInterface interface {
-	Publish(ctx context.Context, key, value []byte) error
+	Publish(ctx context.Context, key []byte, envelope Envelope) error
 	Close() error
 }
 
+// Encoding selects how a Publisher writes events to Kafka.
+type Encoding string
+
+const (
+	// EncodingEnvelope writes the JSON envelope with the event headers.
+	EncodingEnvelope Encoding = "envelope"
+	// EncodingCloudEventsBinary writes CloudEvents in the binary content mode:
+	// the payload is the value and the attributes are ce_ headers.
+	EncodingCloudEventsBinary Encoding = "cloudevents-binary"
+	// EncodingCloudEventsStructured writes CloudEvents in the structured
+	// content mode, as JSON documents, next to the event headers.
+	EncodingCloudEventsStructured Encoding = "cloudevents-structured"
+)
+
+// ParseEncoding returns the Encoding named s, EncodingEnvelope if s is empty.
+func ParseEncoding(s string) (Encoding, error) {
+	switch encoding := Encoding(s); encoding {
+	case "":
+		return EncodingEnvelope, nil
+	case EncodingEnvelope, EncodingCloudEventsBinary, EncodingCloudEventsStructured:
+		return encoding, nil
+	default:
+		return "", fmt.Errorf("unknown event encoding %q", s)
+	}
+}
+
+// Message encodes envelope as a Kafka message. Subscribers decode every
+// encoding with DecodeMessage.
+func (enc Encoding) Message(key []byte, envelope Envelope) (kafka.Message, error) {
+	switch enc {
+	case EncodingCloudEventsBinary:
+		return envelope.CloudEvent().BinaryMessage(key)
+	case EncodingCloudEventsStructured:
+		msg, err := envelope.CloudEvent().StructuredMessage(key)
+		msg.Headers = append(msg.Headers, envelope.headers()...)
+		return msg, err
+	default:
+		return envelope.Message(key)
+	}
+}
+
 type Publisher struct {
-	writer *kafka.Writer
+	writer   *kafka.Writer
+	encoding Encoding
 }
 
-func NewPublisher(broker string, topic string) *Publisher {
-	return &Publisher{
-		writer: &kafka.Writer{
-			Addr:         kafka.TCP(broker),
-			Topic:        topic,
-			Balancer:     &kafka.LeastBytes{},
-			RequiredAcks: kafka.RequireOne,
-			Async:        false,
-		},
+// NewPublisher writes to the topic of config, waiting for the leader to
+// acknowledge each write unless config.RequiredAcks says otherwise.
+func NewPublisher(config KafkaConfig, encoding Encoding) (*Publisher, error) {
+	writer, err := config.NewWriter(config.Topic, kafka.RequireOne)
+	if err != nil {
+		return nil, err
 	}
+	return &Publisher{writer: writer, encoding: encoding}, nil
 }
 
-func (p *Publisher) Publish(ctx context.Context, key, value []byte) error {
-	msg := kafka.Message{
-		Key:   key,
-		Value: value,
-		Time:  time.Now(),
+func (p *Publisher) Publish(ctx context.Context, key []byte, envelope Envelope) error {
+	msg, err := p.encoding.Message(key, envelope)
+	if err != nil {
+		return err
 	}
+	msg.Time = time.Now()
 	return p.writer.WriteMessages(ctx, msg)
 }
 
@@ -0,0 +1,83 @@
+package event
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrUnknownEventType        = errors.New("unknown event type")
+	ErrUnsupportedEventVersion = errors.New("unsupported event schema version")
+)
+
+// Upcaster turns the payload of one schema version into the next.
+type Upcaster func(payload json.RawMessage) (json.RawMessage, error)
+
+type registration struct {
+	version    int
+	newPayload func() Payload
+	upcasters  map[int]Upcaster
+}
+
+// Registry decodes envelopes into the Go types of their events, upcasting
+// payloads of older schema versions to the current one first.
+type Registry struct {
+	types map[string]*registration
+}
+
+func NewRegistry() *Registry {
+	return &Registry{types: map[string]*registration{}}
+}
+
+// Register adds the current schema version of an event type, as reported by
+// the payloads newPayload returns.
+func (r *Registry) Register(newPayload func() Payload) {
+	payload := newPayload()
+	reg := r.registration(payload.EventType())
+	reg.version = payload.SchemaVersion()
+	reg.newPayload = newPayload
+}
+
+// RegisterUpcaster adds the upcaster from version from of eventType to from+1.
+func (r *Registry) RegisterUpcaster(eventType string, from int, upcast Upcaster) {
+	r.registration(eventType).upcasters[from] = upcast
+}
+
+func (r *Registry) registration(eventType string) *registration {
+	reg, ok := r.types[eventType]
+	if !ok {
+		reg = &registration{upcasters: map[int]Upcaster{}}
+		r.types[eventType] = reg
+	}
+	return reg
+}
+
+// Decode returns the payload of envelope as the current version of its type.
+func (r *Registry) Decode(envelope Envelope) (Payload, error) {
+	reg, ok := r.types[envelope.Type]
+	if !ok || reg.newPayload == nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEventType, envelope.Type)
+	}
+	if envelope.SchemaVersion < 1 || envelope.SchemaVersion > reg.version {
+		return nil, fmt.Errorf("%w: %s version %d", ErrUnsupportedEventVersion, envelope.Type, envelope.SchemaVersion)
+	}
+
+	data := envelope.Payload
+	for version := envelope.SchemaVersion; version < reg.version; version++ {
+		upcast, ok := reg.upcasters[version]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s version %d has no upcaster", ErrUnsupportedEventVersion, envelope.Type, version)
+		}
+		var err error
+		if data, err = upcast(data); err != nil {
+			return nil, fmt.Errorf("failed to upcast %s from version %d: %w", envelope.Type, version, err)
+		}
+	}
+
+	payload := reg.newPayload()
+	if err := json.Unmarshal(data, payload); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s event: %w", envelope.Type, err)
+	}
+	return payload, nil
+}
@@ -0,0 +1,93 @@
+package event_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/ashwingopalsamy/upvest-api/internal/event"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_UserEvents_DecodesCurrentVersion(t *testing.T) {
+	payload, err := json.Marshal(event.UserScreeningHit{
+		UserStatusChange: event.UserStatusChange{UserID: "123", PreviousStatus: "ACTIVE", Status: "HELD"},
+		ScreeningHits:    []domain.ScreeningHit{{ID: "h1", UserID: "123"}},
+	})
+	assert.NoError(t, err)
+
+	decoded, err := event.UserEvents.Decode(event.Envelope{Type: event.TypeUserScreeningHit, SchemaVersion: 2, Payload: payload})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &event.UserScreeningHit{
+		UserStatusChange: event.UserStatusChange{UserID: "123", PreviousStatus: "ACTIVE", Status: "HELD"},
+		ScreeningHits:    []domain.ScreeningHit{{ID: "h1", UserID: "123"}},
+	}, decoded)
+}
+
+func Test_UserEvents_UpcastsLegacyPayloads(t *testing.T) {
+	envelope := event.Envelope{
+		Type:          event.TypeUserOffboarding,
+		SchemaVersion: 1,
+		Payload:       []byte(`{"action":"USER_OFFBOARDING","user_id":"123","previous_status":"ACTIVE","status":"OFFBOARDING"}`),
+	}
+
+	decoded, err := event.UserEvents.Decode(envelope)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &event.UserOffboarding{UserStatusChange: event.UserStatusChange{
+		UserID:         "123",
+		PreviousStatus: "ACTIVE",
+		Status:         "OFFBOARDING",
+	}}, decoded)
+}
+
+func Test_UserEvents_RejectsUnknownTypesAndVersions(t *testing.T) {
+	_, err := event.UserEvents.Decode(event.Envelope{Type: "ACCOUNT_OPENED", SchemaVersion: 1, Payload: []byte(`{}`)})
+	assert.ErrorIs(t, err, event.ErrUnknownEventType)
+
+	_, err = event.UserEvents.Decode(event.Envelope{Type: event.TypeUserCreated, SchemaVersion: 3, Payload: []byte(`{}`)})
+	assert.ErrorIs(t, err, event.ErrUnsupportedEventVersion)
+}
+
+// orderPlaced is an event whose schema went through three versions.
+type orderPlaced struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+func (orderPlaced) EventType() string  { return "ORDER_PLACED" }
+func (orderPlaced) SchemaVersion() int { return 3 }
+
+func Test_Registry_ChainsUpcasters(t *testing.T) {
+	registry := event.NewRegistry()
+	registry.Register(func() event.Payload { return &orderPlaced{} })
+	registry.RegisterUpcaster("ORDER_PLACED", 1, func(json.RawMessage) (json.RawMessage, error) {
+		return []byte(`{"amount":1000}`), nil
+	})
+	registry.RegisterUpcaster("ORDER_PLACED", 2, func(payload json.RawMessage) (json.RawMessage, error) {
+		var v2 map[string]interface{}
+		if err := json.Unmarshal(payload, &v2); err != nil {
+			return nil, err
+		}
+		v2["currency"] = "EUR"
+		return json.Marshal(v2)
+	})
+
+	decoded, err := registry.Decode(event.Envelope{Type: "ORDER_PLACED", SchemaVersion: 1, Payload: []byte(`{"amount":"10.00"}`)})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &orderPlaced{Amount: 1000, Currency: "EUR"}, decoded)
+}
+
+func Test_Registry_RequiresUpcasterForEveryVersion(t *testing.T) {
+	registry := event.NewRegistry()
+	registry.Register(func() event.Payload { return &orderPlaced{} })
+	registry.RegisterUpcaster("ORDER_PLACED", 2, func(payload json.RawMessage) (json.RawMessage, error) {
+		return payload, nil
+	})
+
+	_, err := registry.Decode(event.Envelope{Type: "ORDER_PLACED", SchemaVersion: 1, Payload: []byte(`{}`)})
+
+	assert.ErrorIs(t, err, event.ErrUnsupportedEventVersion)
+}
@@ -0,0 +1,154 @@
+package event
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// OutboxMessage is an event that was committed to the outbox table and is
+// waiting to be published.
+type OutboxMessage struct {
+	ID            string
+	Key           []byte
+	Type          string
+	SchemaVersion int
+	CreatedAt     time.Time
+	CorrelationID string
+	Payload       []byte
+	Attempts      int
+}
+
+// OutboxStore is the persistence side of the transactional outbox.
+type OutboxStore interface {
+	// ClaimPending leases up to limit unsent messages in commit order. A claimed
+	// message is not handed out again until the lease expires, and a message is
+	// held back while an older message with the same key is still unsent.
+	ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]OutboxMessage, error)
+	MarkSent(ctx context.Context, id string) error
+	MarkFailed(ctx context.Context, id string, cause error, retryAfter time.Duration) error
+}
+
+type RelayConfig struct {
+	// Producer names the system in the envelopes of the published events.
+	Producer     string
+	PollInterval time.Duration
+	BatchSize    int
+	Lease        time.Duration
+	MinBackoff   time.Duration
+	MaxBackoff   time.Duration
+}
+
+func DefaultRelayConfig() RelayConfig {
+	return RelayConfig{
+		Producer:     "upvest-api",
+		PollInterval: 500 * time.Millisecond,
+		BatchSize:    100,
+		Lease:        30 * time.Second,
+		MinBackoff:   time.Second,
+		MaxBackoff:   5 * time.Minute,
+	}
+}
+
+// Relay drains the outbox to Kafka. Messages are retried with exponential
+// backoff until they are published; nothing is ever dropped.
+type Relay struct {
+	store     OutboxStore
+	publisher PublisherInterface
+	config    RelayConfig
+}
+
+func NewRelay(store OutboxStore, publisher PublisherInterface, config RelayConfig) *Relay {
+	return &Relay{
+		store:     store,
+		publisher: publisher,
+		config:    config,
+	}
+}
+
+// Run polls the outbox until ctx is cancelled. A batch that is being published
+// when ctx is cancelled is finished first, so that its messages are marked as
+// sent instead of being published again once their lease expires.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.config.PollInterval)
+	defer ticker.Stop()
+
+	batchCtx := context.WithoutCancel(ctx)
+	for {
+		// Keep draining while full batches come back, then wait for the next tick.
+		for ctx.Err() == nil {
+			n, err := r.RelayOnce(batchCtx)
+			if err != nil {
+				log.Errorf("outbox relay failed: %v", err)
+				break
+			}
+			if n < r.config.BatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelayOnce publishes a single batch of pending messages and returns how many
+// were claimed.
+func (r *Relay) RelayOnce(ctx context.Context) (int, error) {
+	messages, err := r.store.ClaimPending(ctx, r.config.BatchSize, r.config.Lease)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, msg := range messages {
+		if err := r.publisher.Publish(ctx, msg.Key, r.envelope(msg)); err != nil {
+			log.Warnf("failed to publish outbox message %s (attempt %d): %v", msg.ID, msg.Attempts, err)
+			if err := r.store.MarkFailed(ctx, msg.ID, err, r.backoff(msg.Attempts)); err != nil {
+				log.Errorf("failed to mark outbox message %s as failed: %v", msg.ID, err)
+			}
+			continue
+		}
+
+		if err := r.store.MarkSent(ctx, msg.ID); err != nil {
+			// The message will be re-published once its lease expires.
+			log.Errorf("failed to mark outbox message %s as sent: %v", msg.ID, err)
+		}
+	}
+
+	return len(messages), nil
+}
+
+// envelope wraps msg for publishing. The outbox row identifies the event and
+// dates it.
+func (r *Relay) envelope(msg OutboxMessage) Envelope {
+	return Envelope{
+		ID:            msg.ID,
+		Type:          msg.Type,
+		SchemaVersion: msg.SchemaVersion,
+		OccurredAt:    msg.CreatedAt.UTC(),
+		Producer:      r.config.Producer,
+		CorrelationID: msg.CorrelationID,
+		Payload:       msg.Payload,
+	}
+}
+
+func (r *Relay) backoff(attempts int) time.Duration {
+	return exponentialBackoff(r.config.MinBackoff, r.config.MaxBackoff, attempts)
+}
+
+// exponentialBackoff doubles minDelay with every attempt after the first, up to
+// maxDelay.
+func exponentialBackoff(minDelay, maxDelay time.Duration, attempts int) time.Duration {
+	delay := minDelay
+	for i := 1; i < attempts && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+	return delay
+}
@@ -0,0 +1,21 @@
+package event
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// The relay and the subscriber both back off with exponentialBackoff.
+func Test_ExponentialBackoff_DoublesUpToCap(t *testing.T) {
+	backoff := func(attempts int) time.Duration {
+		return exponentialBackoff(time.Second, 10*time.Second, attempts)
+	}
+
+	assert.Equal(t, time.Second, backoff(1))
+	assert.Equal(t, 2*time.Second, backoff(2))
+	assert.Equal(t, 8*time.Second, backoff(4))
+	assert.Equal(t, 10*time.Second, backoff(5))
+	assert.Equal(t, 10*time.Second, backoff(50))
+}
@@ -0,0 +1,159 @@
+package event_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/event"
+	"github.com/ashwingopalsamy/upvest-api/internal/util/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type fakeOutboxStore struct {
+	pending []event.OutboxMessage
+	sent    []string
+	failed  map[string]time.Duration
+}
+
+func (s *fakeOutboxStore) ClaimPending(_ context.Context, limit int, _ time.Duration) ([]event.OutboxMessage, error) {
+	if len(s.pending) < limit {
+		limit = len(s.pending)
+	}
+	return s.pending[:limit], nil
+}
+
+func (s *fakeOutboxStore) MarkSent(_ context.Context, id string) error {
+	s.sent = append(s.sent, id)
+	return nil
+}
+
+func (s *fakeOutboxStore) MarkFailed(_ context.Context, id string, _ error, retryAfter time.Duration) error {
+	s.failed[id] = retryAfter
+	return nil
+}
+
+func Test_RelayOnce_PublishesAndMarksSent(t *testing.T) {
+	store := &fakeOutboxStore{
+		pending: []event.OutboxMessage{
+			{ID: "1", Key: []byte("user-1"), Payload: []byte(`{"action":"USER_CREATED"}`), Attempts: 1},
+			{ID: "2", Key: []byte("user-2"), Payload: []byte(`{"action":"USER_CREATED"}`), Attempts: 1},
+		},
+		failed: map[string]time.Duration{},
+	}
+	publisher := new(mocks.PublisherInterface)
+	publisher.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	relay := event.NewRelay(store, publisher, event.DefaultRelayConfig())
+	n, err := relay.RelayOnce(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []string{"1", "2"}, store.sent)
+	assert.Empty(t, store.failed)
+	publisher.AssertNumberOfCalls(t, "Publish", 2)
+}
+
+func Test_RelayOnce_WrapsMessagesInEnvelopes(t *testing.T) {
+	createdAt := time.Date(2025, 5, 11, 11, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+	store := &fakeOutboxStore{
+		pending: []event.OutboxMessage{{
+			ID:            "o1",
+			Key:           []byte("user-1"),
+			Type:          event.TypeUserOffboarding,
+			SchemaVersion: 2,
+			CreatedAt:     createdAt,
+			CorrelationID: "corr-1",
+			Payload:       []byte(`{"user_id":"user-1"}`),
+			Attempts:      1,
+		}},
+		failed: map[string]time.Duration{},
+	}
+	publisher := new(mocks.PublisherInterface)
+	publisher.On("Publish", mock.Anything, []byte("user-1"), event.Envelope{
+		ID:            "o1",
+		Type:          event.TypeUserOffboarding,
+		SchemaVersion: 2,
+		OccurredAt:    createdAt.UTC(),
+		Producer:      "upvest-api",
+		CorrelationID: "corr-1",
+		Payload:       []byte(`{"user_id":"user-1"}`),
+	}).Return(nil)
+
+	_, err := event.NewRelay(store, publisher, event.DefaultRelayConfig()).RelayOnce(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"o1"}, store.sent)
+	publisher.AssertExpectations(t)
+}
+
+func Test_RelayOnce_BacksOffOnPublishFailure(t *testing.T) {
+	store := &fakeOutboxStore{
+		pending: []event.OutboxMessage{
+			{ID: "1", Key: []byte("user-1"), Payload: []byte(`{}`), Attempts: 3},
+			{ID: "2", Key: []byte("user-2"), Payload: []byte(`{}`), Attempts: 1},
+		},
+		failed: map[string]time.Duration{},
+	}
+	publisher := new(mocks.PublisherInterface)
+	publisher.On("Publish", mock.Anything, []byte("user-1"), mock.Anything).Return(errors.New("broker unavailable"))
+	publisher.On("Publish", mock.Anything, []byte("user-2"), mock.Anything).Return(nil)
+
+	relay := event.NewRelay(store, publisher, event.DefaultRelayConfig())
+	_, err := relay.RelayOnce(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"2"}, store.sent)
+	assert.Equal(t, 4*time.Second, store.failed["1"])
+}
+
+func Test_RelayOnce_BackoffIsCapped(t *testing.T) {
+	store := &fakeOutboxStore{
+		pending: []event.OutboxMessage{
+			{ID: "1", Key: []byte("user-1"), Payload: []byte(`{}`), Attempts: 50},
+		},
+		failed: map[string]time.Duration{},
+	}
+	publisher := new(mocks.PublisherInterface)
+	publisher.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("broker unavailable"))
+
+	config := event.DefaultRelayConfig()
+	config.MaxBackoff = 10 * time.Second
+	relay := event.NewRelay(store, publisher, config)
+	_, err := relay.RelayOnce(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 10*time.Second, store.failed["1"])
+}
+
+func Test_Run_FinishesBatchInFlightWhenCancelled(t *testing.T) {
+	store := &fakeOutboxStore{
+		pending: []event.OutboxMessage{
+			{ID: "1", Key: []byte("user-1"), Payload: []byte(`{}`), Attempts: 1},
+			{ID: "2", Key: []byte("user-2"), Payload: []byte(`{}`), Attempts: 1},
+		},
+		failed: map[string]time.Duration{},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	publisher := new(mocks.PublisherInterface)
+	publisher.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		cancel()
+		assert.NoError(t, args.Get(0).(context.Context).Err())
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		event.NewRelay(store, publisher, event.DefaultRelayConfig()).Run(ctx)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after cancellation")
+	}
+	assert.Equal(t, []string{"1", "2"}, store.sent)
+	publisher.AssertNumberOfCalls(t, "Publish", 2)
+}
@@ -3,44 +3,297 @@ package event
 
 import (
 	"context"
-	"log"
+	"errors"
+	"time"
 
 	"github.com/segmentio/kafka-go"
+	log "github.com/sirupsen/logrus"
 )
 
 type SubscriberInterface interface {
-	Consume(ctx context.Context, handler func(key, value []byte) error)
+	Consume(ctx context.Context, handler func(msg kafka.Message) error)
 	Close() error
 }
 
+// MessageReader fetches the messages of a topic and commits their offsets once
+// they are processed. *kafka.Reader implements it.
+type MessageReader interface {
+	FetchMessage(ctx context.Context) (kafka.Message, error)
+	CommitMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+// MessageWriter writes messages to Kafka. *kafka.Writer implements it.
+type MessageWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+// ProcessedStore remembers the messages a consumer group has processed, so that
+// a message delivered again after a crash or a rebalance is skipped.
+type ProcessedStore interface {
+	IsProcessed(ctx context.Context, groupID, topic string, partition int, offset int64) (bool, error)
+	MarkProcessed(ctx context.Context, groupID, topic string, partition int, offset int64) error
+}
+
+// RetryPolicy controls how often a message is handled before it is given up,
+// and how long the subscriber waits between attempts.
+type RetryPolicy struct {
+	MaxAttempts int
+	MinBackoff  time.Duration
+	MaxBackoff  time.Duration
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 5,
+		MinBackoff:  time.Second,
+		MaxBackoff:  30 * time.Second,
+	}
+}
+
+type SubscriberConfig struct {
+	Retry RetryPolicy
+	// DeadLetterTopic receives the messages that failed every attempt. Without
+	// one they are dropped after logging.
+	DeadLetterTopic string
+	// Offsets are committed once CommitBatchSize messages are processed, or
+	// CommitInterval after the oldest uncommitted one.
+	CommitBatchSize int
+	CommitInterval  time.Duration
+}
+
+func DefaultSubscriberConfig(topic string) SubscriberConfig {
+	return SubscriberConfig{
+		Retry:           DefaultRetryPolicy(),
+		DeadLetterTopic: topic + DeadLetterTopicSuffix,
+		CommitBatchSize: 100,
+		CommitInterval:  time.Second,
+	}
+}
+
+// commitTimeout bounds the final commit on shutdown, when the consumer's
+// context is already cancelled.
+const commitTimeout = 5 * time.Second
+
 type Subscriber struct {
-	reader *kafka.Reader
+	reader      MessageReader
+	deadLetters MessageWriter
+	processed   ProcessedStore
+	groupID     string
+	config      SubscriberConfig
 }
 
-func NewSubscriber(broker string, topic string, groupID string) *Subscriber {
+// NewSubscriber consumes the topic of kafkaConfig as a member of its group.
+// Messages found in processed, which may be nil, are skipped.
+func NewSubscriber(kafkaConfig KafkaConfig, config SubscriberConfig, processed ProcessedStore) (*Subscriber, error) {
+	// The reader only commits when asked to, which Consume does once a message
+	// is processed.
+	reader, err := kafkaConfig.NewReader(kafkaConfig.Topic, kafkaConfig.GroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	var deadLetters MessageWriter
+	if config.DeadLetterTopic != "" {
+		writer, err := kafkaConfig.NewWriter(config.DeadLetterTopic, kafka.RequireAll)
+		if err != nil {
+			reader.Close()
+			return nil, err
+		}
+		deadLetters = writer
+	}
+
+	return NewSubscriberWithReader(reader, deadLetters, processed, kafkaConfig.GroupID, config), nil
+}
+
+// NewSubscriberWithReader consumes from reader and writes the messages that
+// failed every attempt to deadLetters. deadLetters and processed may be nil;
+// config.DeadLetterTopic is not used.
+func NewSubscriberWithReader(reader MessageReader, deadLetters MessageWriter, processed ProcessedStore,
+	groupID string, config SubscriberConfig) *Subscriber {
 	return &Subscriber{
-		reader: kafka.NewReader(kafka.ReaderConfig{
-			Brokers: []string{broker},
-			Topic:   topic,
-			GroupID: groupID,
-		}),
+		reader:      reader,
+		deadLetters: deadLetters,
+		processed:   processed,
+		groupID:     groupID,
+		config:      config,
 	}
 }
 
-func (c *Subscriber) Consume(ctx context.Context, handler func(key, value []byte) error) {
-	for {
-		msg, err := c.reader.ReadMessage(ctx)
+// Consume hands every message to handler until ctx is cancelled, processing
+// each at least once: its offset is committed only after the handler succeeded
+// or the message was dead-lettered. A failing message is retried with
+// exponential backoff and, once the attempts of the retry policy are used up,
+// moved to the dead-letter topic so that the messages behind it are not held up.
+//
+// When ctx is cancelled the message being handled is finished and the offsets
+// of the processed messages are committed before Consume returns, while a
+// message waiting to be retried is left for the next member of the group. A
+// commit that fails, e.g. because the group rebalanced, is not retried: the
+// messages are delivered again and skipped as processed.
+func (c *Subscriber) Consume(ctx context.Context, handler func(msg kafka.Message) error) {
+	var (
+		pending      []kafka.Message
+		oldest       time.Time
+		readFailures int
+	)
+	defer func() {
+		commitCtx, cancel := context.WithTimeout(context.Background(), commitTimeout)
+		defer cancel()
+		c.commit(commitCtx, pending)
+	}()
+
+	for ctx.Err() == nil {
+		// Wake up to commit when no further message arrives in time.
+		fetchCtx, cancel := ctx, context.CancelFunc(func() {})
+		if len(pending) > 0 {
+			fetchCtx, cancel = context.WithDeadline(ctx, oldest.Add(c.config.CommitInterval))
+		}
+		msg, err := c.reader.FetchMessage(fetchCtx)
+		cancel()
 		if err != nil {
-			log.Printf("failed to read message: %v", err)
+			if ctx.Err() != nil {
+				return
+			}
+			if errors.Is(err, context.DeadlineExceeded) && len(pending) > 0 {
+				pending = c.commit(ctx, pending)
+				continue
+			}
+			readFailures++
+			delay := c.backoff(readFailures)
+			log.Errorf("failed to read message, retrying in %s: %v", delay, err)
+			if !sleep(ctx, delay) {
+				return
+			}
 			continue
 		}
+		readFailures = 0
+
+		if !c.handle(ctx, msg, handler) {
+			return
+		}
+
+		if len(pending) == 0 {
+			oldest = time.Now()
+		}
+		pending = append(pending, msg)
+		if len(pending) >= c.config.CommitBatchSize || time.Since(oldest) >= c.config.CommitInterval {
+			pending = c.commit(ctx, pending)
+		}
+	}
+}
+
+// commit commits the offsets of msgs and returns what is left to commit, which
+// is nothing: see Consume for why failed commits are dropped.
+func (c *Subscriber) commit(ctx context.Context, msgs []kafka.Message) []kafka.Message {
+	if len(msgs) == 0 {
+		return nil
+	}
+	if err := c.reader.CommitMessages(ctx, msgs...); err != nil {
+		log.Warnf("failed to commit %d messages, they will be delivered again: %v", len(msgs), err)
+	}
+	return nil
+}
+
+// handle processes msg unless it was processed before, and reports whether it
+// is done with, i.e. processed or dead-lettered. It reports false only if ctx
+// was cancelled first.
+func (c *Subscriber) handle(ctx context.Context, msg kafka.Message, handler func(msg kafka.Message) error) bool {
+	if c.processed != nil {
+		processed, err := c.processed.IsProcessed(ctx, c.groupID, msg.Topic, msg.Partition, msg.Offset)
+		if err != nil {
+			log.Warnf("failed to look up message %s/%d/%d, processing it: %v", msg.Topic, msg.Partition, msg.Offset, err)
+		} else if processed {
+			log.Infof("skipping message %s/%d/%d, it was processed before", msg.Topic, msg.Partition, msg.Offset)
+			return true
+		}
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := handler(msg)
+		if err == nil {
+			c.markProcessed(ctx, msg)
+			return true
+		}
+
+		if attempt >= c.config.Retry.MaxAttempts {
+			log.Errorf("failed to process message %s/%d/%d after %d attempts: %v",
+				msg.Topic, msg.Partition, msg.Offset, attempt, err)
+			if !c.deadLetter(ctx, msg, err, attempt) {
+				return false
+			}
+			c.markProcessed(ctx, msg)
+			return true
+		}
+
+		delay := c.backoff(attempt)
+		log.Warnf("failed to process message %s/%d/%d (attempt %d), retrying in %s: %v",
+			msg.Topic, msg.Partition, msg.Offset, attempt, delay, err)
+		if !sleep(ctx, delay) {
+			return false
+		}
+	}
+}
+
+// markProcessed records msg as processed even if ctx was cancelled while it was
+// handled, since its offset is still committed on shutdown.
+func (c *Subscriber) markProcessed(ctx context.Context, msg kafka.Message) {
+	if c.processed == nil {
+		return
+	}
+	if err := c.processed.MarkProcessed(context.WithoutCancel(ctx), c.groupID, msg.Topic, msg.Partition, msg.Offset); err != nil {
+		log.Warnf("failed to mark message %s/%d/%d as processed: %v", msg.Topic, msg.Partition, msg.Offset, err)
+	}
+}
+
+// deadLetter moves msg to the dead-letter topic, retrying until it is written
+// since its offset must not be committed before. It reports false if ctx was
+// cancelled first.
+func (c *Subscriber) deadLetter(ctx context.Context, msg kafka.Message, cause error, attempts int) bool {
+	if c.deadLetters == nil {
+		log.Errorf("dropping message %s/%d/%d, no dead-letter topic is configured", msg.Topic, msg.Partition, msg.Offset)
+		return true
+	}
+
+	dead := DeadLetterMessage(msg, cause, attempts, time.Now())
+	for failures := 1; ; failures++ {
+		err := c.deadLetters.WriteMessages(ctx, dead)
+		if err == nil {
+			return true
+		}
 
-		if err := handler(msg.Key, msg.Value); err != nil {
-			log.Printf("failed to process message: %v", err)
+		delay := c.backoff(failures)
+		log.Errorf("failed to dead-letter message %s/%d/%d, retrying in %s: %v",
+			msg.Topic, msg.Partition, msg.Offset, delay, err)
+		if !sleep(ctx, delay) {
+			return false
 		}
 	}
 }
 
+func (c *Subscriber) backoff(attempts int) time.Duration {
+	return exponentialBackoff(c.config.Retry.MinBackoff, c.config.Retry.MaxBackoff, attempts)
+}
+
 func (c *Subscriber) Close() error {
+	if c.deadLetters != nil {
+		if err := c.deadLetters.Close(); err != nil {
+			log.Errorf("failed to close dead-letter writer: %v", err)
+		}
+	}
 	return c.reader.Close()
 }
+
+// sleep waits for delay and reports false if ctx was cancelled first.
+func sleep(ctx context.Context, delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
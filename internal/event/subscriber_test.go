@@ -0,0 +1,365 @@
+package event_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/event"
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeReader hands out its messages, failing the reads listed in errs first,
+// and cancels the consumer once it has run out of messages.
+type fakeReader struct {
+	messages  []kafka.Message
+	errs      []error
+	cancel    context.CancelFunc
+	reads     int
+	committed []int64
+	commits   int
+}
+
+func (r *fakeReader) FetchMessage(ctx context.Context) (kafka.Message, error) {
+	r.reads++
+	if len(r.errs) > 0 {
+		err := r.errs[0]
+		r.errs = r.errs[1:]
+		return kafka.Message{}, err
+	}
+	if len(r.messages) == 0 {
+		r.cancel()
+		<-ctx.Done()
+		return kafka.Message{}, ctx.Err()
+	}
+	msg := r.messages[0]
+	r.messages = r.messages[1:]
+	return msg, nil
+}
+
+func (r *fakeReader) CommitMessages(_ context.Context, msgs ...kafka.Message) error {
+	r.commits++
+	for _, msg := range msgs {
+		r.committed = append(r.committed, msg.Offset)
+	}
+	return nil
+}
+
+func (r *fakeReader) Close() error {
+	return nil
+}
+
+type fakeWriter struct {
+	mu      sync.Mutex
+	written []kafka.Message
+	err     error
+}
+
+func (w *fakeWriter) WriteMessages(_ context.Context, msgs ...kafka.Message) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.err != nil {
+		return w.err
+	}
+	w.written = append(w.written, msgs...)
+	return nil
+}
+
+func (w *fakeWriter) Close() error {
+	return nil
+}
+
+// fakeProcessedStore remembers processed offsets of the test group.
+type fakeProcessedStore map[int64]bool
+
+func (s fakeProcessedStore) IsProcessed(_ context.Context, groupID, _ string, _ int, offset int64) (bool, error) {
+	return groupID == "test-group" && s[offset], nil
+}
+
+func (s fakeProcessedStore) MarkProcessed(_ context.Context, _, _ string, _ int, offset int64) error {
+	s[offset] = true
+	return nil
+}
+
+func testSubscriberConfig() event.SubscriberConfig {
+	return event.SubscriberConfig{
+		Retry:           event.RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: 4 * time.Millisecond},
+		CommitBatchSize: 100,
+		CommitInterval:  time.Hour,
+	}
+}
+
+func consume(reader *fakeReader, deadLetters event.MessageWriter, handler func(msg kafka.Message) error) {
+	consumeWith(reader, deadLetters, nil, testSubscriberConfig(), handler)
+}
+
+func consumeWith(reader *fakeReader, deadLetters event.MessageWriter, processed event.ProcessedStore,
+	config event.SubscriberConfig, handler func(msg kafka.Message) error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reader.cancel = cancel
+
+	event.NewSubscriberWithReader(reader, deadLetters, processed, "test-group", config).Consume(ctx, handler)
+}
+
+func header(msg kafka.Message, key string) string {
+	for _, h := range msg.Headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+func Test_Consume_RetriesUntilHandled(t *testing.T) {
+	reader := &fakeReader{messages: []kafka.Message{{Key: []byte("user-1"), Value: []byte(`{}`)}}}
+	deadLetters := &fakeWriter{}
+
+	attempts := 0
+	consume(reader, deadLetters, func(_ kafka.Message) error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("database unavailable")
+		}
+		return nil
+	})
+
+	assert.Equal(t, 3, attempts)
+	assert.Empty(t, deadLetters.written)
+}
+
+func Test_Consume_DeadLettersAfterRetries(t *testing.T) {
+	failing := kafka.Message{
+		Topic:     "user-events",
+		Partition: 2,
+		Offset:    42,
+		Key:       []byte("user-1"),
+		Value:     []byte(`{"action":"USER_OFFBOARDING"}`),
+		Headers:   []kafka.Header{{Key: "trace-id", Value: []byte("abc")}},
+	}
+	next := kafka.Message{Topic: "user-events", Partition: 2, Offset: 43, Key: []byte("user-2"), Value: []byte(`{}`)}
+	reader := &fakeReader{messages: []kafka.Message{failing, next}}
+	deadLetters := &fakeWriter{}
+
+	var handled []string
+	consume(reader, deadLetters, func(msg kafka.Message) error {
+		handled = append(handled, string(msg.Key))
+		if string(msg.Key) == "user-1" {
+			return errors.New("user not found")
+		}
+		return nil
+	})
+
+	assert.Equal(t, []string{"user-1", "user-1", "user-1", "user-2"}, handled)
+	if assert.Len(t, deadLetters.written, 1) {
+		dead := deadLetters.written[0]
+		assert.Equal(t, failing.Key, dead.Key)
+		assert.Equal(t, failing.Value, dead.Value)
+		assert.Equal(t, "abc", header(dead, "trace-id"))
+		assert.Equal(t, "user-events", header(dead, event.HeaderOriginalTopic))
+		assert.Equal(t, "2", header(dead, event.HeaderOriginalPartition))
+		assert.Equal(t, "42", header(dead, event.HeaderOriginalOffset))
+		assert.Equal(t, "user not found", header(dead, event.HeaderDeadLetterError))
+		assert.Equal(t, "3", header(dead, event.HeaderDeadLetterAttempts))
+	}
+}
+
+func Test_Consume_WithoutDeadLetterTopicMovesOn(t *testing.T) {
+	reader := &fakeReader{messages: []kafka.Message{{Key: []byte("user-1")}, {Key: []byte("user-2")}}}
+
+	var handled []string
+	consume(reader, nil, func(msg kafka.Message) error {
+		handled = append(handled, string(msg.Key))
+		return errors.New("invalid event")
+	})
+
+	assert.Equal(t, []string{"user-1", "user-1", "user-1", "user-2", "user-2", "user-2"}, handled)
+}
+
+func Test_Consume_BacksOffOnReadErrors(t *testing.T) {
+	reader := &fakeReader{
+		errs:     []error{errors.New("broker unavailable"), errors.New("broker unavailable")},
+		messages: []kafka.Message{{Key: []byte("user-1")}},
+	}
+
+	handled := 0
+	consume(reader, nil, func(_ kafka.Message) error {
+		handled++
+		return nil
+	})
+
+	assert.Equal(t, 1, handled)
+	assert.Equal(t, 4, reader.reads)
+}
+
+func Test_Consume_StopsRetryingWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	reader := &fakeReader{messages: []kafka.Message{{Key: []byte("user-1"), Offset: 1}}, cancel: cancel}
+	deadLetters := &fakeWriter{}
+	config := testSubscriberConfig()
+	config.Retry = event.RetryPolicy{MaxAttempts: 3, MinBackoff: time.Hour, MaxBackoff: time.Hour}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		event.NewSubscriberWithReader(reader, deadLetters, nil, "test-group", config).Consume(ctx, func(_ kafka.Message) error {
+			cancel()
+			return errors.New("database unavailable")
+		})
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Consume did not return after cancellation")
+	}
+	assert.Empty(t, deadLetters.written)
+	assert.Empty(t, reader.committed)
+}
+
+func Test_Consume_CommitsProcessedMessagesOnShutdown(t *testing.T) {
+	reader := &fakeReader{messages: []kafka.Message{{Offset: 1}, {Offset: 2}, {Offset: 3}}}
+
+	consume(reader, nil, func(_ kafka.Message) error { return nil })
+
+	assert.Equal(t, []int64{1, 2, 3}, reader.committed)
+	assert.Equal(t, 1, reader.commits)
+}
+
+func Test_Consume_CommitsInBatches(t *testing.T) {
+	reader := &fakeReader{messages: []kafka.Message{{Offset: 1}, {Offset: 2}, {Offset: 3}, {Offset: 4}, {Offset: 5}}}
+	config := testSubscriberConfig()
+	config.CommitBatchSize = 2
+
+	consumeWith(reader, nil, nil, config, func(_ kafka.Message) error { return nil })
+
+	assert.Equal(t, []int64{1, 2, 3, 4, 5}, reader.committed)
+	assert.Equal(t, 3, reader.commits)
+}
+
+func Test_Consume_CommitsAfterInterval(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reader := &idleReader{messages: []kafka.Message{{Offset: 1}}, committed: make(chan []kafka.Message, 1)}
+	config := testSubscriberConfig()
+	config.CommitInterval = 10 * time.Millisecond
+
+	go event.NewSubscriberWithReader(reader, nil, nil, "test-group", config).Consume(ctx, func(_ kafka.Message) error { return nil })
+
+	select {
+	case msgs := <-reader.committed:
+		assert.Equal(t, int64(1), msgs[0].Offset)
+	case <-time.After(time.Second):
+		t.Fatal("processed message was not committed while the topic was idle")
+	}
+}
+
+func Test_Consume_SkipsProcessedMessages(t *testing.T) {
+	reader := &fakeReader{messages: []kafka.Message{{Key: []byte("user-1"), Offset: 1}, {Key: []byte("user-2"), Offset: 2}}}
+	processed := fakeProcessedStore{1: true}
+
+	var handled []string
+	consumeWith(reader, nil, processed, testSubscriberConfig(), func(msg kafka.Message) error {
+		handled = append(handled, string(msg.Key))
+		return nil
+	})
+
+	assert.Equal(t, []string{"user-2"}, handled)
+	assert.True(t, processed[2])
+	assert.Equal(t, []int64{1, 2}, reader.committed)
+}
+
+func Test_Consume_DoesNotCommitUntilDeadLettered(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	reader := &fakeReader{messages: []kafka.Message{{Key: []byte("user-1"), Offset: 1}}, cancel: cancel}
+	deadLetters := &cancellingWriter{cancel: cancel}
+
+	event.NewSubscriberWithReader(reader, deadLetters, nil, "test-group", testSubscriberConfig()).Consume(ctx, func(_ kafka.Message) error {
+		return errors.New("invalid event")
+	})
+
+	assert.Equal(t, 1, deadLetters.writes)
+	assert.Empty(t, reader.committed)
+}
+
+// idleReader hands out its messages and then waits like an idle topic,
+// reporting commits on a channel.
+type idleReader struct {
+	messages  []kafka.Message
+	committed chan []kafka.Message
+}
+
+func (r *idleReader) FetchMessage(ctx context.Context) (kafka.Message, error) {
+	if len(r.messages) == 0 {
+		<-ctx.Done()
+		return kafka.Message{}, ctx.Err()
+	}
+	msg := r.messages[0]
+	r.messages = r.messages[1:]
+	return msg, nil
+}
+
+func (r *idleReader) CommitMessages(_ context.Context, msgs ...kafka.Message) error {
+	select {
+	case r.committed <- msgs:
+	default:
+	}
+	return nil
+}
+
+func (r *idleReader) Close() error {
+	return nil
+}
+
+// cancellingWriter fails to write and shuts the consumer down meanwhile.
+type cancellingWriter struct {
+	cancel context.CancelFunc
+	writes int
+}
+
+func (w *cancellingWriter) WriteMessages(_ context.Context, _ ...kafka.Message) error {
+	w.writes++
+	w.cancel()
+	return errors.New("broker unavailable")
+}
+
+func (w *cancellingWriter) Close() error {
+	return nil
+}
+
+func Test_Consume_FinishesMessageInFlightWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	reader := &fakeReader{messages: []kafka.Message{{Key: []byte("user-1"), Offset: 1}, {Key: []byte("user-2"), Offset: 2}}, cancel: cancel}
+	processed := fakeProcessedStore{}
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	var handled []string
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		event.NewSubscriberWithReader(reader, nil, processed, "test-group", testSubscriberConfig()).Consume(ctx, func(msg kafka.Message) error {
+			close(started)
+			<-release
+			handled = append(handled, string(msg.Key))
+			return nil
+		})
+	}()
+
+	<-started
+	cancel()
+	select {
+	case <-done:
+		t.Fatal("Consume returned while a message was in flight")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(release)
+	<-done
+
+	assert.Equal(t, []string{"user-1"}, handled)
+	assert.True(t, processed[1])
+	assert.Equal(t, []int64{1}, reader.committed)
+}
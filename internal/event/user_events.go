@@ -0,0 +1,144 @@
+package event
+
+import (
+	"encoding/json"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+)
+
+const (
+	TypeUserCreated            = "USER_CREATED"
+	TypeUserUpdated            = "USER_UPDATED"
+	TypeUserOffboarding        = "USER_OFFBOARDING"
+	TypeUserOffboarded         = "USER_OFFBOARDED"
+	TypeUserFeeSegmentAssigned = "USER_FEE_SEGMENT_ASSIGNED"
+	TypeUserGuardianAdded      = "USER_GUARDIAN_ADDED"
+	TypeUserCameOfAge          = "USER_CAME_OF_AGE"
+	TypeUserScreeningHit       = "USER_SCREENING_HIT"
+	TypeScreeningHitReviewed   = "SCREENING_HIT_REVIEWED"
+	TypeUserReleased           = "USER_RELEASED"
+)
+
+// userEventVersion is the schema version of all user events. Version 1 was the
+// bare payload with the event type in its action field.
+const userEventVersion = 2
+
+// UserEvents decodes the events of the user aggregate.
+var UserEvents = newUserEventRegistry()
+
+func newUserEventRegistry() *Registry {
+	registry := NewRegistry()
+	for _, newPayload := range []func() Payload{
+		func() Payload { return &UserCreated{} },
+		func() Payload { return &UserUpdated{} },
+		func() Payload { return &UserOffboarding{} },
+		func() Payload { return &UserOffboarded{} },
+		func() Payload { return &UserFeeSegmentAssigned{} },
+		func() Payload { return &UserGuardianAdded{} },
+		func() Payload { return &UserCameOfAge{} },
+		func() Payload { return &UserScreeningHit{} },
+		func() Payload { return &ScreeningHitReviewed{} },
+		func() Payload { return &UserReleased{} },
+	} {
+		registry.Register(newPayload)
+		registry.RegisterUpcaster(newPayload().EventType(), 1, dropAction)
+	}
+	return registry
+}
+
+// dropAction upcasts a version 1 payload, whose type is now in the envelope.
+func dropAction(payload json.RawMessage) (json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return nil, err
+	}
+	delete(fields, "action")
+	return json.Marshal(fields)
+}
+
+type UserCreated struct {
+	User *domain.User `json:"user"`
+}
+
+func (UserCreated) EventType() string  { return TypeUserCreated }
+func (UserCreated) SchemaVersion() int { return userEventVersion }
+
+type UserUpdated struct {
+	UserID        string       `json:"user_id"`
+	ChangedFields []string     `json:"changed_fields"`
+	User          *domain.User `json:"user"`
+}
+
+func (UserUpdated) EventType() string  { return TypeUserUpdated }
+func (UserUpdated) SchemaVersion() int { return userEventVersion }
+
+// UserStatusChange is part of every event that moves a user to another status.
+type UserStatusChange struct {
+	UserID         string `json:"user_id"`
+	PreviousStatus string `json:"previous_status"`
+	Status         string `json:"status"`
+}
+
+type UserOffboarding struct {
+	UserStatusChange
+}
+
+func (UserOffboarding) EventType() string  { return TypeUserOffboarding }
+func (UserOffboarding) SchemaVersion() int { return userEventVersion }
+
+type UserOffboarded struct {
+	UserStatusChange
+}
+
+func (UserOffboarded) EventType() string  { return TypeUserOffboarded }
+func (UserOffboarded) SchemaVersion() int { return userEventVersion }
+
+type UserFeeSegmentAssigned struct {
+	UserID  string `json:"user_id"`
+	Segment string `json:"segment"`
+}
+
+func (UserFeeSegmentAssigned) EventType() string  { return TypeUserFeeSegmentAssigned }
+func (UserFeeSegmentAssigned) SchemaVersion() int { return userEventVersion }
+
+type UserGuardianAdded struct {
+	UserID       string               `json:"user_id"`
+	Guardianship *domain.Guardianship `json:"guardianship"`
+}
+
+func (UserGuardianAdded) EventType() string  { return TypeUserGuardianAdded }
+func (UserGuardianAdded) SchemaVersion() int { return userEventVersion }
+
+type UserCameOfAge struct {
+	UserID      string   `json:"user_id"`
+	BirthDate   string   `json:"birth_date"`
+	GuardianIDs []string `json:"guardian_ids"`
+}
+
+func (UserCameOfAge) EventType() string  { return TypeUserCameOfAge }
+func (UserCameOfAge) SchemaVersion() int { return userEventVersion }
+
+// UserScreeningHit holds a user for review. The status is unchanged if the
+// user was held already.
+type UserScreeningHit struct {
+	UserStatusChange
+	ScreeningHits []domain.ScreeningHit `json:"screening_hits"`
+}
+
+func (UserScreeningHit) EventType() string  { return TypeUserScreeningHit }
+func (UserScreeningHit) SchemaVersion() int { return userEventVersion }
+
+type ScreeningHitReviewed struct {
+	UserID       string               `json:"user_id"`
+	ScreeningHit *domain.ScreeningHit `json:"screening_hit"`
+}
+
+func (ScreeningHitReviewed) EventType() string  { return TypeScreeningHitReviewed }
+func (ScreeningHitReviewed) SchemaVersion() int { return userEventVersion }
+
+type UserReleased struct {
+	UserStatusChange
+}
+
+func (UserReleased) EventType() string  { return TypeUserReleased }
+func (UserReleased) SchemaVersion() int { return userEventVersion }
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/event"
+)
+
+const (
+	CorrelationIDHeader    = "X-Correlation-ID"
+	MaxCorrelationIDLength = 100
+)
+
+// CorrelationID tags the events recorded while serving a request with the
+// request's X-Correlation-ID header, and echoes it in the response. Requests
+// without a usable header are given a random ID.
+func CorrelationID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(CorrelationIDHeader)
+		if id == "" || len(id) > MaxCorrelationIDLength {
+			id = newCorrelationID()
+		}
+
+		w.Header().Set(CorrelationIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(event.WithCorrelationID(r.Context(), id)))
+	})
+}
+
+func newCorrelationID() string {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		// crypto/rand does not fail on supported platforms.
+		panic(err)
+	}
+	return hex.EncodeToString(id)
+}
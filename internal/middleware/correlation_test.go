@@ -0,0 +1,44 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/event"
+	"github.com/ashwingopalsamy/upvest-api/internal/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+func serveWithCorrelationID(header string) (string, *httptest.ResponseRecorder) {
+	var seen string
+	handler := middleware.CorrelationID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = event.CorrelationID(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/users", nil)
+	if header != "" {
+		req.Header.Set(middleware.CorrelationIDHeader, header)
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return seen, w
+}
+
+func Test_CorrelationID_UsesHeader(t *testing.T) {
+	seen, w := serveWithCorrelationID("corr-1")
+
+	assert.Equal(t, "corr-1", seen)
+	assert.Equal(t, "corr-1", w.Header().Get(middleware.CorrelationIDHeader))
+}
+
+func Test_CorrelationID_GeneratesMissingOrOverlongIDs(t *testing.T) {
+	for _, header := range []string{"", strings.Repeat("x", middleware.MaxCorrelationIDLength+1)} {
+		seen, w := serveWithCorrelationID(header)
+
+		assert.Len(t, seen, 32)
+		assert.NotEqual(t, header, seen)
+		assert.Equal(t, seen, w.Header().Get(middleware.CorrelationIDHeader))
+	}
+}
@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/repository"
+)
+
+var ErrInvalidCursor = errors.New("cursor is malformed or has been tampered with")
+
+// CursorCodec turns list positions into opaque, HMAC-signed cursor tokens, so
+// clients can neither read nor forge them.
+type CursorCodec struct {
+	secret []byte
+}
+
+func NewCursorCodec(secret []byte) *CursorCodec {
+	return &CursorCodec{secret: secret}
+}
+
+// cursorPayload is the signed content of a cursor token. Sort and order travel
+// with the position because a position is only meaningful in its ordering.
+type cursorPayload struct {
+	Sort      string `json:"s"`
+	Order     string `json:"o"`
+	SortValue string `json:"v"`
+	ID        string `json:"id"`
+	Backward  bool   `json:"b,omitempty"`
+}
+
+// Encode returns the token for a position in a list ordered by sort and order.
+func (c *CursorCodec) Encode(sort, order string, cursor repository.Cursor) string {
+	payload, _ := json.Marshal(cursorPayload{
+		Sort:      sort,
+		Order:     order,
+		SortValue: cursor.SortValue,
+		ID:        cursor.ID,
+		Backward:  cursor.Backward,
+	})
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(c.sign(encoded))
+}
+
+// Decode verifies a token and returns the ordering and position it carries.
+func (c *CursorCodec) Decode(token string) (string, string, *repository.Cursor, error) {
+	encoded, signature, found := strings.Cut(token, ".")
+	if !found {
+		return "", "", nil, ErrInvalidCursor
+	}
+
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, c.sign(encoded)) {
+		return "", "", nil, ErrInvalidCursor
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", "", nil, ErrInvalidCursor
+	}
+	var payload cursorPayload
+	if err := json.Unmarshal(raw, &payload); err != nil || payload.ID == "" {
+		return "", "", nil, ErrInvalidCursor
+	}
+
+	return payload.Sort, payload.Order, &repository.Cursor{
+		SortValue: payload.SortValue,
+		ID:        payload.ID,
+		Backward:  payload.Backward,
+	}, nil
+}
+
+func (c *CursorCodec) sign(encoded string) []byte {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
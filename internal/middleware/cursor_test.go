@@ -0,0 +1,38 @@
+package middleware_test
+
+import (
+	"testing"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/middleware"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CursorCodec_RoundTrip(t *testing.T) {
+	codec := middleware.NewCursorCodec([]byte("secret"))
+	cursor := repository.Cursor{SortValue: "2025-01-01T00:00:00.123456Z", ID: "4f5c", Backward: true}
+
+	sort, order, decoded, err := codec.Decode(codec.Encode("updated_at", "DESC", cursor))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "updated_at", sort)
+	assert.Equal(t, "DESC", order)
+	assert.Equal(t, &cursor, decoded)
+}
+
+func Test_CursorCodec_RejectsForeignSignature(t *testing.T) {
+	token := middleware.NewCursorCodec([]byte("other-secret")).Encode("created_at", "ASC", repository.Cursor{ID: "1"})
+
+	_, _, _, err := middleware.NewCursorCodec([]byte("secret")).Decode(token)
+
+	assert.ErrorIs(t, err, middleware.ErrInvalidCursor)
+}
+
+func Test_CursorCodec_RejectsMalformedToken(t *testing.T) {
+	codec := middleware.NewCursorCodec([]byte("secret"))
+
+	for _, token := range []string{"", "no-signature", "!!!.???", "e30.e30"} {
+		_, _, _, err := codec.Decode(token)
+		assert.ErrorIs(t, err, middleware.ErrInvalidCursor, token)
+	}
+}
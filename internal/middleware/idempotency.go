@@ -0,0 +1,172 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/repository"
+	"github.com/ashwingopalsamy/upvest-api/internal/util/writer"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotentReplayedHeader  = "Idempotent-Replayed"
+	MaxIdempotencyKeyLength   = 255
+	maxIdempotentRequestBytes = domain.MaxDocumentSize + 1<<20 // room for document uploads
+)
+
+// Idempotency makes mutating requests carrying an Idempotency-Key header safe to
+// retry. The first response for a key is stored and replayed for every repeat of
+// the same request; reusing a key for a different request is rejected with 422.
+// Safe methods and requests without the header pass through untouched.
+//
+// A request holds its key for lease, renewed while it runs, so that a retry of a
+// slow request is answered with 409 instead of running it twice. The lease must
+// exceed the server's write timeout.
+func Idempotency(store repository.IdempotencyRepository, lease time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if key == "" || !isMutating(r.Method) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if len(key) > MaxIdempotencyKeyLength {
+				writer.WriteErrJSON(w, http.StatusBadRequest, "Invalid Request", "Idempotency-Key must be at most 255 characters")
+				return
+			}
+
+			body, err := io.ReadAll(io.LimitReader(r.Body, maxIdempotentRequestBytes+1))
+			if err != nil || len(body) > maxIdempotentRequestBytes {
+				writer.WriteErrJSON(w, http.StatusBadRequest, "Invalid Request", "request body could not be read")
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			fingerprint := requestFingerprint(r, body)
+			record, reserved, err := store.Reserve(r.Context(), key, fingerprint, lease)
+			if err != nil {
+				log.Error(err)
+				writer.WriteErrJSON(w, http.StatusInternalServerError, "Database Error", "failed to process Idempotency-Key")
+				return
+			}
+
+			if !reserved {
+				switch {
+				case record.Fingerprint != fingerprint:
+					writer.WriteErrJSON(w, http.StatusUnprocessableEntity, "Idempotency Key Reused",
+						"Idempotency-Key was already used for a different request")
+				case !record.Completed:
+					writer.WriteErrJSON(w, http.StatusConflict, "Conflict",
+						"a request with this Idempotency-Key is still being processed")
+				default:
+					replay(w, record)
+				}
+				return
+			}
+
+			// The outcome must be stored even if the client has gone away.
+			ctx := context.WithoutCancel(r.Context())
+
+			recorder := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+			stopRenewing := renewLease(ctx, store, key, lease)
+			next.ServeHTTP(recorder, r)
+			stopRenewing()
+
+			if recorder.status >= http.StatusInternalServerError {
+				// Server errors are not final; let the client retry with the same key.
+				if err := store.Release(ctx, key); err != nil {
+					log.Error(err)
+				}
+				return
+			}
+			if err := store.Complete(ctx, key, recorder.status, w.Header(), recorder.body.Bytes()); err != nil {
+				log.Error(err)
+			}
+		})
+	}
+}
+
+// renewLease renews the lease on key every third of its length until the
+// returned function is called.
+func renewLease(ctx context.Context, store repository.IdempotencyRepository, key string, lease time.Duration) func() {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(lease / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := store.Renew(ctx, key, lease); err != nil {
+					log.Error(err)
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// requestFingerprint identifies a request by its method, target and body.
+func requestFingerprint(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(r.Method + " " + r.URL.RequestURI() + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func replay(w http.ResponseWriter, record *repository.IdempotencyRecord) {
+	for name, values := range record.Header {
+		for _, value := range values {
+			w.Header().Add(name, value)
+		}
+	}
+	w.Header().Set(IdempotentReplayedHeader, "true")
+	w.WriteHeader(record.StatusCode)
+	_, _ = w.Write(record.Body)
+}
+
+// responseRecorder passes a response through while keeping a copy of it.
+type responseRecorder struct {
+	http.ResponseWriter
+	status      int
+	body        bytes.Buffer
+	wroteHeader bool
+}
+
+func (rr *responseRecorder) WriteHeader(status int) {
+	if !rr.wroteHeader {
+		rr.status = status
+		rr.wroteHeader = true
+	}
+	rr.ResponseWriter.WriteHeader(status)
+}
+
+func (rr *responseRecorder) Write(b []byte) (int, error) {
+	rr.wroteHeader = true
+	rr.body.Write(b)
+	return rr.ResponseWriter.Write(b)
+}
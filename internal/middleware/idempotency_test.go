@@ -0,0 +1,141 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/middleware"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/repository"
+	"github.com/ashwingopalsamy/upvest-api/internal/util/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const lease = time.Minute
+
+func createdHandler(calls *int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"1"}`))
+	})
+}
+
+func newIdempotentRequest(body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(body))
+	req.Header.Set(middleware.IdempotencyKeyHeader, "key-1")
+	return req
+}
+
+func Test_Idempotency_WithoutHeader(t *testing.T) {
+	store := new(mocks.IdempotencyRepository)
+	calls := 0
+
+	req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{}`))
+	w := httptest.NewRecorder()
+	middleware.Idempotency(store, lease)(createdHandler(&calls)).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, 1, calls)
+	store.AssertNotCalled(t, "Reserve", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_Idempotency_StoresFirstResponse(t *testing.T) {
+	store := new(mocks.IdempotencyRepository)
+	store.On("Reserve", mock.Anything, "key-1", mock.Anything, lease).Return(nil, true, nil)
+	store.On("Complete", mock.Anything, "key-1", http.StatusCreated, mock.Anything, []byte(`{"id":"1"}`)).Return(nil)
+	calls := 0
+
+	w := httptest.NewRecorder()
+	middleware.Idempotency(store, lease)(createdHandler(&calls)).ServeHTTP(w, newIdempotentRequest(`{"first_name":"Rob"}`))
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, `{"id":"1"}`, w.Body.String())
+	assert.Equal(t, 1, calls)
+	store.AssertExpectations(t)
+}
+
+func Test_Idempotency_ReplaysStoredResponse(t *testing.T) {
+	var fingerprint string
+	store := new(mocks.IdempotencyRepository)
+	store.On("Reserve", mock.Anything, "key-1", mock.Anything, lease).
+		Run(func(args mock.Arguments) { fingerprint = args.String(2) }).
+		Return(nil, true, nil).Once()
+	store.On("Complete", mock.Anything, "key-1", http.StatusCreated, mock.Anything, mock.Anything).Return(nil)
+	calls := 0
+	handler := middleware.Idempotency(store, lease)(createdHandler(&calls))
+
+	handler.ServeHTTP(httptest.NewRecorder(), newIdempotentRequest(`{"first_name":"Rob"}`))
+
+	store.On("Reserve", mock.Anything, "key-1", fingerprint, lease).Return(&repository.IdempotencyRecord{
+		Key:         "key-1",
+		Fingerprint: fingerprint,
+		Completed:   true,
+		StatusCode:  http.StatusCreated,
+		Header:      map[string][]string{"Content-Type": {"application/json; charset=utf-8"}},
+		Body:        []byte(`{"id":"1"}`),
+	}, false, nil)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, newIdempotentRequest(`{"first_name":"Rob"}`))
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, `{"id":"1"}`, w.Body.String())
+	assert.Equal(t, "true", w.Header().Get(middleware.IdempotentReplayedHeader))
+	assert.Equal(t, 1, calls)
+}
+
+func Test_Idempotency_RejectsKeyReuseWithDifferentBody(t *testing.T) {
+	store := new(mocks.IdempotencyRepository)
+	store.On("Reserve", mock.Anything, "key-1", mock.Anything, lease).Return(&repository.IdempotencyRecord{
+		Key:         "key-1",
+		Fingerprint: "fingerprint-of-another-request",
+		Completed:   true,
+		StatusCode:  http.StatusCreated,
+	}, false, nil)
+	calls := 0
+
+	w := httptest.NewRecorder()
+	middleware.Idempotency(store, lease)(createdHandler(&calls)).ServeHTTP(w, newIdempotentRequest(`{"first_name":"Bob"}`))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, 0, calls)
+}
+
+func Test_Idempotency_ReleasesKeyOnServerError(t *testing.T) {
+	store := new(mocks.IdempotencyRepository)
+	store.On("Reserve", mock.Anything, "key-1", mock.Anything, lease).Return(nil, true, nil)
+	store.On("Release", mock.Anything, "key-1").Return(nil)
+
+	failing := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	w := httptest.NewRecorder()
+	middleware.Idempotency(store, lease)(failing).ServeHTTP(w, newIdempotentRequest(`{}`))
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	store.AssertExpectations(t)
+	store.AssertNotCalled(t, "Complete", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_Idempotency_RenewsLeaseWhileRunning(t *testing.T) {
+	store := new(mocks.IdempotencyRepository)
+	store.On("Reserve", mock.Anything, "key-1", mock.Anything, 30*time.Millisecond).Return(nil, true, nil)
+	store.On("Renew", mock.Anything, "key-1", 30*time.Millisecond).Return(nil)
+	store.On("Complete", mock.Anything, "key-1", http.StatusCreated, mock.Anything, mock.Anything).Return(nil)
+	slow := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	w := httptest.NewRecorder()
+	middleware.Idempotency(store, 30*time.Millisecond)(slow).ServeHTTP(w, newIdempotentRequest(`{}`))
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	store.AssertExpectations(t)
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"net/http"
 	"strconv"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/repository"
+	"github.com/ashwingopalsamy/upvest-api/internal/util/writer"
 )
 
 type PagingParams struct {
@@ -11,6 +14,8 @@ type PagingParams struct {
 	Limit  int
 	Sort   string
 	Order  string
+	// Cursor is set when the client pages with a cursor instead of an offset.
+	Cursor *repository.Cursor
 }
 
 const (
@@ -21,42 +26,89 @@ const (
 	DefaultOrder  = "ASC"
 )
 
-func ExtractPagingParams(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		query := r.URL.Query()
-
-		// Parse and validate offset
-		offset, err := strconv.Atoi(query.Get("offset"))
-		if err != nil || offset < 0 {
-			offset = DefaultOffset
-		}
-
-		// Parse and validate limit
-		limit, err := strconv.Atoi(query.Get("limit"))
-		if err != nil || limit <= 0 || limit > MaxLimit {
-			limit = DefaultLimit
-		}
-
-		// Parse sort field
-		sort := query.Get("sort")
-		if sort != "created_at" && sort != "updated_at" {
-			sort = DefaultSort
-		}
-
-		// Parse sort order
-		order := query.Get("order")
-		if order != "ASC" && order != "DESC" {
-			order = DefaultOrder
-		}
-
-		ctx := r.Context()
-		ctx = context.WithValue(ctx, "pagingParams", PagingParams{
-			Offset: offset,
-			Limit:  limit,
-			Sort:   sort,
-			Order:  order,
-		})
+type contextKey string
+
+const pagingParamsKey contextKey = "pagingParams"
+
+// Page converts the paging parameters to a repository page.
+func (p PagingParams) Page() repository.Page {
+	return repository.Page{
+		Offset: p.Offset,
+		Limit:  p.Limit,
+		Sort:   p.Sort,
+		Order:  p.Order,
+		Cursor: p.Cursor,
+	}
+}
+
+// PagingParamsFromContext returns the paging parameters extracted by
+// ExtractPagingParams, or the defaults when the request carries none.
+func PagingParamsFromContext(ctx context.Context) PagingParams {
+	if params, ok := ctx.Value(pagingParamsKey).(PagingParams); ok {
+		return params
+	}
+	return PagingParams{
+		Offset: DefaultOffset,
+		Limit:  DefaultLimit,
+		Sort:   DefaultSort,
+		Order:  DefaultOrder,
+	}
+}
+
+// ExtractPagingParams parses offset or cursor paging parameters into the request
+// context. A cursor takes precedence over offset, sort and order; a cursor that
+// fails verification is rejected with 400.
+func ExtractPagingParams(cursors *CursorCodec) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			query := r.URL.Query()
 
-		next.ServeHTTP(w, r.WithContext(ctx))
-	})
+			// Parse and validate offset
+			offset, err := strconv.Atoi(query.Get("offset"))
+			if err != nil || offset < 0 {
+				offset = DefaultOffset
+			}
+
+			// Parse and validate limit
+			limit, err := strconv.Atoi(query.Get("limit"))
+			if err != nil || limit <= 0 || limit > MaxLimit {
+				limit = DefaultLimit
+			}
+
+			// Parse sort field
+			sort := query.Get("sort")
+
+			// Parse sort order
+			order := query.Get("order")
+
+			// Parse cursor, which carries its own ordering
+			var cursor *repository.Cursor
+			if token := query.Get("cursor"); token != "" {
+				sort, order, cursor, err = cursors.Decode(token)
+				if err != nil {
+					writer.WriteErrJSON(w, http.StatusBadRequest, "Invalid Request", err.Error())
+					return
+				}
+				offset = DefaultOffset
+			}
+
+			if sort != "created_at" && sort != "updated_at" {
+				sort = DefaultSort
+			}
+			if order != "ASC" && order != "DESC" {
+				order = DefaultOrder
+			}
+
+			ctx := r.Context()
+			ctx = context.WithValue(ctx, pagingParamsKey, PagingParams{
+				Offset: offset,
+				Limit:  limit,
+				Sort:   sort,
+				Order:  order,
+				Cursor: cursor,
+			})
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
 }
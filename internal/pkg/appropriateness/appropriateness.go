@@ -0,0 +1,52 @@
+// Package appropriateness scores answers to the MiFID II appropriateness
+// questionnaire. It has no side effects; storing an assessment is up to the
+// caller.
+package appropriateness
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+)
+
+// Assess scores the submission against the questionnaire version it answers.
+// Every question adds the points of the chosen option to each of its
+// categories, and the categories reaching the passing score are allowed. The
+// submission must be valid.
+func Assess(userID string, submission domain.AppropriatenessSubmission) (*domain.AppropriatenessAssessment, error) {
+	questionnaire, ok := domain.GetQuestionnaire(submission.QuestionnaireVersion)
+	if !ok {
+		return nil, fmt.Errorf("unknown questionnaire version %d", submission.QuestionnaireVersion)
+	}
+
+	scores := make(map[string]int)
+	for _, category := range questionnaire.Categories() {
+		scores[category] = 0
+	}
+	for _, question := range questionnaire.Questions {
+		option, ok := question.Option(submission.Answers[question.ID])
+		if !ok {
+			return nil, fmt.Errorf("question %s has no valid answer", question.ID)
+		}
+		for _, category := range question.Categories {
+			scores[category] += option.Points
+		}
+	}
+
+	allowed := []string{}
+	for category, score := range scores {
+		if score >= questionnaire.PassingScore {
+			allowed = append(allowed, category)
+		}
+	}
+	sort.Strings(allowed)
+
+	return &domain.AppropriatenessAssessment{
+		UserID:               userID,
+		QuestionnaireVersion: questionnaire.Version,
+		Answers:              submission.Answers,
+		Scores:               scores,
+		AllowedCategories:    allowed,
+	}, nil
+}
@@ -0,0 +1,65 @@
+package appropriateness
+
+import (
+	"testing"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func answers(overrides map[string]string) map[string]string {
+	answers := map[string]string{
+		"PROFESSION":        "NO",
+		"EQUITY_KNOWLEDGE":  "DONT_KNOW",
+		"EQUITY_EXPERIENCE": "NONE",
+		"ETF_KNOWLEDGE":     "DONT_KNOW",
+		"ETF_EXPERIENCE":    "NONE",
+		"FUND_KNOWLEDGE":    "DONT_KNOW",
+		"FUND_EXPERIENCE":   "NONE",
+		"BOND_KNOWLEDGE":    "DONT_KNOW",
+		"BOND_EXPERIENCE":   "NONE",
+	}
+	for question, answer := range overrides {
+		answers[question] = answer
+	}
+	return answers
+}
+
+func Test_Assess(t *testing.T) {
+	tests := []struct {
+		name     string
+		answers  map[string]string
+		allowed  []string
+		etfScore int
+	}{
+		{"no knowledge", answers(nil), []string{}, 0},
+		{"knowledge without experience", answers(map[string]string{"ETF_KNOWLEDGE": "FALLS"}), []string{}, 2},
+		{"knowledge and some experience", answers(map[string]string{"ETF_KNOWLEDGE": "FALLS", "ETF_EXPERIENCE": "1_TO_5"}),
+			[]string{"ETF"}, 3},
+		{"experience without knowledge", answers(map[string]string{"ETF_EXPERIENCE": "MORE_THAN_5", "PROFESSION": "YES"}),
+			[]string{"ETF"}, 3},
+		{"profession counts for every category", answers(map[string]string{
+			"PROFESSION": "YES", "EQUITY_KNOWLEDGE": "YES", "BOND_KNOWLEDGE": "FALLS", "FUND_KNOWLEDGE": "INVESTOR",
+		}), []string{"BOND", "EQUITY"}, 1},
+	}
+
+	for _, tt := range tests {
+		submission := domain.AppropriatenessSubmission{QuestionnaireVersion: 1, Answers: tt.answers}
+		assert.NoError(t, submission.Validate(), tt.name)
+
+		assessment, err := Assess("u1", submission)
+
+		assert.NoError(t, err, tt.name)
+		assert.Equal(t, tt.allowed, assessment.AllowedCategories, tt.name)
+		assert.Equal(t, tt.etfScore, assessment.Scores["ETF"], tt.name)
+		assert.Len(t, assessment.Scores, 4, tt.name)
+	}
+}
+
+func Test_Assess_Invalid(t *testing.T) {
+	_, err := Assess("u1", domain.AppropriatenessSubmission{QuestionnaireVersion: 99})
+	assert.EqualError(t, err, "unknown questionnaire version 99")
+
+	_, err = Assess("u1", domain.AppropriatenessSubmission{QuestionnaireVersion: 1, Answers: map[string]string{}})
+	assert.EqualError(t, err, "question PROFESSION has no valid answer")
+}
@@ -0,0 +1,31 @@
+package documents
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+)
+
+// Scanner checks an uploaded file for malware before it is stored. It returns
+// domain.ErrDocumentInfected, possibly wrapped, for a file that must be
+// rejected, and any other error when the file could not be scanned.
+type Scanner interface {
+	Scan(ctx context.Context, content io.Reader) error
+}
+
+// ScannerFunc adapts a function to the Scanner interface.
+type ScannerFunc func(ctx context.Context, content io.Reader) error
+
+func (f ScannerFunc) Scan(ctx context.Context, content io.Reader) error {
+	return f(ctx, content)
+}
+
+// NoScan accepts every file. It is used when no virus scanner is configured.
+var NoScan Scanner = ScannerFunc(func(context.Context, io.Reader) error { return nil })
+
+// Checksum returns the hex encoded SHA-256 digest of content.
+func Checksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,43 @@
+package documents
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_LocalStorage(t *testing.T) {
+	ctx := context.Background()
+	storage, err := NewLocalStorage(t.TempDir())
+	assert.NoError(t, err)
+
+	assert.NoError(t, storage.Put(ctx, "u1/d1", strings.NewReader("%PDF-1.7")))
+
+	blob, err := storage.Get(ctx, "u1/d1")
+	assert.NoError(t, err)
+	content, _ := io.ReadAll(blob)
+	blob.Close()
+	assert.Equal(t, "%PDF-1.7", string(content))
+
+	assert.NoError(t, storage.Delete(ctx, "u1/d1"))
+	assert.NoError(t, storage.Delete(ctx, "u1/d1"))
+
+	_, err = storage.Get(ctx, "u1/d1")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func Test_LocalStorage_InvalidKeys(t *testing.T) {
+	storage, err := NewLocalStorage(t.TempDir())
+	assert.NoError(t, err)
+
+	for _, key := range []string{"", "/etc/passwd", "../d1", "u1/../../d1", "u1//d1", "u1/./d1"} {
+		assert.Error(t, storage.Put(context.Background(), key, strings.NewReader("x")), key)
+	}
+}
+
+func Test_Checksum(t *testing.T) {
+	assert.Equal(t, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", Checksum(nil))
+}
@@ -0,0 +1,117 @@
+// Package fees calculates fees in minor units. It has no side effects; charging
+// a calculated fee is up to the caller.
+//
+// Intermediate results are exact fractions, and only the final fee is rounded
+// half up to whole minor units, so that 0.5 cent rounds to 1 cent.
+package fees
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+)
+
+// DaysInYear is the day count basis of custody fees (ACT/365).
+const DaysInYear = 365
+
+var (
+	ErrNegativeBasis = errors.New("basis amount must not be negative")
+	ErrOverflow      = errors.New("fee does not fit into 64 bits")
+)
+
+// Calculate returns the fee charge incurs under schedule. The schedule must be
+// valid and match the charge's fee type.
+func Calculate(schedule domain.FeeSchedule, charge domain.FeeCharge) (int64, error) {
+	if schedule.FeeType != charge.FeeType {
+		return 0, fmt.Errorf("schedule for %s fees cannot charge a %s fee", schedule.FeeType, charge.FeeType)
+	}
+
+	switch schedule.Method {
+	case domain.FeeMethodFlat:
+		return schedule.FlatAmount, nil
+	case domain.FeeMethodPercentage:
+		return Percentage(charge.BasisAmount, schedule.Rate, schedule.MinAmount, schedule.MaxAmount)
+	case domain.FeeMethodBasisPoints:
+		days, err := charge.Days()
+		if err != nil {
+			return 0, err
+		}
+		return Custody(charge.BasisAmount, schedule.Rate, days, schedule.MinAmount, schedule.MaxAmount)
+	default:
+		return 0, fmt.Errorf("unknown fee method %q", schedule.Method)
+	}
+}
+
+// Percentage returns ratePercent percent of notional, bounded by min and max.
+// A max of zero means the fee is not capped.
+func Percentage(notional int64, ratePercent string, min, max int64) (int64, error) {
+	rate, err := parseRate(ratePercent)
+	if err != nil {
+		return 0, err
+	}
+	if notional < 0 {
+		return 0, ErrNegativeBasis
+	}
+
+	fee := new(big.Rat).Mul(big.NewRat(notional, 1), rate)
+	fee.Quo(fee, big.NewRat(100, 1))
+
+	return bounded(fee, min, max)
+}
+
+// Custody returns the custody fee for holding value for days at an annual rate
+// of bpsPerAnnum basis points, bounded by min and max. A max of zero means the
+// fee is not capped.
+func Custody(value int64, bpsPerAnnum string, days int, min, max int64) (int64, error) {
+	rate, err := parseRate(bpsPerAnnum)
+	if err != nil {
+		return 0, err
+	}
+	if value < 0 {
+		return 0, ErrNegativeBasis
+	}
+	if days < 0 {
+		return 0, errors.New("days must not be negative")
+	}
+
+	fee := new(big.Rat).Mul(big.NewRat(value, 1), rate)
+	fee.Mul(fee, big.NewRat(int64(days), 10000*DaysInYear))
+
+	return bounded(fee, min, max)
+}
+
+// RoundHalfUp rounds a non-negative fraction to the nearest integer, rounding
+// halves up.
+func RoundHalfUp(r *big.Rat) *big.Int {
+	// floor((2 * num + den) / (2 * den))
+	num := new(big.Int).Mul(r.Num(), big.NewInt(2))
+	num.Add(num, r.Denom())
+	den := new(big.Int).Mul(r.Denom(), big.NewInt(2))
+	return num.Div(num, den)
+}
+
+func bounded(fee *big.Rat, min, max int64) (int64, error) {
+	rounded := RoundHalfUp(fee)
+	if !rounded.IsInt64() {
+		return 0, ErrOverflow
+	}
+
+	amount := rounded.Int64()
+	if amount < min {
+		amount = min
+	}
+	if max > 0 && amount > max {
+		amount = max
+	}
+	return amount, nil
+}
+
+func parseRate(s string) (*big.Rat, error) {
+	rate, ok := new(big.Rat).SetString(s)
+	if !ok || rate.Sign() < 0 {
+		return nil, fmt.Errorf("invalid rate %q", s)
+	}
+	return rate, nil
+}
@@ -0,0 +1,161 @@
+package fees
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RoundHalfUp(t *testing.T) {
+	tests := []struct {
+		num, den int64
+		expected int64
+	}{
+		{0, 1, 0},
+		{1, 3, 0},
+		{499999, 1000000, 0},
+		{1, 2, 1},
+		{2, 3, 1},
+		{999999, 1000000, 1},
+		{3, 2, 2},
+		{5, 2, 3},
+		{7, 2, 4},
+		{1000001, 2, 500001},
+		{25, 1, 25},
+	}
+
+	for _, tt := range tests {
+		rounded := RoundHalfUp(big.NewRat(tt.num, tt.den))
+		assert.Equal(t, tt.expected, rounded.Int64(), "%d/%d", tt.num, tt.den)
+	}
+}
+
+func Test_Percentage(t *testing.T) {
+	tests := []struct {
+		name     string
+		notional int64
+		rate     string
+		min, max int64
+		expected int64
+	}{
+		{"exact", 10000, "0.25", 0, 0, 25},
+		{"below half rounds down", 199, "0.25", 0, 0, 0},
+		{"exactly half rounds up", 200, "0.25", 0, 0, 1},
+		{"above half rounds up", 201, "0.25", 0, 0, 1},
+		{"below one and a half", 599, "0.25", 0, 0, 1},
+		{"one and a half rounds up", 600, "0.25", 0, 0, 2},
+		{"half of one minor unit", 1, "50", 0, 0, 1},
+		{"just below half of one minor unit", 1, "49.999999", 0, 0, 0},
+		{"repeating fraction", 3, "33.333333", 0, 0, 1},
+		{"no trailing precision lost", 123456789, "0.1", 0, 0, 123457},
+		{"rounds up to the cap", 999999, "0.25", 0, 2500, 2500},
+		{"zero notional", 0, "0.25", 0, 0, 0},
+		{"minimum on zero notional", 0, "0.25", 99, 0, 99},
+		{"minimum", 10000, "0.25", 99, 0, 99},
+		{"at the minimum", 39600, "0.25", 99, 0, 99},
+		{"above the minimum", 39800, "0.25", 99, 0, 100},
+		{"maximum", 1000000000, "0.25", 99, 2500, 2500},
+		{"at the maximum", 1000000, "0.25", 99, 2500, 2500},
+		{"uncapped", 1000000000, "0.25", 99, 0, 2500000},
+		{"full percent", 12345, "100", 0, 0, 12345},
+	}
+
+	for _, tt := range tests {
+		fee, err := Percentage(tt.notional, tt.rate, tt.min, tt.max)
+
+		assert.NoError(t, err, tt.name)
+		assert.Equal(t, tt.expected, fee, tt.name)
+	}
+}
+
+func Test_Percentage_Errors(t *testing.T) {
+	_, err := Percentage(-1, "0.25", 0, 0)
+	assert.ErrorIs(t, err, ErrNegativeBasis)
+
+	_, err = Percentage(100, "abc", 0, 0)
+	assert.EqualError(t, err, `invalid rate "abc"`)
+
+	_, err = Percentage(100, "-0.25", 0, 0)
+	assert.EqualError(t, err, `invalid rate "-0.25"`)
+
+	_, err = Percentage(math.MaxInt64, "200", 0, 0)
+	assert.ErrorIs(t, err, ErrOverflow)
+}
+
+func Test_Custody(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    int64
+		bps      string
+		days     int
+		min, max int64
+		expected int64
+	}{
+		{"full year", 10000000, "25", 365, 0, 0, 25000},
+		{"leap year", 10000000, "25", 366, 0, 0, 25068},
+		{"thirty days rounds up", 10000000, "25", 30, 0, 0, 2055},
+		{"thirty-one days rounds down", 10000000, "25", 31, 0, 0, 2123},
+		{"single day exact", 7300000, "25", 1, 0, 0, 50},
+		{"fractional basis points", 7300000, "0.5", 73, 0, 0, 73},
+		{"exactly half rounds up", 365, "50", 100, 0, 0, 1},
+		{"below half rounds down", 146, "25", 365, 0, 0, 0},
+		{"half of one minor unit", 200, "25", 365, 0, 0, 1},
+		{"no days", 10000000, "25", 0, 0, 0, 0},
+		{"no value", 0, "25", 30, 0, 0, 0},
+		{"minimum", 10000000, "25", 30, 2500, 0, 2500},
+		{"maximum", 10000000, "25", 30, 0, 2000, 2000},
+	}
+
+	for _, tt := range tests {
+		fee, err := Custody(tt.value, tt.bps, tt.days, tt.min, tt.max)
+
+		assert.NoError(t, err, tt.name)
+		assert.Equal(t, tt.expected, fee, tt.name)
+	}
+}
+
+func Test_Custody_Errors(t *testing.T) {
+	_, err := Custody(-1, "25", 30, 0, 0)
+	assert.ErrorIs(t, err, ErrNegativeBasis)
+
+	_, err = Custody(100, "25", -1, 0, 0)
+	assert.EqualError(t, err, "days must not be negative")
+
+	_, err = Custody(math.MaxInt64, "10000", 3650, 0, 0)
+	assert.ErrorIs(t, err, ErrOverflow)
+}
+
+func Test_Calculate(t *testing.T) {
+	flat := domain.FeeSchedule{FeeType: domain.FeeTypeTrade, Method: domain.FeeMethodFlat, FlatAmount: 100}
+	percentage := domain.FeeSchedule{FeeType: domain.FeeTypeTrade, Method: domain.FeeMethodPercentage,
+		Rate: "0.25", MinAmount: 99}
+	custody := domain.FeeSchedule{FeeType: domain.FeeTypeCustody, Method: domain.FeeMethodBasisPoints, Rate: "25"}
+
+	trade := domain.FeeCharge{FeeType: domain.FeeTypeTrade, BasisAmount: 100000}
+	period := domain.FeeCharge{FeeType: domain.FeeTypeCustody, BasisAmount: 10000000,
+		PeriodStart: "2025-04-01", PeriodEnd: "2025-05-01"}
+
+	tests := []struct {
+		name     string
+		schedule domain.FeeSchedule
+		charge   domain.FeeCharge
+		expected int64
+	}{
+		{"flat", flat, trade, 100},
+		{"percentage", percentage, trade, 250},
+		{"custody for April", custody, period, 2055},
+	}
+
+	for _, tt := range tests {
+		fee, err := Calculate(tt.schedule, tt.charge)
+
+		assert.NoError(t, err, tt.name)
+		assert.Equal(t, tt.expected, fee, tt.name)
+	}
+
+	_, err := Calculate(custody, trade)
+	assert.EqualError(t, err, "schedule for CUSTODY fees cannot charge a TRADE fee")
+}
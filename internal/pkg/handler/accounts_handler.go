@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/ashwingopalsamy/upvest-api/internal/middleware"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/repository"
+	"github.com/ashwingopalsamy/upvest-api/internal/util/writer"
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+)
+
+type AccountHandler struct {
+	repo    repository.AccountRepository
+	cursors *middleware.CursorCodec
+}
+
+func NewAccountHandler(repo repository.AccountRepository, cursors *middleware.CursorCodec) *AccountHandler {
+	return &AccountHandler{
+		repo:    repo,
+		cursors: cursors,
+	}
+}
+
+// CreateAccount opens an account for an ACTIVE user.
+func (h *AccountHandler) CreateAccount(w http.ResponseWriter, r *http.Request) {
+	var account domain.Account
+	if err := json.NewDecoder(r.Body).Decode(&account); err != nil {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgInvalidRequestBody)
+		return
+	}
+
+	if err := account.Validate(); err != nil {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleValidationError, err.Error())
+		return
+	}
+
+	opened, err := h.repo.OpenAccount(r.Context(), &account)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writer.WriteErrJSON(w, http.StatusNotFound, ErrTitleNotFound, ErrMsgUserNotFound)
+		} else if errors.Is(err, domain.ErrUserNotActive) || errors.Is(err, domain.ErrGuardianRequired) {
+			writer.WriteErrJSON(w, http.StatusConflict, ErrTitleConflict, err.Error())
+		} else {
+			log.Error(err)
+			writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleDatabaseError, ErrMsgOpenAccountFailed)
+		}
+		return
+	}
+
+	writer.WriteJSON(w, http.StatusCreated, opened)
+}
+
+func (h *AccountHandler) GetAllAccounts(w http.ResponseWriter, r *http.Request) {
+	params := middleware.PagingParamsFromContext(r.Context())
+
+	filter, err := parseAccountFilter(r.URL.Query())
+	if err != nil {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, err.Error())
+		return
+	}
+
+	accounts, pageInfo, err := h.repo.GetAllAccounts(r.Context(), filter, params.Page())
+	if err != nil {
+		log.Error(err)
+		writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleDatabaseError, ErrMsgFailedToFetchAccounts)
+		return
+	}
+
+	var first, last repository.Cursor
+	if len(accounts) > 0 {
+		first = accountCursor(accounts[0], params.Sort)
+		last = accountCursor(accounts[len(accounts)-1], params.Sort)
+	}
+	meta := pageMeta(w, r, h.cursors, params, len(accounts), pageInfo, first, last)
+	meta["filter"] = filter
+
+	writer.WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"meta": meta,
+		"data": accounts,
+	})
+}
+
+func (h *AccountHandler) GetAccountByID(w http.ResponseWriter, r *http.Request) {
+	accountID := mux.Vars(r)["account_id"]
+	if accountID == "" {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgAccountIDRequired)
+		return
+	}
+
+	account, err := h.repo.GetAccountByID(r.Context(), accountID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writer.WriteErrJSON(w, http.StatusNotFound, ErrTitleNotFound, ErrMsgAccountNotFound)
+		} else {
+			log.Error(err)
+			writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleDatabaseError, ErrMsgFailedToFetchAccount)
+		}
+		return
+	}
+
+	writer.WriteJSON(w, http.StatusOK, account)
+}
+
+// DeleteAccount closes an account. Closed accounts remain readable.
+func (h *AccountHandler) DeleteAccount(w http.ResponseWriter, r *http.Request) {
+	accountID := mux.Vars(r)["account_id"]
+	if accountID == "" {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgAccountIDRequired)
+		return
+	}
+
+	err := h.repo.CloseAccount(r.Context(), accountID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writer.WriteErrJSON(w, http.StatusNotFound, ErrTitleNotFound, ErrMsgAccountNotFound)
+		} else if errors.Is(err, domain.ErrAccountNotActive) {
+			writer.WriteErrJSON(w, http.StatusConflict, ErrTitleConflict, err.Error())
+		} else {
+			log.Error(err)
+			writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleDatabaseError, ErrMsgCloseAccountFailed)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseAccountFilter reads the account list filters user_id and status from
+// the query string.
+func parseAccountFilter(query url.Values) (repository.AccountFilter, error) {
+	filter := repository.AccountFilter{
+		UserID: query.Get("user_id"),
+		Status: query.Get("status"),
+	}
+	if filter.UserID != "" && !domain.IsValidUUID(filter.UserID) {
+		return filter, errors.New("user_id must be a valid UUID")
+	}
+	if filter.Status != "" && !domain.IsValidAccountStatus(filter.Status) {
+		return filter, errors.New("status is not a valid account status")
+	}
+	return filter, nil
+}
+
+// accountCursor marks the position of account in a list ordered by sort.
+func accountCursor(account domain.Account, sort string) repository.Cursor {
+	return sortCursor(account.ID, account.CreatedAt, account.UpdatedAt, sort)
+}
@@ -0,0 +1,170 @@
+package handler_test
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/ashwingopalsamy/upvest-api/internal/middleware"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/handler"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/repository"
+	"github.com/ashwingopalsamy/upvest-api/internal/util/mocks"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+const testUserID = "0b6f6b6e-3c1f-4a7e-9d6a-2f1c8e4b5a77"
+
+type AccountHandlerTestSuite struct {
+	suite.Suite
+	mockRepo *mocks.AccountRepository
+	cursors  *middleware.CursorCodec
+	handler  *handler.AccountHandler
+}
+
+func TestAccountHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(AccountHandlerTestSuite))
+}
+
+func (suite *AccountHandlerTestSuite) SetupTest() {
+	suite.mockRepo = new(mocks.AccountRepository)
+	suite.cursors = middleware.NewCursorCodec([]byte("test-secret"))
+	suite.handler = handler.NewAccountHandler(suite.mockRepo, suite.cursors)
+}
+
+func (suite *AccountHandlerTestSuite) createAccount(account domain.Account) *http.Response {
+	body, _ := json.Marshal(account)
+	req := httptest.NewRequest(http.MethodPost, "/accounts", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	suite.handler.CreateAccount(w, req)
+
+	return w.Result()
+}
+
+func (suite *AccountHandlerTestSuite) TestCreateAccount_Success() {
+	opened := &domain.Account{ID: "a1", UserID: testUserID, AccountGroupID: "g1", Type: "TRADING", Status: "ACTIVE"}
+	suite.mockRepo.On("OpenAccount", mock.Anything, mock.MatchedBy(func(a *domain.Account) bool {
+		return a.UserID == testUserID && a.Type == "TRADING"
+	})).Return(opened, nil)
+
+	res := suite.createAccount(domain.Account{UserID: testUserID, Type: "TRADING"})
+	defer res.Body.Close()
+
+	suite.Equal(http.StatusCreated, res.StatusCode)
+	var resp domain.Account
+	suite.NoError(json.NewDecoder(res.Body).Decode(&resp))
+	suite.Equal("g1", resp.AccountGroupID)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *AccountHandlerTestSuite) TestCreateAccount_ValidationError() {
+	res := suite.createAccount(domain.Account{UserID: "not-a-uuid", Type: "TRADING"})
+	defer res.Body.Close()
+
+	suite.Equal(http.StatusBadRequest, res.StatusCode)
+
+	res = suite.createAccount(domain.Account{UserID: testUserID, Type: "CHECKING"})
+	defer res.Body.Close()
+
+	suite.Equal(http.StatusBadRequest, res.StatusCode)
+	suite.mockRepo.AssertNotCalled(suite.T(), "OpenAccount", mock.Anything, mock.Anything)
+}
+
+func (suite *AccountHandlerTestSuite) TestCreateAccount_UserNotActive() {
+	suite.mockRepo.On("OpenAccount", mock.Anything, mock.Anything).
+		Return(nil, fmt.Errorf("%w: user %s is OFFBOARDING", domain.ErrUserNotActive, testUserID))
+
+	res := suite.createAccount(domain.Account{UserID: testUserID, Type: "TRADING"})
+	defer res.Body.Close()
+
+	suite.Equal(http.StatusConflict, res.StatusCode)
+}
+
+func (suite *AccountHandlerTestSuite) TestCreateAccount_MinorWithoutGuardian() {
+	suite.mockRepo.On("OpenAccount", mock.Anything, mock.Anything).
+		Return(nil, fmt.Errorf("%w: user %s needs a guardian to open an account", domain.ErrGuardianRequired, testUserID))
+
+	res := suite.createAccount(domain.Account{UserID: testUserID, Type: "TRADING"})
+	defer res.Body.Close()
+
+	suite.Equal(http.StatusConflict, res.StatusCode)
+}
+
+func (suite *AccountHandlerTestSuite) TestCreateAccount_UserNotFound() {
+	suite.mockRepo.On("OpenAccount", mock.Anything, mock.Anything).Return(nil, sql.ErrNoRows)
+
+	res := suite.createAccount(domain.Account{UserID: testUserID, Type: "TRADING"})
+	defer res.Body.Close()
+
+	suite.Equal(http.StatusNotFound, res.StatusCode)
+}
+
+func (suite *AccountHandlerTestSuite) TestGetAllAccounts_Filter() {
+	accounts := []domain.Account{{ID: "a1", UserID: testUserID, Type: "TRADING", Status: "ACTIVE"}}
+	suite.mockRepo.On("GetAllAccounts", mock.Anything,
+		repository.AccountFilter{UserID: testUserID, Status: "ACTIVE"},
+		repository.Page{Offset: 0, Limit: 100, Sort: "created_at", Order: "ASC"}).
+		Return(accounts, repository.PageInfo{}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts?status=ACTIVE&user_id="+testUserID, nil)
+	w := httptest.NewRecorder()
+	middleware.ExtractPagingParams(suite.cursors)(http.HandlerFunc(suite.handler.GetAllAccounts)).ServeHTTP(w, req)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	suite.Equal(http.StatusOK, res.StatusCode)
+	var resp struct {
+		Meta map[string]interface{} `json:"meta"`
+		Data []domain.Account       `json:"data"`
+	}
+	suite.NoError(json.NewDecoder(res.Body).Decode(&resp))
+	suite.Len(resp.Data, 1)
+	suite.Equal(map[string]interface{}{"user_id": testUserID, "status": "ACTIVE"}, resp.Meta["filter"])
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *AccountHandlerTestSuite) TestGetAllAccounts_InvalidFilter() {
+	req := httptest.NewRequest(http.MethodGet, "/accounts?status=FROZEN", nil)
+	w := httptest.NewRecorder()
+	middleware.ExtractPagingParams(suite.cursors)(http.HandlerFunc(suite.handler.GetAllAccounts)).ServeHTTP(w, req)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.mockRepo.AssertNotCalled(suite.T(), "GetAllAccounts", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *AccountHandlerTestSuite) TestGetAccountByID_NotFound() {
+	suite.mockRepo.On("GetAccountByID", mock.Anything, "a1").Return(nil, fmt.Errorf("account not found: %w", sql.ErrNoRows))
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/a1", nil)
+	req = mux.SetURLVars(req, map[string]string{"account_id": "a1"})
+	w := httptest.NewRecorder()
+
+	suite.handler.GetAccountByID(w, req)
+
+	suite.Equal(http.StatusNotFound, w.Code)
+}
+
+func (suite *AccountHandlerTestSuite) TestDeleteAccount() {
+	suite.mockRepo.On("CloseAccount", mock.Anything, "a1").Return(nil).Once()
+	suite.mockRepo.On("CloseAccount", mock.Anything, "a1").
+		Return(fmt.Errorf("%w: account a1 is CLOSED", domain.ErrAccountNotActive)).Once()
+
+	for _, expected := range []int{http.StatusNoContent, http.StatusConflict} {
+		req := httptest.NewRequest(http.MethodDelete, "/accounts/a1", nil)
+		req = mux.SetURLVars(req, map[string]string{"account_id": "a1"})
+		w := httptest.NewRecorder()
+
+		suite.handler.DeleteAccount(w, req)
+
+		suite.Equal(expected, w.Code)
+	}
+	suite.mockRepo.AssertExpectations(suite.T())
+}
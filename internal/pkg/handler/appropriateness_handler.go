@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/appropriateness"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/repository"
+	"github.com/ashwingopalsamy/upvest-api/internal/util/writer"
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+)
+
+type AppropriatenessHandler struct {
+	repo repository.AppropriatenessRepository
+}
+
+func NewAppropriatenessHandler(repo repository.AppropriatenessRepository) *AppropriatenessHandler {
+	return &AppropriatenessHandler{repo: repo}
+}
+
+// GetQuestionnaire returns the current questionnaire, or the one given by the
+// version query parameter.
+func (h *AppropriatenessHandler) GetQuestionnaire(w http.ResponseWriter, r *http.Request) {
+	version := domain.CurrentQuestionnaireVersion
+	if raw := r.URL.Query().Get("version"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, "version must be an integer")
+			return
+		}
+		version = parsed
+	}
+
+	questionnaire, ok := domain.GetQuestionnaire(version)
+	if !ok {
+		writer.WriteErrJSON(w, http.StatusNotFound, ErrTitleNotFound, ErrMsgQuestionnaireNotFound)
+		return
+	}
+
+	writer.WriteJSON(w, http.StatusOK, questionnaire)
+}
+
+// SubmitAnswers scores a user's answers to the current questionnaire and stores
+// the result as the user's current assessment.
+func (h *AppropriatenessHandler) SubmitAnswers(w http.ResponseWriter, r *http.Request) {
+	userID := mux.Vars(r)["user_id"]
+	if userID == "" {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgUserIDRequired)
+		return
+	}
+
+	var submission domain.AppropriatenessSubmission
+	if err := json.NewDecoder(r.Body).Decode(&submission); err != nil {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgInvalidRequestBody)
+		return
+	}
+
+	if err := submission.Validate(); err != nil {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleValidationError, err.Error())
+		return
+	}
+
+	assessment, err := appropriateness.Assess(userID, submission)
+	if err != nil {
+		log.Error(err)
+		writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleInternalError, ErrMsgAssessAppropriatenessFailed)
+		return
+	}
+
+	created, err := h.repo.CreateAssessment(r.Context(), assessment)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writer.WriteErrJSON(w, http.StatusNotFound, ErrTitleNotFound, ErrMsgUserNotFound)
+		} else if errors.Is(err, domain.ErrUserNotActive) {
+			writer.WriteErrJSON(w, http.StatusConflict, ErrTitleConflict, err.Error())
+		} else {
+			log.Error(err)
+			writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleDatabaseError, ErrMsgAssessAppropriatenessFailed)
+		}
+		return
+	}
+
+	writer.WriteJSON(w, http.StatusCreated, created)
+}
+
+// GetAssessment returns the user's current assessment together with all of the
+// user's assessments, most recent first.
+func (h *AppropriatenessHandler) GetAssessment(w http.ResponseWriter, r *http.Request) {
+	userID := mux.Vars(r)["user_id"]
+	if userID == "" {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgUserIDRequired)
+		return
+	}
+
+	assessments, err := h.repo.GetAssessments(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writer.WriteErrJSON(w, http.StatusNotFound, ErrTitleNotFound, ErrMsgUserNotFound)
+		} else {
+			log.Error(err)
+			writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleDatabaseError, ErrMsgFailedToFetchAssessments)
+		}
+		return
+	}
+	if len(assessments) == 0 {
+		writer.WriteErrJSON(w, http.StatusNotFound, ErrTitleNotFound, ErrMsgNoAppropriatenessAssessment)
+		return
+	}
+
+	writer.WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"current": assessments[0],
+		"history": assessments,
+	})
+}
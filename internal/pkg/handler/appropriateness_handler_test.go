@@ -0,0 +1,119 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/handler"
+	"github.com/ashwingopalsamy/upvest-api/internal/util/mocks"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type AppropriatenessHandlerTestSuite struct {
+	suite.Suite
+	mockRepo *mocks.AppropriatenessRepository
+	handler  *handler.AppropriatenessHandler
+}
+
+func TestAppropriatenessHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(AppropriatenessHandlerTestSuite))
+}
+
+func (suite *AppropriatenessHandlerTestSuite) SetupTest() {
+	suite.mockRepo = new(mocks.AppropriatenessRepository)
+	suite.handler = handler.NewAppropriatenessHandler(suite.mockRepo)
+}
+
+func (suite *AppropriatenessHandlerTestSuite) submitAnswers(body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/users/"+testUserID+"/appropriateness", strings.NewReader(body))
+	req = mux.SetURLVars(req, map[string]string{"user_id": testUserID})
+	w := httptest.NewRecorder()
+
+	suite.handler.SubmitAnswers(w, req)
+
+	return w
+}
+
+const testAppropriatenessAnswers = `{"questionnaire_version":1,"answers":{
+	"PROFESSION":"NO",
+	"EQUITY_KNOWLEDGE":"YES","EQUITY_EXPERIENCE":"MORE_THAN_5",
+	"ETF_KNOWLEDGE":"FALLS","ETF_EXPERIENCE":"1_TO_5",
+	"FUND_KNOWLEDGE":"DONT_KNOW","FUND_EXPERIENCE":"NONE",
+	"BOND_KNOWLEDGE":"RISES","BOND_EXPERIENCE":"NONE"}}`
+
+func (suite *AppropriatenessHandlerTestSuite) TestSubmitAnswers_Success() {
+	suite.mockRepo.On("CreateAssessment", mock.Anything, mock.MatchedBy(func(a *domain.AppropriatenessAssessment) bool {
+		return a.UserID == testUserID && len(a.AllowedCategories) == 2 &&
+			a.AllowedCategories[0] == "EQUITY" && a.AllowedCategories[1] == "ETF"
+	})).Return(&domain.AppropriatenessAssessment{ID: "aa1", AllowedCategories: []string{"EQUITY", "ETF"}}, nil)
+
+	w := suite.submitAnswers(testAppropriatenessAnswers)
+
+	suite.Equal(http.StatusCreated, w.Code)
+	suite.Contains(w.Body.String(), `"allowed_categories":["EQUITY","ETF"]`)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *AppropriatenessHandlerTestSuite) TestSubmitAnswers_Invalid() {
+	w := suite.submitAnswers(`{"questionnaire_version":1,"answers":{"PROFESSION":"NO"}}`)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.Contains(w.Body.String(), "must be answered")
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateAssessment", mock.Anything, mock.Anything)
+}
+
+func (suite *AppropriatenessHandlerTestSuite) TestSubmitAnswers_UserNotActive() {
+	suite.mockRepo.On("CreateAssessment", mock.Anything, mock.Anything).Return(nil, domain.ErrUserNotActive)
+
+	suite.Equal(http.StatusConflict, suite.submitAnswers(testAppropriatenessAnswers).Code)
+}
+
+func (suite *AppropriatenessHandlerTestSuite) getAssessment() *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/users/"+testUserID+"/appropriateness", nil)
+	req = mux.SetURLVars(req, map[string]string{"user_id": testUserID})
+	w := httptest.NewRecorder()
+
+	suite.handler.GetAssessment(w, req)
+
+	return w
+}
+
+func (suite *AppropriatenessHandlerTestSuite) TestGetAssessment() {
+	suite.mockRepo.On("GetAssessments", mock.Anything, testUserID).Return([]domain.AppropriatenessAssessment{
+		{ID: "aa2", UserID: testUserID, AllowedCategories: []string{"ETF"}},
+		{ID: "aa1", UserID: testUserID, AllowedCategories: []string{}},
+	}, nil)
+
+	w := suite.getAssessment()
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Contains(w.Body.String(), `"current":{"id":"aa2"`)
+	suite.Contains(w.Body.String(), `"id":"aa1"`)
+}
+
+func (suite *AppropriatenessHandlerTestSuite) TestGetAssessment_NoneYet() {
+	suite.mockRepo.On("GetAssessments", mock.Anything, testUserID).Return([]domain.AppropriatenessAssessment{}, nil)
+
+	w := suite.getAssessment()
+
+	suite.Equal(http.StatusNotFound, w.Code)
+	suite.Contains(w.Body.String(), "user has no appropriateness assessment")
+}
+
+func (suite *AppropriatenessHandlerTestSuite) TestGetQuestionnaire() {
+	w := httptest.NewRecorder()
+	suite.handler.GetQuestionnaire(w, httptest.NewRequest(http.MethodGet, "/appropriateness/questionnaire", nil))
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Contains(w.Body.String(), `"version":1`)
+
+	w = httptest.NewRecorder()
+	suite.handler.GetQuestionnaire(w, httptest.NewRequest(http.MethodGet, "/appropriateness/questionnaire?version=7", nil))
+
+	suite.Equal(http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/repository"
+	"github.com/ashwingopalsamy/upvest-api/internal/util/writer"
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+)
+
+type BusinessHandler struct {
+	repo repository.BusinessRepository
+}
+
+func NewBusinessHandler(repo repository.BusinessRepository) *BusinessHandler {
+	return &BusinessHandler{repo: repo}
+}
+
+// CreateBusiness onboards a business together with its UBOs and legal
+// representatives, who must be ACTIVE users.
+func (h *BusinessHandler) CreateBusiness(w http.ResponseWriter, r *http.Request) {
+	var business domain.Business
+	if err := json.NewDecoder(r.Body).Decode(&business); err != nil {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgInvalidRequestBody)
+		return
+	}
+
+	if err := business.Validate(); err != nil {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleValidationError, err.Error())
+		return
+	}
+
+	created, err := h.repo.CreateBusiness(r.Context(), &business)
+	if err != nil {
+		if errors.Is(err, domain.ErrLEIAlreadyRegistered) {
+			writer.WriteErrJSON(w, http.StatusConflict, ErrTitleConflict, err.Error())
+		} else if errors.Is(err, domain.ErrBusinessNotAccepted) {
+			writer.WriteErrJSON(w, http.StatusUnprocessableEntity, ErrTitleUnprocessableEntity, err.Error())
+		} else {
+			log.Error(err)
+			writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleDatabaseError, ErrMsgCreateBusinessFailed)
+		}
+		return
+	}
+
+	writer.WriteJSON(w, http.StatusCreated, created)
+}
+
+func (h *BusinessHandler) GetBusinessByID(w http.ResponseWriter, r *http.Request) {
+	businessID := mux.Vars(r)["business_id"]
+	if businessID == "" {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgBusinessIDRequired)
+		return
+	}
+
+	business, err := h.repo.GetBusinessByID(r.Context(), businessID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writer.WriteErrJSON(w, http.StatusNotFound, ErrTitleNotFound, ErrMsgBusinessNotFound)
+		} else {
+			log.Error(err)
+			writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleDatabaseError, ErrMsgFailedToFetchBusiness)
+		}
+		return
+	}
+
+	writer.WriteJSON(w, http.StatusOK, business)
+}
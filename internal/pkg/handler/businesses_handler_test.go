@@ -0,0 +1,101 @@
+package handler_test
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/handler"
+	"github.com/ashwingopalsamy/upvest-api/internal/util/mocks"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type BusinessHandlerTestSuite struct {
+	suite.Suite
+	mockRepo *mocks.BusinessRepository
+	handler  *handler.BusinessHandler
+}
+
+func TestBusinessHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(BusinessHandlerTestSuite))
+}
+
+func (suite *BusinessHandlerTestSuite) SetupTest() {
+	suite.mockRepo = new(mocks.BusinessRepository)
+	suite.handler = handler.NewBusinessHandler(suite.mockRepo)
+}
+
+func (suite *BusinessHandlerTestSuite) createBusiness(body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/businesses", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	suite.handler.CreateBusiness(w, req)
+
+	return w
+}
+
+var testBusiness = fmt.Sprintf(`{
+	"name": "Muster GmbH",
+	"registration_number": "HRB 123456",
+	"legal_form": "GMBH",
+	"registered_address": {"address_line1": "Hauptstr. 1", "postcode": "10115", "city": "Berlin", "country": "DE"},
+	"lei": "529900T8BM49AURSDO55",
+	"persons": [
+		{"user_id": %q, "role": "LEGAL_REPRESENTATIVE"},
+		{"user_id": %q, "role": "UBO", "ownership_percentage": "100"}
+	]
+}`, testUserID, testUserID)
+
+func (suite *BusinessHandlerTestSuite) TestCreateBusiness_Success() {
+	suite.mockRepo.On("CreateBusiness", mock.Anything, mock.MatchedBy(func(b *domain.Business) bool {
+		return b.LEI == "529900T8BM49AURSDO55" && len(b.Persons) == 2
+	})).Return(&domain.Business{ID: "b1", Name: "Muster GmbH"}, nil)
+
+	w := suite.createBusiness(testBusiness)
+
+	suite.Equal(http.StatusCreated, w.Code)
+	suite.Contains(w.Body.String(), `"id":"b1"`)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *BusinessHandlerTestSuite) TestCreateBusiness_InvalidLEI() {
+	w := suite.createBusiness(strings.Replace(testBusiness, "529900T8BM49AURSDO55", "529900T8BM49AURSDO56", 1))
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.Contains(w.Body.String(), "lei must be a valid ISO 17442 LEI")
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateBusiness", mock.Anything, mock.Anything)
+}
+
+func (suite *BusinessHandlerTestSuite) TestCreateBusiness_Errors() {
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{fmt.Errorf("%w: 529900T8BM49AURSDO55", domain.ErrLEIAlreadyRegistered), http.StatusConflict},
+		{fmt.Errorf("%w: user %s does not exist", domain.ErrBusinessNotAccepted, testUserID), http.StatusUnprocessableEntity},
+		{sql.ErrConnDone, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		suite.mockRepo.On("CreateBusiness", mock.Anything, mock.Anything).Return(nil, tt.err).Once()
+
+		suite.Equal(tt.expected, suite.createBusiness(testBusiness).Code, tt.err.Error())
+	}
+}
+
+func (suite *BusinessHandlerTestSuite) TestGetBusinessByID_NotFound() {
+	suite.mockRepo.On("GetBusinessByID", mock.Anything, "b1").Return(nil, fmt.Errorf("business not found: %w", sql.ErrNoRows))
+
+	req := httptest.NewRequest(http.MethodGet, "/businesses/b1", nil)
+	req = mux.SetURLVars(req, map[string]string{"business_id": "b1"})
+	w := httptest.NewRecorder()
+
+	suite.handler.GetBusinessByID(w, req)
+
+	suite.Equal(http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/documents"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/repository"
+	"github.com/ashwingopalsamy/upvest-api/internal/util/writer"
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+)
+
+// maxDocumentRequestBytes bounds an upload request: the file plus the form
+// fields and multipart framing.
+const maxDocumentRequestBytes = domain.MaxDocumentSize + 1<<20
+
+type DocumentHandler struct {
+	repo    repository.DocumentRepository
+	storage documents.Storage
+	scanner documents.Scanner
+}
+
+func NewDocumentHandler(repo repository.DocumentRepository, storage documents.Storage, scanner documents.Scanner) *DocumentHandler {
+	return &DocumentHandler{repo: repo, storage: storage, scanner: scanner}
+}
+
+// UploadDocument accepts a multipart form with the document's type,
+// issuing_country and expiry_date and its file. The file is checksummed and
+// scanned before it is stored.
+func (h *DocumentHandler) UploadDocument(w http.ResponseWriter, r *http.Request) {
+	userID := mux.Vars(r)["user_id"]
+	if userID == "" {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgUserIDRequired)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxDocumentRequestBytes)
+	if err := r.ParseMultipartForm(maxDocumentRequestBytes); err != nil {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgInvalidMultipartForm)
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgDocumentFileRequired)
+		return
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(io.LimitReader(file, domain.MaxDocumentSize+1))
+	if err != nil {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgInvalidMultipartForm)
+		return
+	}
+
+	document := &domain.Document{
+		UserID:         userID,
+		Type:           r.FormValue("type"),
+		IssuingCountry: r.FormValue("issuing_country"),
+		ExpiryDate:     r.FormValue("expiry_date"),
+		FileName:       filepath.Base(header.Filename),
+		ContentType:    http.DetectContentType(content),
+		Size:           int64(len(content)),
+		SHA256:         documents.Checksum(content),
+	}
+	if err := document.Validate(); err != nil {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleValidationError, err.Error())
+		return
+	}
+
+	if err := h.scanner.Scan(r.Context(), bytes.NewReader(content)); err != nil {
+		if errors.Is(err, domain.ErrDocumentInfected) {
+			writer.WriteErrJSON(w, http.StatusUnprocessableEntity, ErrTitleUnprocessableEntity, err.Error())
+		} else {
+			log.Error(err)
+			writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleInternalError, ErrMsgScanDocumentFailed)
+		}
+		return
+	}
+
+	document.StorageKey, err = documents.NewKey(userID)
+	if err == nil {
+		err = h.storage.Put(r.Context(), document.StorageKey, bytes.NewReader(content))
+	}
+	if err != nil {
+		log.Error(err)
+		writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleInternalError, ErrMsgUploadDocumentFailed)
+		return
+	}
+
+	created, err := h.repo.CreateDocument(r.Context(), document)
+	if err != nil {
+		// The blob is not referenced by any document.
+		if err := h.storage.Delete(r.Context(), document.StorageKey); err != nil {
+			log.Error(err)
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			writer.WriteErrJSON(w, http.StatusNotFound, ErrTitleNotFound, ErrMsgUserNotFound)
+		} else if errors.Is(err, domain.ErrUserNotActive) {
+			writer.WriteErrJSON(w, http.StatusConflict, ErrTitleConflict, err.Error())
+		} else {
+			log.Error(err)
+			writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleDatabaseError, ErrMsgUploadDocumentFailed)
+		}
+		return
+	}
+
+	writer.WriteJSON(w, http.StatusCreated, created)
+}
+
+// GetDocuments lists the documents of a user, the latest upload first.
+func (h *DocumentHandler) GetDocuments(w http.ResponseWriter, r *http.Request) {
+	userID := mux.Vars(r)["user_id"]
+	if userID == "" {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgUserIDRequired)
+		return
+	}
+
+	userDocuments, err := h.repo.GetDocuments(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writer.WriteErrJSON(w, http.StatusNotFound, ErrTitleNotFound, ErrMsgUserNotFound)
+		} else {
+			log.Error(err)
+			writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleDatabaseError, ErrMsgFailedToFetchDocuments)
+		}
+		return
+	}
+
+	writer.WriteJSON(w, http.StatusOK, userDocuments)
+}
+
+// DownloadDocument streams the file of a document as an attachment.
+func (h *DocumentHandler) DownloadDocument(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	userID, documentID := vars["user_id"], vars["document_id"]
+	if userID == "" {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgUserIDRequired)
+		return
+	}
+	if documentID == "" {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgDocumentIDRequired)
+		return
+	}
+
+	document, err := h.repo.GetDocument(r.Context(), userID, documentID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writer.WriteErrJSON(w, http.StatusNotFound, ErrTitleNotFound, ErrMsgDocumentNotFound)
+		} else {
+			log.Error(err)
+			writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleDatabaseError, ErrMsgFailedToFetchDocument)
+		}
+		return
+	}
+
+	blob, err := h.storage.Get(r.Context(), document.StorageKey)
+	if err != nil {
+		log.Error(err)
+		writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleInternalError, ErrMsgFailedToFetchDocument)
+		return
+	}
+	defer blob.Close()
+
+	w.Header().Set("Content-Type", document.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(document.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": document.FileName}))
+	w.Header().Set("ETag", strconv.Quote(document.SHA256))
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, blob); err != nil {
+		log.Errorf("failed to send document %s: %v", document.ID, err)
+	}
+}
@@ -0,0 +1,182 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/documents"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/handler"
+	"github.com/ashwingopalsamy/upvest-api/internal/util/mocks"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+const testPDF = "%PDF-1.7\n1 0 obj\n<<>>\nendobj\n"
+
+type DocumentHandlerTestSuite struct {
+	suite.Suite
+	mockRepo *mocks.DocumentRepository
+	storage  *documents.LocalStorage
+	scanErr  error
+	handler  *handler.DocumentHandler
+}
+
+func TestDocumentHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(DocumentHandlerTestSuite))
+}
+
+func (suite *DocumentHandlerTestSuite) SetupTest() {
+	storage, err := documents.NewLocalStorage(suite.T().TempDir())
+	suite.Require().NoError(err)
+
+	suite.mockRepo = new(mocks.DocumentRepository)
+	suite.storage = storage
+	suite.scanErr = nil
+	scanner := documents.ScannerFunc(func(context.Context, io.Reader) error { return suite.scanErr })
+	suite.handler = handler.NewDocumentHandler(suite.mockRepo, storage, scanner)
+}
+
+func (suite *DocumentHandlerTestSuite) upload(fields map[string]string, content string) *httptest.ResponseRecorder {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	for name, value := range fields {
+		form.WriteField(name, value)
+	}
+	file, _ := form.CreateFormFile("file", "passport.pdf")
+	file.Write([]byte(content))
+	form.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/users/"+testUserID+"/documents", &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	req = mux.SetURLVars(req, map[string]string{"user_id": testUserID})
+	w := httptest.NewRecorder()
+
+	suite.handler.UploadDocument(w, req)
+
+	return w
+}
+
+var testDocumentFields = map[string]string{"type": "PASSPORT", "issuing_country": "DE", "expiry_date": "2099-12-31"}
+
+func (suite *DocumentHandlerTestSuite) TestUploadDocument_Success() {
+	var storageKey string
+	suite.mockRepo.On("CreateDocument", mock.Anything, mock.MatchedBy(func(d *domain.Document) bool {
+		storageKey = d.StorageKey
+		return d.UserID == testUserID && d.Type == "PASSPORT" && d.FileName == "passport.pdf" &&
+			d.ContentType == "application/pdf" && d.Size == int64(len(testPDF)) &&
+			d.SHA256 == documents.Checksum([]byte(testPDF)) && strings.HasPrefix(d.StorageKey, testUserID+"/")
+	})).Return(&domain.Document{ID: "d1", UserID: testUserID, Status: "VALID"}, nil)
+
+	w := suite.upload(testDocumentFields, testPDF)
+
+	suite.Equal(http.StatusCreated, w.Code)
+	suite.Contains(w.Body.String(), `"id":"d1"`)
+
+	blob, err := suite.storage.Get(context.Background(), storageKey)
+	suite.Require().NoError(err)
+	defer blob.Close()
+	content, _ := io.ReadAll(blob)
+	suite.Equal(testPDF, string(content))
+}
+
+func (suite *DocumentHandlerTestSuite) TestUploadDocument_ValidationError() {
+	w := suite.upload(map[string]string{"type": "PASSPORT", "issuing_country": "DE"}, testPDF)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.Contains(w.Body.String(), "expiry_date is required for PASSPORT")
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateDocument", mock.Anything, mock.Anything)
+}
+
+func (suite *DocumentHandlerTestSuite) TestUploadDocument_UnsupportedContent() {
+	w := suite.upload(testDocumentFields, "plain text")
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.Contains(w.Body.String(), "file must be a PDF, JPEG or PNG")
+}
+
+func (suite *DocumentHandlerTestSuite) TestUploadDocument_Infected() {
+	suite.scanErr = fmt.Errorf("%w: Eicar-Test-Signature", domain.ErrDocumentInfected)
+
+	w := suite.upload(testDocumentFields, testPDF)
+
+	suite.Equal(http.StatusUnprocessableEntity, w.Code)
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateDocument", mock.Anything, mock.Anything)
+}
+
+func (suite *DocumentHandlerTestSuite) TestUploadDocument_ScanFailed() {
+	suite.scanErr = errors.New("scanner unavailable")
+
+	w := suite.upload(testDocumentFields, testPDF)
+
+	suite.Equal(http.StatusInternalServerError, w.Code)
+}
+
+func (suite *DocumentHandlerTestSuite) TestUploadDocument_UserNotActiveRemovesBlob() {
+	var storageKey string
+	suite.mockRepo.On("CreateDocument", mock.Anything, mock.MatchedBy(func(d *domain.Document) bool {
+		storageKey = d.StorageKey
+		return true
+	})).Return(nil, fmt.Errorf("%w: user %s is OFFBOARDED", domain.ErrUserNotActive, testUserID))
+
+	w := suite.upload(testDocumentFields, testPDF)
+
+	suite.Equal(http.StatusConflict, w.Code)
+	_, err := suite.storage.Get(context.Background(), storageKey)
+	suite.ErrorIs(err, documents.ErrNotFound)
+}
+
+func (suite *DocumentHandlerTestSuite) TestUploadDocument_NotMultipart() {
+	req := httptest.NewRequest(http.MethodPost, "/users/"+testUserID+"/documents", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	req = mux.SetURLVars(req, map[string]string{"user_id": testUserID})
+	w := httptest.NewRecorder()
+
+	suite.handler.UploadDocument(w, req)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (suite *DocumentHandlerTestSuite) TestDownloadDocument_Success() {
+	suite.Require().NoError(suite.storage.Put(context.Background(), testUserID+"/k1", strings.NewReader(testPDF)))
+	suite.mockRepo.On("GetDocument", mock.Anything, testUserID, "d1").Return(&domain.Document{
+		ID: "d1", UserID: testUserID, FileName: "pass port.pdf", ContentType: "application/pdf",
+		Size: int64(len(testPDF)), SHA256: "abc", StorageKey: testUserID + "/k1",
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/users/"+testUserID+"/documents/d1/file", nil)
+	req = mux.SetURLVars(req, map[string]string{"user_id": testUserID, "document_id": "d1"})
+	w := httptest.NewRecorder()
+
+	suite.handler.DownloadDocument(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal("application/pdf", w.Header().Get("Content-Type"))
+	suite.Equal(`attachment; filename="pass port.pdf"`, w.Header().Get("Content-Disposition"))
+	suite.Equal(testPDF, w.Body.String())
+}
+
+func (suite *DocumentHandlerTestSuite) TestGetDocuments_Success() {
+	suite.mockRepo.On("GetDocuments", mock.Anything, testUserID).Return([]domain.Document{
+		{ID: "d1", UserID: testUserID, Type: "PASSPORT", StorageKey: testUserID + "/k1"},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/users/"+testUserID+"/documents", nil)
+	req = mux.SetURLVars(req, map[string]string{"user_id": testUserID})
+	w := httptest.NewRecorder()
+
+	suite.handler.GetDocuments(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Contains(w.Body.String(), `"id":"d1"`)
+	suite.NotContains(w.Body.String(), "/k1")
+}
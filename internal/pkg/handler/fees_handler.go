@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/repository"
+	"github.com/ashwingopalsamy/upvest-api/internal/util/writer"
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+)
+
+type FeeHandler struct {
+	repo repository.FeeRepository
+}
+
+func NewFeeHandler(repo repository.FeeRepository) *FeeHandler {
+	return &FeeHandler{repo: repo}
+}
+
+// SetFeeSchedule creates or replaces the schedule of a fee for a segment.
+func (h *FeeHandler) SetFeeSchedule(w http.ResponseWriter, r *http.Request) {
+	var schedule domain.FeeSchedule
+	if err := json.NewDecoder(r.Body).Decode(&schedule); err != nil {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgInvalidRequestBody)
+		return
+	}
+
+	if err := schedule.Validate(); err != nil {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleValidationError, err.Error())
+		return
+	}
+
+	set, err := h.repo.SetFeeSchedule(r.Context(), &schedule)
+	if err != nil {
+		log.Error(err)
+		writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleDatabaseError, ErrMsgSetFeeScheduleFailed)
+		return
+	}
+
+	writer.WriteJSON(w, http.StatusOK, set)
+}
+
+func (h *FeeHandler) GetFeeSchedules(w http.ResponseWriter, r *http.Request) {
+	schedules, err := h.repo.GetFeeSchedules(r.Context())
+	if err != nil {
+		log.Error(err)
+		writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleDatabaseError, ErrMsgFailedToFetchFeeSchedules)
+		return
+	}
+
+	writer.WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"data": schedules,
+	})
+}
+
+// AssignFeeSegment moves the user in the path into the segment given in the
+// request body.
+func (h *FeeHandler) AssignFeeSegment(w http.ResponseWriter, r *http.Request) {
+	userID := mux.Vars(r)["user_id"]
+	if userID == "" {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgUserIDRequired)
+		return
+	}
+
+	var body struct {
+		Segment string `json:"segment"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgInvalidRequestBody)
+		return
+	}
+	if !domain.IsValidSegment(body.Segment) {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleValidationError,
+			"segment must be 1 to 30 upper case letters, digits or underscores")
+		return
+	}
+
+	if err := h.repo.AssignUserFeeSegment(r.Context(), userID, body.Segment); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writer.WriteErrJSON(w, http.StatusNotFound, ErrTitleNotFound, ErrMsgUserNotFound)
+		} else {
+			log.Error(err)
+			writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleDatabaseError, ErrMsgAssignFeeSegmentFailed)
+		}
+		return
+	}
+
+	writer.WriteJSON(w, http.StatusOK, map[string]string{
+		"user_id": userID,
+		"segment": body.Segment,
+	})
+}
+
+// ChargeFee calculates a fee under the user's fee schedule and charges it. A
+// fee is charged once per reference; charging it again is answered with 409.
+func (h *FeeHandler) ChargeFee(w http.ResponseWriter, r *http.Request) {
+	userID := mux.Vars(r)["user_id"]
+	if userID == "" {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgUserIDRequired)
+		return
+	}
+
+	var charge domain.FeeCharge
+	if err := json.NewDecoder(r.Body).Decode(&charge); err != nil {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgInvalidRequestBody)
+		return
+	}
+
+	if err := charge.Validate(); err != nil {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleValidationError, err.Error())
+		return
+	}
+
+	fee, err := h.repo.ChargeFee(r.Context(), userID, &charge)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writer.WriteErrJSON(w, http.StatusNotFound, ErrTitleNotFound, ErrMsgUserNotFound)
+		} else if errors.Is(err, domain.ErrUserNotActive) || errors.Is(err, domain.ErrFeeAlreadyCharged) {
+			writer.WriteErrJSON(w, http.StatusConflict, ErrTitleConflict, err.Error())
+		} else if errors.Is(err, domain.ErrNoFeeSchedule) {
+			writer.WriteErrJSON(w, http.StatusUnprocessableEntity, ErrTitleUnprocessableEntity, err.Error())
+		} else {
+			log.Error(err)
+			writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleDatabaseError, ErrMsgChargeFeeFailed)
+		}
+		return
+	}
+
+	writer.WriteJSON(w, http.StatusCreated, fee)
+}
+
+// GetUserFees lists the fees charged to a user, most recent first.
+func (h *FeeHandler) GetUserFees(w http.ResponseWriter, r *http.Request) {
+	userID := mux.Vars(r)["user_id"]
+	if userID == "" {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgUserIDRequired)
+		return
+	}
+
+	charged, err := h.repo.GetUserFees(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writer.WriteErrJSON(w, http.StatusNotFound, ErrTitleNotFound, ErrMsgUserNotFound)
+		} else {
+			log.Error(err)
+			writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleDatabaseError, ErrMsgFailedToFetchFees)
+		}
+		return
+	}
+
+	writer.WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"data": charged,
+	})
+}
@@ -0,0 +1,99 @@
+package handler_test
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/handler"
+	"github.com/ashwingopalsamy/upvest-api/internal/util/mocks"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type FeeHandlerTestSuite struct {
+	suite.Suite
+	mockRepo *mocks.FeeRepository
+	handler  *handler.FeeHandler
+}
+
+func TestFeeHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(FeeHandlerTestSuite))
+}
+
+func (suite *FeeHandlerTestSuite) SetupTest() {
+	suite.mockRepo = new(mocks.FeeRepository)
+	suite.handler = handler.NewFeeHandler(suite.mockRepo)
+}
+
+func (suite *FeeHandlerTestSuite) chargeFee(body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/users/"+testUserID+"/fees", strings.NewReader(body))
+	req = mux.SetURLVars(req, map[string]string{"user_id": testUserID})
+	w := httptest.NewRecorder()
+
+	suite.handler.ChargeFee(w, req)
+
+	return w
+}
+
+const testTradeFeeCharge = `{"fee_type":"TRADE","reference":"o1","currency":"EUR","basis_amount":100000}`
+
+func (suite *FeeHandlerTestSuite) TestChargeFee_Success() {
+	suite.mockRepo.On("ChargeFee", mock.Anything, testUserID, mock.MatchedBy(func(c *domain.FeeCharge) bool {
+		return c.FeeType == "TRADE" && c.BasisAmount == 100000
+	})).Return(&domain.Fee{ID: "f1", Amount: 250, Currency: "EUR"}, nil)
+
+	w := suite.chargeFee(testTradeFeeCharge)
+
+	suite.Equal(http.StatusCreated, w.Code)
+	suite.Contains(w.Body.String(), `"amount":250`)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *FeeHandlerTestSuite) TestChargeFee_Errors() {
+	suite.Equal(http.StatusBadRequest, suite.chargeFee(`{"fee_type":"CUSTODY","reference":"2025-04","currency":"EUR"}`).Code)
+
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{sql.ErrNoRows, http.StatusNotFound},
+		{fmt.Errorf("%w: TRADE fee for o1", domain.ErrFeeAlreadyCharged), http.StatusConflict},
+		{fmt.Errorf("%w: TRADE fees in EUR", domain.ErrNoFeeSchedule), http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		suite.mockRepo.On("ChargeFee", mock.Anything, testUserID, mock.Anything).Return(nil, tt.err).Once()
+
+		suite.Equal(tt.expected, suite.chargeFee(testTradeFeeCharge).Code, tt.err.Error())
+	}
+}
+
+func (suite *FeeHandlerTestSuite) TestGetUserFees() {
+	suite.mockRepo.On("GetUserFees", mock.Anything, testUserID).
+		Return([]domain.Fee{{ID: "f1", FeeType: "TRADE", Amount: 250, Currency: "EUR"}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/users/"+testUserID+"/fees", nil)
+	req = mux.SetURLVars(req, map[string]string{"user_id": testUserID})
+	w := httptest.NewRecorder()
+
+	suite.handler.GetUserFees(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Contains(w.Body.String(), `"fee_type":"TRADE"`)
+}
+
+func (suite *FeeHandlerTestSuite) TestSetFeeSchedule_Invalid() {
+	req := httptest.NewRequest(http.MethodPost, "/fee_schedules",
+		strings.NewReader(`{"segment":"DEFAULT","fee_type":"CUSTODY","method":"FLAT","currency":"EUR","flat_amount":100}`))
+	w := httptest.NewRecorder()
+
+	suite.handler.SetFeeSchedule(w, req)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.mockRepo.AssertNotCalled(suite.T(), "SetFeeSchedule", mock.Anything, mock.Anything)
+}
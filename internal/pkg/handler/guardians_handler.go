@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/repository"
+	"github.com/ashwingopalsamy/upvest-api/internal/util/writer"
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+)
+
+type GuardianHandler struct {
+	repo repository.GuardianRepository
+}
+
+func NewGuardianHandler(repo repository.GuardianRepository) *GuardianHandler {
+	return &GuardianHandler{repo: repo}
+}
+
+// AddGuardian links a minor to an adult user who acts for them until they come
+// of age.
+func (h *GuardianHandler) AddGuardian(w http.ResponseWriter, r *http.Request) {
+	userID := mux.Vars(r)["user_id"]
+	if userID == "" {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgUserIDRequired)
+		return
+	}
+
+	var body struct {
+		GuardianID string `json:"guardian_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgInvalidRequestBody)
+		return
+	}
+	if !domain.IsValidUUID(body.GuardianID) {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleValidationError, "guardian_id must be a valid UUID")
+		return
+	}
+	if body.GuardianID == userID {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleValidationError, "a user cannot be their own guardian")
+		return
+	}
+
+	guardianship, err := h.repo.AddGuardian(r.Context(), userID, body.GuardianID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writer.WriteErrJSON(w, http.StatusNotFound, ErrTitleNotFound, ErrMsgUserNotFound)
+		} else if errors.Is(err, domain.ErrUserNotActive) || errors.Is(err, domain.ErrTooManyGuardians) {
+			writer.WriteErrJSON(w, http.StatusConflict, ErrTitleConflict, err.Error())
+		} else if errors.Is(err, domain.ErrGuardianNotAccepted) {
+			writer.WriteErrJSON(w, http.StatusUnprocessableEntity, ErrTitleUnprocessableEntity, err.Error())
+		} else {
+			log.Error(err)
+			writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleDatabaseError, ErrMsgAddGuardianFailed)
+		}
+		return
+	}
+
+	writer.WriteJSON(w, http.StatusCreated, guardianship)
+}
+
+// GetGuardians returns the current and past guardians of a user.
+func (h *GuardianHandler) GetGuardians(w http.ResponseWriter, r *http.Request) {
+	userID := mux.Vars(r)["user_id"]
+	if userID == "" {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgUserIDRequired)
+		return
+	}
+
+	guardianships, err := h.repo.GetGuardians(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writer.WriteErrJSON(w, http.StatusNotFound, ErrTitleNotFound, ErrMsgUserNotFound)
+		} else {
+			log.Error(err)
+			writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleDatabaseError, ErrMsgFailedToFetchGuardians)
+		}
+		return
+	}
+
+	writer.WriteJSON(w, http.StatusOK, guardianships)
+}
@@ -0,0 +1,107 @@
+package handler_test
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/handler"
+	"github.com/ashwingopalsamy/upvest-api/internal/util/mocks"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+const testGuardianID = "6f1c2b9e-3d4a-4c5b-8e7f-9a0b1c2d3e4f"
+
+type GuardianHandlerTestSuite struct {
+	suite.Suite
+	mockRepo *mocks.GuardianRepository
+	handler  *handler.GuardianHandler
+}
+
+func TestGuardianHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(GuardianHandlerTestSuite))
+}
+
+func (suite *GuardianHandlerTestSuite) SetupTest() {
+	suite.mockRepo = new(mocks.GuardianRepository)
+	suite.handler = handler.NewGuardianHandler(suite.mockRepo)
+}
+
+func (suite *GuardianHandlerTestSuite) addGuardian(guardianID string) *httptest.ResponseRecorder {
+	body := fmt.Sprintf(`{"guardian_id": %q}`, guardianID)
+	req := httptest.NewRequest(http.MethodPost, "/users/"+testUserID+"/guardians", strings.NewReader(body))
+	req = mux.SetURLVars(req, map[string]string{"user_id": testUserID})
+	w := httptest.NewRecorder()
+
+	suite.handler.AddGuardian(w, req)
+
+	return w
+}
+
+func (suite *GuardianHandlerTestSuite) TestAddGuardian_Success() {
+	suite.mockRepo.On("AddGuardian", mock.Anything, testUserID, testGuardianID).
+		Return(&domain.Guardianship{UserID: testUserID, GuardianID: testGuardianID, CreatedAt: "2025-04-13T09:00:00Z"}, nil)
+
+	w := suite.addGuardian(testGuardianID)
+
+	suite.Equal(http.StatusCreated, w.Code)
+	suite.Contains(w.Body.String(), `"guardian_id":"`+testGuardianID+`"`)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *GuardianHandlerTestSuite) TestAddGuardian_InvalidGuardianID() {
+	w := suite.addGuardian("not-a-uuid")
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.mockRepo.AssertNotCalled(suite.T(), "AddGuardian", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *GuardianHandlerTestSuite) TestAddGuardian_Self() {
+	w := suite.addGuardian(testUserID)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.Contains(w.Body.String(), "a user cannot be their own guardian")
+}
+
+func (suite *GuardianHandlerTestSuite) TestAddGuardian_Errors() {
+	tests := []struct {
+		err    error
+		status int
+	}{
+		{sql.ErrNoRows, http.StatusNotFound},
+		{fmt.Errorf("%w: user %s", domain.ErrTooManyGuardians, testUserID), http.StatusConflict},
+		{fmt.Errorf("%w: user %s is a minor", domain.ErrGuardianNotAccepted, testGuardianID), http.StatusUnprocessableEntity},
+		{fmt.Errorf("connection refused"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		suite.SetupTest()
+		suite.mockRepo.On("AddGuardian", mock.Anything, testUserID, testGuardianID).Return(nil, tt.err)
+
+		w := suite.addGuardian(testGuardianID)
+
+		suite.Equal(tt.status, w.Code, tt.err.Error())
+	}
+}
+
+func (suite *GuardianHandlerTestSuite) TestGetGuardians_Success() {
+	suite.mockRepo.On("GetGuardians", mock.Anything, testUserID).Return([]domain.Guardianship{
+		{UserID: testUserID, GuardianID: testGuardianID, CreatedAt: "2025-04-13T09:00:00Z"},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/users/"+testUserID+"/guardians", nil)
+	req = mux.SetURLVars(req, map[string]string{"user_id": testUserID})
+	w := httptest.NewRecorder()
+
+	suite.handler.GetGuardians(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Contains(w.Body.String(), testGuardianID)
+	suite.NotContains(w.Body.String(), "ended_at")
+}
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/ashwingopalsamy/upvest-api/internal/middleware"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/repository"
+	"github.com/ashwingopalsamy/upvest-api/internal/util/writer"
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+)
+
+const maxInstrumentQueryLength = 100
+
+type InstrumentHandler struct {
+	repo    repository.InstrumentRepository
+	cursors *middleware.CursorCodec
+}
+
+func NewInstrumentHandler(repo repository.InstrumentRepository, cursors *middleware.CursorCodec) *InstrumentHandler {
+	return &InstrumentHandler{
+		repo:    repo,
+		cursors: cursors,
+	}
+}
+
+func (h *InstrumentHandler) GetAllInstruments(w http.ResponseWriter, r *http.Request) {
+	params := middleware.PagingParamsFromContext(r.Context())
+
+	filter, err := parseInstrumentFilter(r.URL.Query())
+	if err != nil {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, err.Error())
+		return
+	}
+
+	instruments, pageInfo, err := h.repo.GetAllInstruments(r.Context(), filter, params.Page())
+	if err != nil {
+		log.Error(err)
+		writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleDatabaseError, ErrMsgFailedToFetchInstruments)
+		return
+	}
+
+	var first, last repository.Cursor
+	if len(instruments) > 0 {
+		first = instrumentCursor(instruments[0], params.Sort)
+		last = instrumentCursor(instruments[len(instruments)-1], params.Sort)
+	}
+	meta := pageMeta(w, r, h.cursors, params, len(instruments), pageInfo, first, last)
+	meta["filter"] = filter
+
+	writer.WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"meta": meta,
+		"data": instruments,
+	})
+}
+
+func (h *InstrumentHandler) GetInstrumentByISIN(w http.ResponseWriter, r *http.Request) {
+	isin := strings.ToUpper(mux.Vars(r)["isin"])
+	if !domain.IsValidISIN(isin) {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgInvalidISIN)
+		return
+	}
+
+	instrument, err := h.repo.GetInstrumentByISIN(r.Context(), isin)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writer.WriteErrJSON(w, http.StatusNotFound, ErrTitleNotFound, ErrMsgInstrumentNotFound)
+		} else {
+			log.Error(err)
+			writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleDatabaseError, ErrMsgFailedToFetchInstrument)
+		}
+		return
+	}
+
+	writer.WriteJSON(w, http.StatusOK, instrument)
+}
+
+// parseInstrumentFilter reads the instrument search from the query string: q
+// matches an ISIN or WKN exactly or any part of the name; type, currency and
+// trading_status filter by value.
+func parseInstrumentFilter(query url.Values) (repository.InstrumentFilter, error) {
+	filter := repository.InstrumentFilter{
+		Query:         strings.TrimSpace(query.Get("q")),
+		Type:          strings.ToUpper(query.Get("type")),
+		Currency:      strings.ToUpper(query.Get("currency")),
+		TradingStatus: strings.ToUpper(query.Get("trading_status")),
+	}
+
+	if len(filter.Query) > maxInstrumentQueryLength {
+		return filter, fmt.Errorf("q must be at most %d characters", maxInstrumentQueryLength)
+	}
+	if filter.Type != "" && !domain.IsValidInstrumentType(filter.Type) {
+		return filter, fmt.Errorf("type %q is not a valid instrument type", filter.Type)
+	}
+	if filter.Currency != "" && !domain.IsValidCurrency(filter.Currency) {
+		return filter, fmt.Errorf("currency %q is not a valid ISO 4217 code", filter.Currency)
+	}
+	if filter.TradingStatus != "" && !domain.IsValidTradingStatus(filter.TradingStatus) {
+		return filter, fmt.Errorf("trading_status %q is not a valid trading status", filter.TradingStatus)
+	}
+	return filter, nil
+}
+
+// instrumentCursor marks the position of instrument in a list ordered by sort.
+func instrumentCursor(instrument domain.Instrument, sort string) repository.Cursor {
+	return sortCursor(instrument.ISIN, instrument.CreatedAt, instrument.UpdatedAt, sort)
+}
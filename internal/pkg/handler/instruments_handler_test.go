@@ -0,0 +1,127 @@
+package handler_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/ashwingopalsamy/upvest-api/internal/middleware"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/handler"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/repository"
+	"github.com/ashwingopalsamy/upvest-api/internal/util/mocks"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type InstrumentHandlerTestSuite struct {
+	suite.Suite
+	mockRepo *mocks.InstrumentRepository
+	cursors  *middleware.CursorCodec
+	handler  *handler.InstrumentHandler
+}
+
+func TestInstrumentHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(InstrumentHandlerTestSuite))
+}
+
+func (suite *InstrumentHandlerTestSuite) SetupTest() {
+	suite.mockRepo = new(mocks.InstrumentRepository)
+	suite.cursors = middleware.NewCursorCodec([]byte("test-secret"))
+	suite.handler = handler.NewInstrumentHandler(suite.mockRepo, suite.cursors)
+}
+
+func (suite *InstrumentHandlerTestSuite) getAllInstruments(target string) *http.Response {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	w := httptest.NewRecorder()
+
+	middleware.ExtractPagingParams(suite.cursors)(http.HandlerFunc(suite.handler.GetAllInstruments)).ServeHTTP(w, req)
+
+	return w.Result()
+}
+
+func (suite *InstrumentHandlerTestSuite) TestGetAllInstruments_Search() {
+	instruments := []domain.Instrument{
+		{ISIN: "IE00B4L5Y983", CreatedAt: "2025-01-01T00:00:00Z", Name: "iShares Core MSCI World UCITS ETF", Type: "ETF"},
+	}
+	suite.mockRepo.On("GetAllInstruments", mock.Anything,
+		repository.InstrumentFilter{Query: "msci world", Type: "ETF", Currency: "USD"},
+		repository.Page{Offset: 0, Limit: 1, Sort: "created_at", Order: "ASC"}).
+		Return(instruments, repository.PageInfo{HasNext: true}, nil)
+
+	res := suite.getAllInstruments("/instruments?q=msci+world&type=etf&currency=usd&limit=1")
+	defer res.Body.Close()
+
+	suite.Equal(http.StatusOK, res.StatusCode)
+	var resp struct {
+		Meta map[string]interface{} `json:"meta"`
+		Data []domain.Instrument    `json:"data"`
+	}
+	suite.NoError(json.NewDecoder(res.Body).Decode(&resp))
+	suite.Len(resp.Data, 1)
+	suite.Equal(map[string]interface{}{"q": "msci world", "type": "ETF", "currency": "USD"}, resp.Meta["filter"])
+
+	nextCursor, _ := resp.Meta["next_cursor"].(string)
+	_, _, cursor, err := suite.cursors.Decode(nextCursor)
+	suite.NoError(err)
+	suite.Equal("IE00B4L5Y983", cursor.ID)
+	suite.Contains(res.Header.Get("Link"), "q=msci+world")
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *InstrumentHandlerTestSuite) TestGetAllInstruments_InvalidFilter() {
+	for _, target := range []string{
+		"/instruments?type=CRYPTO",
+		"/instruments?currency=EURO",
+		"/instruments?trading_status=HALTED",
+	} {
+		res := suite.getAllInstruments(target)
+		res.Body.Close()
+
+		suite.Equal(http.StatusBadRequest, res.StatusCode, target)
+	}
+
+	suite.mockRepo.AssertNotCalled(suite.T(), "GetAllInstruments", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *InstrumentHandlerTestSuite) getInstrument(isin string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/instruments/"+isin, nil)
+	req = mux.SetURLVars(req, map[string]string{"isin": isin})
+	w := httptest.NewRecorder()
+
+	suite.handler.GetInstrumentByISIN(w, req)
+
+	return w
+}
+
+func (suite *InstrumentHandlerTestSuite) TestGetInstrumentByISIN_Success() {
+	instrument := &domain.Instrument{ISIN: "DE0007164600", WKN: "716460", Name: "SAP SE"}
+	suite.mockRepo.On("GetInstrumentByISIN", mock.Anything, "DE0007164600").Return(instrument, nil)
+
+	w := suite.getInstrument("de0007164600")
+
+	suite.Equal(http.StatusOK, w.Code)
+	var resp domain.Instrument
+	suite.NoError(json.NewDecoder(w.Body).Decode(&resp))
+	suite.Equal("716460", resp.WKN)
+}
+
+func (suite *InstrumentHandlerTestSuite) TestGetInstrumentByISIN_InvalidISIN() {
+	w := suite.getInstrument("DE0007164601")
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.mockRepo.AssertNotCalled(suite.T(), "GetInstrumentByISIN", mock.Anything, mock.Anything)
+}
+
+func (suite *InstrumentHandlerTestSuite) TestGetInstrumentByISIN_NotFound() {
+	suite.mockRepo.On("GetInstrumentByISIN", mock.Anything, "US0378331005").
+		Return(nil, fmt.Errorf("instrument not found: %w", sql.ErrNoRows))
+
+	w := suite.getInstrument("US0378331005")
+
+	suite.Equal(http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/repository"
+	"github.com/ashwingopalsamy/upvest-api/internal/util/writer"
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+)
+
+type LedgerHandler struct {
+	repo repository.LedgerRepository
+}
+
+func NewLedgerHandler(repo repository.LedgerRepository) *LedgerHandler {
+	return &LedgerHandler{repo: repo}
+}
+
+// CreateJournalEntry books a balanced set of postings. Amounts are integers in
+// the minor unit of their currency.
+func (h *LedgerHandler) CreateJournalEntry(w http.ResponseWriter, r *http.Request) {
+	var entry domain.JournalEntry
+	if err := json.NewDecoder(r.Body).Decode(&entry); err != nil {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgInvalidRequestBody)
+		return
+	}
+
+	if err := entry.Validate(); err != nil {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleValidationError, err.Error())
+		return
+	}
+
+	posted, err := h.repo.PostJournalEntry(r.Context(), &entry)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writer.WriteErrJSON(w, http.StatusNotFound, ErrTitleNotFound, ErrMsgUserNotFound)
+		} else if errors.Is(err, domain.ErrUserNotActive) {
+			writer.WriteErrJSON(w, http.StatusConflict, ErrTitleConflict, err.Error())
+		} else if errors.Is(err, domain.ErrInsufficientFunds) {
+			writer.WriteErrJSON(w, http.StatusUnprocessableEntity, ErrTitleUnprocessableEntity, err.Error())
+		} else {
+			log.Error(err)
+			writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleDatabaseError, ErrMsgPostJournalEntryFailed)
+		}
+		return
+	}
+
+	writer.WriteJSON(w, http.StatusCreated, posted)
+}
+
+// GetUserBalances returns the user's available, reserved and settled cash per
+// currency, in minor units.
+func (h *LedgerHandler) GetUserBalances(w http.ResponseWriter, r *http.Request) {
+	userID := mux.Vars(r)["user_id"]
+	if userID == "" {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgUserIDRequired)
+		return
+	}
+
+	balances, err := h.repo.GetUserBalances(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writer.WriteErrJSON(w, http.StatusNotFound, ErrTitleNotFound, ErrMsgUserNotFound)
+		} else {
+			log.Error(err)
+			writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleDatabaseError, ErrMsgFailedToFetchBalances)
+		}
+		return
+	}
+
+	writer.WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"data": balances,
+	})
+}
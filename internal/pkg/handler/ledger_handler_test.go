@@ -0,0 +1,126 @@
+package handler_test
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/handler"
+	"github.com/ashwingopalsamy/upvest-api/internal/util/mocks"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type LedgerHandlerTestSuite struct {
+	suite.Suite
+	mockRepo *mocks.LedgerRepository
+	handler  *handler.LedgerHandler
+}
+
+func TestLedgerHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(LedgerHandlerTestSuite))
+}
+
+func (suite *LedgerHandlerTestSuite) SetupTest() {
+	suite.mockRepo = new(mocks.LedgerRepository)
+	suite.handler = handler.NewLedgerHandler(suite.mockRepo)
+}
+
+func newDeposit(amount int64) domain.JournalEntry {
+	return domain.JournalEntry{
+		Description: "Deposit",
+		Postings: []domain.Posting{
+			{LedgerAccount: domain.LedgerAccountClearing, Currency: "EUR", Amount: -amount},
+			{LedgerAccount: domain.LedgerAccountUserAvailable, UserID: testUserID, Currency: "EUR", Amount: amount},
+		},
+	}
+}
+
+func (suite *LedgerHandlerTestSuite) createJournalEntry(entry domain.JournalEntry) *http.Response {
+	body, _ := json.Marshal(entry)
+	req := httptest.NewRequest(http.MethodPost, "/journal-entries", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	suite.handler.CreateJournalEntry(w, req)
+
+	return w.Result()
+}
+
+func (suite *LedgerHandlerTestSuite) TestCreateJournalEntry_Success() {
+	posted := newDeposit(10000)
+	posted.ID = "j1"
+	suite.mockRepo.On("PostJournalEntry", mock.Anything, mock.Anything).Return(&posted, nil)
+
+	res := suite.createJournalEntry(newDeposit(10000))
+	defer res.Body.Close()
+
+	suite.Equal(http.StatusCreated, res.StatusCode)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *LedgerHandlerTestSuite) TestCreateJournalEntry_Unbalanced() {
+	entry := newDeposit(10000)
+	entry.Postings[0].Amount = -9999
+
+	res := suite.createJournalEntry(entry)
+	defer res.Body.Close()
+
+	suite.Equal(http.StatusBadRequest, res.StatusCode)
+	suite.mockRepo.AssertNotCalled(suite.T(), "PostJournalEntry", mock.Anything, mock.Anything)
+}
+
+func (suite *LedgerHandlerTestSuite) TestCreateJournalEntry_UserOffboarded() {
+	suite.mockRepo.On("PostJournalEntry", mock.Anything, mock.Anything).
+		Return(nil, fmt.Errorf("%w: user %s is OFFBOARDED", domain.ErrUserNotActive, testUserID))
+
+	res := suite.createJournalEntry(newDeposit(10000))
+	defer res.Body.Close()
+
+	suite.Equal(http.StatusConflict, res.StatusCode)
+}
+
+func (suite *LedgerHandlerTestSuite) TestCreateJournalEntry_Overdraft() {
+	suite.mockRepo.On("PostJournalEntry", mock.Anything, mock.Anything).
+		Return(nil, fmt.Errorf("%w: user %s holds 0 EUR in USER_AVAILABLE", domain.ErrInsufficientFunds, testUserID))
+
+	res := suite.createJournalEntry(newDeposit(-10000))
+	defer res.Body.Close()
+
+	suite.Equal(http.StatusUnprocessableEntity, res.StatusCode)
+}
+
+func (suite *LedgerHandlerTestSuite) TestGetUserBalances() {
+	balances := []domain.Balance{{Currency: "EUR", Available: 7500, Reserved: 2500, Settled: 10000}}
+	suite.mockRepo.On("GetUserBalances", mock.Anything, testUserID).Return(balances, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/users/"+testUserID+"/balances", nil)
+	req = mux.SetURLVars(req, map[string]string{"user_id": testUserID})
+	w := httptest.NewRecorder()
+
+	suite.handler.GetUserBalances(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	var resp struct {
+		Data []domain.Balance `json:"data"`
+	}
+	suite.NoError(json.NewDecoder(w.Body).Decode(&resp))
+	suite.Equal(balances, resp.Data)
+}
+
+func (suite *LedgerHandlerTestSuite) TestGetUserBalances_UserNotFound() {
+	suite.mockRepo.On("GetUserBalances", mock.Anything, testUserID).Return(nil, fmt.Errorf("user not found: %w", sql.ErrNoRows))
+
+	req := httptest.NewRequest(http.MethodGet, "/users/"+testUserID+"/balances", nil)
+	req = mux.SetURLVars(req, map[string]string{"user_id": testUserID})
+	w := httptest.NewRecorder()
+
+	suite.handler.GetUserBalances(w, req)
+
+	suite.Equal(http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/ashwingopalsamy/upvest-api/internal/middleware"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/repository"
+	"github.com/ashwingopalsamy/upvest-api/internal/util/writer"
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+)
+
+type OrderHandler struct {
+	repo    repository.OrderRepository
+	cursors *middleware.CursorCodec
+}
+
+func NewOrderHandler(repo repository.OrderRepository, cursors *middleware.CursorCodec) *OrderHandler {
+	return &OrderHandler{
+		repo:    repo,
+		cursors: cursors,
+	}
+}
+
+// CreateOrder places a buy or sell order for an ACTIVE user. Orders the user,
+// account or instrument cannot accept are answered with 422.
+func (h *OrderHandler) CreateOrder(w http.ResponseWriter, r *http.Request) {
+	var order domain.Order
+	if err := json.NewDecoder(r.Body).Decode(&order); err != nil {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgInvalidRequestBody)
+		return
+	}
+	order.ISIN = strings.ToUpper(order.ISIN)
+
+	if err := order.Validate(); err != nil {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleValidationError, err.Error())
+		return
+	}
+
+	created, err := h.repo.CreateOrder(r.Context(), &order)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writer.WriteErrJSON(w, http.StatusNotFound, ErrTitleNotFound, ErrMsgUserNotFound)
+		} else if errors.Is(err, domain.ErrUserNotActive) {
+			writer.WriteErrJSON(w, http.StatusConflict, ErrTitleConflict, err.Error())
+		} else if errors.Is(err, domain.ErrOrderNotAccepted) {
+			writer.WriteErrJSON(w, http.StatusUnprocessableEntity, ErrTitleUnprocessableEntity, err.Error())
+		} else {
+			log.Error(err)
+			writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleDatabaseError, ErrMsgCreateOrderFailed)
+		}
+		return
+	}
+
+	writer.WriteJSON(w, http.StatusCreated, created)
+}
+
+func (h *OrderHandler) GetAllOrders(w http.ResponseWriter, r *http.Request) {
+	params := middleware.PagingParamsFromContext(r.Context())
+
+	filter, err := parseOrderFilter(r.URL.Query())
+	if err != nil {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, err.Error())
+		return
+	}
+
+	orders, pageInfo, err := h.repo.GetAllOrders(r.Context(), filter, params.Page())
+	if err != nil {
+		log.Error(err)
+		writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleDatabaseError, ErrMsgFailedToFetchOrders)
+		return
+	}
+
+	var first, last repository.Cursor
+	if len(orders) > 0 {
+		first = orderCursor(orders[0], params.Sort)
+		last = orderCursor(orders[len(orders)-1], params.Sort)
+	}
+	meta := pageMeta(w, r, h.cursors, params, len(orders), pageInfo, first, last)
+	meta["filter"] = filter
+
+	writer.WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"meta": meta,
+		"data": orders,
+	})
+}
+
+func (h *OrderHandler) GetOrderByID(w http.ResponseWriter, r *http.Request) {
+	orderID := mux.Vars(r)["order_id"]
+	if orderID == "" {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgOrderIDRequired)
+		return
+	}
+
+	order, err := h.repo.GetOrderByID(r.Context(), orderID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writer.WriteErrJSON(w, http.StatusNotFound, ErrTitleNotFound, ErrMsgOrderNotFound)
+		} else {
+			log.Error(err)
+			writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleDatabaseError, ErrMsgFailedToFetchOrder)
+		}
+		return
+	}
+
+	writer.WriteJSON(w, http.StatusOK, order)
+}
+
+// CancelOrder cancels an order that has not been filled, cancelled or rejected
+// yet and returns it in its new state.
+func (h *OrderHandler) CancelOrder(w http.ResponseWriter, r *http.Request) {
+	orderID := mux.Vars(r)["order_id"]
+	if orderID == "" {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgOrderIDRequired)
+		return
+	}
+
+	order, err := h.repo.CancelOrder(r.Context(), orderID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writer.WriteErrJSON(w, http.StatusNotFound, ErrTitleNotFound, ErrMsgOrderNotFound)
+		} else if errors.Is(err, domain.ErrIllegalOrderTransition) {
+			writer.WriteErrJSON(w, http.StatusConflict, ErrTitleConflict, err.Error())
+		} else {
+			log.Error(err)
+			writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleDatabaseError, ErrMsgCancelOrderFailed)
+		}
+		return
+	}
+
+	writer.WriteJSON(w, http.StatusOK, order)
+}
+
+// parseOrderFilter reads the order list filters user_id, account_id, isin and
+// one or more statuses, comma separated or repeated, from the query string.
+func parseOrderFilter(query url.Values) (repository.OrderFilter, error) {
+	filter := repository.OrderFilter{
+		UserID:    query.Get("user_id"),
+		AccountID: query.Get("account_id"),
+		ISIN:      strings.ToUpper(query.Get("isin")),
+	}
+	if filter.UserID != "" && !domain.IsValidUUID(filter.UserID) {
+		return filter, errors.New("user_id must be a valid UUID")
+	}
+	if filter.AccountID != "" && !domain.IsValidUUID(filter.AccountID) {
+		return filter, errors.New("account_id must be a valid UUID")
+	}
+	if filter.ISIN != "" && !domain.IsValidISIN(filter.ISIN) {
+		return filter, errors.New(ErrMsgInvalidISIN)
+	}
+	for _, value := range query["status"] {
+		for _, status := range strings.Split(value, ",") {
+			status = strings.ToUpper(strings.TrimSpace(status))
+			if status == "" {
+				continue
+			}
+			if !domain.IsValidOrderStatus(status) {
+				return filter, fmt.Errorf("status %q is not a valid order status", status)
+			}
+			filter.Statuses = append(filter.Statuses, status)
+		}
+	}
+	return filter, nil
+}
+
+// orderCursor marks the position of order in a list ordered by sort.
+func orderCursor(order domain.Order, sort string) repository.Cursor {
+	return sortCursor(order.ID, order.CreatedAt, order.UpdatedAt, sort)
+}
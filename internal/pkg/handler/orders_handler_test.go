@@ -0,0 +1,168 @@
+package handler_test
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/ashwingopalsamy/upvest-api/internal/middleware"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/handler"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/repository"
+	"github.com/ashwingopalsamy/upvest-api/internal/util/mocks"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+const testAccountID = "5d2e4c1a-8f3b-4e6d-a9c7-1b2f3e4d5c6a"
+
+type OrderHandlerTestSuite struct {
+	suite.Suite
+	mockRepo *mocks.OrderRepository
+	cursors  *middleware.CursorCodec
+	handler  *handler.OrderHandler
+}
+
+func TestOrderHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(OrderHandlerTestSuite))
+}
+
+func (suite *OrderHandlerTestSuite) SetupTest() {
+	suite.mockRepo = new(mocks.OrderRepository)
+	suite.cursors = middleware.NewCursorCodec([]byte("test-secret"))
+	suite.handler = handler.NewOrderHandler(suite.mockRepo, suite.cursors)
+}
+
+func newMarketOrder() domain.Order {
+	return domain.Order{
+		UserID:     testUserID,
+		AccountID:  testAccountID,
+		ISIN:       "de0007164600",
+		Side:       "BUY",
+		Type:       "MARKET",
+		CashAmount: "100.00",
+		Currency:   "EUR",
+	}
+}
+
+func (suite *OrderHandlerTestSuite) createOrder(order domain.Order) *http.Response {
+	body, _ := json.Marshal(order)
+	req := httptest.NewRequest(http.MethodPost, "/orders", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	suite.handler.CreateOrder(w, req)
+
+	return w.Result()
+}
+
+func (suite *OrderHandlerTestSuite) TestCreateOrder_Success() {
+	created := &domain.Order{ID: "o1", UserID: testUserID, ISIN: "DE0007164600", Status: "NEW"}
+	suite.mockRepo.On("CreateOrder", mock.Anything, mock.MatchedBy(func(o *domain.Order) bool {
+		return o.ISIN == "DE0007164600" && o.CashAmount == "100.00"
+	})).Return(created, nil)
+
+	res := suite.createOrder(newMarketOrder())
+	defer res.Body.Close()
+
+	suite.Equal(http.StatusCreated, res.StatusCode)
+	var resp domain.Order
+	suite.NoError(json.NewDecoder(res.Body).Decode(&resp))
+	suite.Equal("NEW", resp.Status)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *OrderHandlerTestSuite) TestCreateOrder_ValidationError() {
+	order := newMarketOrder()
+	order.Quantity = "1.5"
+
+	res := suite.createOrder(order)
+	defer res.Body.Close()
+
+	suite.Equal(http.StatusBadRequest, res.StatusCode)
+	suite.mockRepo.AssertNotCalled(suite.T(), "CreateOrder", mock.Anything, mock.Anything)
+}
+
+func (suite *OrderHandlerTestSuite) TestCreateOrder_Errors() {
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{sql.ErrNoRows, http.StatusNotFound},
+		{fmt.Errorf("%w: user %s is OFFBOARDING", domain.ErrUserNotActive, testUserID), http.StatusConflict},
+		{fmt.Errorf("%w: instrument DE0007164600 is SUSPENDED", domain.ErrOrderNotAccepted), http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		suite.mockRepo.On("CreateOrder", mock.Anything, mock.Anything).Return(nil, tt.err).Once()
+
+		res := suite.createOrder(newMarketOrder())
+		res.Body.Close()
+
+		suite.Equal(tt.expected, res.StatusCode, tt.err.Error())
+	}
+}
+
+func (suite *OrderHandlerTestSuite) TestGetAllOrders_Filter() {
+	orders := []domain.Order{{ID: "o1", UserID: testUserID, Status: "NEW"}}
+	suite.mockRepo.On("GetAllOrders", mock.Anything,
+		repository.OrderFilter{UserID: testUserID, Statuses: []string{"NEW", "PROCESSING"}},
+		repository.Page{Offset: 0, Limit: 100, Sort: "created_at", Order: "ASC"}).
+		Return(orders, repository.PageInfo{}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/orders?status=new,processing&user_id="+testUserID, nil)
+	w := httptest.NewRecorder()
+	middleware.ExtractPagingParams(suite.cursors)(http.HandlerFunc(suite.handler.GetAllOrders)).ServeHTTP(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	var resp struct {
+		Meta map[string]interface{} `json:"meta"`
+		Data []domain.Order         `json:"data"`
+	}
+	suite.NoError(json.NewDecoder(w.Body).Decode(&resp))
+	suite.Len(resp.Data, 1)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *OrderHandlerTestSuite) TestGetAllOrders_InvalidFilter() {
+	req := httptest.NewRequest(http.MethodGet, "/orders?status=OPEN", nil)
+	w := httptest.NewRecorder()
+	middleware.ExtractPagingParams(suite.cursors)(http.HandlerFunc(suite.handler.GetAllOrders)).ServeHTTP(w, req)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.mockRepo.AssertNotCalled(suite.T(), "GetAllOrders", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *OrderHandlerTestSuite) TestGetOrderByID_NotFound() {
+	suite.mockRepo.On("GetOrderByID", mock.Anything, "o1").Return(nil, fmt.Errorf("order not found: %w", sql.ErrNoRows))
+
+	req := httptest.NewRequest(http.MethodGet, "/orders/o1", nil)
+	req = mux.SetURLVars(req, map[string]string{"order_id": "o1"})
+	w := httptest.NewRecorder()
+
+	suite.handler.GetOrderByID(w, req)
+
+	suite.Equal(http.StatusNotFound, w.Code)
+}
+
+func (suite *OrderHandlerTestSuite) TestCancelOrder() {
+	suite.mockRepo.On("CancelOrder", mock.Anything, "o1").Return(&domain.Order{ID: "o1", Status: "CANCELLED"}, nil).Once()
+	suite.mockRepo.On("CancelOrder", mock.Anything, "o1").
+		Return(nil, fmt.Errorf("%w: CANCELLED -> CANCELLED", domain.ErrIllegalOrderTransition)).Once()
+	suite.mockRepo.On("CancelOrder", mock.Anything, "o1").Return(nil, sql.ErrNoRows).Once()
+
+	for _, expected := range []int{http.StatusOK, http.StatusConflict, http.StatusNotFound} {
+		req := httptest.NewRequest(http.MethodPost, "/orders/o1/cancel", nil)
+		req = mux.SetURLVars(req, map[string]string{"order_id": "o1"})
+		w := httptest.NewRecorder()
+
+		suite.handler.CancelOrder(w, req)
+
+		suite.Equal(expected, w.Code)
+	}
+	suite.mockRepo.AssertExpectations(suite.T())
+}
@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/middleware"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/repository"
+)
+
+// pageMeta describes a returned list page and sets its Link headers. first and
+// last are the positions of the page's first and last rows and are ignored for
+// an empty page.
+func pageMeta(w http.ResponseWriter, r *http.Request, cursors *middleware.CursorCodec, params middleware.PagingParams,
+	count int, info repository.PageInfo, first, last repository.Cursor) map[string]interface{} {
+	meta := map[string]interface{}{
+		"count": count,
+		"limit": params.Limit,
+		"sort":  params.Sort,
+		"order": params.Order,
+	}
+	if params.Cursor == nil {
+		meta["offset"] = params.Offset
+	}
+
+	var nextCursor, prevCursor string
+	if count > 0 {
+		if info.HasNext {
+			last.Backward = false
+			nextCursor = cursors.Encode(params.Sort, params.Order, last)
+			meta["next_cursor"] = nextCursor
+		}
+		if info.HasPrev {
+			first.Backward = true
+			prevCursor = cursors.Encode(params.Sort, params.Order, first)
+			meta["prev_cursor"] = prevCursor
+		}
+	}
+	setPageLinks(w, r, nextCursor, prevCursor)
+
+	return meta
+}
+
+// sortCursor marks the position of a row in a list ordered by sort.
+func sortCursor(id, createdAt, updatedAt, sort string) repository.Cursor {
+	sortValue := createdAt
+	if sort == "updated_at" {
+		sortValue = updatedAt
+	}
+	return repository.Cursor{SortValue: sortValue, ID: id}
+}
+
+// setPageLinks adds RFC 8288 Link headers pointing at the next and previous
+// pages. The links keep the request's query and swap its paging position for
+// the given cursors.
+func setPageLinks(w http.ResponseWriter, r *http.Request, nextCursor, prevCursor string) {
+	var links []string
+	for _, link := range []struct{ rel, cursor string }{{"next", nextCursor}, {"prev", prevCursor}} {
+		if link.cursor == "" {
+			continue
+		}
+
+		query := r.URL.Query()
+		query.Del("offset")
+		query.Del("sort")
+		query.Del("order")
+		query.Set("cursor", link.cursor)
+		links = append(links, "<"+r.URL.Path+"?"+query.Encode()+`>; rel="`+link.rel+`"`)
+	}
+
+	if len(links) > 0 {
+		w.Header().Set("Link", strings.Join(links, ", "))
+	}
+}
@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/repository"
+	"github.com/ashwingopalsamy/upvest-api/internal/util/writer"
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+)
+
+type ReferenceAccountHandler struct {
+	repo repository.ReferenceAccountRepository
+}
+
+func NewReferenceAccountHandler(repo repository.ReferenceAccountRepository) *ReferenceAccountHandler {
+	return &ReferenceAccountHandler{repo: repo}
+}
+
+// CreateReferenceAccount registers a bank account of the user as payout
+// destination. The account holder must match the user's name.
+func (h *ReferenceAccountHandler) CreateReferenceAccount(w http.ResponseWriter, r *http.Request) {
+	userID := mux.Vars(r)["user_id"]
+	if userID == "" {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgUserIDRequired)
+		return
+	}
+
+	var account domain.ReferenceAccount
+	if err := json.NewDecoder(r.Body).Decode(&account); err != nil {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgInvalidRequestBody)
+		return
+	}
+	account.UserID = userID
+	account.IBAN = domain.NormalizeIBAN(account.IBAN)
+	account.BIC = strings.ToUpper(strings.TrimSpace(account.BIC))
+	account.AccountHolder = strings.TrimSpace(account.AccountHolder)
+
+	if err := account.Validate(); err != nil {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleValidationError, err.Error())
+		return
+	}
+
+	added, err := h.repo.AddReferenceAccount(r.Context(), &account)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writer.WriteErrJSON(w, http.StatusNotFound, ErrTitleNotFound, ErrMsgUserNotFound)
+		} else if errors.Is(err, domain.ErrUserNotActive) {
+			writer.WriteErrJSON(w, http.StatusConflict, ErrTitleConflict, err.Error())
+		} else if errors.Is(err, domain.ErrHolderNameMismatch) {
+			writer.WriteErrJSON(w, http.StatusUnprocessableEntity, ErrTitleUnprocessableEntity, err.Error())
+		} else {
+			log.Error(err)
+			writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleDatabaseError, ErrMsgAddReferenceAccountFailed)
+		}
+		return
+	}
+
+	writer.WriteJSON(w, http.StatusCreated, added)
+}
+
+func (h *ReferenceAccountHandler) GetReferenceAccounts(w http.ResponseWriter, r *http.Request) {
+	userID := mux.Vars(r)["user_id"]
+	if userID == "" {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgUserIDRequired)
+		return
+	}
+
+	accounts, err := h.repo.GetReferenceAccounts(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writer.WriteErrJSON(w, http.StatusNotFound, ErrTitleNotFound, ErrMsgUserNotFound)
+		} else {
+			log.Error(err)
+			writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleDatabaseError, ErrMsgFailedToFetchReferenceAccounts)
+		}
+		return
+	}
+
+	writer.WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"data": accounts,
+	})
+}
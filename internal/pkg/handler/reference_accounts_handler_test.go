@@ -0,0 +1,74 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/handler"
+	"github.com/ashwingopalsamy/upvest-api/internal/util/mocks"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ReferenceAccountHandlerTestSuite struct {
+	suite.Suite
+	mockRepo *mocks.ReferenceAccountRepository
+	handler  *handler.ReferenceAccountHandler
+}
+
+func TestReferenceAccountHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(ReferenceAccountHandlerTestSuite))
+}
+
+func (suite *ReferenceAccountHandlerTestSuite) SetupTest() {
+	suite.mockRepo = new(mocks.ReferenceAccountRepository)
+	suite.handler = handler.NewReferenceAccountHandler(suite.mockRepo)
+}
+
+func (suite *ReferenceAccountHandlerTestSuite) createReferenceAccount(body map[string]string) *httptest.ResponseRecorder {
+	raw, _ := json.Marshal(body)
+	req := httptest.NewRequest(http.MethodPost, "/users/"+testUserID+"/reference_accounts", bytes.NewReader(raw))
+	req = mux.SetURLVars(req, map[string]string{"user_id": testUserID})
+	w := httptest.NewRecorder()
+
+	suite.handler.CreateReferenceAccount(w, req)
+
+	return w
+}
+
+func (suite *ReferenceAccountHandlerTestSuite) TestCreateReferenceAccount_Success() {
+	suite.mockRepo.On("AddReferenceAccount", mock.Anything, mock.MatchedBy(func(a *domain.ReferenceAccount) bool {
+		return a.UserID == testUserID && a.IBAN == "DE89370400440532013000" && a.BIC == "COBADEFFXXX"
+	})).Return(&domain.ReferenceAccount{ID: "r1", UserID: testUserID}, nil)
+
+	w := suite.createReferenceAccount(map[string]string{
+		"iban": "de89 3704 0044 0532 0130 00", "bic": "cobadeffxxx", "account_holder": "Rob Smith",
+	})
+
+	suite.Equal(http.StatusCreated, w.Code)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *ReferenceAccountHandlerTestSuite) TestCreateReferenceAccount_InvalidIBAN() {
+	w := suite.createReferenceAccount(map[string]string{
+		"iban": "DE89370400440532013001", "bic": "COBADEFFXXX", "account_holder": "Rob Smith",
+	})
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.mockRepo.AssertNotCalled(suite.T(), "AddReferenceAccount", mock.Anything, mock.Anything)
+}
+
+func (suite *ReferenceAccountHandlerTestSuite) TestCreateReferenceAccount_HolderMismatch() {
+	suite.mockRepo.On("AddReferenceAccount", mock.Anything, mock.Anything).Return(nil, domain.ErrHolderNameMismatch)
+
+	w := suite.createReferenceAccount(map[string]string{
+		"iban": "DE89370400440532013000", "bic": "COBADEFFXXX", "account_holder": "Jane Doe",
+	})
+
+	suite.Equal(http.StatusUnprocessableEntity, w.Code)
+}
@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/repository"
+	"github.com/ashwingopalsamy/upvest-api/internal/util/writer"
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+)
+
+type SavingsPlanHandler struct {
+	repo repository.SavingsPlanRepository
+}
+
+func NewSavingsPlanHandler(repo repository.SavingsPlanRepository) *SavingsPlanHandler {
+	return &SavingsPlanHandler{repo: repo}
+}
+
+// CreateSavingsPlan sets up a recurring investment for the user in the path.
+func (h *SavingsPlanHandler) CreateSavingsPlan(w http.ResponseWriter, r *http.Request) {
+	userID := mux.Vars(r)["user_id"]
+	if userID == "" {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgUserIDRequired)
+		return
+	}
+
+	var plan domain.SavingsPlan
+	if err := json.NewDecoder(r.Body).Decode(&plan); err != nil {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgInvalidRequestBody)
+		return
+	}
+	plan.UserID = userID
+
+	if err := plan.Validate(); err != nil {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleValidationError, err.Error())
+		return
+	}
+
+	created, err := h.repo.CreateSavingsPlan(r.Context(), &plan)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writer.WriteErrJSON(w, http.StatusNotFound, ErrTitleNotFound, ErrMsgUserNotFound)
+		} else if errors.Is(err, domain.ErrUserNotActive) {
+			writer.WriteErrJSON(w, http.StatusConflict, ErrTitleConflict, err.Error())
+		} else if errors.Is(err, domain.ErrSavingsPlanNotAccepted) {
+			writer.WriteErrJSON(w, http.StatusUnprocessableEntity, ErrTitleUnprocessableEntity, err.Error())
+		} else {
+			log.Error(err)
+			writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleDatabaseError, ErrMsgCreateSavingsPlanFailed)
+		}
+		return
+	}
+
+	writer.WriteJSON(w, http.StatusCreated, created)
+}
+
+func (h *SavingsPlanHandler) GetSavingsPlans(w http.ResponseWriter, r *http.Request) {
+	userID := mux.Vars(r)["user_id"]
+	if userID == "" {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgUserIDRequired)
+		return
+	}
+
+	plans, err := h.repo.GetSavingsPlans(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writer.WriteErrJSON(w, http.StatusNotFound, ErrTitleNotFound, ErrMsgUserNotFound)
+		} else {
+			log.Error(err)
+			writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleDatabaseError, ErrMsgFailedToFetchSavingsPlans)
+		}
+		return
+	}
+
+	writer.WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"data": plans,
+	})
+}
+
+func (h *SavingsPlanHandler) GetSavingsPlanByID(w http.ResponseWriter, r *http.Request) {
+	planID := mux.Vars(r)["savings_plan_id"]
+	if planID == "" {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgSavingsPlanIDRequired)
+		return
+	}
+
+	plan, err := h.repo.GetSavingsPlanByID(r.Context(), planID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writer.WriteErrJSON(w, http.StatusNotFound, ErrTitleNotFound, ErrMsgSavingsPlanNotFound)
+		} else {
+			log.Error(err)
+			writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleDatabaseError, ErrMsgFailedToFetchSavingsPlan)
+		}
+		return
+	}
+
+	writer.WriteJSON(w, http.StatusOK, plan)
+}
+
+// GetSavingsPlanExecutions lists the runs of a plan, most recent first.
+func (h *SavingsPlanHandler) GetSavingsPlanExecutions(w http.ResponseWriter, r *http.Request) {
+	planID := mux.Vars(r)["savings_plan_id"]
+	if planID == "" {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgSavingsPlanIDRequired)
+		return
+	}
+
+	executions, err := h.repo.GetSavingsPlanExecutions(r.Context(), planID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writer.WriteErrJSON(w, http.StatusNotFound, ErrTitleNotFound, ErrMsgSavingsPlanNotFound)
+		} else {
+			log.Error(err)
+			writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleDatabaseError, ErrMsgFailedToFetchExecutions)
+		}
+		return
+	}
+
+	writer.WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"data": executions,
+	})
+}
+
+func (h *SavingsPlanHandler) PauseSavingsPlan(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.repo.PauseSavingsPlan)
+}
+
+func (h *SavingsPlanHandler) ResumeSavingsPlan(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.repo.ResumeSavingsPlan)
+}
+
+func (h *SavingsPlanHandler) CancelSavingsPlan(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.repo.CancelSavingsPlan)
+}
+
+func (h *SavingsPlanHandler) changeStatus(w http.ResponseWriter, r *http.Request,
+	change func(ctx context.Context, planID string) (*domain.SavingsPlan, error)) {
+	planID := mux.Vars(r)["savings_plan_id"]
+	if planID == "" {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgSavingsPlanIDRequired)
+		return
+	}
+
+	plan, err := change(r.Context(), planID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writer.WriteErrJSON(w, http.StatusNotFound, ErrTitleNotFound, ErrMsgSavingsPlanNotFound)
+		} else if errors.Is(err, domain.ErrIllegalSavingsPlanTransition) {
+			writer.WriteErrJSON(w, http.StatusConflict, ErrTitleConflict, err.Error())
+		} else {
+			log.Error(err)
+			writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleDatabaseError, ErrMsgChangeSavingsPlanFailed)
+		}
+		return
+	}
+
+	writer.WriteJSON(w, http.StatusOK, plan)
+}
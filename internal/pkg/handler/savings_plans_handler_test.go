@@ -0,0 +1,98 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/handler"
+	"github.com/ashwingopalsamy/upvest-api/internal/util/mocks"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type SavingsPlanHandlerTestSuite struct {
+	suite.Suite
+	mockRepo *mocks.SavingsPlanRepository
+	handler  *handler.SavingsPlanHandler
+}
+
+func TestSavingsPlanHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(SavingsPlanHandlerTestSuite))
+}
+
+func (suite *SavingsPlanHandlerTestSuite) SetupTest() {
+	suite.mockRepo = new(mocks.SavingsPlanRepository)
+	suite.handler = handler.NewSavingsPlanHandler(suite.mockRepo)
+}
+
+func (suite *SavingsPlanHandlerTestSuite) createSavingsPlan(plan domain.SavingsPlan) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(plan)
+	req := httptest.NewRequest(http.MethodPost, "/users/"+testUserID+"/savings_plans", bytes.NewReader(body))
+	req = mux.SetURLVars(req, map[string]string{"user_id": testUserID})
+	w := httptest.NewRecorder()
+
+	suite.handler.CreateSavingsPlan(w, req)
+
+	return w
+}
+
+func newSavingsPlanRequest() domain.SavingsPlan {
+	return domain.SavingsPlan{
+		AccountID: testAccountID,
+		ISIN:      "IE00B4L5Y983",
+		Amount:    "50.00",
+		Currency:  "EUR",
+		Cadence:   domain.SavingsPlanCadenceMonthly,
+		StartDate: "2025-04-01",
+	}
+}
+
+func (suite *SavingsPlanHandlerTestSuite) TestCreateSavingsPlan_Success() {
+	suite.mockRepo.On("CreateSavingsPlan", mock.Anything, mock.MatchedBy(func(p *domain.SavingsPlan) bool {
+		return p.UserID == testUserID && p.ISIN == "IE00B4L5Y983"
+	})).Return(&domain.SavingsPlan{ID: "sp1", Status: "ACTIVE", NextExecutionDate: "2025-04-01"}, nil)
+
+	w := suite.createSavingsPlan(newSavingsPlanRequest())
+
+	suite.Equal(http.StatusCreated, w.Code)
+	suite.Contains(w.Body.String(), `"next_execution_date":"2025-04-01"`)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *SavingsPlanHandlerTestSuite) TestCreateSavingsPlan_Errors() {
+	invalid := newSavingsPlanRequest()
+	invalid.Cadence = "DAILY"
+	suite.Equal(http.StatusBadRequest, suite.createSavingsPlan(invalid).Code)
+
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{fmt.Errorf("%w: user is INACTIVE", domain.ErrUserNotActive), http.StatusConflict},
+		{fmt.Errorf("%w: instrument is SUSPENDED", domain.ErrSavingsPlanNotAccepted), http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		suite.mockRepo.On("CreateSavingsPlan", mock.Anything, mock.Anything).Return(nil, tt.err).Once()
+
+		suite.Equal(tt.expected, suite.createSavingsPlan(newSavingsPlanRequest()).Code, tt.err.Error())
+	}
+}
+
+func (suite *SavingsPlanHandlerTestSuite) TestPauseSavingsPlan_AlreadyCancelled() {
+	suite.mockRepo.On("PauseSavingsPlan", mock.Anything, "sp1").
+		Return(nil, fmt.Errorf("%w: CANCELLED to PAUSED", domain.ErrIllegalSavingsPlanTransition))
+
+	req := httptest.NewRequest(http.MethodPost, "/savings_plans/sp1/pause", nil)
+	req = mux.SetURLVars(req, map[string]string{"savings_plan_id": "sp1"})
+	w := httptest.NewRecorder()
+
+	suite.handler.PauseSavingsPlan(w, req)
+
+	suite.Equal(http.StatusConflict, w.Code)
+}
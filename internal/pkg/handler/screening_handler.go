@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/repository"
+	"github.com/ashwingopalsamy/upvest-api/internal/util/writer"
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+)
+
+type ScreeningHandler struct {
+	repo repository.ScreeningRepository
+}
+
+func NewScreeningHandler(repo repository.ScreeningRepository) *ScreeningHandler {
+	return &ScreeningHandler{repo: repo}
+}
+
+// GetScreeningHits returns the sanctions and PEP list hits of a user, the
+// latest first.
+func (h *ScreeningHandler) GetScreeningHits(w http.ResponseWriter, r *http.Request) {
+	userID := mux.Vars(r)["user_id"]
+	if userID == "" {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgUserIDRequired)
+		return
+	}
+
+	hits, err := h.repo.GetHits(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writer.WriteErrJSON(w, http.StatusNotFound, ErrTitleNotFound, ErrMsgUserNotFound)
+		} else {
+			log.Error(err)
+			writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleDatabaseError, ErrMsgFailedToFetchScreeningHits)
+		}
+		return
+	}
+
+	writer.WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"data": hits,
+	})
+}
+
+// ReviewScreeningHit records the decision of compliance on a pending hit. The
+// user is released once no hit is pending or confirmed any more.
+func (h *ScreeningHandler) ReviewScreeningHit(w http.ResponseWriter, r *http.Request) {
+	hitID := mux.Vars(r)["screening_hit_id"]
+	if hitID == "" {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgScreeningHitIDRequired)
+		return
+	}
+
+	var review domain.ScreeningReview
+	if err := json.NewDecoder(r.Body).Decode(&review); err != nil {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgInvalidRequestBody)
+		return
+	}
+	if err := review.Validate(); err != nil {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleValidationError, err.Error())
+		return
+	}
+
+	hit, err := h.repo.ReviewHit(r.Context(), hitID, review.Decision)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writer.WriteErrJSON(w, http.StatusNotFound, ErrTitleNotFound, ErrMsgScreeningHitNotFound)
+		} else if errors.Is(err, domain.ErrScreeningHitReviewed) {
+			writer.WriteErrJSON(w, http.StatusConflict, ErrTitleConflict, err.Error())
+		} else {
+			log.Error(err)
+			writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleDatabaseError, ErrMsgReviewScreeningHitFailed)
+		}
+		return
+	}
+
+	writer.WriteJSON(w, http.StatusOK, hit)
+}
@@ -0,0 +1,109 @@
+package handler_test
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/handler"
+	"github.com/ashwingopalsamy/upvest-api/internal/util/mocks"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+const testScreeningHitID = "3e2a6c1d-7b4f-4e8a-9c5d-1f0b2a3c4d5e"
+
+type ScreeningHandlerTestSuite struct {
+	suite.Suite
+	mockRepo *mocks.ScreeningRepository
+	handler  *handler.ScreeningHandler
+}
+
+func TestScreeningHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(ScreeningHandlerTestSuite))
+}
+
+func (suite *ScreeningHandlerTestSuite) SetupTest() {
+	suite.mockRepo = new(mocks.ScreeningRepository)
+	suite.handler = handler.NewScreeningHandler(suite.mockRepo)
+}
+
+func (suite *ScreeningHandlerTestSuite) getHits() *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/users/"+testUserID+"/screening_hits", nil)
+	req = mux.SetURLVars(req, map[string]string{"user_id": testUserID})
+	w := httptest.NewRecorder()
+
+	suite.handler.GetScreeningHits(w, req)
+
+	return w
+}
+
+func (suite *ScreeningHandlerTestSuite) reviewHit(decision string) *httptest.ResponseRecorder {
+	body := fmt.Sprintf(`{"decision": %q}`, decision)
+	req := httptest.NewRequest(http.MethodPost, "/screening_hits/"+testScreeningHitID+"/review", strings.NewReader(body))
+	req = mux.SetURLVars(req, map[string]string{"screening_hit_id": testScreeningHitID})
+	w := httptest.NewRecorder()
+
+	suite.handler.ReviewScreeningHit(w, req)
+
+	return w
+}
+
+func (suite *ScreeningHandlerTestSuite) TestGetScreeningHits_Success() {
+	suite.mockRepo.On("GetHits", mock.Anything, testUserID).
+		Return([]domain.ScreeningHit{{ID: testScreeningHitID, UserID: testUserID, EntryID: "13", Status: "PENDING"}}, nil)
+
+	w := suite.getHits()
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Contains(w.Body.String(), `"entry_id":"13"`)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *ScreeningHandlerTestSuite) TestGetScreeningHits_UserNotFound() {
+	suite.mockRepo.On("GetHits", mock.Anything, testUserID).Return(nil, sql.ErrNoRows)
+
+	w := suite.getHits()
+
+	suite.Equal(http.StatusNotFound, w.Code)
+}
+
+func (suite *ScreeningHandlerTestSuite) TestReviewScreeningHit_Success() {
+	suite.mockRepo.On("ReviewHit", mock.Anything, testScreeningHitID, "DISMISSED").
+		Return(&domain.ScreeningHit{ID: testScreeningHitID, Status: "DISMISSED"}, nil)
+
+	w := suite.reviewHit("DISMISSED")
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Contains(w.Body.String(), `"status":"DISMISSED"`)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *ScreeningHandlerTestSuite) TestReviewScreeningHit_InvalidDecision() {
+	w := suite.reviewHit("PENDING")
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.mockRepo.AssertNotCalled(suite.T(), "ReviewHit", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *ScreeningHandlerTestSuite) TestReviewScreeningHit_NotFound() {
+	suite.mockRepo.On("ReviewHit", mock.Anything, testScreeningHitID, "CONFIRMED").Return(nil, sql.ErrNoRows)
+
+	w := suite.reviewHit("CONFIRMED")
+
+	suite.Equal(http.StatusNotFound, w.Code)
+}
+
+func (suite *ScreeningHandlerTestSuite) TestReviewScreeningHit_AlreadyReviewed() {
+	suite.mockRepo.On("ReviewHit", mock.Anything, testScreeningHitID, "CONFIRMED").
+		Return(nil, fmt.Errorf("%w: %s", domain.ErrScreeningHitReviewed, testScreeningHitID))
+
+	w := suite.reviewHit("CONFIRMED")
+
+	suite.Equal(http.StatusConflict, w.Code)
+}
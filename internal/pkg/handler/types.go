@@ -2,15 +2,108 @@ package handler
 
 const (
 	// Error Titles
-	ErrTitleDatabaseError   = "Database Error"
-	ErrTitleInvalidRequest  = "Invalid Request"
-	ErrTitleKafkaError      = "Kafka Error"
-	ErrTitleValidationError = "Validation Error"
+	ErrTitleConflict             = "Conflict"
+	ErrTitleDatabaseError        = "Database Error"
+	ErrTitleInternalError        = "Internal Error"
+	ErrTitleInvalidRequest       = "Invalid Request"
+	ErrTitleNotFound             = "Not Found"
+	ErrTitleUnprocessableEntity  = "Unprocessable Entity"
+	ErrTitleUnsupportedMediaType = "Unsupported Media Type"
+	ErrTitleValidationError      = "Validation Error"
 
 	// Error Messages
-	ErrMsgCreateUserFailed   = "failed to create user"
-	ErrMsgFailedToFetchUsers = "failed to fetch users"
-	ErrMsgEmitEventFailed    = "failed to emit user creation event"
-	ErrMsgInvalidRequestBody = "request body could not be parsed"
-	ErrMsgMarshalEventFailed = "failed to marshal event"
+	ErrMsgAccountIDRequired              = "account_id is required"
+	ErrMsgAccountNotFound                = "account does not exist"
+	ErrMsgAddGuardianFailed              = "failed to add guardian"
+	ErrMsgAddReferenceAccountFailed      = "failed to add reference account"
+	ErrMsgAssessAppropriatenessFailed    = "failed to assess appropriateness"
+	ErrMsgAssignFeeSegmentFailed         = "failed to assign fee segment"
+	ErrMsgBusinessIDRequired             = "business_id is required"
+	ErrMsgBusinessNotFound               = "business does not exist"
+	ErrMsgCancelOrderFailed              = "failed to cancel order"
+	ErrMsgChangeSavingsPlanFailed        = "failed to change savings plan"
+	ErrMsgChargeFeeFailed                = "failed to charge fee"
+	ErrMsgCloseAccountFailed             = "failed to close account"
+	ErrMsgCompleteWithdrawalFailed       = "failed to complete withdrawal"
+	ErrMsgCreateBusinessFailed           = "failed to create business"
+	ErrMsgCreateOrderFailed              = "failed to create order"
+	ErrMsgCreateSavingsPlanFailed        = "failed to create savings plan"
+	ErrMsgCreateUserFailed               = "failed to create user"
+	ErrMsgCreateWithdrawalFailed         = "failed to create withdrawal"
+	ErrMsgDocumentFileRequired           = "file is required"
+	ErrMsgDocumentIDRequired             = "document_id is required"
+	ErrMsgDocumentNotFound               = "document does not exist"
+	ErrMsgExportIDRequired               = "export_id is required"
+	ErrMsgExportNotFound                 = "withdrawal export does not exist"
+	ErrMsgExportWithdrawalsFailed        = "failed to export withdrawals"
+	ErrMsgFailedToFetchAccount           = "failed to fetch account"
+	ErrMsgFailedToFetchAccounts          = "failed to fetch accounts"
+	ErrMsgFailedToFetchAssessments       = "failed to fetch appropriateness assessments"
+	ErrMsgFailedToFetchBalances          = "failed to fetch balances"
+	ErrMsgFailedToFetchBusiness          = "failed to fetch business"
+	ErrMsgFailedToFetchDocument          = "failed to fetch document"
+	ErrMsgFailedToFetchDocuments         = "failed to fetch documents"
+	ErrMsgFailedToFetchExecutions        = "failed to fetch savings plan executions"
+	ErrMsgFailedToFetchExport            = "failed to fetch withdrawal export"
+	ErrMsgFailedToFetchFeeSchedules      = "failed to fetch fee schedules"
+	ErrMsgFailedToFetchFees              = "failed to fetch fees"
+	ErrMsgFailedToFetchGuardians         = "failed to fetch guardians"
+	ErrMsgFailedToFetchInstrument        = "failed to fetch instrument"
+	ErrMsgFailedToFetchInstruments       = "failed to fetch instruments"
+	ErrMsgFailedToFetchOrder             = "failed to fetch order"
+	ErrMsgFailedToFetchOrders            = "failed to fetch orders"
+	ErrMsgFailedToFetchReferenceAccounts = "failed to fetch reference accounts"
+	ErrMsgFailedToFetchSavingsPlan       = "failed to fetch savings plan"
+	ErrMsgFailedToFetchSavingsPlans      = "failed to fetch savings plans"
+	ErrMsgFailedToFetchScreeningHits     = "failed to fetch screening hits"
+	ErrMsgFailedToFetchUser              = "failed to fetch user"
+	ErrMsgFailedToFetchUsers             = "failed to fetch users"
+	ErrMsgFailedToFetchWithdrawal        = "failed to fetch withdrawal"
+	ErrMsgInstrumentNotFound             = "instrument does not exist"
+	ErrMsgInvalidFieldType               = "one or more fields have an invalid type"
+	ErrMsgInvalidISIN                    = "isin must be a valid ISIN"
+	ErrMsgInvalidMultipartForm           = "request body must be a multipart form with a file of at most 10 MiB"
+	ErrMsgInvalidRequestBody             = "request body could not be parsed"
+	ErrMsgMergePatchRequired             = "request body must be a JSON merge patch (application/merge-patch+json)"
+	ErrMsgNoAppropriatenessAssessment    = "user has no appropriateness assessment"
+	ErrMsgOpenAccountFailed              = "failed to open account"
+	ErrMsgOrderIDRequired                = "order_id is required"
+	ErrMsgOrderNotFound                  = "order does not exist"
+	ErrMsgPayoutDebtorNotConfigured      = "payout debtor account is not configured"
+	ErrMsgPostJournalEntryFailed         = "failed to post journal entry"
+	ErrMsgQuestionnaireNotFound          = "questionnaire version does not exist"
+	ErrMsgReviewScreeningHitFailed       = "failed to review screening hit"
+	ErrMsgSavingsPlanIDRequired          = "savings_plan_id is required"
+	ErrMsgSavingsPlanNotFound            = "savings plan does not exist"
+	ErrMsgScanDocumentFailed             = "failed to scan document"
+	ErrMsgScreeningHitIDRequired         = "screening_hit_id is required"
+	ErrMsgScreeningHitNotFound           = "screening hit does not exist"
+	ErrMsgSetFeeScheduleFailed           = "failed to set fee schedule"
+	ErrMsgUpdateUserFailed               = "failed to update user"
+	ErrMsgUploadDocumentFailed           = "failed to upload document"
+	ErrMsgUserIDRequired                 = "user_id is required"
+	ErrMsgUserModified                   = "user was modified by another request, retry the update"
+	ErrMsgUserNotFound                   = "user does not exist"
+	ErrMsgWithdrawalIDRequired           = "withdrawal_id is required"
+	ErrMsgWithdrawalNotFound             = "withdrawal does not exist"
 )
+
+const mergePatchMediaType = "application/merge-patch+json"
+
+// userReadOnlyFields are managed by the service and cannot be patched by clients.
+var userReadOnlyFields = map[string]struct{}{
+	"id":         {},
+	"created_at": {},
+	"updated_at": {},
+	"status":     {},
+	"fatca":      {},
+}
+
+// screenedUserFields are the fields users are screened on; changing any of them
+// screens the user again.
+var screenedUserFields = map[string]struct{}{
+	"first_name": {},
+	"last_name":  {},
+	"birth_name": {},
+	"birth_date": {},
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/repository"
+)
+
+const maxNameFilterLength = 100
+
+// parseUserFilter reads the user list filters from the query string:
+//
+//	status        one or more statuses, comma separated or repeated
+//	birth_country ISO 3166-1 alpha-2 code
+//	nationality   ISO 3166-1 alpha-2 code contained in the user's nationalities
+//	created_from  inclusive lower bound on created_at (RFC 3339 or YYYY-MM-DD)
+//	created_to    exclusive upper bound on created_at (RFC 3339 or YYYY-MM-DD)
+//	name          case-insensitive substring of "first_name last_name"
+func parseUserFilter(query url.Values) (repository.UserFilter, error) {
+	var filter repository.UserFilter
+
+	for _, value := range query["status"] {
+		for _, status := range strings.Split(value, ",") {
+			status = strings.ToUpper(strings.TrimSpace(status))
+			if status == "" {
+				continue
+			}
+			if !domain.IsValidUserStatus(status) {
+				return filter, fmt.Errorf("status %q is not a valid user status", status)
+			}
+			filter.Statuses = append(filter.Statuses, status)
+		}
+	}
+
+	if country := query.Get("birth_country"); country != "" {
+		country = strings.ToUpper(country)
+		if !domain.IsValidCountry(country) {
+			return filter, fmt.Errorf("birth_country %q is not a valid ISO 3166-1 alpha-2 code", country)
+		}
+		filter.BirthCountry = country
+	}
+
+	if nationality := query.Get("nationality"); nationality != "" {
+		nationality = strings.ToUpper(nationality)
+		if !domain.IsValidCountry(nationality) {
+			return filter, fmt.Errorf("nationality %q is not a valid ISO 3166-1 alpha-2 code", nationality)
+		}
+		filter.Nationality = nationality
+	}
+
+	var err error
+	if filter.CreatedAfter, err = parseTimeFilter(query, "created_from"); err != nil {
+		return filter, err
+	}
+	if filter.CreatedBefore, err = parseTimeFilter(query, "created_to"); err != nil {
+		return filter, err
+	}
+	if filter.CreatedAfter != nil && filter.CreatedBefore != nil && !filter.CreatedAfter.Before(*filter.CreatedBefore) {
+		return filter, fmt.Errorf("created_from must be before created_to")
+	}
+
+	if name := strings.TrimSpace(query.Get("name")); name != "" {
+		if utf8.RuneCountInString(name) > maxNameFilterLength {
+			return filter, fmt.Errorf("name must be at most %d characters", maxNameFilterLength)
+		}
+		filter.Name = name
+	}
+
+	return filter, nil
+}
+
+// parseTimeFilter parses an RFC 3339 timestamp or a date, which stands for
+// midnight UTC.
+func parseTimeFilter(query url.Values, param string) (*time.Time, error) {
+	value := query.Get(param)
+	if value == "" {
+		return nil, nil
+	}
+
+	for _, layout := range []string{time.RFC3339Nano, time.DateOnly} {
+		if t, err := time.Parse(layout, value); err == nil {
+			t = t.UTC()
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("%s must be an RFC 3339 timestamp or a date in YYYY-MM-DD format", param)
+}
@@ -4,26 +4,31 @@ import (
 	"database/sql"
 	"encoding/json"
 	"errors"
+	"io"
+	"mime"
 	"net/http"
-	"strconv"
 
 	"github.com/ashwingopalsamy/upvest-api/internal/domain"
-	"github.com/ashwingopalsamy/upvest-api/internal/event"
+	"github.com/ashwingopalsamy/upvest-api/internal/middleware"
 	"github.com/ashwingopalsamy/upvest-api/internal/pkg/repository"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/screening"
+	"github.com/ashwingopalsamy/upvest-api/internal/util/mergepatch"
 	"github.com/ashwingopalsamy/upvest-api/internal/util/writer"
 	"github.com/gorilla/mux"
 	log "github.com/sirupsen/logrus"
 )
 
 type UserHandler struct {
-	repo      repository.UserRepository
-	publisher event.PublisherInterface
+	repo     repository.UserRepository
+	cursors  *middleware.CursorCodec
+	screener *screening.Screener
 }
 
-func NewUserHandler(repo repository.UserRepository, publisher event.PublisherInterface) *UserHandler {
+func NewUserHandler(repo repository.UserRepository, cursors *middleware.CursorCodec, screener *screening.Screener) *UserHandler {
 	return &UserHandler{
-		repo:      repo,
-		publisher: publisher,
+		repo:     repo,
+		cursors:  cursors,
+		screener: screener,
 	}
 }
 
@@ -39,29 +44,17 @@ func (h *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	createdUser, err := h.repo.CreateUser(r.Context(), &user)
+	// A user matching a sanctions or PEP list is created HELD until compliance
+	// has reviewed the hits.
+	createdUser, err := h.repo.CreateUser(r.Context(), &user, h.screener.Screen(&user))
 	if err != nil {
 		log.Error(err)
 		writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleDatabaseError, ErrMsgCreateUserFailed)
 		return
 	}
 
-	// Prepare Kafka event and serialize it
-	event := map[string]interface{}{
-		"action": "USER_CREATED",
-		"user":   createdUser,
-	}
-	eventBytes, err := json.Marshal(event)
-	if err != nil {
-		writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleKafkaError, ErrMsgMarshalEventFailed)
-		return
-	}
-
-	if err := h.publisher.Publish(r.Context(), []byte(createdUser.ID), eventBytes); err != nil {
-		writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleKafkaError, ErrMsgEmitEventFailed)
-		return
-	}
-
+	// The USER_CREATED event was committed to the outbox together with the user
+	// and is relayed to Kafka asynchronously.
 	w.WriteHeader(http.StatusCreated)
 	err = json.NewEncoder(w).Encode(createdUser)
 	if err != nil {
@@ -70,38 +63,30 @@ func (h *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *UserHandler) GetAllUsers(w http.ResponseWriter, r *http.Request) {
-	// Parse query parameters
-	offsetStr := r.URL.Query().Get("offset")
-	limitStr := r.URL.Query().Get("limit")
-	sort := r.URL.Query().Get("sort")
-	order := r.URL.Query().Get("order")
-
-	// Default values
-	offset := 0
-	limit := 100
+	params := middleware.PagingParamsFromContext(r.Context())
 
-	// Parse integers with error handling
-	if offsetVal, err := strconv.Atoi(offsetStr); err == nil && offsetVal >= 0 {
-		offset = offsetVal
-	}
-	if limitVal, err := strconv.Atoi(limitStr); err == nil && limitVal > 0 && limitVal <= 1000 {
-		limit = limitVal
+	filter, err := parseUserFilter(r.URL.Query())
+	if err != nil {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, err.Error())
+		return
 	}
 
-	users, err := h.repo.GetAllUsers(r.Context(), offset, limit, sort, order)
+	users, pageInfo, err := h.repo.GetAllUsers(r.Context(), filter, params.Page())
 	if err != nil {
 		writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleDatabaseError, ErrMsgFailedToFetchUsers)
 		return
 	}
 
+	var first, last repository.Cursor
+	if len(users) > 0 {
+		first = userCursor(users[0], params.Sort)
+		last = userCursor(users[len(users)-1], params.Sort)
+	}
+	meta := pageMeta(w, r, h.cursors, params, len(users), pageInfo, first, last)
+	meta["filter"] = filter
+
 	writer.WriteJSON(w, http.StatusOK, map[string]interface{}{
-		"meta": map[string]interface{}{
-			"count":  len(users),
-			"offset": offset,
-			"limit":  limit,
-			"sort":   sort,
-			"order":  order,
-		},
+		"meta": meta,
 		"data": users,
 	})
 }
@@ -126,6 +111,110 @@ func (h *UserHandler) GetUserByID(w http.ResponseWriter, r *http.Request) {
 	writer.WriteJSON(w, http.StatusOK, user)
 }
 
+// UpdateUser applies a JSON Merge Patch (RFC 7386) to a user and persists the
+// fields that actually changed.
+func (h *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
+	userID := mux.Vars(r)["user_id"]
+	if userID == "" {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgUserIDRequired)
+		return
+	}
+
+	if contentType := r.Header.Get("Content-Type"); contentType != "" {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil || (mediaType != mergePatchMediaType && mediaType != "application/json") {
+			writer.WriteErrJSON(w, http.StatusUnsupportedMediaType, ErrTitleUnsupportedMediaType, ErrMsgMergePatchRequired)
+			return
+		}
+	}
+
+	patch, err := io.ReadAll(r.Body)
+	if err != nil {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgInvalidRequestBody)
+		return
+	}
+
+	var patchFields map[string]json.RawMessage
+	if err := json.Unmarshal(patch, &patchFields); err != nil {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgInvalidRequestBody)
+		return
+	}
+	for field := range patchFields {
+		if _, readOnly := userReadOnlyFields[field]; readOnly {
+			writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleValidationError, field+" cannot be modified")
+			return
+		}
+	}
+
+	existing, err := h.repo.GetUserByID(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writer.WriteErrJSON(w, http.StatusNotFound, ErrTitleNotFound, ErrMsgUserNotFound)
+		} else {
+			log.Error(err)
+			writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleDatabaseError, ErrMsgFailedToFetchUser)
+		}
+		return
+	}
+
+	original, err := json.Marshal(existing)
+	if err != nil {
+		writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleInternalError, ErrMsgUpdateUserFailed)
+		return
+	}
+	merged, err := mergepatch.Apply(original, patch)
+	if err != nil {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgInvalidRequestBody)
+		return
+	}
+
+	var updated domain.User
+	if err := json.Unmarshal(merged, &updated); err != nil {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleValidationError, ErrMsgInvalidFieldType)
+		return
+	}
+	if err := updated.Validate(); err != nil {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleValidationError, err.Error())
+		return
+	}
+
+	changed := existing.ChangedFields(&updated)
+	if len(changed) == 0 {
+		writer.WriteJSON(w, http.StatusOK, existing)
+		return
+	}
+
+	var hits []domain.ScreeningHit
+	for _, field := range changed {
+		if _, screened := screenedUserFields[field]; screened {
+			hits = h.screener.Screen(&updated)
+			break
+		}
+	}
+
+	result, err := h.repo.UpdateUser(r.Context(), &updated, changed, hits)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writer.WriteErrJSON(w, http.StatusNotFound, ErrTitleNotFound, ErrMsgUserNotFound)
+		} else if errors.Is(err, domain.ErrUserModified) {
+			writer.WriteErrJSON(w, http.StatusConflict, ErrTitleConflict, ErrMsgUserModified)
+		} else if errors.Is(err, domain.ErrUserNotActive) {
+			writer.WriteErrJSON(w, http.StatusConflict, ErrTitleConflict, err.Error())
+		} else {
+			log.Error(err)
+			writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleDatabaseError, ErrMsgUpdateUserFailed)
+		}
+		return
+	}
+
+	writer.WriteJSON(w, http.StatusOK, result)
+}
+
+// userCursor marks the position of user in a list ordered by sort.
+func userCursor(user domain.User, sort string) repository.Cursor {
+	return sortCursor(user.ID, user.CreatedAt, user.UpdatedAt, sort)
+}
+
 func (h *UserHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
 	userID := mux.Vars(r)["user_id"]
 	if userID == "" {
@@ -137,6 +226,8 @@ func (h *UserHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			writer.WriteErrJSON(w, http.StatusNotFound, "not_found", "user does not exist")
+		} else if errors.Is(err, domain.ErrIllegalStatusTransition) || errors.Is(err, domain.ErrNonZeroBalance) {
+			writer.WriteErrJSON(w, http.StatusConflict, ErrTitleConflict, err.Error())
 		} else {
 			writer.WriteErrJSON(w, http.StatusInternalServerError, "database_error", "failed to offboard user")
 		}
@@ -145,4 +236,3 @@ func (h *UserHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
 
 	w.WriteHeader(http.StatusAccepted)
 }
-
@@ -6,12 +6,17 @@ import (
 	"database/sql"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/ashwingopalsamy/upvest-api/internal/middleware"
 	"github.com/ashwingopalsamy/upvest-api/internal/pkg/handler"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/repository"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/screening"
 	"github.com/ashwingopalsamy/upvest-api/internal/util/mocks"
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/mock"
@@ -20,9 +25,9 @@ import (
 
 type UserHandlerTestSuite struct {
 	suite.Suite
-	mockRepo      *mocks.UserRepository
-	mockPublisher *mocks.PublisherInterface
-	handler       *handler.UserHandler
+	mockRepo *mocks.UserRepository
+	cursors  *middleware.CursorCodec
+	handler  *handler.UserHandler
 }
 
 func TestUserHandlerTestSuite(t *testing.T) {
@@ -31,10 +36,15 @@ func TestUserHandlerTestSuite(t *testing.T) {
 
 func (suite *UserHandlerTestSuite) SetupTest() {
 	suite.mockRepo = new(mocks.UserRepository)
-	suite.mockPublisher = new(mocks.PublisherInterface)
-	suite.handler = handler.NewUserHandler(suite.mockRepo, suite.mockPublisher)
+	suite.cursors = middleware.NewCursorCodec([]byte("test-secret"))
+	suite.handler = handler.NewUserHandler(suite.mockRepo, suite.cursors, testScreener)
 }
 
+// testScreener lists "Rob Fraud", born in 1990.
+var testScreener = screening.NewScreener(screening.List{Name: "peps", Entries: []screening.Entry{
+	{ID: "P1", Names: []string{"Rob Fraud"}, BirthDates: []string{"1990"}, Category: domain.ScreeningCategoryPEP},
+}})
+
 func (suite *UserHandlerTestSuite) Test_CreateUser_Success() {
 	ctx := context.Background()
 
@@ -59,8 +69,7 @@ func (suite *UserHandlerTestSuite) Test_CreateUser_Success() {
 		},
 	}
 
-	suite.mockRepo.On("CreateUser", ctx, mock.Anything).Return(reqBody, nil)
-	suite.mockPublisher.On("Publish", ctx, mock.Anything, mock.Anything).Return(nil)
+	suite.mockRepo.On("CreateUser", ctx, mock.Anything, []domain.ScreeningHit(nil)).Return(reqBody, nil)
 
 	body, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest(http.MethodPost, "/users", bytes.NewReader(body))
@@ -78,7 +87,6 @@ func (suite *UserHandlerTestSuite) Test_CreateUser_Success() {
 	suite.NoError(err)
 	suite.Equal("Rob", resp.FirstName)
 	suite.mockRepo.AssertExpectations(suite.T())
-	suite.mockPublisher.AssertExpectations(suite.T())
 }
 
 func (suite *UserHandlerTestSuite) Test_CreateUser_Failure() {
@@ -98,6 +106,39 @@ func (suite *UserHandlerTestSuite) Test_CreateUser_Failure() {
 	suite.Equal(http.StatusBadRequest, res.StatusCode)
 }
 
+func (suite *UserHandlerTestSuite) TestCreateUser_ScreeningHit() {
+	reqBody := &domain.User{
+		FirstName:     "Rob",
+		LastName:      "Fraud",
+		BirthDate:     "1990-01-01",
+		BirthCity:     "Berlin",
+		BirthCountry:  "DE",
+		Nationalities: []string{"DE"},
+		Address: domain.Address{
+			AddressLine1: "123 Main St",
+			Postcode:     "12345",
+			City:         "Berlin",
+			Country:      "DE",
+		},
+	}
+	held := *reqBody
+	held.Status = domain.UserStatusHeld
+
+	suite.mockRepo.On("CreateUser", mock.Anything, mock.Anything, mock.MatchedBy(func(hits []domain.ScreeningHit) bool {
+		return len(hits) == 1 && hits[0].EntryID == "P1" && hits[0].BirthDateMatched
+	})).Return(&held, nil)
+
+	body, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest(http.MethodPost, "/users", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	suite.handler.CreateUser(w, req)
+
+	suite.Equal(http.StatusCreated, w.Code)
+	suite.Contains(w.Body.String(), `"status":"HELD"`)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
 func (suite *UserHandlerTestSuite) TestCreateUser_DatabaseFailure() {
 	reqBody := &domain.User{
 		FirstName:     "Rob",
@@ -120,7 +161,7 @@ func (suite *UserHandlerTestSuite) TestCreateUser_DatabaseFailure() {
 		},
 	}
 
-	suite.mockRepo.On("CreateUser", mock.Anything, mock.Anything).Return(nil, errors.New("database error"))
+	suite.mockRepo.On("CreateUser", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("database error"))
 
 	body, _ := json.Marshal(reqBody)
 	req := httptest.NewRequest(http.MethodPost, "/users", bytes.NewReader(body))
@@ -134,7 +175,15 @@ func (suite *UserHandlerTestSuite) TestCreateUser_DatabaseFailure() {
 	suite.Equal(http.StatusInternalServerError, res.StatusCode)
 
 	suite.mockRepo.AssertExpectations(suite.T())
-	suite.mockPublisher.AssertNotCalled(suite.T(), "Publish")
+}
+
+func (suite *UserHandlerTestSuite) getAllUsers(target string) *http.Response {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	w := httptest.NewRecorder()
+
+	middleware.ExtractPagingParams(suite.cursors)(http.HandlerFunc(suite.handler.GetAllUsers)).ServeHTTP(w, req)
+
+	return w.Result()
 }
 
 func (suite *UserHandlerTestSuite) TestGetAllUsers_Success() {
@@ -143,14 +192,10 @@ func (suite *UserHandlerTestSuite) TestGetAllUsers_Success() {
 		{ID: "2", FirstName: "Jane", LastName: "Schmidt"},
 	}
 
-	suite.mockRepo.On("GetAllUsers", mock.Anything, 0, 100, "created_at", "ASC").Return(users, nil)
-
-	req := httptest.NewRequest(http.MethodGet, "/users?offset=0&limit=100&sort=created_at&order=ASC", nil)
-	w := httptest.NewRecorder()
+	suite.mockRepo.On("GetAllUsers", mock.Anything, repository.UserFilter{}, repository.Page{Offset: 0, Limit: 100, Sort: "created_at", Order: "ASC"}).
+		Return(users, repository.PageInfo{}, nil)
 
-	suite.handler.GetAllUsers(w, req)
-
-	res := w.Result()
+	res := suite.getAllUsers("/users?offset=0&limit=100&sort=created_at&order=ASC")
 	defer res.Body.Close()
 
 	suite.Equal(http.StatusOK, res.StatusCode)
@@ -168,19 +213,121 @@ func (suite *UserHandlerTestSuite) TestGetAllUsers_Success() {
 	suite.Equal(100, int(resp.Meta["limit"].(float64)))
 	suite.Equal("created_at", resp.Meta["sort"])
 	suite.Equal("ASC", resp.Meta["order"])
+	suite.Empty(res.Header.Get("Link"))
 
 	suite.mockRepo.AssertExpectations(suite.T())
 }
 
-func (suite *UserHandlerTestSuite) TestGetAllUsers_DatabaseFailure() {
-	suite.mockRepo.On("GetAllUsers", mock.Anything, 0, 100, "created_at", "ASC").Return(nil, errors.New("database error"))
+func (suite *UserHandlerTestSuite) TestGetAllUsers_CursorLinks() {
+	first := []domain.User{
+		{ID: "1", CreatedAt: "2025-01-01T00:00:00Z", FirstName: "John"},
+		{ID: "2", CreatedAt: "2025-01-02T00:00:00Z", FirstName: "Jane"},
+	}
+	second := []domain.User{
+		{ID: "3", CreatedAt: "2025-01-03T00:00:00Z", FirstName: "Mark"},
+	}
 
-	req := httptest.NewRequest(http.MethodGet, "/users?offset=0&limit=100&sort=created_at&order=ASC", nil)
-	w := httptest.NewRecorder()
+	suite.mockRepo.On("GetAllUsers", mock.Anything, repository.UserFilter{}, repository.Page{Limit: 2, Sort: "created_at", Order: "ASC"}).
+		Return(first, repository.PageInfo{HasNext: true}, nil)
+	suite.mockRepo.On("GetAllUsers", mock.Anything, repository.UserFilter{}, repository.Page{
+		Limit:  2,
+		Sort:   "created_at",
+		Order:  "ASC",
+		Cursor: &repository.Cursor{SortValue: "2025-01-02T00:00:00Z", ID: "2"},
+	}).Return(second, repository.PageInfo{HasPrev: true}, nil)
 
-	suite.handler.GetAllUsers(w, req)
+	res := suite.getAllUsers("/users?limit=2")
+	defer res.Body.Close()
 
-	res := w.Result()
+	var resp struct {
+		Meta map[string]interface{} `json:"meta"`
+	}
+	suite.NoError(json.NewDecoder(res.Body).Decode(&resp))
+	nextCursor, _ := resp.Meta["next_cursor"].(string)
+	suite.NotEmpty(nextCursor)
+	suite.Nil(resp.Meta["prev_cursor"])
+	suite.Equal(`</users?cursor=`+nextCursor+`&limit=2>; rel="next"`, res.Header.Get("Link"))
+
+	res = suite.getAllUsers("/users?limit=2&cursor=" + nextCursor)
+	defer res.Body.Close()
+
+	suite.Equal(http.StatusOK, res.StatusCode)
+	var next struct {
+		Meta map[string]interface{} `json:"meta"`
+	}
+	suite.NoError(json.NewDecoder(res.Body).Decode(&next))
+	suite.Nil(next.Meta["offset"])
+	suite.Nil(next.Meta["next_cursor"])
+	suite.NotEmpty(next.Meta["prev_cursor"])
+	suite.Contains(res.Header.Get("Link"), `rel="prev"`)
+
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *UserHandlerTestSuite) TestGetAllUsers_TamperedCursor() {
+	res := suite.getAllUsers("/users?cursor=eyJzIjoiY3JlYXRlZF9hdCJ9.bm90LWEtc2lnbmF0dXJl")
+	defer res.Body.Close()
+
+	suite.Equal(http.StatusBadRequest, res.StatusCode)
+	suite.mockRepo.AssertNotCalled(suite.T(), "GetAllUsers", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *UserHandlerTestSuite) TestGetAllUsers_Filter() {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	filter := repository.UserFilter{
+		Statuses:     []string{"ACTIVE", "INACTIVE"},
+		BirthCountry: "DE",
+		Nationality:  "US",
+		CreatedAfter: &from,
+		Name:         "schmidt",
+	}
+
+	suite.mockRepo.On("GetAllUsers", mock.Anything, filter, repository.Page{Offset: 0, Limit: 100, Sort: "created_at", Order: "ASC"}).
+		Return([]domain.User{{ID: "1", FirstName: "John", LastName: "Schmidt"}}, repository.PageInfo{}, nil)
+
+	res := suite.getAllUsers("/users?status=active,INACTIVE&birth_country=de&nationality=US&created_from=2025-01-01&name=schmidt")
+	defer res.Body.Close()
+
+	suite.Equal(http.StatusOK, res.StatusCode)
+
+	var resp struct {
+		Meta struct {
+			Filter map[string]interface{} `json:"filter"`
+		} `json:"meta"`
+	}
+	suite.NoError(json.NewDecoder(res.Body).Decode(&resp))
+	suite.Equal([]interface{}{"ACTIVE", "INACTIVE"}, resp.Meta.Filter["status"])
+	suite.Equal("DE", resp.Meta.Filter["birth_country"])
+	suite.Equal("US", resp.Meta.Filter["nationality"])
+	suite.Equal("2025-01-01T00:00:00Z", resp.Meta.Filter["created_from"])
+	suite.Equal("schmidt", resp.Meta.Filter["name"])
+	suite.NotContains(resp.Meta.Filter, "created_to")
+
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *UserHandlerTestSuite) TestGetAllUsers_InvalidFilter() {
+	for _, target := range []string{
+		"/users?status=DELETED",
+		"/users?birth_country=XX",
+		"/users?nationality=germany",
+		"/users?created_from=yesterday",
+		"/users?created_from=2025-02-01&created_to=2025-01-01",
+	} {
+		res := suite.getAllUsers(target)
+		res.Body.Close()
+
+		suite.Equal(http.StatusBadRequest, res.StatusCode, target)
+	}
+
+	suite.mockRepo.AssertNotCalled(suite.T(), "GetAllUsers", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *UserHandlerTestSuite) TestGetAllUsers_DatabaseFailure() {
+	suite.mockRepo.On("GetAllUsers", mock.Anything, repository.UserFilter{}, repository.Page{Offset: 0, Limit: 100, Sort: "created_at", Order: "ASC"}).
+		Return(nil, repository.PageInfo{}, errors.New("database error"))
+
+	res := suite.getAllUsers("/users?offset=0&limit=100&sort=created_at&order=ASC")
 	defer res.Body.Close()
 
 	suite.Equal(http.StatusInternalServerError, res.StatusCode)
@@ -276,3 +423,271 @@ func (suite *UserHandlerTestSuite) TestDeleteUser_DatabaseError() {
 
 	suite.mockRepo.AssertExpectations(suite.T())
 }
+
+func (suite *UserHandlerTestSuite) existingUser() *domain.User {
+	return &domain.User{
+		ID:            "1",
+		FirstName:     "Rob",
+		LastName:      "Schmidt",
+		BirthDate:     "1990-01-01",
+		BirthCity:     "Berlin",
+		BirthCountry:  "DE",
+		Nationalities: []string{"DE"},
+		Address: domain.Address{
+			AddressLine1: "123 Main St",
+			Postcode:     "12345",
+			City:         "Berlin",
+			Country:      "DE",
+		},
+		Status: "ACTIVE",
+	}
+}
+
+func (suite *UserHandlerTestSuite) TestUpdateUser_Success() {
+	existing := suite.existingUser()
+	updated := suite.existingUser()
+	updated.LastName = "Meyer"
+	updated.Address.City = "Hamburg"
+
+	suite.mockRepo.On("GetUserByID", mock.Anything, "1").Return(existing, nil)
+	suite.mockRepo.On("UpdateUser", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+		return u.LastName == "Meyer" && u.Address.City == "Hamburg" && u.Address.Postcode == "12345"
+	}), []string{"address", "last_name"}, []domain.ScreeningHit(nil)).Return(updated, nil)
+
+	body := `{"last_name":"Meyer","address":{"city":"Hamburg"}}`
+	req := httptest.NewRequest(http.MethodPatch, "/users/1", bytes.NewReader([]byte(body)))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	req = mux.SetURLVars(req, map[string]string{"user_id": "1"})
+	w := httptest.NewRecorder()
+
+	suite.handler.UpdateUser(w, req)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	suite.Equal(http.StatusOK, res.StatusCode)
+
+	var resp domain.User
+	err := json.NewDecoder(res.Body).Decode(&resp)
+	suite.NoError(err)
+	suite.Equal("Meyer", resp.LastName)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *UserHandlerTestSuite) TestUpdateUser_ScreensChangedName() {
+	existing := suite.existingUser()
+	updated := suite.existingUser()
+	updated.LastName = "Fraud"
+	updated.Status = domain.UserStatusHeld
+
+	suite.mockRepo.On("GetUserByID", mock.Anything, "1").Return(existing, nil)
+	suite.mockRepo.On("UpdateUser", mock.Anything, mock.Anything, []string{"last_name"}, mock.MatchedBy(func(hits []domain.ScreeningHit) bool {
+		return len(hits) == 1 && hits[0].MatchedName == "Rob Fraud"
+	})).Return(updated, nil)
+
+	req := httptest.NewRequest(http.MethodPatch, "/users/1", bytes.NewReader([]byte(`{"last_name":"Fraud"}`)))
+	req = mux.SetURLVars(req, map[string]string{"user_id": "1"})
+	w := httptest.NewRecorder()
+
+	suite.handler.UpdateUser(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Contains(w.Body.String(), `"status":"HELD"`)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *UserHandlerTestSuite) TestUpdateUser_NoChanges() {
+	suite.mockRepo.On("GetUserByID", mock.Anything, "1").Return(suite.existingUser(), nil)
+
+	req := httptest.NewRequest(http.MethodPatch, "/users/1", bytes.NewReader([]byte(`{"first_name":"Rob"}`)))
+	req = mux.SetURLVars(req, map[string]string{"user_id": "1"})
+	w := httptest.NewRecorder()
+
+	suite.handler.UpdateUser(w, req)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	suite.Equal(http.StatusOK, res.StatusCode)
+	suite.mockRepo.AssertNotCalled(suite.T(), "UpdateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *UserHandlerTestSuite) TestUpdateUser_ValidationError() {
+	suite.mockRepo.On("GetUserByID", mock.Anything, "1").Return(suite.existingUser(), nil)
+
+	req := httptest.NewRequest(http.MethodPatch, "/users/1", bytes.NewReader([]byte(`{"nationalities":null}`)))
+	req = mux.SetURLVars(req, map[string]string{"user_id": "1"})
+	w := httptest.NewRecorder()
+
+	suite.handler.UpdateUser(w, req)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	suite.Equal(http.StatusBadRequest, res.StatusCode)
+	suite.mockRepo.AssertNotCalled(suite.T(), "UpdateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *UserHandlerTestSuite) TestUpdateUser_ReadOnlyField() {
+	req := httptest.NewRequest(http.MethodPatch, "/users/1", bytes.NewReader([]byte(`{"status":"OFFBOARDED"}`)))
+	req = mux.SetURLVars(req, map[string]string{"user_id": "1"})
+	w := httptest.NewRecorder()
+
+	suite.handler.UpdateUser(w, req)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	suite.Equal(http.StatusBadRequest, res.StatusCode)
+	suite.mockRepo.AssertNotCalled(suite.T(), "GetUserByID", mock.Anything, mock.Anything)
+}
+
+func (suite *UserHandlerTestSuite) TestUpdateUser_TaxResidencies() {
+	existing := suite.existingUser()
+	updated := suite.existingUser()
+	updated.TaxResidencies = []domain.TaxResidency{{Country: "US", TIN: "123-45-6789"}}
+	updated.FATCA = true
+
+	suite.mockRepo.On("GetUserByID", mock.Anything, "1").Return(existing, nil)
+	suite.mockRepo.On("UpdateUser", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+		return len(u.TaxResidencies) == 1 && u.TaxResidencies[0].TIN == "123-45-6789"
+	}), []string{"tax_residencies"}, []domain.ScreeningHit(nil)).Return(updated, nil)
+
+	body := `{"tax_residencies":[{"country":"US","tin":"123-45-6789"}]}`
+	req := httptest.NewRequest(http.MethodPatch, "/users/1", bytes.NewReader([]byte(body)))
+	req = mux.SetURLVars(req, map[string]string{"user_id": "1"})
+	w := httptest.NewRecorder()
+
+	suite.handler.UpdateUser(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Contains(w.Body.String(), `"fatca":true`)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *UserHandlerTestSuite) TestUpdateUser_InvalidTIN() {
+	suite.mockRepo.On("GetUserByID", mock.Anything, "1").Return(suite.existingUser(), nil)
+
+	body := `{"tax_residencies":[{"country":"DE","tin":"86095742718"}]}`
+	req := httptest.NewRequest(http.MethodPatch, "/users/1", bytes.NewReader([]byte(body)))
+	req = mux.SetURLVars(req, map[string]string{"user_id": "1"})
+	w := httptest.NewRecorder()
+
+	suite.handler.UpdateUser(w, req)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.Contains(w.Body.String(), "invalid check digit")
+	suite.mockRepo.AssertNotCalled(suite.T(), "UpdateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *UserHandlerTestSuite) TestUpdateUser_FATCAIsReadOnly() {
+	req := httptest.NewRequest(http.MethodPatch, "/users/1", bytes.NewReader([]byte(`{"fatca":false}`)))
+	req = mux.SetURLVars(req, map[string]string{"user_id": "1"})
+	w := httptest.NewRecorder()
+
+	suite.handler.UpdateUser(w, req)
+
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.mockRepo.AssertNotCalled(suite.T(), "GetUserByID", mock.Anything, mock.Anything)
+}
+
+func (suite *UserHandlerTestSuite) TestUpdateUser_UnsupportedMediaType() {
+	req := httptest.NewRequest(http.MethodPatch, "/users/1", bytes.NewReader([]byte(`[]`)))
+	req.Header.Set("Content-Type", "application/json-patch+json")
+	req = mux.SetURLVars(req, map[string]string{"user_id": "1"})
+	w := httptest.NewRecorder()
+
+	suite.handler.UpdateUser(w, req)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	suite.Equal(http.StatusUnsupportedMediaType, res.StatusCode)
+}
+
+func (suite *UserHandlerTestSuite) TestUpdateUser_NotFound() {
+	suite.mockRepo.On("GetUserByID", mock.Anything, "1").Return(nil, sql.ErrNoRows)
+
+	req := httptest.NewRequest(http.MethodPatch, "/users/1", bytes.NewReader([]byte(`{"last_name":"Meyer"}`)))
+	req = mux.SetURLVars(req, map[string]string{"user_id": "1"})
+	w := httptest.NewRecorder()
+
+	suite.handler.UpdateUser(w, req)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	suite.Equal(http.StatusNotFound, res.StatusCode)
+}
+
+func (suite *UserHandlerTestSuite) TestUpdateUser_ModifiedConcurrently() {
+	suite.mockRepo.On("GetUserByID", mock.Anything, "1").Return(suite.existingUser(), nil)
+	suite.mockRepo.On("UpdateUser", mock.Anything, mock.Anything, []string{"last_name"}, []domain.ScreeningHit(nil)).
+		Return(nil, fmt.Errorf("user 1: %w", domain.ErrUserModified))
+
+	req := httptest.NewRequest(http.MethodPatch, "/users/1", bytes.NewReader([]byte(`{"last_name":"Meyer"}`)))
+	req = mux.SetURLVars(req, map[string]string{"user_id": "1"})
+	w := httptest.NewRecorder()
+
+	suite.handler.UpdateUser(w, req)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	suite.Equal(http.StatusConflict, res.StatusCode)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *UserHandlerTestSuite) TestUpdateUser_OffboardingUser() {
+	suite.mockRepo.On("GetUserByID", mock.Anything, "1").Return(suite.existingUser(), nil)
+	suite.mockRepo.On("UpdateUser", mock.Anything, mock.Anything, []string{"last_name"}, []domain.ScreeningHit(nil)).
+		Return(nil, fmt.Errorf("%w: user 1 is OFFBOARDING", domain.ErrUserNotActive))
+
+	req := httptest.NewRequest(http.MethodPatch, "/users/1", bytes.NewReader([]byte(`{"last_name":"Meyer"}`)))
+	req = mux.SetURLVars(req, map[string]string{"user_id": "1"})
+	w := httptest.NewRecorder()
+
+	suite.handler.UpdateUser(w, req)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	suite.Equal(http.StatusConflict, res.StatusCode)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *UserHandlerTestSuite) TestDeleteUser_IllegalTransition() {
+	suite.mockRepo.On("OffboardUser", mock.Anything, "1").
+		Return(fmt.Errorf("%w: OFFBOARDED to OFFBOARDING", domain.ErrIllegalStatusTransition))
+
+	req := httptest.NewRequest(http.MethodDelete, "/users/1", nil)
+	req = mux.SetURLVars(req, map[string]string{"user_id": "1"})
+	w := httptest.NewRecorder()
+
+	suite.handler.DeleteUser(w, req)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	suite.Equal(http.StatusConflict, res.StatusCode)
+
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *UserHandlerTestSuite) TestDeleteUser_NonZeroBalance() {
+	suite.mockRepo.On("OffboardUser", mock.Anything, "1").
+		Return(fmt.Errorf("%w: user 1 holds EUR", domain.ErrNonZeroBalance))
+
+	req := httptest.NewRequest(http.MethodDelete, "/users/1", nil)
+	req = mux.SetURLVars(req, map[string]string{"user_id": "1"})
+	w := httptest.NewRecorder()
+
+	suite.handler.DeleteUser(w, req)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	suite.Equal(http.StatusConflict, res.StatusCode)
+
+	suite.mockRepo.AssertExpectations(suite.T())
+}
@@ -0,0 +1,230 @@
+package handler
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/repository"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/sepa"
+	"github.com/ashwingopalsamy/upvest-api/internal/util/writer"
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+)
+
+type WithdrawalHandler struct {
+	repo   repository.WithdrawalRepository
+	debtor sepa.Debtor
+}
+
+// NewWithdrawalHandler creates a handler that exports payouts as debits of the
+// debtor account.
+func NewWithdrawalHandler(repo repository.WithdrawalRepository, debtor sepa.Debtor) *WithdrawalHandler {
+	return &WithdrawalHandler{
+		repo:   repo,
+		debtor: debtor,
+	}
+}
+
+// CreateWithdrawal requests a payout of available cash to one of the user's
+// reference accounts. Withdrawals the user's funds or accounts cannot cover
+// are answered with 422.
+func (h *WithdrawalHandler) CreateWithdrawal(w http.ResponseWriter, r *http.Request) {
+	userID := mux.Vars(r)["user_id"]
+	if userID == "" {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgUserIDRequired)
+		return
+	}
+
+	var withdrawal domain.Withdrawal
+	if err := json.NewDecoder(r.Body).Decode(&withdrawal); err != nil {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgInvalidRequestBody)
+		return
+	}
+	withdrawal.UserID = userID
+
+	if err := withdrawal.Validate(); err != nil {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleValidationError, err.Error())
+		return
+	}
+
+	created, err := h.repo.CreateWithdrawal(r.Context(), &withdrawal)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writer.WriteErrJSON(w, http.StatusNotFound, ErrTitleNotFound, ErrMsgUserNotFound)
+		} else if errors.Is(err, domain.ErrUserNotActive) {
+			writer.WriteErrJSON(w, http.StatusConflict, ErrTitleConflict, err.Error())
+		} else if errors.Is(err, domain.ErrWithdrawalNotAccepted) || errors.Is(err, domain.ErrInsufficientFunds) {
+			writer.WriteErrJSON(w, http.StatusUnprocessableEntity, ErrTitleUnprocessableEntity, err.Error())
+		} else {
+			log.Error(err)
+			writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleDatabaseError, ErrMsgCreateWithdrawalFailed)
+		}
+		return
+	}
+
+	writer.WriteJSON(w, http.StatusCreated, created)
+}
+
+func (h *WithdrawalHandler) GetWithdrawalByID(w http.ResponseWriter, r *http.Request) {
+	withdrawalID := mux.Vars(r)["withdrawal_id"]
+	if withdrawalID == "" {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgWithdrawalIDRequired)
+		return
+	}
+
+	withdrawal, err := h.repo.GetWithdrawalByID(r.Context(), withdrawalID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writer.WriteErrJSON(w, http.StatusNotFound, ErrTitleNotFound, ErrMsgWithdrawalNotFound)
+		} else {
+			log.Error(err)
+			writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleDatabaseError, ErrMsgFailedToFetchWithdrawal)
+		}
+		return
+	}
+
+	writer.WriteJSON(w, http.StatusOK, withdrawal)
+}
+
+// BookWithdrawal marks a pending withdrawal as executed by the bank.
+func (h *WithdrawalHandler) BookWithdrawal(w http.ResponseWriter, r *http.Request) {
+	withdrawalID := mux.Vars(r)["withdrawal_id"]
+	if withdrawalID == "" {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgWithdrawalIDRequired)
+		return
+	}
+
+	withdrawal, err := h.repo.BookWithdrawal(r.Context(), withdrawalID)
+	h.writeCompleted(w, withdrawal, err)
+}
+
+// FailWithdrawal marks a pending withdrawal as failed for the reason given in
+// the request body and releases its reserved cash.
+func (h *WithdrawalHandler) FailWithdrawal(w http.ResponseWriter, r *http.Request) {
+	withdrawalID := mux.Vars(r)["withdrawal_id"]
+	if withdrawalID == "" {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgWithdrawalIDRequired)
+		return
+	}
+
+	var body struct {
+		Reason string `json:"reason"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgInvalidRequestBody)
+		return
+	}
+	if err := domain.ValidateFailureReason(body.Reason); err != nil {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleValidationError, err.Error())
+		return
+	}
+
+	withdrawal, err := h.repo.FailWithdrawal(r.Context(), withdrawalID, body.Reason)
+	h.writeCompleted(w, withdrawal, err)
+}
+
+func (h *WithdrawalHandler) writeCompleted(w http.ResponseWriter, withdrawal *domain.Withdrawal, err error) {
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writer.WriteErrJSON(w, http.StatusNotFound, ErrTitleNotFound, ErrMsgWithdrawalNotFound)
+		} else if errors.Is(err, domain.ErrIllegalWithdrawalTransition) {
+			writer.WriteErrJSON(w, http.StatusConflict, ErrTitleConflict, err.Error())
+		} else {
+			log.Error(err)
+			writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleDatabaseError, ErrMsgCompleteWithdrawalFailed)
+		}
+		return
+	}
+
+	writer.WriteJSON(w, http.StatusOK, withdrawal)
+}
+
+// ExportPendingWithdrawals exports the pending withdrawals that were not
+// exported yet as a pain.001 SEPA credit transfer file, or answers 204 if there
+// is nothing to pay out. The export is stored and its withdrawals are left out
+// of later exports; they stay PENDING until booked or failed. The file can be
+// downloaded again from the Location of the response.
+func (h *WithdrawalHandler) ExportPendingWithdrawals(w http.ResponseWriter, r *http.Request) {
+	if err := h.debtor.Validate(); err != nil {
+		log.Errorf("cannot export withdrawals: %v", err)
+		writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleInternalError, ErrMsgPayoutDebtorNotConfigured)
+		return
+	}
+
+	instructions, err := h.repo.GetPendingPaymentInstructions(r.Context())
+	if err != nil {
+		log.Error(err)
+		writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleDatabaseError, ErrMsgExportWithdrawalsFailed)
+		return
+	}
+	if len(instructions) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	createdAt := time.Now()
+	var file bytes.Buffer
+	if err := sepa.WriteCreditTransfers(&file, h.debtor, createdAt, instructions); err != nil {
+		log.Error(err)
+		writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleInternalError, ErrMsgExportWithdrawalsFailed)
+		return
+	}
+
+	export := &domain.WithdrawalExport{MessageID: sepa.MessageID(createdAt), File: file.Bytes()}
+	for _, instruction := range instructions {
+		export.WithdrawalIDs = append(export.WithdrawalIDs, instruction.Withdrawal.ID)
+	}
+	export, err = h.repo.CreateWithdrawalExport(r.Context(), export)
+	if err != nil {
+		if errors.Is(err, domain.ErrWithdrawalExportConflict) {
+			writer.WriteErrJSON(w, http.StatusConflict, ErrTitleConflict, err.Error())
+		} else {
+			log.Error(err)
+			writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleDatabaseError, ErrMsgExportWithdrawalsFailed)
+		}
+		return
+	}
+
+	log.Infof("exported %d withdrawals as %s", len(export.WithdrawalIDs), export.MessageID)
+	w.Header().Set("Location", "/withdrawals/exports/"+export.ID)
+	writeExportFile(w, http.StatusCreated, export)
+}
+
+// GetWithdrawalExport downloads the file of an earlier export again, e.g. when
+// handing it to the bank failed. The bank pays out every file it receives, so
+// a file must only be resent if the bank did not receive it.
+func (h *WithdrawalHandler) GetWithdrawalExport(w http.ResponseWriter, r *http.Request) {
+	exportID := mux.Vars(r)["export_id"]
+	if exportID == "" {
+		writer.WriteErrJSON(w, http.StatusBadRequest, ErrTitleInvalidRequest, ErrMsgExportIDRequired)
+		return
+	}
+
+	export, err := h.repo.GetWithdrawalExport(r.Context(), exportID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writer.WriteErrJSON(w, http.StatusNotFound, ErrTitleNotFound, ErrMsgExportNotFound)
+		} else {
+			log.Error(err)
+			writer.WriteErrJSON(w, http.StatusInternalServerError, ErrTitleDatabaseError, ErrMsgFailedToFetchExport)
+		}
+		return
+	}
+
+	writeExportFile(w, http.StatusOK, export)
+}
+
+// writeExportFile sends the pain.001 file of export as an attachment named
+// after its message ID.
+func writeExportFile(w http.ResponseWriter, status int, export *domain.WithdrawalExport) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.xml"`, export.MessageID))
+	w.WriteHeader(status)
+	_, _ = w.Write(export.File)
+}
@@ -0,0 +1,166 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/handler"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/sepa"
+	"github.com/ashwingopalsamy/upvest-api/internal/util/mocks"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+const testReferenceAccountID = "7e6d5c4b-3a29-4817-9f6e-5d4c3b2a1908"
+
+type WithdrawalHandlerTestSuite struct {
+	suite.Suite
+	mockRepo *mocks.WithdrawalRepository
+	handler  *handler.WithdrawalHandler
+}
+
+func TestWithdrawalHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(WithdrawalHandlerTestSuite))
+}
+
+func (suite *WithdrawalHandlerTestSuite) SetupTest() {
+	suite.mockRepo = new(mocks.WithdrawalRepository)
+	suite.handler = handler.NewWithdrawalHandler(suite.mockRepo,
+		sepa.Debtor{Name: "Upvest GmbH", IBAN: "DE89370400440532013000", BIC: "COBADEFFXXX"})
+}
+
+func (suite *WithdrawalHandlerTestSuite) createWithdrawal(amount int64) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(domain.Withdrawal{ReferenceAccountID: testReferenceAccountID, Amount: amount, Currency: "EUR"})
+	req := httptest.NewRequest(http.MethodPost, "/users/"+testUserID+"/withdrawals", bytes.NewReader(body))
+	req = mux.SetURLVars(req, map[string]string{"user_id": testUserID})
+	w := httptest.NewRecorder()
+
+	suite.handler.CreateWithdrawal(w, req)
+
+	return w
+}
+
+func (suite *WithdrawalHandlerTestSuite) TestCreateWithdrawal_Success() {
+	suite.mockRepo.On("CreateWithdrawal", mock.Anything, mock.MatchedBy(func(w *domain.Withdrawal) bool {
+		return w.UserID == testUserID && w.Amount == 2500
+	})).Return(&domain.Withdrawal{ID: "w1", Status: "PENDING"}, nil)
+
+	w := suite.createWithdrawal(2500)
+
+	suite.Equal(http.StatusCreated, w.Code)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *WithdrawalHandlerTestSuite) TestCreateWithdrawal_Errors() {
+	suite.Equal(http.StatusBadRequest, suite.createWithdrawal(0).Code)
+
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{sql.ErrNoRows, http.StatusNotFound},
+		{fmt.Errorf("%w: user is OFFBOARDING", domain.ErrUserNotActive), http.StatusConflict},
+		{fmt.Errorf("%w: 100 EUR available", domain.ErrInsufficientFunds), http.StatusUnprocessableEntity},
+		{fmt.Errorf("%w: reference account of another user", domain.ErrWithdrawalNotAccepted), http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		suite.mockRepo.On("CreateWithdrawal", mock.Anything, mock.Anything).Return(nil, tt.err).Once()
+
+		suite.Equal(tt.expected, suite.createWithdrawal(2500).Code, tt.err.Error())
+	}
+}
+
+func (suite *WithdrawalHandlerTestSuite) TestFailWithdrawal() {
+	suite.mockRepo.On("FailWithdrawal", mock.Anything, "w1", "account closed").
+		Return(&domain.Withdrawal{ID: "w1", Status: "FAILED"}, nil).Once()
+	suite.mockRepo.On("FailWithdrawal", mock.Anything, "w1", "account closed").
+		Return(nil, fmt.Errorf("%w: FAILED to FAILED", domain.ErrIllegalWithdrawalTransition)).Once()
+
+	for _, expected := range []int{http.StatusOK, http.StatusConflict} {
+		req := httptest.NewRequest(http.MethodPost, "/withdrawals/w1/fail", strings.NewReader(`{"reason":"account closed"}`))
+		req = mux.SetURLVars(req, map[string]string{"withdrawal_id": "w1"})
+		w := httptest.NewRecorder()
+
+		suite.handler.FailWithdrawal(w, req)
+
+		suite.Equal(expected, w.Code)
+	}
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *WithdrawalHandlerTestSuite) TestExportPendingWithdrawals() {
+	suite.mockRepo.On("GetPendingPaymentInstructions", mock.Anything).Return([]domain.PaymentInstruction{{
+		Withdrawal: domain.Withdrawal{ID: "w1", Amount: 2500, Currency: "EUR"},
+		Creditor:   domain.ReferenceAccount{IBAN: "AT611904300234573201", BIC: "BKAUATWW", AccountHolder: "Rob Smith"},
+	}}, nil).Once()
+	suite.mockRepo.On("CreateWithdrawalExport", mock.Anything, mock.MatchedBy(func(export *domain.WithdrawalExport) bool {
+		return strings.HasPrefix(export.MessageID, "WDR-") && len(export.WithdrawalIDs) == 1 &&
+			export.WithdrawalIDs[0] == "w1" && bytes.Contains(export.File, []byte("<MsgId>"+export.MessageID+"</MsgId>"))
+	})).Return(func(_ context.Context, export *domain.WithdrawalExport) (*domain.WithdrawalExport, error) {
+		export.ID = "x1"
+		return export, nil
+	}).Once()
+	// Exported withdrawals are not listed again.
+	suite.mockRepo.On("GetPendingPaymentInstructions", mock.Anything).Return(nil, nil).Once()
+
+	req := httptest.NewRequest(http.MethodPost, "/withdrawals/exports", nil)
+	w := httptest.NewRecorder()
+	suite.handler.ExportPendingWithdrawals(w, req)
+
+	suite.Equal(http.StatusCreated, w.Code)
+	suite.Equal("/withdrawals/exports/x1", w.Header().Get("Location"))
+	suite.Equal("application/xml", w.Header().Get("Content-Type"))
+	suite.Contains(w.Body.String(), "<InstdAmt Ccy=\"EUR\">25.00</InstdAmt>")
+
+	w = httptest.NewRecorder()
+	suite.handler.ExportPendingWithdrawals(w, req)
+
+	suite.Equal(http.StatusNoContent, w.Code)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *WithdrawalHandlerTestSuite) TestExportPendingWithdrawals_Conflict() {
+	suite.mockRepo.On("GetPendingPaymentInstructions", mock.Anything).Return([]domain.PaymentInstruction{{
+		Withdrawal: domain.Withdrawal{ID: "w1", Amount: 2500, Currency: "EUR"},
+		Creditor:   domain.ReferenceAccount{IBAN: "AT611904300234573201", BIC: "BKAUATWW", AccountHolder: "Rob Smith"},
+	}}, nil)
+	suite.mockRepo.On("CreateWithdrawalExport", mock.Anything, mock.Anything).
+		Return(nil, fmt.Errorf("%w: withdrawal w1 is no longer pending export", domain.ErrWithdrawalExportConflict))
+
+	req := httptest.NewRequest(http.MethodPost, "/withdrawals/exports", nil)
+	w := httptest.NewRecorder()
+	suite.handler.ExportPendingWithdrawals(w, req)
+
+	suite.Equal(http.StatusConflict, w.Code)
+	suite.NotContains(w.Body.String(), "<?xml")
+}
+
+func (suite *WithdrawalHandlerTestSuite) TestGetWithdrawalExport() {
+	suite.mockRepo.On("GetWithdrawalExport", mock.Anything, "x1").Return(&domain.WithdrawalExport{
+		ID: "x1", MessageID: "WDR-20250601090000", File: []byte("<Document/>"),
+	}, nil)
+	suite.mockRepo.On("GetWithdrawalExport", mock.Anything, "x2").Return(nil, fmt.Errorf("withdrawal export not found: %w", sql.ErrNoRows))
+
+	req := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/withdrawals/exports/x1", nil), map[string]string{"export_id": "x1"})
+	w := httptest.NewRecorder()
+	suite.handler.GetWithdrawalExport(w, req)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal(`attachment; filename="WDR-20250601090000.xml"`, w.Header().Get("Content-Disposition"))
+	suite.Equal("<Document/>", w.Body.String())
+
+	req = mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/withdrawals/exports/x2", nil), map[string]string{"export_id": "x2"})
+	w = httptest.NewRecorder()
+	suite.handler.GetWithdrawalExport(w, req)
+
+	suite.Equal(http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,109 @@
+// Package referencedata reads reference data files used to seed environments.
+package referencedata
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+)
+
+// RowError reports an invalid row of a reference data file.
+type RowError struct {
+	Line int
+	Err  error
+}
+
+func (e RowError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e RowError) Unwrap() error {
+	return e.Err
+}
+
+var requiredInstrumentColumns = []string{"isin", "name", "type", "currency"}
+
+// ReadInstruments parses instruments from CSV with a header row. Columns are
+// matched by name, case-insensitively: isin, name, type and currency are
+// required; wkn and trading_status are optional, the latter defaulting to
+// ACTIVE. Codes are upper-cased.
+//
+// Rows that fail validation, or repeat an ISIN, are reported as RowErrors and
+// left out of the result; the returned error is set only if the file as a whole
+// cannot be read.
+func ReadInstruments(r io.Reader) ([]domain.Instrument, []RowError, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, errors.New("file is empty")
+	} else if err != nil {
+		return nil, nil, fmt.Errorf("failed to read header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range requiredInstrumentColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, nil, fmt.Errorf("missing required column %q", name)
+		}
+	}
+	// Rows may have any number of fields; missing trailing fields read as empty.
+	reader.FieldsPerRecord = -1
+
+	var (
+		instruments []domain.Instrument
+		rowErrors   []RowError
+		seen        = map[string]int{}
+	)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line, _ := reader.FieldPos(0)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read line %d: %w", line, err)
+		}
+
+		field := func(name string) string {
+			i, ok := columns[name]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		instrument := domain.Instrument{
+			ISIN:          strings.ToUpper(field("isin")),
+			WKN:           strings.ToUpper(field("wkn")),
+			Name:          field("name"),
+			Type:          strings.ToUpper(field("type")),
+			Currency:      strings.ToUpper(field("currency")),
+			TradingStatus: strings.ToUpper(field("trading_status")),
+		}
+		if instrument.TradingStatus == "" {
+			instrument.TradingStatus = domain.TradingStatusActive
+		}
+
+		if err := instrument.Validate(); err != nil {
+			rowErrors = append(rowErrors, RowError{Line: line, Err: err})
+			continue
+		}
+		if first, duplicate := seen[instrument.ISIN]; duplicate {
+			rowErrors = append(rowErrors, RowError{Line: line, Err: fmt.Errorf("isin %s already defined on line %d", instrument.ISIN, first)})
+			continue
+		}
+		seen[instrument.ISIN] = line
+		instruments = append(instruments, instrument)
+	}
+
+	return instruments, rowErrors, nil
+}
@@ -0,0 +1,49 @@
+package referencedata
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ReadInstruments(t *testing.T) {
+	input := `ISIN,WKN,Name,Type,Currency,Trading_Status
+DE0007164600,716460,SAP SE,equity,eur,
+US0378331005,865985,Apple Inc.,EQUITY,USD,SUSPENDED
+US0378331006,,Broken check digit,EQUITY,USD,
+IE00B4L5Y983,A0RPWH,"iShares Core MSCI World UCITS ETF",ETF,USD,ACTIVE
+DE0007164600,716460,SAP SE duplicate,EQUITY,EUR,ACTIVE
+`
+
+	instruments, rowErrors, err := ReadInstruments(strings.NewReader(input))
+
+	assert.NoError(t, err)
+	assert.Len(t, instruments, 3)
+	assert.Equal(t, "EQUITY", instruments[0].Type)
+	assert.Equal(t, "EUR", instruments[0].Currency)
+	assert.Equal(t, "ACTIVE", instruments[0].TradingStatus)
+	assert.Equal(t, "SUSPENDED", instruments[1].TradingStatus)
+
+	if assert.Len(t, rowErrors, 2) {
+		assert.Equal(t, 4, rowErrors[0].Line)
+		assert.EqualError(t, rowErrors[0], "line 4: isin must be a valid ISIN")
+		assert.Equal(t, 6, rowErrors[1].Line)
+		assert.EqualError(t, rowErrors[1], "line 6: isin DE0007164600 already defined on line 2")
+	}
+}
+
+func Test_ReadInstruments_OptionalColumns(t *testing.T) {
+	instruments, rowErrors, err := ReadInstruments(strings.NewReader("currency,name,isin,type\nEUR,SAP SE,DE0007164600,EQUITY\n"))
+
+	assert.NoError(t, err)
+	assert.Empty(t, rowErrors)
+	assert.Len(t, instruments, 1)
+	assert.Empty(t, instruments[0].WKN)
+}
+
+func Test_ReadInstruments_MissingColumn(t *testing.T) {
+	_, _, err := ReadInstruments(strings.NewReader("isin,name,type\nDE0007164600,SAP SE,EQUITY\n"))
+
+	assert.EqualError(t, err, `missing required column "currency"`)
+}
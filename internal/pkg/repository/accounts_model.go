@@ -0,0 +1,273 @@
+//go:generate mockery --name=AccountRepository --output=../../util/mocks --outpkg=mocks
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+)
+
+type AccountRepository interface {
+	OpenAccount(ctx context.Context, account *domain.Account) (*domain.Account, error)
+	GetAllAccounts(ctx context.Context, filter AccountFilter, page Page) ([]domain.Account, PageInfo, error)
+	GetAccountByID(ctx context.Context, accountID string) (*domain.Account, error)
+	CloseAccount(ctx context.Context, accountID string) error
+	CloseUserAccounts(ctx context.Context, userID string) error
+}
+
+// AccountFilter narrows an account list. Zero-valued fields do not filter.
+type AccountFilter struct {
+	UserID string `json:"user_id,omitempty"`
+	Status string `json:"status,omitempty"`
+}
+
+func (f AccountFilter) conditions(argPos int) ([]string, []interface{}) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+	if f.UserID != "" {
+		args = append(args, f.UserID)
+		conditions = append(conditions, fmt.Sprintf("user_id = $%d", argPos+len(args)-1))
+	}
+	if f.Status != "" {
+		args = append(args, f.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", argPos+len(args)-1))
+	}
+	return conditions, args
+}
+
+type accountRepo struct {
+	db *sql.DB
+}
+
+func NewAccountRepository(db *sql.DB) AccountRepository {
+	return &accountRepo{db: db}
+}
+
+// OpenAccount opens an account in the user's account group, creating the group
+// if needed. The user row stays locked until commit, so a user cannot start
+// offboarding while an account is being opened. Minors need a guardian first.
+func (r *accountRepo) OpenAccount(ctx context.Context, account *domain.Account) (*domain.Account, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var userStatus string
+	err = tx.QueryRowContext(ctx, queryLockUserStatus, account.UserID).Scan(&userStatus)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, sql.ErrNoRows
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read user status: %w", err)
+	}
+	if userStatus != domain.UserStatusActive {
+		return nil, fmt.Errorf("%w: user %s is %s", domain.ErrUserNotActive, account.UserID, userStatus)
+	}
+
+	var guardianRequired bool
+	if err := tx.QueryRowContext(ctx, queryMinorWithoutGuardian, account.UserID).Scan(&guardianRequired); err != nil {
+		return nil, fmt.Errorf("failed to read guardians: %w", err)
+	}
+	if guardianRequired {
+		return nil, fmt.Errorf("%w: user %s needs a guardian to open an account", domain.ErrGuardianRequired, account.UserID)
+	}
+
+	if err := tx.QueryRowContext(ctx, queryUpsertAccountGroup, account.UserID).Scan(&account.AccountGroupID); err != nil {
+		return nil, fmt.Errorf("failed to open account group: %w", err)
+	}
+
+	err = tx.QueryRowContext(ctx, queryCreateAccount,
+		account.UserID, account.AccountGroupID, account.Type, account.Name, domain.AccountStatusActive,
+	).Scan(&account.ID, &account.CreatedAt, &account.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create account: %w", err)
+	}
+	account.Status = domain.AccountStatusActive
+
+	if err := insertOutboxEvent(ctx, tx, aggregateAccount, account.ID, eventAccountOpened, map[string]interface{}{
+		"action":  eventAccountOpened,
+		"account": account,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return account, nil
+}
+
+func (r *accountRepo) GetAllAccounts(ctx context.Context, filter AccountFilter, page Page) ([]domain.Account, PageInfo, error) {
+	page = page.normalize()
+
+	conditions, args := filter.conditions(1)
+
+	if page.Cursor != nil {
+		condition, orderBy, keysetArgs := page.keyset(len(args) + 1)
+		conditions = append(conditions, condition)
+		args = append(args, keysetArgs...)
+		args = append(args, page.Limit+1)
+		query := fmt.Sprintf(queryReadAccountsByCursor, whereClause(conditions), orderBy, len(args))
+
+		accounts, err := r.queryAccounts(ctx, query, args...)
+		if err != nil {
+			return nil, PageInfo{}, err
+		}
+		accounts, info := trimPage(accounts, page)
+		return accounts, info, nil
+	}
+
+	args = append(args, page.Limit, page.Offset)
+	query := fmt.Sprintf(queryReadAccounts, whereClause(conditions), page.Sort, page.Order, len(args)-1, len(args))
+
+	accounts, err := r.queryAccounts(ctx, query, args...)
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+
+	return accounts, PageInfo{HasNext: len(accounts) == page.Limit, HasPrev: page.Offset > 0}, nil
+}
+
+func (r *accountRepo) queryAccounts(ctx context.Context, query string, args ...interface{}) ([]domain.Account, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	var accounts []domain.Account
+	for rows.Next() {
+		account, err := scanAccount(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		accounts = append(accounts, *account)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return accounts, nil
+}
+
+func (r *accountRepo) GetAccountByID(ctx context.Context, accountID string) (*domain.Account, error) {
+	account, err := scanAccount(r.db.QueryRowContext(ctx, queryReadAccountByID, accountID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("account not found: %w", err)
+	} else if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	return account, nil
+}
+
+// CloseAccount closes an open account and records an ACCOUNT_CLOSED event.
+func (r *accountRepo) CloseAccount(ctx context.Context, accountID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var userID, status string
+	err = tx.QueryRowContext(ctx, queryLockAccountStatus, accountID).Scan(&userID, &status)
+	if errors.Is(err, sql.ErrNoRows) {
+		return sql.ErrNoRows
+	} else if err != nil {
+		return fmt.Errorf("failed to read account status: %w", err)
+	}
+	if status != domain.AccountStatusActive {
+		return fmt.Errorf("%w: account %s is %s", domain.ErrAccountNotActive, accountID, status)
+	}
+
+	if _, err := tx.ExecContext(ctx, queryCloseAccount, accountID); err != nil {
+		return fmt.Errorf("failed to close account: %w", err)
+	}
+
+	if err := insertAccountClosedEvent(ctx, tx, accountID, userID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// CloseUserAccounts closes all open accounts and the account group of a user.
+// It is idempotent, so it can run as an offboarding step.
+func (r *accountRepo) CloseUserAccounts(ctx context.Context, userID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, queryCloseUserAccounts, userID)
+	if err != nil {
+		return fmt.Errorf("failed to close accounts: %w", err)
+	}
+	var closed []string
+	for rows.Next() {
+		var accountID string
+		if err := rows.Scan(&accountID); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan row: %w", err)
+		}
+		closed = append(closed, accountID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("row iteration error: %w", err)
+	}
+
+	for _, accountID := range closed {
+		if err := insertAccountClosedEvent(ctx, tx, accountID, userID); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, queryCloseUserAccountGroups, userID); err != nil {
+		return fmt.Errorf("failed to close account group: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func insertAccountClosedEvent(ctx context.Context, tx *sql.Tx, accountID, userID string) error {
+	return insertOutboxEvent(ctx, tx, aggregateAccount, accountID, eventAccountClosed, map[string]interface{}{
+		"action":     eventAccountClosed,
+		"account_id": accountID,
+		"user_id":    userID,
+	})
+}
+
+// scanAccount reads an account row selected in the column order of queryReadAccounts.
+func scanAccount(row rowScanner) (*domain.Account, error) {
+	var (
+		account  domain.Account
+		closedAt sql.NullString
+	)
+
+	if err := row.Scan(
+		&account.ID, &account.CreatedAt, &account.UpdatedAt, &account.UserID, &account.AccountGroupID,
+		&account.Type, &account.Name, &account.Status, &closedAt,
+	); err != nil {
+		return nil, err
+	}
+	account.ClosedAt = closedAt.String
+
+	return &account, nil
+}
@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_OpenAccount_Success(t *testing.T) {
+	accounts := NewAccountRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status FROM users WHERE id = \$1 FOR UPDATE`).
+		WithArgs("u1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("ACTIVE"))
+	mock.ExpectQuery(`SELECT minor AND NOT EXISTS`).
+		WithArgs("u1").
+		WillReturnRows(sqlmock.NewRows([]string{"guardian_required"}).AddRow(false))
+	mock.ExpectQuery(`INSERT INTO account_groups`).
+		WithArgs("u1").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("g1"))
+	mock.ExpectQuery(`INSERT INTO accounts`).
+		WithArgs("u1", "g1", "TRADING", "Main", "ACTIVE").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow("a1", "2025-01-01T00:00:00Z", "2025-01-01T00:00:00Z"))
+	mock.ExpectExec(`INSERT INTO outbox`).
+		WithArgs("account", "a1", "ACCOUNT_OPENED", sqlmock.AnyArg(), 1, "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	account, err := accounts.OpenAccount(context.Background(), &domain.Account{UserID: "u1", Type: "TRADING", Name: "Main"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "a1", account.ID)
+	assert.Equal(t, "g1", account.AccountGroupID)
+	assert.Equal(t, "ACTIVE", account.Status)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_OpenAccount_UserNotActive(t *testing.T) {
+	accounts := NewAccountRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status FROM users WHERE id = \$1 FOR UPDATE`).
+		WithArgs("u1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("OFFBOARDING"))
+	mock.ExpectRollback()
+
+	account, err := accounts.OpenAccount(context.Background(), &domain.Account{UserID: "u1", Type: "TRADING"})
+
+	assert.ErrorIs(t, err, domain.ErrUserNotActive)
+	assert.Nil(t, account)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_OpenAccount_UserNotFound(t *testing.T) {
+	accounts := NewAccountRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status FROM users WHERE id = \$1 FOR UPDATE`).
+		WithArgs("u1").
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+
+	_, err := accounts.OpenAccount(context.Background(), &domain.Account{UserID: "u1", Type: "TRADING"})
+
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_GetAllAccounts_Filter(t *testing.T) {
+	accounts := NewAccountRepository(db)
+
+	rows := sqlmock.NewRows([]string{
+		"id", "created_at", "updated_at", "user_id", "account_group_id", "type", "name", "status", "closed_at",
+	}).
+		AddRow("a1", "2025-01-01T00:00:00Z", "2025-01-01T00:00:00Z", "u1", "g1", "TRADING", "", "CLOSED", "2025-01-02T00:00:00Z")
+
+	mock.ExpectQuery(`FROM accounts WHERE user_id = \$1 AND status = \$2 ORDER BY created_at ASC LIMIT \$3 OFFSET \$4`).
+		WithArgs("u1", "CLOSED", 100, 0).
+		WillReturnRows(rows)
+
+	result, _, err := accounts.GetAllAccounts(context.Background(), AccountFilter{UserID: "u1", Status: "CLOSED"},
+		Page{Limit: 100})
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, "2025-01-02T00:00:00Z", result[0].ClosedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_CloseAccount_Success(t *testing.T) {
+	accounts := NewAccountRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT user_id, status FROM accounts WHERE id = \$1 FOR UPDATE`).
+		WithArgs("a1").
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "status"}).AddRow("u1", "ACTIVE"))
+	mock.ExpectExec(`UPDATE accounts`).WithArgs("a1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO outbox`).
+		WithArgs("account", "a1", "ACCOUNT_CLOSED", sqlmock.AnyArg(), 1, "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err := accounts.CloseAccount(context.Background(), "a1")
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_CloseAccount_AlreadyClosed(t *testing.T) {
+	accounts := NewAccountRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT user_id, status FROM accounts WHERE id = \$1 FOR UPDATE`).
+		WithArgs("a1").
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "status"}).AddRow("u1", "CLOSED"))
+	mock.ExpectRollback()
+
+	err := accounts.CloseAccount(context.Background(), "a1")
+
+	assert.ErrorIs(t, err, domain.ErrAccountNotActive)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_CloseUserAccounts(t *testing.T) {
+	accounts := NewAccountRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`UPDATE accounts`).
+		WithArgs("u1").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("a1").AddRow("a2"))
+	mock.ExpectExec(`INSERT INTO outbox`).
+		WithArgs("account", "a1", "ACCOUNT_CLOSED", sqlmock.AnyArg(), 1, "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`INSERT INTO outbox`).
+		WithArgs("account", "a2", "ACCOUNT_CLOSED", sqlmock.AnyArg(), 1, "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`UPDATE account_groups`).WithArgs("u1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := accounts.CloseUserAccounts(context.Background(), "u1")
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,126 @@
+//go:generate mockery --name=AppropriatenessRepository --output=../../util/mocks --outpkg=mocks
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+)
+
+type AppropriatenessRepository interface {
+	CreateAssessment(ctx context.Context, assessment *domain.AppropriatenessAssessment) (*domain.AppropriatenessAssessment, error)
+	GetAssessments(ctx context.Context, userID string) ([]domain.AppropriatenessAssessment, error)
+}
+
+type appropriatenessRepo struct {
+	db *sql.DB
+}
+
+func NewAppropriatenessRepository(db *sql.DB) AppropriatenessRepository {
+	return &appropriatenessRepo{db: db}
+}
+
+// CreateAssessment stores a scored assessment of an ACTIVE user, which becomes
+// the user's current one, and records an APPROPRIATENESS_ASSESSED event.
+func (r *appropriatenessRepo) CreateAssessment(ctx context.Context, assessment *domain.AppropriatenessAssessment) (*domain.AppropriatenessAssessment, error) {
+	answers, err := json.Marshal(assessment.Answers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal answers: %w", err)
+	}
+	scores, err := json.Marshal(assessment.Scores)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal scores: %w", err)
+	}
+	allowed, err := json.Marshal(assessment.AllowedCategories)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal allowed_categories: %w", err)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var status string
+	err = tx.QueryRowContext(ctx, queryLockUserForPosting, assessment.UserID).Scan(&status)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, sql.ErrNoRows
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read user status: %w", err)
+	}
+	if status != domain.UserStatusActive {
+		return nil, fmt.Errorf("%w: user %s is %s", domain.ErrUserNotActive, assessment.UserID, status)
+	}
+
+	err = tx.QueryRowContext(ctx, queryCreateAppropriatenessAssessment,
+		assessment.UserID, assessment.QuestionnaireVersion, answers, scores, allowed,
+	).Scan(&assessment.ID, &assessment.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create assessment: %w", err)
+	}
+
+	if err := insertOutboxEvent(ctx, tx, aggregateAppropriatenessAssessment, assessment.ID, eventAppropriatenessAssessed, map[string]interface{}{
+		"action":     eventAppropriatenessAssessed,
+		"assessment": assessment,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return assessment, nil
+}
+
+// GetAssessments returns the assessments of a user, the current one first.
+func (r *appropriatenessRepo) GetAssessments(ctx context.Context, userID string) ([]domain.AppropriatenessAssessment, error) {
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, queryUserExists, userID).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("user not found: %w", sql.ErrNoRows)
+	}
+
+	rows, err := r.db.QueryContext(ctx, queryReadAppropriatenessAssessments, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	assessments := []domain.AppropriatenessAssessment{}
+	for rows.Next() {
+		var (
+			assessment               domain.AppropriatenessAssessment
+			answers, scores, allowed []byte
+		)
+		if err := rows.Scan(
+			&assessment.ID, &assessment.CreatedAt, &assessment.UserID, &assessment.QuestionnaireVersion,
+			&answers, &scores, &allowed,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		if err := json.Unmarshal(answers, &assessment.Answers); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal answers: %w", err)
+		}
+		if err := json.Unmarshal(scores, &assessment.Scores); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal scores: %w", err)
+		}
+		if err := json.Unmarshal(allowed, &assessment.AllowedCategories); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal allowed_categories: %w", err)
+		}
+		assessments = append(assessments, assessment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return assessments, nil
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestAssessment() *domain.AppropriatenessAssessment {
+	return &domain.AppropriatenessAssessment{
+		UserID:               "u1",
+		QuestionnaireVersion: 1,
+		Answers:              map[string]string{"ETF_KNOWLEDGE": "FALLS"},
+		Scores:               map[string]int{"ETF": 3},
+		AllowedCategories:    []string{"ETF"},
+	}
+}
+
+func Test_CreateAssessment_Success(t *testing.T) {
+	appropriatenessRepo := NewAppropriatenessRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status FROM users WHERE id = \$1 FOR SHARE`).
+		WithArgs("u1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("ACTIVE"))
+	mock.ExpectQuery(`INSERT INTO appropriateness_assessments`).
+		WithArgs("u1", 1, []byte(`{"ETF_KNOWLEDGE":"FALLS"}`), []byte(`{"ETF":3}`), []byte(`["ETF"]`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow("aa1", "2025-01-01T00:00:00Z"))
+	mock.ExpectExec(`INSERT INTO outbox`).
+		WithArgs("appropriateness_assessment", "aa1", "APPROPRIATENESS_ASSESSED", sqlmock.AnyArg(), 1, "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	assessment, err := appropriatenessRepo.CreateAssessment(context.Background(), newTestAssessment())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "aa1", assessment.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_CreateAssessment_UserNotActive(t *testing.T) {
+	appropriatenessRepo := NewAppropriatenessRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status FROM users WHERE id = \$1 FOR SHARE`).
+		WithArgs("u1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("OFFBOARDING"))
+	mock.ExpectRollback()
+
+	_, err := appropriatenessRepo.CreateAssessment(context.Background(), newTestAssessment())
+
+	assert.ErrorIs(t, err, domain.ErrUserNotActive)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_GetAssessments(t *testing.T) {
+	appropriatenessRepo := NewAppropriatenessRepository(db)
+
+	mock.ExpectQuery(`SELECT EXISTS`).
+		WithArgs("u1").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery(`FROM appropriateness_assessments WHERE user_id = \$1 ORDER BY created_at DESC`).
+		WithArgs("u1").
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "created_at", "user_id", "questionnaire_version", "answers", "scores", "allowed_categories",
+		}).
+			AddRow("aa2", "2025-02-01T00:00:00Z", "u1", 1, `{"ETF_KNOWLEDGE":"FALLS"}`, `{"ETF":3}`, `["ETF"]`).
+			AddRow("aa1", "2025-01-01T00:00:00Z", "u1", 1, `{"ETF_KNOWLEDGE":"RISES"}`, `{"ETF":0}`, `[]`))
+
+	assessments, err := appropriatenessRepo.GetAssessments(context.Background(), "u1")
+
+	assert.NoError(t, err)
+	assert.Len(t, assessments, 2)
+	assert.Equal(t, []string{"ETF"}, assessments[0].AllowedCategories)
+	assert.Empty(t, assessments[1].AllowedCategories)
+	assert.Equal(t, 0, assessments[1].Scores["ETF"])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,121 @@
+//go:generate mockery --name=BusinessRepository --output=../../util/mocks --outpkg=mocks
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+)
+
+type BusinessRepository interface {
+	CreateBusiness(ctx context.Context, business *domain.Business) (*domain.Business, error)
+	GetBusinessByID(ctx context.Context, businessID string) (*domain.Business, error)
+}
+
+type businessRepo struct {
+	db *sql.DB
+}
+
+func NewBusinessRepository(db *sql.DB) BusinessRepository {
+	return &businessRepo{db: db}
+}
+
+// CreateBusiness onboards a business whose persons are all ACTIVE users and
+// records a BUSINESS_CREATED event.
+func (r *businessRepo) CreateBusiness(ctx context.Context, business *domain.Business) (*domain.Business, error) {
+	address, err := json.Marshal(business.RegisteredAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal registered_address: %w", err)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, queryCreateBusiness,
+		business.Name, business.RegistrationNumber, business.LegalForm, address, business.LEI,
+	).Scan(&business.ID, &business.CreatedAt, &business.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", domain.ErrLEIAlreadyRegistered, business.LEI)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to create business: %w", err)
+	}
+
+	for _, person := range business.Persons {
+		var status string
+		err := tx.QueryRowContext(ctx, queryLockUserForPosting, person.UserID).Scan(&status)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: user %s does not exist", domain.ErrBusinessNotAccepted, person.UserID)
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read user status: %w", err)
+		}
+		if status != domain.UserStatusActive {
+			return nil, fmt.Errorf("%w: user %s is %s", domain.ErrBusinessNotAccepted, person.UserID, status)
+		}
+
+		if _, err := tx.ExecContext(ctx, queryCreateBusinessPerson,
+			business.ID, person.UserID, person.Role, person.OwnershipPercentage,
+		); err != nil {
+			return nil, fmt.Errorf("failed to add business person: %w", err)
+		}
+	}
+
+	if err := insertOutboxEvent(ctx, tx, aggregateBusiness, business.ID, eventBusinessCreated, map[string]interface{}{
+		"action":   eventBusinessCreated,
+		"business": business,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return business, nil
+}
+
+func (r *businessRepo) GetBusinessByID(ctx context.Context, businessID string) (*domain.Business, error) {
+	var (
+		business domain.Business
+		address  []byte
+	)
+	err := r.db.QueryRowContext(ctx, queryReadBusinessByID, businessID).Scan(
+		&business.ID, &business.CreatedAt, &business.UpdatedAt, &business.Name, &business.RegistrationNumber,
+		&business.LegalForm, &address, &business.LEI,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("business not found: %w", err)
+	} else if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	if err := json.Unmarshal(address, &business.RegisteredAddress); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal registered_address: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, queryReadBusinessPersons, businessID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	business.Persons = []domain.BusinessPerson{}
+	for rows.Next() {
+		var person domain.BusinessPerson
+		if err := rows.Scan(&person.UserID, &person.Role, &person.OwnershipPercentage); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		business.Persons = append(business.Persons, person)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return &business, nil
+}
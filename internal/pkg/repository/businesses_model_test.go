@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestBusiness() *domain.Business {
+	return &domain.Business{
+		Name:               "Muster GmbH",
+		RegistrationNumber: "HRB 123456",
+		LegalForm:          "GMBH",
+		RegisteredAddress:  domain.Address{AddressLine1: "Hauptstr. 1", Postcode: "10115", City: "Berlin", Country: "DE"},
+		LEI:                "529900T8BM49AURSDO55",
+		Persons: []domain.BusinessPerson{
+			{UserID: "u1", Role: "LEGAL_REPRESENTATIVE"},
+			{UserID: "u2", Role: "UBO", OwnershipPercentage: "75.5"},
+		},
+	}
+}
+
+func expectBusinessInsert() {
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO businesses`).
+		WithArgs("Muster GmbH", "HRB 123456", "GMBH", sqlmock.AnyArg(), "529900T8BM49AURSDO55").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).
+			AddRow("b1", "2025-01-01T00:00:00Z", "2025-01-01T00:00:00Z"))
+}
+
+func Test_CreateBusiness_Success(t *testing.T) {
+	businessRepo := NewBusinessRepository(db)
+
+	expectBusinessInsert()
+	mock.ExpectQuery(`SELECT status FROM users WHERE id = \$1 FOR SHARE`).
+		WithArgs("u1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("ACTIVE"))
+	mock.ExpectExec(`INSERT INTO business_persons`).
+		WithArgs("b1", "u1", "LEGAL_REPRESENTATIVE", "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(`SELECT status FROM users WHERE id = \$1 FOR SHARE`).
+		WithArgs("u2").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("ACTIVE"))
+	mock.ExpectExec(`INSERT INTO business_persons`).
+		WithArgs("b1", "u2", "UBO", "75.5").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`INSERT INTO outbox`).
+		WithArgs("business", "b1", "BUSINESS_CREATED", sqlmock.AnyArg(), 1, "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	business, err := businessRepo.CreateBusiness(context.Background(), newTestBusiness())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "b1", business.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_CreateBusiness_PersonNotActive(t *testing.T) {
+	businessRepo := NewBusinessRepository(db)
+
+	expectBusinessInsert()
+	mock.ExpectQuery(`SELECT status FROM users WHERE id = \$1 FOR SHARE`).
+		WithArgs("u1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("OFFBOARDED"))
+	mock.ExpectRollback()
+
+	_, err := businessRepo.CreateBusiness(context.Background(), newTestBusiness())
+
+	assert.ErrorIs(t, err, domain.ErrBusinessNotAccepted)
+	assert.EqualError(t, err, "business not accepted: user u1 is OFFBOARDED")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_CreateBusiness_LEIAlreadyRegistered(t *testing.T) {
+	businessRepo := NewBusinessRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO businesses`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}))
+	mock.ExpectRollback()
+
+	_, err := businessRepo.CreateBusiness(context.Background(), newTestBusiness())
+
+	assert.ErrorIs(t, err, domain.ErrLEIAlreadyRegistered)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_GetBusinessByID(t *testing.T) {
+	businessRepo := NewBusinessRepository(db)
+
+	mock.ExpectQuery(`FROM businesses WHERE id = \$1`).
+		WithArgs("b1").
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "created_at", "updated_at", "name", "registration_number", "legal_form", "registered_address", "lei",
+		}).AddRow("b1", "2025-01-01T00:00:00Z", "2025-01-01T00:00:00Z", "Muster GmbH", "HRB 123456", "GMBH",
+			`{"address_line1":"Hauptstr. 1","postcode":"10115","city":"Berlin","country":"DE"}`, "529900T8BM49AURSDO55"))
+	mock.ExpectQuery(`FROM business_persons WHERE business_id = \$1`).
+		WithArgs("b1").
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "role", "ownership_percentage"}).
+			AddRow("u1", "LEGAL_REPRESENTATIVE", "").
+			AddRow("u2", "UBO", "75.5"))
+
+	business, err := businessRepo.GetBusinessByID(context.Background(), "b1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Berlin", business.RegisteredAddress.City)
+	assert.Equal(t, newTestBusiness().Persons, business.Persons)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
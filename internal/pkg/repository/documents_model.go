@@ -0,0 +1,172 @@
+//go:generate mockery --name=DocumentRepository --output=../../util/mocks --outpkg=mocks
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+)
+
+type DocumentRepository interface {
+	CreateDocument(ctx context.Context, document *domain.Document) (*domain.Document, error)
+	GetDocuments(ctx context.Context, userID string) ([]domain.Document, error)
+	GetDocument(ctx context.Context, userID, documentID string) (*domain.Document, error)
+	ExpireDocuments(ctx context.Context, today time.Time) (int, error)
+}
+
+type documentRepo struct {
+	db *sql.DB
+}
+
+func NewDocumentRepository(db *sql.DB) DocumentRepository {
+	return &documentRepo{db: db}
+}
+
+// CreateDocument records a document of an ACTIVE user whose file is already in
+// blob storage and records a DOCUMENT_UPLOADED event.
+func (r *documentRepo) CreateDocument(ctx context.Context, document *domain.Document) (*domain.Document, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var status string
+	err = tx.QueryRowContext(ctx, queryLockUserForPosting, document.UserID).Scan(&status)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, sql.ErrNoRows
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read user status: %w", err)
+	}
+	if status != domain.UserStatusActive {
+		return nil, fmt.Errorf("%w: user %s is %s", domain.ErrUserNotActive, document.UserID, status)
+	}
+
+	err = tx.QueryRowContext(ctx, queryCreateDocument,
+		document.UserID, document.Type, document.IssuingCountry, document.ExpiryDate, document.FileName,
+		document.ContentType, document.Size, document.SHA256, document.StorageKey,
+	).Scan(&document.ID, &document.CreatedAt, &document.Status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create document: %w", err)
+	}
+
+	if err := insertOutboxEvent(ctx, tx, aggregateDocument, document.ID, eventDocumentUploaded, map[string]interface{}{
+		"action":   eventDocumentUploaded,
+		"user_id":  document.UserID,
+		"document": document,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return document, nil
+}
+
+// GetDocuments returns the documents of a user, the latest upload first.
+func (r *documentRepo) GetDocuments(ctx context.Context, userID string) ([]domain.Document, error) {
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, queryUserExists, userID).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("user not found: %w", sql.ErrNoRows)
+	}
+
+	rows, err := r.db.QueryContext(ctx, queryReadDocuments, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	documents := []domain.Document{}
+	for rows.Next() {
+		document, err := scanDocument(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		documents = append(documents, *document)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return documents, nil
+}
+
+func (r *documentRepo) GetDocument(ctx context.Context, userID, documentID string) (*domain.Document, error) {
+	document, err := scanDocument(r.db.QueryRowContext(ctx, queryReadDocument, userID, documentID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("document not found: %w", err)
+	} else if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	return document, nil
+}
+
+// ExpireDocuments marks every valid document that expired before the given day
+// as EXPIRED and records a DOCUMENT_EXPIRED event for each. It returns the
+// number of documents that expired.
+func (r *documentRepo) ExpireDocuments(ctx context.Context, today time.Time) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, queryExpireDocuments, today.Format(domain.DateLayout))
+	if err != nil {
+		return 0, fmt.Errorf("failed to expire documents: %w", err)
+	}
+	var expired []domain.Document
+	for rows.Next() {
+		document := domain.Document{Status: domain.DocumentStatusExpired}
+		if err := rows.Scan(&document.ID, &document.UserID, &document.Type, &document.ExpiryDate); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan row: %w", err)
+		}
+		expired = append(expired, document)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	for _, document := range expired {
+		if err := insertOutboxEvent(ctx, tx, aggregateDocument, document.ID, eventDocumentExpired, map[string]interface{}{
+			"action":      eventDocumentExpired,
+			"user_id":     document.UserID,
+			"document_id": document.ID,
+			"type":        document.Type,
+			"expiry_date": document.ExpiryDate,
+		}); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return len(expired), nil
+}
+
+func scanDocument(row rowScanner) (*domain.Document, error) {
+	var document domain.Document
+	err := row.Scan(
+		&document.ID, &document.CreatedAt, &document.UserID, &document.Type, &document.IssuingCountry,
+		&document.ExpiryDate, &document.FileName, &document.ContentType, &document.Size, &document.SHA256,
+		&document.Status, &document.StorageKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &document, nil
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+const testChecksum = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+var documentColumns = []string{
+	"id", "created_at", "user_id", "type", "issuing_country", "expiry_date",
+	"file_name", "content_type", "size", "sha256", "status", "storage_key",
+}
+
+func newTestDocument() *domain.Document {
+	return &domain.Document{
+		UserID:         "u1",
+		Type:           "PASSPORT",
+		IssuingCountry: "DE",
+		ExpiryDate:     "2030-01-31",
+		FileName:       "passport.pdf",
+		ContentType:    "application/pdf",
+		Size:           2048,
+		SHA256:         testChecksum,
+		StorageKey:     "u1/k1",
+	}
+}
+
+func Test_CreateDocument_Success(t *testing.T) {
+	documents := NewDocumentRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status FROM users WHERE id = \$1 FOR SHARE`).
+		WithArgs("u1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("ACTIVE"))
+	mock.ExpectQuery(`INSERT INTO documents`).
+		WithArgs("u1", "PASSPORT", "DE", "2030-01-31", "passport.pdf", "application/pdf", int64(2048), testChecksum, "u1/k1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "status"}).AddRow("d1", "2025-04-20T09:00:00Z", "VALID"))
+	mock.ExpectExec(`INSERT INTO outbox`).
+		WithArgs("document", "d1", "DOCUMENT_UPLOADED", sqlmock.AnyArg(), 1, "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	document, err := documents.CreateDocument(context.Background(), newTestDocument())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "d1", document.ID)
+	assert.Equal(t, "VALID", document.Status)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_CreateDocument_UserNotActive(t *testing.T) {
+	documents := NewDocumentRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status FROM users WHERE id = \$1 FOR SHARE`).
+		WithArgs("u1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("OFFBOARDED"))
+	mock.ExpectRollback()
+
+	_, err := documents.CreateDocument(context.Background(), newTestDocument())
+
+	assert.ErrorIs(t, err, domain.ErrUserNotActive)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_GetDocuments_Success(t *testing.T) {
+	documents := NewDocumentRepository(db)
+
+	mock.ExpectQuery(`SELECT EXISTS`).
+		WithArgs("u1").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery(`FROM documents WHERE user_id = \$1 ORDER BY created_at DESC`).
+		WithArgs("u1").
+		WillReturnRows(sqlmock.NewRows(documentColumns).
+			AddRow("d2", "2025-04-20T09:00:00Z", "u1", "PROOF_OF_ADDRESS", "DE", "", "bill.png", "image/png", 512, testChecksum, "VALID", "u1/k2").
+			AddRow("d1", "2025-04-19T09:00:00Z", "u1", "PASSPORT", "DE", "2030-01-31", "passport.pdf", "application/pdf", 2048, testChecksum, "VALID", "u1/k1"))
+
+	result, err := documents.GetDocuments(context.Background(), "u1")
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, "", result[0].ExpiryDate)
+	assert.Equal(t, "u1/k1", result[1].StorageKey)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_GetDocument_NotFound(t *testing.T) {
+	documents := NewDocumentRepository(db)
+
+	mock.ExpectQuery(`FROM documents WHERE user_id = \$1 AND id = \$2`).
+		WithArgs("u1", "d1").
+		WillReturnError(sql.ErrNoRows)
+
+	_, err := documents.GetDocument(context.Background(), "u1", "d1")
+
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_ExpireDocuments(t *testing.T) {
+	documents := NewDocumentRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`UPDATE documents SET status = 'EXPIRED'`).
+		WithArgs("2025-04-20").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "type", "expiry_date"}).
+			AddRow("d1", "u1", "PASSPORT", "2025-04-19").
+			AddRow("d3", "u2", "ID_CARD", "2025-04-01"))
+	mock.ExpectExec(`INSERT INTO outbox`).
+		WithArgs("document", "d1", "DOCUMENT_EXPIRED", sqlmock.AnyArg(), 1, "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`INSERT INTO outbox`).
+		WithArgs("document", "d3", "DOCUMENT_EXPIRED", sqlmock.AnyArg(), 1, "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	count, err := documents.ExpireDocuments(context.Background(), time.Date(2025, 4, 20, 0, 0, 0, 0, time.UTC))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
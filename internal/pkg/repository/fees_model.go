@@ -0,0 +1,258 @@
+//go:generate mockery --name=FeeRepository --output=../../util/mocks --outpkg=mocks
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/ashwingopalsamy/upvest-api/internal/event"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/fees"
+)
+
+type FeeRepository interface {
+	SetFeeSchedule(ctx context.Context, schedule *domain.FeeSchedule) (*domain.FeeSchedule, error)
+	GetFeeSchedules(ctx context.Context) ([]domain.FeeSchedule, error)
+	AssignUserFeeSegment(ctx context.Context, userID, segment string) error
+	ChargeFee(ctx context.Context, userID string, charge *domain.FeeCharge) (*domain.Fee, error)
+	GetUserFees(ctx context.Context, userID string) ([]domain.Fee, error)
+}
+
+type feeRepo struct {
+	db *sql.DB
+}
+
+func NewFeeRepository(db *sql.DB) FeeRepository {
+	return &feeRepo{db: db}
+}
+
+// SetFeeSchedule creates the schedule of a fee for a segment and currency, or
+// replaces the existing one. Fees charged before keep their amounts.
+func (r *feeRepo) SetFeeSchedule(ctx context.Context, schedule *domain.FeeSchedule) (*domain.FeeSchedule, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, queryUpsertFeeSchedule,
+		schedule.Segment, schedule.FeeType, schedule.Method, schedule.Currency, schedule.FlatAmount,
+		schedule.Rate, schedule.MinAmount, schedule.MaxAmount,
+	).Scan(&schedule.ID, &schedule.CreatedAt, &schedule.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set fee schedule: %w", err)
+	}
+
+	if err := insertOutboxEvent(ctx, tx, aggregateFeeSchedule, schedule.ID, eventFeeScheduleSet, map[string]interface{}{
+		"action":       eventFeeScheduleSet,
+		"fee_schedule": schedule,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return schedule, nil
+}
+
+func (r *feeRepo) GetFeeSchedules(ctx context.Context) ([]domain.FeeSchedule, error) {
+	rows, err := r.db.QueryContext(ctx, queryReadFeeSchedules)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	schedules := []domain.FeeSchedule{}
+	for rows.Next() {
+		schedule, err := scanFeeSchedule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		schedules = append(schedules, *schedule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return schedules, nil
+}
+
+// AssignUserFeeSegment moves a user into a segment. Fees charged from then on
+// follow the segment's schedules.
+func (r *feeRepo) AssignUserFeeSegment(ctx context.Context, userID, segment string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	if err := tx.QueryRowContext(ctx, queryUserExists, userID).Scan(&exists); err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("user not found: %w", sql.ErrNoRows)
+	}
+
+	if _, err := tx.ExecContext(ctx, queryUpsertUserFeeSegment, userID, segment); err != nil {
+		return fmt.Errorf("failed to assign fee segment: %w", err)
+	}
+
+	if err := insertEvent(ctx, tx, aggregateUser, userID, event.UserFeeSegmentAssigned{UserID: userID, Segment: segment}); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ChargeFee calculates a fee under the schedule of the user's segment, books it
+// from the user's available cash to FEE_INCOME and records a FEE_CHARGED event.
+// Fees are charged even if they overdraw the available cash. A fee that rounds
+// to zero is recorded without a journal entry. Users that are held, offboarding
+// or offboarded are not charged: a held user's cash is frozen, and offboarding
+// users were checked to hold no cash.
+func (r *feeRepo) ChargeFee(ctx context.Context, userID string, charge *domain.FeeCharge) (*domain.Fee, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var status string
+	err = tx.QueryRowContext(ctx, queryLockUserForPosting, userID).Scan(&status)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, sql.ErrNoRows
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read user status: %w", err)
+	}
+	switch status {
+	case domain.UserStatusHeld, domain.UserStatusOffboarding, domain.UserStatusOffboarded:
+		return nil, fmt.Errorf("%w: user %s is %s", domain.ErrUserNotActive, userID, status)
+	}
+
+	schedule, err := scanFeeSchedule(tx.QueryRowContext(ctx, queryReadUserFeeSchedule, userID, charge.FeeType, charge.Currency))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s fees in %s", domain.ErrNoFeeSchedule, charge.FeeType, charge.Currency)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read fee schedule: %w", err)
+	}
+
+	amount, err := fees.Calculate(*schedule, *charge)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate fee: %w", err)
+	}
+
+	fee := domain.Fee{
+		UserID:        userID,
+		FeeScheduleID: schedule.ID,
+		FeeType:       charge.FeeType,
+		Reference:     charge.Reference,
+		BasisAmount:   charge.BasisAmount,
+		Amount:        amount,
+		Currency:      charge.Currency,
+	}
+
+	if amount > 0 {
+		entry := domain.JournalEntry{
+			Description: fmt.Sprintf("%s fee", charge.FeeType),
+			Reference:   charge.Reference,
+			Postings: []domain.Posting{
+				{LedgerAccount: domain.LedgerAccountUserAvailable, UserID: userID, Currency: charge.Currency, Amount: -amount},
+				{LedgerAccount: domain.LedgerAccountFeeIncome, Currency: charge.Currency, Amount: amount},
+			},
+		}
+		if err := insertJournalEntry(ctx, tx, &entry); err != nil {
+			return nil, err
+		}
+		fee.JournalEntryID = entry.ID
+	}
+
+	err = tx.QueryRowContext(ctx, queryCreateFee,
+		fee.UserID, fee.FeeScheduleID, fee.FeeType, fee.Reference, fee.BasisAmount, fee.Amount, fee.Currency,
+		fee.JournalEntryID,
+	).Scan(&fee.ID, &fee.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s fee for %s", domain.ErrFeeAlreadyCharged, charge.FeeType, charge.Reference)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to record fee: %w", err)
+	}
+
+	if err := insertOutboxEvent(ctx, tx, aggregateFee, fee.ID, eventFeeCharged, map[string]interface{}{
+		"action": eventFeeCharged,
+		"fee":    fee,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &fee, nil
+}
+
+// GetUserFees returns the fees charged to a user, most recent first.
+func (r *feeRepo) GetUserFees(ctx context.Context, userID string) ([]domain.Fee, error) {
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, queryUserExists, userID).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("user not found: %w", sql.ErrNoRows)
+	}
+
+	rows, err := r.db.QueryContext(ctx, queryReadUserFees, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	charged := []domain.Fee{}
+	for rows.Next() {
+		var (
+			fee            domain.Fee
+			journalEntryID sql.NullString
+		)
+		if err := rows.Scan(
+			&fee.ID, &fee.CreatedAt, &fee.UserID, &fee.FeeScheduleID, &fee.FeeType, &fee.Reference,
+			&fee.BasisAmount, &fee.Amount, &fee.Currency, &journalEntryID,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		fee.JournalEntryID = journalEntryID.String
+		charged = append(charged, fee)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return charged, nil
+}
+
+// scanFeeSchedule reads a fee schedule row selected with feeScheduleColumns.
+func scanFeeSchedule(row rowScanner) (*domain.FeeSchedule, error) {
+	var (
+		schedule domain.FeeSchedule
+		rate     sql.NullString
+	)
+
+	if err := row.Scan(
+		&schedule.ID, &schedule.CreatedAt, &schedule.UpdatedAt, &schedule.Segment, &schedule.FeeType,
+		&schedule.Method, &schedule.Currency, &schedule.FlatAmount, &rate, &schedule.MinAmount, &schedule.MaxAmount,
+	); err != nil {
+		return nil, err
+	}
+	schedule.Rate = rate.String
+
+	return &schedule, nil
+}
@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+var feeScheduleColumnNames = []string{
+	"id", "created_at", "updated_at", "segment", "fee_type", "method", "currency", "flat_amount", "rate",
+	"min_amount", "max_amount",
+}
+
+func expectFeeSchedule(method string, flatAmount, minAmount int64, rate interface{}) {
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status FROM users WHERE id = \$1 FOR SHARE`).
+		WithArgs("u1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("ACTIVE"))
+	mock.ExpectQuery(`FROM fee_schedules`).
+		WithArgs("u1", "TRADE", "EUR").
+		WillReturnRows(sqlmock.NewRows(feeScheduleColumnNames).AddRow("fs1", "2025-01-01T00:00:00Z",
+			"2025-01-01T00:00:00Z", "PREMIUM", "TRADE", method, "EUR", flatAmount, rate, minAmount, 2500))
+}
+
+func expectFeeEntry(amount int64) {
+	mock.ExpectQuery(`INSERT INTO journal_entries`).
+		WithArgs("TRADE fee", "o1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow("j1", "2025-01-01T00:00:00Z"))
+	mock.ExpectQuery(`INSERT INTO ledger_postings`).
+		WithArgs("j1", "USER_AVAILABLE", "u1", "EUR", -amount).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow("p1", "2025-01-01T00:00:00Z"))
+	mock.ExpectExec(`INSERT INTO outbox`).
+		WithArgs("journal_entry", "j1", "LEDGER_POSTING_CREATED", sqlmock.AnyArg(), 1, "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(`INSERT INTO ledger_postings`).
+		WithArgs("j1", "FEE_INCOME", "", "EUR", amount).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow("p2", "2025-01-01T00:00:00Z"))
+	mock.ExpectExec(`INSERT INTO outbox`).
+		WithArgs("journal_entry", "j1", "LEDGER_POSTING_CREATED", sqlmock.AnyArg(), 1, "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+}
+
+func newTestTradeFeeCharge() *domain.FeeCharge {
+	return &domain.FeeCharge{FeeType: "TRADE", Reference: "o1", Currency: "EUR", BasisAmount: 100000}
+}
+
+func Test_ChargeFee_Success(t *testing.T) {
+	feeRepo := NewFeeRepository(db)
+
+	expectFeeSchedule("PERCENTAGE", 0, 99, "0.25")
+	expectFeeEntry(250)
+	mock.ExpectQuery(`INSERT INTO fees`).
+		WithArgs("u1", "fs1", "TRADE", "o1", int64(100000), int64(250), "EUR", "j1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow("f1", "2025-01-01T00:00:00Z"))
+	mock.ExpectExec(`INSERT INTO outbox`).
+		WithArgs("fee", "f1", "FEE_CHARGED", sqlmock.AnyArg(), 1, "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	fee, err := feeRepo.ChargeFee(context.Background(), "u1", newTestTradeFeeCharge())
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(250), fee.Amount)
+	assert.Equal(t, "j1", fee.JournalEntryID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_ChargeFee_ZeroFee(t *testing.T) {
+	feeRepo := NewFeeRepository(db)
+
+	charge := newTestTradeFeeCharge()
+	charge.BasisAmount = 100
+
+	expectFeeSchedule("PERCENTAGE", 0, 0, "0.25")
+	mock.ExpectQuery(`INSERT INTO fees`).
+		WithArgs("u1", "fs1", "TRADE", "o1", int64(100), int64(0), "EUR", "").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow("f1", "2025-01-01T00:00:00Z"))
+	mock.ExpectExec(`INSERT INTO outbox`).
+		WithArgs("fee", "f1", "FEE_CHARGED", sqlmock.AnyArg(), 1, "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	fee, err := feeRepo.ChargeFee(context.Background(), "u1", charge)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), fee.Amount)
+	assert.Empty(t, fee.JournalEntryID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_ChargeFee_AlreadyCharged(t *testing.T) {
+	feeRepo := NewFeeRepository(db)
+
+	expectFeeSchedule("FLAT", 100, 0, nil)
+	expectFeeEntry(100)
+	mock.ExpectQuery(`INSERT INTO fees`).
+		WithArgs("u1", "fs1", "TRADE", "o1", int64(100000), int64(100), "EUR", "j1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}))
+	mock.ExpectRollback()
+
+	_, err := feeRepo.ChargeFee(context.Background(), "u1", newTestTradeFeeCharge())
+
+	assert.ErrorIs(t, err, domain.ErrFeeAlreadyCharged)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_ChargeFee_UserNotChargeable(t *testing.T) {
+	for _, status := range []string{"HELD", "OFFBOARDING", "OFFBOARDED"} {
+		t.Run(status, func(t *testing.T) {
+			feeRepo := NewFeeRepository(db)
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(`SELECT status FROM users WHERE id = \$1 FOR SHARE`).
+				WithArgs("u1").
+				WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(status))
+			mock.ExpectRollback()
+
+			_, err := feeRepo.ChargeFee(context.Background(), "u1", newTestTradeFeeCharge())
+
+			assert.ErrorIs(t, err, domain.ErrUserNotActive)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_ChargeFee_NoSchedule(t *testing.T) {
+	feeRepo := NewFeeRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status FROM users WHERE id = \$1 FOR SHARE`).
+		WithArgs("u1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("ACTIVE"))
+	mock.ExpectQuery(`FROM fee_schedules`).
+		WithArgs("u1", "TRADE", "EUR").
+		WillReturnRows(sqlmock.NewRows(feeScheduleColumnNames))
+	mock.ExpectRollback()
+
+	_, err := feeRepo.ChargeFee(context.Background(), "u1", newTestTradeFeeCharge())
+
+	assert.ErrorIs(t, err, domain.ErrNoFeeSchedule)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_AssignUserFeeSegment(t *testing.T) {
+	feeRepo := NewFeeRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT EXISTS`).
+		WithArgs("u1").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectExec(`INSERT INTO user_fee_segments`).
+		WithArgs("u1", "PREMIUM").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`INSERT INTO outbox`).
+		WithArgs("user", "u1", "USER_FEE_SEGMENT_ASSIGNED", sqlmock.AnyArg(), 2, "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	assert.NoError(t, feeRepo.AssignUserFeeSegment(context.Background(), "u1", "PREMIUM"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
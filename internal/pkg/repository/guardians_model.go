@@ -0,0 +1,240 @@
+//go:generate mockery --name=GuardianRepository --output=../../util/mocks --outpkg=mocks
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/ashwingopalsamy/upvest-api/internal/event"
+)
+
+type GuardianRepository interface {
+	AddGuardian(ctx context.Context, userID, guardianID string) (*domain.Guardianship, error)
+	GetGuardians(ctx context.Context, userID string) ([]domain.Guardianship, error)
+	RecordComingOfAge(ctx context.Context, today time.Time) (int, error)
+}
+
+type guardianRepo struct {
+	db  *sql.DB
+	now func() time.Time
+}
+
+func NewGuardianRepository(db *sql.DB) GuardianRepository {
+	return &guardianRepo{db: db, now: time.Now}
+}
+
+// AddGuardian links an ACTIVE minor to an ACTIVE adult user as guardian and
+// records a USER_GUARDIAN_ADDED event. The minor stays locked until commit, so
+// concurrent requests cannot exceed MaxGuardians.
+func (r *guardianRepo) AddGuardian(ctx context.Context, userID, guardianID string) (*domain.Guardianship, error) {
+	today := r.now().UTC()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	minor, err := lockGuardianshipParty(ctx, tx, queryLockMinor, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, sql.ErrNoRows
+	} else if err != nil {
+		return nil, err
+	}
+	if minor.Status != domain.UserStatusActive {
+		return nil, fmt.Errorf("%w: user %s is %s", domain.ErrUserNotActive, userID, minor.Status)
+	}
+	if !minor.IsMinorOn(today) {
+		return nil, fmt.Errorf("%w: user %s is not a minor", domain.ErrGuardianNotAccepted, userID)
+	}
+
+	guardianIDs, err := readActiveGuardianIDs(ctx, tx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range guardianIDs {
+		if id == guardianID {
+			return nil, fmt.Errorf("%w: user %s is already a guardian of user %s", domain.ErrGuardianNotAccepted, guardianID, userID)
+		}
+	}
+	if len(guardianIDs) >= domain.MaxGuardians {
+		return nil, fmt.Errorf("%w: user %s", domain.ErrTooManyGuardians, userID)
+	}
+
+	guardian, err := lockGuardianshipParty(ctx, tx, queryLockGuardian, guardianID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: user %s does not exist", domain.ErrGuardianNotAccepted, guardianID)
+	} else if err != nil {
+		return nil, err
+	}
+	if guardian.Status != domain.UserStatusActive {
+		return nil, fmt.Errorf("%w: user %s is %s", domain.ErrGuardianNotAccepted, guardianID, guardian.Status)
+	}
+	if guardian.IsMinorOn(today) {
+		return nil, fmt.Errorf("%w: user %s is a minor", domain.ErrGuardianNotAccepted, guardianID)
+	}
+
+	guardianship := &domain.Guardianship{UserID: userID, GuardianID: guardianID}
+	if err := tx.QueryRowContext(ctx, queryCreateGuardianship, userID, guardianID).Scan(&guardianship.CreatedAt); err != nil {
+		return nil, fmt.Errorf("failed to add guardian: %w", err)
+	}
+
+	if err := insertEvent(ctx, tx, aggregateUser, userID, event.UserGuardianAdded{UserID: userID, Guardianship: guardianship}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return guardianship, nil
+}
+
+// GetGuardians returns the current and past guardianships of a user.
+func (r *guardianRepo) GetGuardians(ctx context.Context, userID string) ([]domain.Guardianship, error) {
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, queryUserExists, userID).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("user not found: %w", sql.ErrNoRows)
+	}
+
+	rows, err := r.db.QueryContext(ctx, queryReadGuardianships, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	guardianships := []domain.Guardianship{}
+	for rows.Next() {
+		var (
+			guardianship domain.Guardianship
+			endedAt      sql.NullString
+		)
+		if err := rows.Scan(&guardianship.UserID, &guardianship.GuardianID, &guardianship.CreatedAt, &endedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		guardianship.EndedAt = endedAt.String
+		guardianships = append(guardianships, guardianship)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return guardianships, nil
+}
+
+// RecordComingOfAge clears the minor flag of every user who is of age on the
+// given day, ends their guardianships and records a USER_CAME_OF_AGE event for
+// each. It returns the number of users that came of age.
+func (r *guardianRepo) RecordComingOfAge(ctx context.Context, today time.Time) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	cutoff := domain.ComingOfAgeCutoff(today).Format(domain.DateLayout)
+	rows, err := tx.QueryContext(ctx, queryLockComingOfAge, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("failed to execute query: %w", err)
+	}
+	var users []domain.User
+	for rows.Next() {
+		var user domain.User
+		if err := rows.Scan(&user.ID, &user.BirthDate); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan row: %w", err)
+		}
+		users = append(users, user)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	for _, user := range users {
+		if _, err := tx.ExecContext(ctx, queryEndMinority, user.ID); err != nil {
+			return 0, fmt.Errorf("failed to end minority of user %s: %w", user.ID, err)
+		}
+		guardianIDs, err := endGuardianships(ctx, tx, user.ID)
+		if err != nil {
+			return 0, err
+		}
+
+		if err := insertEvent(ctx, tx, aggregateUser, user.ID, event.UserCameOfAge{
+			UserID:      user.ID,
+			BirthDate:   user.BirthDate,
+			GuardianIDs: guardianIDs,
+		}); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return len(users), nil
+}
+
+// lockGuardianshipParty reads the status and birth date of a user with the
+// given locking query.
+func lockGuardianshipParty(ctx context.Context, tx *sql.Tx, query, userID string) (*domain.User, error) {
+	user := &domain.User{ID: userID}
+	err := tx.QueryRowContext(ctx, query, userID).Scan(&user.Status, &user.BirthDate)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read user %s: %w", userID, err)
+	}
+	return user, nil
+}
+
+func readActiveGuardianIDs(ctx context.Context, tx *sql.Tx, userID string) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, queryReadActiveGuardianIDs, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read guardians: %w", err)
+	}
+	defer rows.Close()
+
+	var guardianIDs []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		guardianIDs = append(guardianIDs, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+	return guardianIDs, nil
+}
+
+func endGuardianships(ctx context.Context, tx *sql.Tx, userID string) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, queryEndGuardianships, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to end guardianships of user %s: %w", userID, err)
+	}
+	defer rows.Close()
+
+	guardianIDs := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		guardianIDs = append(guardianIDs, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+	return guardianIDs, nil
+}
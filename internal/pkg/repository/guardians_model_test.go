@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestGuardianRepository() GuardianRepository {
+	return &guardianRepo{db: db, now: func() time.Time { return time.Date(2025, 4, 13, 9, 0, 0, 0, time.UTC) }}
+}
+
+func expectLockMinor(status, birthDate string) {
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status, to_char\(birth_date, 'YYYY-MM-DD'\) FROM users WHERE id = \$1 FOR UPDATE`).
+		WithArgs("m1").
+		WillReturnRows(sqlmock.NewRows([]string{"status", "birth_date"}).AddRow(status, birthDate))
+}
+
+func expectActiveGuardians(guardianIDs ...string) {
+	rows := sqlmock.NewRows([]string{"guardian_id"})
+	for _, id := range guardianIDs {
+		rows.AddRow(id)
+	}
+	mock.ExpectQuery(`SELECT guardian_id FROM user_guardians`).WithArgs("m1").WillReturnRows(rows)
+}
+
+func Test_AddGuardian_Success(t *testing.T) {
+	guardians := newTestGuardianRepository()
+
+	expectLockMinor("ACTIVE", "2010-05-01")
+	expectActiveGuardians("g0")
+	mock.ExpectQuery(`SELECT status, to_char\(birth_date, 'YYYY-MM-DD'\) FROM users WHERE id = \$1 FOR SHARE`).
+		WithArgs("g1").
+		WillReturnRows(sqlmock.NewRows([]string{"status", "birth_date"}).AddRow("ACTIVE", "1980-01-01"))
+	mock.ExpectQuery(`INSERT INTO user_guardians`).
+		WithArgs("m1", "g1").
+		WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow("2025-04-13T09:00:00Z"))
+	mock.ExpectExec(`INSERT INTO outbox`).
+		WithArgs("user", "m1", "USER_GUARDIAN_ADDED", sqlmock.AnyArg(), 2, "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	guardianship, err := guardians.AddGuardian(context.Background(), "m1", "g1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.Guardianship{UserID: "m1", GuardianID: "g1", CreatedAt: "2025-04-13T09:00:00Z"}, guardianship)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_AddGuardian_UserNotFound(t *testing.T) {
+	guardians := newTestGuardianRepository()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status, to_char\(birth_date, 'YYYY-MM-DD'\) FROM users WHERE id = \$1 FOR UPDATE`).
+		WithArgs("m1").
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+
+	_, err := guardians.AddGuardian(context.Background(), "m1", "g1")
+
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_AddGuardian_NotAMinor(t *testing.T) {
+	guardians := newTestGuardianRepository()
+
+	expectLockMinor("ACTIVE", "2007-04-13")
+	mock.ExpectRollback()
+
+	_, err := guardians.AddGuardian(context.Background(), "m1", "g1")
+
+	assert.ErrorIs(t, err, domain.ErrGuardianNotAccepted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_AddGuardian_TooManyGuardians(t *testing.T) {
+	guardians := newTestGuardianRepository()
+
+	expectLockMinor("ACTIVE", "2010-05-01")
+	expectActiveGuardians("g0", "g2")
+	mock.ExpectRollback()
+
+	_, err := guardians.AddGuardian(context.Background(), "m1", "g1")
+
+	assert.ErrorIs(t, err, domain.ErrTooManyGuardians)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_AddGuardian_GuardianIsMinor(t *testing.T) {
+	guardians := newTestGuardianRepository()
+
+	expectLockMinor("ACTIVE", "2010-05-01")
+	expectActiveGuardians()
+	mock.ExpectQuery(`SELECT status, to_char\(birth_date, 'YYYY-MM-DD'\) FROM users WHERE id = \$1 FOR SHARE`).
+		WithArgs("g1").
+		WillReturnRows(sqlmock.NewRows([]string{"status", "birth_date"}).AddRow("ACTIVE", "2007-04-14"))
+	mock.ExpectRollback()
+
+	_, err := guardians.AddGuardian(context.Background(), "m1", "g1")
+
+	assert.ErrorIs(t, err, domain.ErrGuardianNotAccepted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_GetGuardians_Success(t *testing.T) {
+	guardians := newTestGuardianRepository()
+
+	mock.ExpectQuery(`SELECT EXISTS`).
+		WithArgs("m1").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery(`SELECT user_id, guardian_id, created_at, ended_at`).
+		WithArgs("m1").
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "guardian_id", "created_at", "ended_at"}).
+			AddRow("m1", "g1", "2025-04-13T09:00:00Z", nil))
+
+	result, err := guardians.GetGuardians(context.Background(), "m1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Guardianship{{UserID: "m1", GuardianID: "g1", CreatedAt: "2025-04-13T09:00:00Z"}}, result)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_RecordComingOfAge(t *testing.T) {
+	guardians := newTestGuardianRepository()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT id, to_char\(birth_date, 'YYYY-MM-DD'\) FROM users`).
+		WithArgs("2007-04-13").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "birth_date"}).AddRow("m1", "2007-04-13"))
+	mock.ExpectExec(`UPDATE users SET minor = FALSE`).
+		WithArgs("m1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`UPDATE user_guardians SET ended_at = NOW\(\)`).
+		WithArgs("m1").
+		WillReturnRows(sqlmock.NewRows([]string{"guardian_id"}).AddRow("g1"))
+	mock.ExpectExec(`INSERT INTO outbox`).
+		WithArgs("user", "m1", "USER_CAME_OF_AGE", sqlmock.AnyArg(), 2, "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	count, err := guardians.RecordComingOfAge(context.Background(), time.Date(2025, 4, 13, 0, 0, 0, 0, time.UTC))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,114 @@
+//go:generate mockery --name=IdempotencyRepository --output=../../util/mocks --outpkg=mocks
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// IdempotencyRecord is a request stored under an Idempotency-Key, together with
+// its response once the request has completed.
+type IdempotencyRecord struct {
+	Key         string
+	Fingerprint string
+	Completed   bool
+	StatusCode  int
+	Header      map[string][]string
+	Body        []byte
+}
+
+type IdempotencyRepository interface {
+	// Reserve claims key for a request with the given fingerprint and holds it
+	// for lease. When the key is already taken, it returns the stored record and
+	// false.
+	Reserve(ctx context.Context, key, fingerprint string, lease time.Duration) (*IdempotencyRecord, bool, error)
+	// Renew holds a key that is still being processed for another lease.
+	Renew(ctx context.Context, key string, lease time.Duration) error
+	Complete(ctx context.Context, key string, statusCode int, header map[string][]string, body []byte) error
+	Release(ctx context.Context, key string) error
+	// DeleteExpired deletes the keys that expired before today and returns how
+	// many were deleted.
+	DeleteExpired(ctx context.Context, today time.Time) (int, error)
+}
+
+type idempotencyRepo struct {
+	db *sql.DB
+}
+
+func NewIdempotencyRepository(db *sql.DB) IdempotencyRepository {
+	return &idempotencyRepo{db: db}
+}
+
+func (r *idempotencyRepo) Reserve(ctx context.Context, key, fingerprint string, lease time.Duration) (*IdempotencyRecord, bool, error) {
+	var reserved string
+	err := r.db.QueryRowContext(ctx, queryReserveIdempotencyKey, key, fingerprint, lease.Milliseconds()).Scan(&reserved)
+	if err == nil {
+		return nil, true, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, false, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+
+	var (
+		record     = IdempotencyRecord{Key: key}
+		statusCode sql.NullInt64
+		header     sql.NullString
+	)
+	err = r.db.QueryRowContext(ctx, queryReadIdempotencyKey, key).Scan(
+		&record.Fingerprint, &statusCode, &header, &record.Body, &record.Completed,
+	)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read idempotency key: %w", err)
+	}
+
+	record.StatusCode = int(statusCode.Int64)
+	if header.Valid {
+		if err := json.Unmarshal([]byte(header.String), &record.Header); err != nil {
+			return nil, false, fmt.Errorf("failed to unmarshal response headers: %w", err)
+		}
+	}
+
+	return &record, false, nil
+}
+
+func (r *idempotencyRepo) Renew(ctx context.Context, key string, lease time.Duration) error {
+	if _, err := r.db.ExecContext(ctx, queryRenewIdempotencyKey, key, lease.Milliseconds()); err != nil {
+		return fmt.Errorf("failed to renew idempotency key: %w", err)
+	}
+	return nil
+}
+
+func (r *idempotencyRepo) Complete(ctx context.Context, key string, statusCode int, header map[string][]string, body []byte) error {
+	headerJSON, err := json.Marshal(header)
+	if err != nil {
+		return fmt.Errorf("failed to marshal response headers: %w", err)
+	}
+
+	if _, err := r.db.ExecContext(ctx, queryCompleteIdempotencyKey, key, statusCode, headerJSON, body); err != nil {
+		return fmt.Errorf("failed to store idempotent response: %w", err)
+	}
+	return nil
+}
+
+func (r *idempotencyRepo) Release(ctx context.Context, key string) error {
+	if _, err := r.db.ExecContext(ctx, queryReleaseIdempotencyKey, key); err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
+
+func (r *idempotencyRepo) DeleteExpired(ctx context.Context, today time.Time) (int, error) {
+	result, err := r.db.ExecContext(ctx, queryDeleteExpiredIdempotencyKeys, today)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+	return int(deleted), nil
+}
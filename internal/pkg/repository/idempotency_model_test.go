@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Reserve_LeasesKey(t *testing.T) {
+	idempotency := NewIdempotencyRepository(db)
+
+	mock.ExpectQuery(`INSERT INTO idempotency_keys`).
+		WithArgs("key-1", "fp", int64(240000)).
+		WillReturnRows(sqlmock.NewRows([]string{"key"}).AddRow("key-1"))
+
+	record, reserved, err := idempotency.Reserve(context.Background(), "key-1", "fp", 4*time.Minute)
+
+	assert.NoError(t, err)
+	assert.True(t, reserved)
+	assert.Nil(t, record)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_Renew_ExtendsLease(t *testing.T) {
+	idempotency := NewIdempotencyRepository(db)
+
+	mock.ExpectExec(`UPDATE idempotency_keys\s+SET locked_until`).
+		WithArgs("key-1", int64(240000)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := idempotency.Renew(context.Background(), "key-1", 4*time.Minute)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_DeleteExpired_ReturnsDeletedCount(t *testing.T) {
+	idempotency := NewIdempotencyRepository(db)
+	today := time.Date(2025, 5, 18, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectExec(`DELETE FROM idempotency_keys`).
+		WithArgs(today).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	deleted, err := idempotency.DeleteExpired(context.Background(), today)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, deleted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
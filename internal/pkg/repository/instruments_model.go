@@ -0,0 +1,183 @@
+//go:generate mockery --name=InstrumentRepository --output=../../util/mocks --outpkg=mocks
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+)
+
+type InstrumentRepository interface {
+	GetAllInstruments(ctx context.Context, filter InstrumentFilter, page Page) ([]domain.Instrument, PageInfo, error)
+	GetInstrumentByISIN(ctx context.Context, isin string) (*domain.Instrument, error)
+	UpsertInstruments(ctx context.Context, instruments []domain.Instrument) (int, error)
+}
+
+// InstrumentFilter narrows an instrument list. Zero-valued fields do not filter.
+type InstrumentFilter struct {
+	// Query matches an ISIN or WKN exactly, or any part of the name.
+	Query         string `json:"q,omitempty"`
+	Type          string `json:"type,omitempty"`
+	Currency      string `json:"currency,omitempty"`
+	TradingStatus string `json:"trading_status,omitempty"`
+}
+
+func (f InstrumentFilter) conditions(argPos int) ([]string, []interface{}) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+	placeholder := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", argPos+len(args)-1)
+	}
+
+	if f.Query != "" {
+		code := placeholder(strings.ToUpper(f.Query))
+		conditions = append(conditions, fmt.Sprintf("(isin = %s OR wkn = %s OR name ILIKE %s)",
+			code, code, placeholder("%"+escapeLike(f.Query)+"%")))
+	}
+	if f.Type != "" {
+		conditions = append(conditions, "type = "+placeholder(f.Type))
+	}
+	if f.Currency != "" {
+		conditions = append(conditions, "currency = "+placeholder(f.Currency))
+	}
+	if f.TradingStatus != "" {
+		conditions = append(conditions, "trading_status = "+placeholder(f.TradingStatus))
+	}
+
+	return conditions, args
+}
+
+type instrumentRepo struct {
+	db *sql.DB
+}
+
+func NewInstrumentRepository(db *sql.DB) InstrumentRepository {
+	return &instrumentRepo{db: db}
+}
+
+func (r *instrumentRepo) GetAllInstruments(ctx context.Context, filter InstrumentFilter, page Page) ([]domain.Instrument, PageInfo, error) {
+	page = page.normalize()
+
+	conditions, args := filter.conditions(1)
+
+	if page.Cursor != nil {
+		condition, orderBy, keysetArgs := page.keysetOn(len(args)+1, "isin", "CHAR(12)")
+		conditions = append(conditions, condition)
+		args = append(args, keysetArgs...)
+		args = append(args, page.Limit+1)
+		query := fmt.Sprintf(queryReadInstrumentsByCursor, whereClause(conditions), orderBy, len(args))
+
+		instruments, err := r.queryInstruments(ctx, query, args...)
+		if err != nil {
+			return nil, PageInfo{}, err
+		}
+		instruments, info := trimPage(instruments, page)
+		return instruments, info, nil
+	}
+
+	args = append(args, page.Limit, page.Offset)
+	query := fmt.Sprintf(queryReadInstruments, whereClause(conditions), page.Sort, page.Order, len(args)-1, len(args))
+
+	instruments, err := r.queryInstruments(ctx, query, args...)
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+
+	return instruments, PageInfo{HasNext: len(instruments) == page.Limit, HasPrev: page.Offset > 0}, nil
+}
+
+func (r *instrumentRepo) queryInstruments(ctx context.Context, query string, args ...interface{}) ([]domain.Instrument, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	var instruments []domain.Instrument
+	for rows.Next() {
+		instrument, err := scanInstrument(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		instruments = append(instruments, *instrument)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return instruments, nil
+}
+
+func (r *instrumentRepo) GetInstrumentByISIN(ctx context.Context, isin string) (*domain.Instrument, error) {
+	instrument, err := scanInstrument(r.db.QueryRowContext(ctx, queryReadInstrumentByISIN, isin))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("instrument not found: %w", err)
+	} else if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	return instrument, nil
+}
+
+// UpsertInstruments inserts or updates instruments by ISIN in one transaction
+// and returns how many of them were new or changed.
+func (r *instrumentRepo) UpsertInstruments(ctx context.Context, instruments []domain.Instrument) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, queryUpsertInstrument)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prepare statement: %w", err)
+	}
+	defer stmt.Close()
+
+	changed := 0
+	for _, instrument := range instruments {
+		result, err := stmt.ExecContext(ctx,
+			instrument.ISIN, instrument.WKN, instrument.Name, instrument.Type, instrument.Currency, instrument.TradingStatus,
+		)
+		if err != nil {
+			return 0, fmt.Errorf("failed to upsert instrument %s: %w", instrument.ISIN, err)
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return 0, fmt.Errorf("failed to upsert instrument %s: %w", instrument.ISIN, err)
+		}
+		changed += int(affected)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return changed, nil
+}
+
+// scanInstrument reads an instrument row selected in the column order of queryReadInstruments.
+func scanInstrument(row rowScanner) (*domain.Instrument, error) {
+	var (
+		instrument domain.Instrument
+		wkn        sql.NullString
+	)
+
+	if err := row.Scan(
+		&instrument.ISIN, &instrument.CreatedAt, &instrument.UpdatedAt, &wkn, &instrument.Name,
+		&instrument.Type, &instrument.Currency, &instrument.TradingStatus,
+	); err != nil {
+		return nil, err
+	}
+	instrument.WKN = wkn.String
+
+	return &instrument, nil
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetAllInstruments_Search(t *testing.T) {
+	instruments := NewInstrumentRepository(db)
+
+	rows := sqlmock.NewRows([]string{
+		"isin", "created_at", "updated_at", "wkn", "name", "type", "currency", "trading_status",
+	}).AddRow("IE00B4L5Y983", "2025-01-01T00:00:00Z", "2025-01-01T00:00:00Z", "A0RPWH",
+		"iShares Core MSCI World UCITS ETF", "ETF", "USD", "ACTIVE")
+
+	mock.ExpectQuery(`FROM instruments WHERE \(isin = \$1 OR wkn = \$1 OR name ILIKE \$2\) AND type = \$3 `+
+		`ORDER BY created_at ASC LIMIT \$4 OFFSET \$5`).
+		WithArgs("MSCI WORLD", "%msci world%", "ETF", 100, 0).
+		WillReturnRows(rows)
+
+	result, _, err := instruments.GetAllInstruments(context.Background(),
+		InstrumentFilter{Query: "msci world", Type: "ETF"}, Page{Limit: 100})
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, "A0RPWH", result[0].WKN)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_GetAllInstruments_Cursor(t *testing.T) {
+	instruments := NewInstrumentRepository(db)
+
+	mock.ExpectQuery(`FROM instruments WHERE \(created_at, isin\) > \(\$1::TIMESTAMP, \$2::CHAR\(12\)\) `+
+		`ORDER BY created_at ASC, isin ASC LIMIT \$3`).
+		WithArgs("2025-01-01T00:00:00Z", "DE0007164600", 11).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"isin", "created_at", "updated_at", "wkn", "name", "type", "currency", "trading_status",
+		}))
+
+	_, pageInfo, err := instruments.GetAllInstruments(context.Background(), InstrumentFilter{}, Page{
+		Limit:  10,
+		Cursor: &Cursor{SortValue: "2025-01-01T00:00:00Z", ID: "DE0007164600"},
+	})
+
+	assert.NoError(t, err)
+	assert.False(t, pageInfo.HasNext)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_UpsertInstruments_CountsChangedRows(t *testing.T) {
+	instruments := NewInstrumentRepository(db)
+
+	mock.ExpectBegin()
+	prepared := mock.ExpectPrepare(`INSERT INTO instruments`)
+	prepared.ExpectExec().
+		WithArgs("DE0007164600", "716460", "SAP SE", "EQUITY", "EUR", "ACTIVE").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	prepared.ExpectExec().
+		WithArgs("US0378331005", "", "Apple Inc.", "EQUITY", "USD", "ACTIVE").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	changed, err := instruments.UpsertInstruments(context.Background(), []domain.Instrument{
+		{ISIN: "DE0007164600", WKN: "716460", Name: "SAP SE", Type: "EQUITY", Currency: "EUR", TradingStatus: "ACTIVE"},
+		{ISIN: "US0378331005", Name: "Apple Inc.", Type: "EQUITY", Currency: "USD", TradingStatus: "ACTIVE"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, changed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
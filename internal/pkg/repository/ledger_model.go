@@ -0,0 +1,197 @@
+//go:generate mockery --name=LedgerRepository --output=../../util/mocks --outpkg=mocks
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+)
+
+type LedgerRepository interface {
+	PostJournalEntry(ctx context.Context, entry *domain.JournalEntry) (*domain.JournalEntry, error)
+	GetUserBalances(ctx context.Context, userID string) ([]domain.Balance, error)
+}
+
+type ledgerRepo struct {
+	db *sql.DB
+}
+
+func NewLedgerRepository(db *sql.DB) LedgerRepository {
+	return &ledgerRepo{db: db}
+}
+
+// PostJournalEntry books a balanced journal entry and records one
+// LEDGER_POSTING_CREATED event per posting. Users whose accounts are posted to
+// must exist and must not be offboarding or offboarded: their balances were
+// checked to be zero when offboarding started. An entry that would leave a
+// user's ledger account negative is refused with domain.ErrInsufficientFunds.
+func (r *ledgerRepo) PostJournalEntry(ctx context.Context, entry *domain.JournalEntry) (*domain.JournalEntry, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// The exclusive lock serialises the entries and withdrawals of a user, so
+	// that the balances checked below do not change before the commit.
+	for _, userID := range postingUserIDs(entry.Postings) {
+		var status string
+		err := tx.QueryRowContext(ctx, queryLockUserStatus, userID).Scan(&status)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read user status: %w", err)
+		}
+		if status == domain.UserStatusOffboarding || status == domain.UserStatusOffboarded {
+			return nil, fmt.Errorf("%w: user %s is %s", domain.ErrUserNotActive, userID, status)
+		}
+	}
+	if err := requireNonNegativeBalances(ctx, tx, entry.Postings); err != nil {
+		return nil, err
+	}
+
+	if err := insertJournalEntry(ctx, tx, entry); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return entry, nil
+}
+
+// insertJournalEntry books entry within the caller's transaction and records
+// one LEDGER_POSTING_CREATED event per posting. The entry must be balanced.
+func insertJournalEntry(ctx context.Context, tx *sql.Tx, entry *domain.JournalEntry) error {
+	err := tx.QueryRowContext(ctx, queryCreateJournalEntry, entry.Description, entry.Reference).
+		Scan(&entry.ID, &entry.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create journal entry: %w", err)
+	}
+
+	for i := range entry.Postings {
+		posting := &entry.Postings[i]
+		posting.JournalEntryID = entry.ID
+
+		err := tx.QueryRowContext(ctx, queryCreatePosting,
+			entry.ID, posting.LedgerAccount, posting.UserID, posting.Currency, posting.Amount,
+		).Scan(&posting.ID, &posting.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to create posting: %w", err)
+		}
+
+		if err := insertOutboxEvent(ctx, tx, aggregateJournalEntry, entry.ID, eventLedgerPostingCreated, map[string]interface{}{
+			"action":  eventLedgerPostingCreated,
+			"posting": posting,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetUserBalances returns the user's cash per currency, ordered by currency.
+func (r *ledgerRepo) GetUserBalances(ctx context.Context, userID string) ([]domain.Balance, error) {
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, queryUserExists, userID).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("user not found: %w", sql.ErrNoRows)
+	}
+
+	rows, err := r.db.QueryContext(ctx, queryReadUserBalances, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	balances := []domain.Balance{}
+	for rows.Next() {
+		var balance domain.Balance
+		if err := rows.Scan(&balance.Currency, &balance.Available, &balance.Reserved); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		balance.Settled = balance.Available + balance.Reserved
+		balances = append(balances, balance)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return balances, nil
+}
+
+// requireZeroBalances refuses to go on while any ledger account of the user
+// holds money. It runs inside the transaction that locked the user.
+func requireZeroBalances(ctx context.Context, tx *sql.Tx, userID string) error {
+	var currency string
+	err := tx.QueryRowContext(ctx, queryReadNonZeroBalance, userID).Scan(&currency)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to read balances: %w", err)
+	}
+	return fmt.Errorf("%w: user %s holds %s", domain.ErrNonZeroBalance, userID, currency)
+}
+
+// requireNonNegativeBalances refuses postings that would take a user's ledger
+// account below zero. It runs inside the transaction that locked the users.
+func requireNonNegativeBalances(ctx context.Context, tx *sql.Tx, postings []domain.Posting) error {
+	type balanceKey struct {
+		userID, ledgerAccount, currency string
+	}
+	var keys []balanceKey
+	changes := make(map[balanceKey]int64)
+	for _, posting := range postings {
+		if !domain.IsUserLedgerAccount(posting.LedgerAccount) {
+			continue
+		}
+		key := balanceKey{posting.UserID, posting.LedgerAccount, posting.Currency}
+		if _, ok := changes[key]; !ok {
+			keys = append(keys, key)
+		}
+		changes[key] += posting.Amount
+	}
+
+	for _, key := range keys {
+		if changes[key] >= 0 {
+			continue
+		}
+		var balance int64
+		err := tx.QueryRowContext(ctx, queryReadLedgerBalance, key.userID, key.ledgerAccount, key.currency).Scan(&balance)
+		if err != nil {
+			return fmt.Errorf("failed to read balance: %w", err)
+		}
+		if balance+changes[key] < 0 {
+			return fmt.Errorf("%w: user %s holds %d %s in %s", domain.ErrInsufficientFunds,
+				key.userID, balance, key.currency, key.ledgerAccount)
+		}
+	}
+	return nil
+}
+
+// postingUserIDs returns the distinct users posted to, sorted so that
+// concurrent entries lock users in the same order.
+func postingUserIDs(postings []domain.Posting) []string {
+	seen := make(map[string]struct{})
+	var userIDs []string
+	for _, posting := range postings {
+		if posting.UserID == "" {
+			continue
+		}
+		if _, ok := seen[posting.UserID]; !ok {
+			seen[posting.UserID] = struct{}{}
+			userIDs = append(userIDs, posting.UserID)
+		}
+	}
+	sort.Strings(userIDs)
+	return userIDs
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestDeposit() *domain.JournalEntry {
+	return &domain.JournalEntry{
+		Description: "Deposit",
+		Postings: []domain.Posting{
+			{LedgerAccount: "CLEARING", Currency: "EUR", Amount: -10000},
+			{LedgerAccount: "USER_AVAILABLE", UserID: "u1", Currency: "EUR", Amount: 10000},
+		},
+	}
+}
+
+func Test_PostJournalEntry_Success(t *testing.T) {
+	ledger := NewLedgerRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status FROM users WHERE id = \$1 FOR UPDATE`).
+		WithArgs("u1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("ACTIVE"))
+	mock.ExpectQuery(`INSERT INTO journal_entries`).
+		WithArgs("Deposit", "").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow("j1", "2025-01-01T00:00:00Z"))
+	mock.ExpectQuery(`INSERT INTO ledger_postings`).
+		WithArgs("j1", "CLEARING", "", "EUR", int64(-10000)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow("p1", "2025-01-01T00:00:00Z"))
+	mock.ExpectExec(`INSERT INTO outbox`).
+		WithArgs("journal_entry", "j1", "LEDGER_POSTING_CREATED", sqlmock.AnyArg(), 1, "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(`INSERT INTO ledger_postings`).
+		WithArgs("j1", "USER_AVAILABLE", "u1", "EUR", int64(10000)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow("p2", "2025-01-01T00:00:00Z"))
+	mock.ExpectExec(`INSERT INTO outbox`).
+		WithArgs("journal_entry", "j1", "LEDGER_POSTING_CREATED", sqlmock.AnyArg(), 1, "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	entry, err := ledger.PostJournalEntry(context.Background(), newTestDeposit())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "j1", entry.ID)
+	assert.Equal(t, "p2", entry.Postings[1].ID)
+	assert.Equal(t, "j1", entry.Postings[1].JournalEntryID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_PostJournalEntry_UserOffboarded(t *testing.T) {
+	for _, status := range []string{"OFFBOARDING", "OFFBOARDED"} {
+		t.Run(status, func(t *testing.T) {
+			ledger := NewLedgerRepository(db)
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(`SELECT status FROM users WHERE id = \$1 FOR UPDATE`).
+				WithArgs("u1").
+				WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(status))
+			mock.ExpectRollback()
+
+			_, err := ledger.PostJournalEntry(context.Background(), newTestDeposit())
+
+			assert.ErrorIs(t, err, domain.ErrUserNotActive)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_PostJournalEntry_RejectsOverdraft(t *testing.T) {
+	ledger := NewLedgerRepository(db)
+	payout := &domain.JournalEntry{
+		Description: "Payout",
+		Postings: []domain.Posting{
+			{LedgerAccount: "USER_AVAILABLE", UserID: "u1", Currency: "EUR", Amount: -10000},
+			{LedgerAccount: "CLEARING", Currency: "EUR", Amount: 10000},
+		},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status FROM users WHERE id = \$1 FOR UPDATE`).
+		WithArgs("u1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("ACTIVE"))
+	mock.ExpectQuery(`SELECT COALESCE\(SUM\(amount\), 0\) FROM ledger_postings`).
+		WithArgs("u1", "USER_AVAILABLE", "EUR").
+		WillReturnRows(sqlmock.NewRows([]string{"balance"}).AddRow(9999))
+	mock.ExpectRollback()
+
+	_, err := ledger.PostJournalEntry(context.Background(), payout)
+
+	assert.ErrorIs(t, err, domain.ErrInsufficientFunds)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_GetUserBalances(t *testing.T) {
+	ledger := NewLedgerRepository(db)
+
+	mock.ExpectQuery(`SELECT EXISTS`).WithArgs("u1").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery(`FROM ledger_postings WHERE user_id = \$1 GROUP BY currency`).
+		WithArgs("u1").
+		WillReturnRows(sqlmock.NewRows([]string{"currency", "available", "reserved"}).
+			AddRow("EUR", 7500, 2500).
+			AddRow("USD", 0, 0))
+
+	balances, err := ledger.GetUserBalances(context.Background(), "u1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Balance{
+		{Currency: "EUR", Available: 7500, Reserved: 2500, Settled: 10000},
+		{Currency: "USD"},
+	}, balances)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_GetUserBalances_UserNotFound(t *testing.T) {
+	ledger := NewLedgerRepository(db)
+
+	mock.ExpectQuery(`SELECT EXISTS`).WithArgs("u1").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+	_, err := ledger.GetUserBalances(context.Background(), "u1")
+
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
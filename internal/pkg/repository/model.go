@@ -7,58 +7,122 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/ashwingopalsamy/upvest-api/internal/event"
 )
 
 type UserRepository interface {
-	CreateUser(ctx context.Context, user *domain.User) (*domain.User, error)
-	GetAllUsers(ctx context.Context, offset, limit int, sort, order string) ([]domain.User, error)
+	CreateUser(ctx context.Context, user *domain.User, hits []domain.ScreeningHit) (*domain.User, error)
+	GetAllUsers(ctx context.Context, filter UserFilter, page Page) ([]domain.User, PageInfo, error)
 	GetUserByID(ctx context.Context, userID string) (*domain.User, error)
+	UpdateUser(ctx context.Context, user *domain.User, fields []string, hits []domain.ScreeningHit) (*domain.User, error)
 	OffboardUser(ctx context.Context, userID string) error
+	CompleteOffboarding(ctx context.Context, userID string) error
 }
 
 type userRepo struct {
-	db *sql.DB
+	db  *sql.DB
+	now func() time.Time
 }
 
 func NewUserRepository(db *sql.DB) UserRepository {
-	return &userRepo{db: db}
+	return &userRepo{db: db, now: time.Now}
 }
 
-func (r *userRepo) CreateUser(ctx context.Context, user *domain.User) (*domain.User, error) {
+// CreateUser inserts the user and records a USER_CREATED event. A user with
+// screening hits is created HELD and the hits are stored for review.
+func (r *userRepo) CreateUser(ctx context.Context, user *domain.User, hits []domain.ScreeningHit) (*domain.User, error) {
 	postalAddress, _ := json.Marshal(user.PostalAddress)
 	address, _ := json.Marshal(user.Address)
 	nationalities, err := json.Marshal(user.Nationalities)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal nationalities: %w", err)
 	}
+	taxResidencies, err := marshalTaxResidencies(user.TaxResidencies)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
 
-	err = r.db.QueryRowContext(ctx, queryCreateUsers,
+	status := domain.UserStatusActive
+	if len(hits) > 0 {
+		status = domain.UserStatusHeld
+	}
+	err = tx.QueryRowContext(ctx, queryCreateUsers,
 		user.FirstName, user.LastName, user.Salutation, user.Title,
 		user.BirthDate, user.BirthCity, user.BirthCountry, user.BirthName,
-		nationalities, postalAddress, address, fieldStatusActive,
+		nationalities, postalAddress, address, taxResidencies, status, user.IsMinorOn(r.now().UTC()),
 	).Scan(&user.ID, &user.CreatedAt, &user.UpdatedAt)
 
 	if err != nil {
 		return nil, err
 	}
+	user.Status = status
+	user.FATCA = user.IsFATCAReportable()
+
+	if err := insertEvent(ctx, tx, aggregateUser, user.ID, event.UserCreated{User: user}); err != nil {
+		return nil, err
+	}
+	if _, _, err := recordScreeningHits(ctx, tx, user.ID, status, hits); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
 
 	return user, nil
 }
 
-func (r *userRepo) GetAllUsers(ctx context.Context, offset, limit int, sort, order string) ([]domain.User, error) {
+func (r *userRepo) GetAllUsers(ctx context.Context, filter UserFilter, page Page) ([]domain.User, PageInfo, error) {
 	// Validate and normalize sorting inputs
-	if sort != "created_at" && sort != "updated_at" {
-		sort = "created_at"
+	page = page.normalize()
+
+	if page.Cursor != nil {
+		return r.getUsersByCursor(ctx, filter, page)
 	}
-	if order != "ASC" && order != "DESC" {
-		order = "ASC"
+
+	conditions, args := filter.conditions(1)
+	args = append(args, page.Limit, page.Offset)
+	query := fmt.Sprintf(queryReadUsers, whereClause(conditions), page.Sort, page.Order, len(args)-1, len(args))
+
+	users, err := r.queryUsers(ctx, query, args...)
+	if err != nil {
+		return nil, PageInfo{}, err
 	}
 
-	query := fmt.Sprintf(queryReadUsers, sort, order)
+	return users, PageInfo{HasNext: len(users) == page.Limit, HasPrev: page.Offset > 0}, nil
+}
+
+// getUsersByCursor reads one row beyond the limit to find out whether the list
+// continues past the page.
+func (r *userRepo) getUsersByCursor(ctx context.Context, filter UserFilter, page Page) ([]domain.User, PageInfo, error) {
+	conditions, args := filter.conditions(1)
+	condition, orderBy, keysetArgs := page.keyset(len(args) + 1)
+	conditions = append(conditions, condition)
+	args = append(args, keysetArgs...)
+	args = append(args, page.Limit+1)
+	query := fmt.Sprintf(queryReadUsersByCursor, whereClause(conditions), orderBy, len(args))
 
-	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	users, err := r.queryUsers(ctx, query, args...)
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+
+	users, info := trimPage(users, page)
+	return users, info, nil
+}
+
+func (r *userRepo) queryUsers(ctx context.Context, query string, args ...interface{}) ([]domain.User, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute query: %w", err)
 	}
@@ -66,82 +130,224 @@ func (r *userRepo) GetAllUsers(ctx context.Context, offset, limit int, sort, ord
 
 	var users []domain.User
 	for rows.Next() {
-		var (
-			user          domain.User
-			nationalities sql.NullString
-			postalAddress sql.NullString
-			address       string
-		)
-
-		if err := rows.Scan(
-			&user.ID, &user.CreatedAt, &user.UpdatedAt, &user.FirstName, &user.LastName,
-			&user.Salutation, &user.Title, &user.BirthDate, &user.BirthCity, &user.BirthCountry,
-			&user.BirthName, &nationalities, &postalAddress, &address, &user.Status,
-		); err != nil {
+		user, err := scanUser(rows)
+		if err != nil {
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
+		users = append(users, *user)
+	}
 
-		// Deserializing the JSON fields
-		if nationalities.Valid && nationalities.String != "" {
-			if err := json.Unmarshal([]byte(nationalities.String), &user.Nationalities); err != nil {
-				return nil, fmt.Errorf("failed to unmarshal nationalities: %w", err)
-			}
-		} else {
-			user.Nationalities = nil
-		}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
 
-		if postalAddress.Valid && postalAddress.String != "" {
-			var addr domain.Address
-			if err := json.Unmarshal([]byte(postalAddress.String), &addr); err != nil {
-				return nil, fmt.Errorf("failed to unmarshal postal_address: %w", err)
-			}
-			user.PostalAddress = &addr
-		} else {
-			user.PostalAddress = nil
+	return users, nil
+}
+
+func (r *userRepo) GetUserByID(ctx context.Context, userID string) (*domain.User, error) {
+	user, err := scanUser(r.db.QueryRowContext(ctx, queryReadUserByID, userID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("user not found: %w", err)
+	} else if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	return user, nil
+}
+
+// UpdateUser persists the given fields of user, identified by their JSON names,
+// and records a USER_UPDATED event listing them. New screening hits hold the
+// user as on creation. The update is refused with domain.ErrUserModified when
+// the user changed since user was read, as given by its UpdatedAt, and with
+// domain.ErrUserNotActive once the user is being offboarded.
+func (r *userRepo) UpdateUser(ctx context.Context, user *domain.User, fields []string, hits []domain.ScreeningHit) (*domain.User, error) {
+	if len(fields) == 0 {
+		return nil, errors.New("no fields to update")
+	}
+
+	assignments := make([]string, 0, len(fields))
+	args := make([]interface{}, 0, len(fields)+1)
+	for _, field := range fields {
+		column, value, err := userColumnValue(user, field)
+		if err != nil {
+			return nil, err
 		}
+		args = append(args, value)
+		assignments = append(assignments, fmt.Sprintf("%s = $%d", column, len(args)))
 
-		if err := json.Unmarshal([]byte(address), &user.Address); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal address: %w", err)
+		// The minor flag follows corrections of the birth date.
+		if field == "birth_date" {
+			args = append(args, user.IsMinorOn(r.now().UTC()))
+			assignments = append(assignments, fmt.Sprintf("minor = $%d", len(args)))
 		}
+	}
+	args = append(args, user.ID)
+	query := fmt.Sprintf(queryUpdateUser, strings.Join(assignments, ", "), len(args))
 
-		users = append(users, user)
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
+	defer tx.Rollback()
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("row iteration error: %w", err)
+	var status, updatedAt string
+	err = tx.QueryRowContext(ctx, queryLockUserForUpdate, user.ID).Scan(&status, &updatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("user not found: %w", err)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to lock user: %w", err)
+	}
+	if status == domain.UserStatusOffboarding || status == domain.UserStatusOffboarded {
+		return nil, fmt.Errorf("%w: user %s is %s", domain.ErrUserNotActive, user.ID, status)
+	}
+	if updatedAt != user.UpdatedAt {
+		return nil, fmt.Errorf("user %s: %w", user.ID, domain.ErrUserModified)
 	}
 
-	return users, nil
+	updated, err := scanUser(tx.QueryRowContext(ctx, query, args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("user not found: %w", err)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+
+	if err := insertEvent(ctx, tx, aggregateUser, updated.ID, event.UserUpdated{
+		UserID:        updated.ID,
+		ChangedFields: fields,
+		User:          updated,
+	}); err != nil {
+		return nil, err
+	}
+	if _, updated.Status, err = recordScreeningHits(ctx, tx, updated.ID, updated.Status, hits); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return updated, nil
 }
 
-func (r *userRepo) GetUserByID(ctx context.Context, userID string) (*domain.User, error) {
-	query := `SELECT id, created_at, updated_at, first_name, last_name, salutation, title, birth_date,
-		birth_city, birth_country, birth_name, nationalities, postal_address, address, status
-		FROM users WHERE id = $1`
+// OffboardUser starts offboarding a user whose cash balances are all zero. The
+// subscriber completes it asynchronously once the user's dependent resources
+// are closed.
+func (r *userRepo) OffboardUser(ctx context.Context, userID string) error {
+	return r.changeUserStatus(ctx, userID, domain.UserStatusOffboarding, func(change event.UserStatusChange) event.Payload {
+		return event.UserOffboarding{UserStatusChange: change}
+	}, requireZeroBalances)
+}
 
-	var user domain.User
-	var nationalities sql.NullString
-	var postalAddress sql.NullString
-	var address string
+// CompleteOffboarding marks a user that is being offboarded as OFFBOARDED. The
+// balances are checked again, so that a user is never closed holding cash.
+func (r *userRepo) CompleteOffboarding(ctx context.Context, userID string) error {
+	return r.changeUserStatus(ctx, userID, domain.UserStatusOffboarded, func(change event.UserStatusChange) event.Payload {
+		return event.UserOffboarded{UserStatusChange: change}
+	}, requireZeroBalances)
+}
 
-	err := r.db.QueryRowContext(ctx, query, userID).Scan(
-		&user.ID, &user.CreatedAt, &user.UpdatedAt, &user.FirstName, &user.LastName,
-		&user.Salutation, &user.Title, &user.BirthDate, &user.BirthCity, &user.BirthCountry,
-		&user.BirthName, &nationalities, &postalAddress, &address, &user.Status,
-	)
+// statusPrecondition vets a status change within the transaction that locked
+// the user.
+type statusPrecondition func(ctx context.Context, tx *sql.Tx, userID string) error
+
+// changeUserStatus moves a user to a new status if the domain allows the
+// transition and all preconditions hold, and records the event of newEvent in
+// the same transaction.
+func (r *userRepo) changeUserStatus(ctx context.Context, userID, status string, newEvent statusEvent, preconditions ...statusPrecondition) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var current string
+	err = tx.QueryRowContext(ctx, queryLockUserStatus, userID).Scan(&current)
 	if errors.Is(err, sql.ErrNoRows) {
-		return nil, fmt.Errorf("user not found: %w", err)
+		return sql.ErrNoRows
 	} else if err != nil {
-		return nil, fmt.Errorf("database error: %w", err)
+		return fmt.Errorf("failed to read user status: %w", err)
+	}
+
+	if err := domain.ValidateUserStatusTransition(current, status); err != nil {
+		return err
+	}
+
+	for _, precondition := range preconditions {
+		if err := precondition(ctx, tx, userID); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, queryUpdateUserStatus, status, userID); err != nil {
+		return fmt.Errorf("failed to update user status: %w", err)
+	}
+
+	if err := insertEvent(ctx, tx, aggregateUser, userID, newEvent(event.UserStatusChange{
+		UserID:         userID,
+		PreviousStatus: current,
+		Status:         status,
+	})); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// calendarDate scans a DATE column into date in the YYYY-MM-DD form, whether the
+// driver returns it as text or as a time.
+type calendarDate struct {
+	date *string
+}
+
+func (d calendarDate) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case time.Time:
+		*d.date = value.Format(domain.DateLayout)
+	case string:
+		*d.date = value
+	case []byte:
+		*d.date = string(value)
+	default:
+		return fmt.Errorf("cannot scan %T into a calendar date", src)
+	}
+	return nil
+}
+
+// scanUser reads a user row selected in the column order of queryReadUsers.
+func scanUser(row rowScanner) (*domain.User, error) {
+	var (
+		user           domain.User
+		nationalities  sql.NullString
+		postalAddress  sql.NullString
+		address        string
+		taxResidencies sql.NullString
+	)
+
+	if err := row.Scan(
+		&user.ID, &user.CreatedAt, &user.UpdatedAt, &user.FirstName, &user.LastName,
+		&user.Salutation, &user.Title, calendarDate{&user.BirthDate}, &user.BirthCity, &user.BirthCountry,
+		&user.BirthName, &nationalities, &postalAddress, &address, &taxResidencies,
+		&user.Status,
+	); err != nil {
+		return nil, err
 	}
 
 	// Deserializing the JSON fields
-	if nationalities.Valid {
+	if nationalities.Valid && nationalities.String != "" {
 		if err := json.Unmarshal([]byte(nationalities.String), &user.Nationalities); err != nil {
 			return nil, fmt.Errorf("failed to unmarshal nationalities: %w", err)
 		}
 	}
-	if postalAddress.Valid {
+	if postalAddress.Valid && postalAddress.String != "" {
 		if err := json.Unmarshal([]byte(postalAddress.String), &user.PostalAddress); err != nil {
 			return nil, fmt.Errorf("failed to unmarshal postal_address: %w", err)
 		}
@@ -149,25 +355,61 @@ func (r *userRepo) GetUserByID(ctx context.Context, userID string) (*domain.User
 	if err := json.Unmarshal([]byte(address), &user.Address); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal address: %w", err)
 	}
+	if taxResidencies.Valid && taxResidencies.String != "" {
+		if err := json.Unmarshal([]byte(taxResidencies.String), &user.TaxResidencies); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal tax_residencies: %w", err)
+		}
+	}
+	user.FATCA = user.IsFATCAReportable()
 
 	return &user, nil
 }
 
-func (r *userRepo) OffboardUser(ctx context.Context, userID string) error {
-	result, err := r.db.ExecContext(ctx, queryOffboardUser, "OFFBOARDED", userID)
-	if err != nil {
-		return fmt.Errorf("failed to update user status: %w", err)
+// userColumnValue maps a patchable JSON field of a user to its column and value.
+func userColumnValue(user *domain.User, field string) (string, interface{}, error) {
+	switch field {
+	case "first_name":
+		return "first_name", user.FirstName, nil
+	case "last_name":
+		return "last_name", user.LastName, nil
+	case "salutation":
+		return "salutation", user.Salutation, nil
+	case "title":
+		return "title", user.Title, nil
+	case "birth_date":
+		return "birth_date", user.BirthDate, nil
+	case "birth_city":
+		return "birth_city", user.BirthCity, nil
+	case "birth_country":
+		return "birth_country", user.BirthCountry, nil
+	case "birth_name":
+		return "birth_name", user.BirthName, nil
+	case "nationalities":
+		value, err := json.Marshal(user.Nationalities)
+		return "nationalities", value, err
+	case "postal_address":
+		value, err := json.Marshal(user.PostalAddress)
+		return "postal_address", value, err
+	case "address":
+		value, err := json.Marshal(user.Address)
+		return "address", value, err
+	case "tax_residencies":
+		value, err := marshalTaxResidencies(user.TaxResidencies)
+		return "tax_residencies", value, err
+	default:
+		return "", nil, fmt.Errorf("field %q cannot be updated", field)
 	}
+}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("failed to check rows affected: %w", err)
+// marshalTaxResidencies stores a user without tax residencies as an empty list
+// rather than JSON null.
+func marshalTaxResidencies(residencies []domain.TaxResidency) ([]byte, error) {
+	if residencies == nil {
+		residencies = []domain.TaxResidency{}
 	}
-
-	if rowsAffected == 0 {
-		return sql.ErrNoRows
+	value, err := json.Marshal(residencies)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal tax_residencies: %w", err)
 	}
-
-	return nil
+	return value, nil
 }
-
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/ashwingopalsamy/upvest-api/internal/domain"
@@ -60,6 +61,7 @@ func Test_CreateUser_Success(t *testing.T) {
 	nationalities, _ := json.Marshal(mockUser.Nationalities)
 	address, _ := json.Marshal(mockUser.Address)
 
+	mock.ExpectBegin()
 	mock.ExpectQuery(`INSERT INTO users`).
 		WithArgs(
 			mockUser.FirstName,
@@ -73,16 +75,55 @@ func Test_CreateUser_Success(t *testing.T) {
 			nationalities,
 			sqlmock.AnyArg(),
 			address,
+			[]byte(`[]`),
 			"ACTIVE",
+			false,
 		).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow("123", "2025-01-01T00:00:00Z", "2025-01-01T00:00:00Z"))
+	mock.ExpectExec(`INSERT INTO outbox`).
+		WithArgs("user", "123", "USER_CREATED", sqlmock.AnyArg(), 2, "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
 
-	createdUser, err := repo.CreateUser(context.Background(), mockUser)
+	createdUser, err := repo.CreateUser(context.Background(), mockUser, nil)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, createdUser)
 	assert.Equal(t, "123", createdUser.ID)
 	assert.Equal(t, "2025-01-01T00:00:00Z", createdUser.CreatedAt)
+	assert.Equal(t, "ACTIVE", createdUser.Status)
+	assert.True(t, createdUser.FATCA)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Test_CreateUser_OutboxFailure ensures the user insert is rolled back when the event cannot be stored
+func Test_CreateUser_OutboxFailure(t *testing.T) {
+	mockUser := &domain.User{
+		FirstName:     "Rob",
+		LastName:      "Smith",
+		BirthDate:     "1990-01-01",
+		BirthCity:     "Berlin",
+		BirthCountry:  "DE",
+		Nationalities: []string{"DE"},
+		Address: domain.Address{
+			AddressLine1: "123 Main St",
+			Postcode:     "12345",
+			City:         "Berlin",
+			Country:      "DE",
+		},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO users`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow("123", "2025-01-01T00:00:00Z", "2025-01-01T00:00:00Z"))
+	mock.ExpectExec(`INSERT INTO outbox`).WillReturnError(sql.ErrConnDone)
+	mock.ExpectRollback()
+
+	createdUser, err := repo.CreateUser(context.Background(), mockUser, nil)
+
+	assert.Error(t, err)
+	assert.Nil(t, createdUser)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 // Test_CreateUser_Failure tests the failure case for CreateUser
@@ -102,9 +143,11 @@ func Test_CreateUser_Failure(t *testing.T) {
 		},
 	}
 
+	mock.ExpectBegin()
 	mock.ExpectQuery(`INSERT INTO users`).WillReturnError(sql.ErrConnDone)
+	mock.ExpectRollback()
 
-	createdUser, err := repo.CreateUser(context.Background(), mockUser)
+	createdUser, err := repo.CreateUser(context.Background(), mockUser, nil)
 
 	assert.Error(t, err)
 	assert.Nil(t, createdUser)
@@ -115,18 +158,18 @@ func Test_CreateUser_Failure(t *testing.T) {
 func Test_GetAllUsers_Success(t *testing.T) {
 	rows := sqlmock.NewRows([]string{
 		"id", "created_at", "updated_at", "first_name", "last_name", "salutation", "title", "birth_date",
-		"birth_city", "birth_country", "birth_name", "nationalities", "postal_address", "address", "status",
+		"birth_city", "birth_country", "birth_name", "nationalities", "postal_address", "address", "tax_residencies", "status",
 	}).
 		AddRow("1", "2025-01-01T00:00:00Z", "2025-01-01T00:00:00Z", "John", "Schmidt", "", "DR", "1998-01-01",
-			"Berlin", "DE", "", `["DE"]`, `{"address_line1":"123 Main St"}`, `{"address_line1":"456 High St"}`, "ACTIVE").
+			"Berlin", "DE", "", `["DE"]`, `{"address_line1":"123 Main St"}`, `{"address_line1":"456 High St"}`, `[]`, "ACTIVE").
 		AddRow("2", "2025-01-02T00:00:00Z", "2025-01-02T00:00:00Z", "Jane", "Schmidt", "", "PROF", "1999-01-01",
-			"Munich", "DE", "", `["DE","US"]`, `{"address_line1":"789 Park Ave"}`, `{"address_line1":"123 High St"}`, "ACTIVE")
+			"Munich", "DE", "", `["DE","US"]`, `{"address_line1":"789 Park Ave"}`, `{"address_line1":"123 High St"}`, `[]`, "ACTIVE")
 
 	mock.ExpectQuery(`SELECT id, created_at, updated_at`).
 		WithArgs(100, 0).
 		WillReturnRows(rows)
 
-	users, err := repo.GetAllUsers(context.Background(), 0, 100, "created_at", "ASC")
+	users, _, err := repo.GetAllUsers(context.Background(), UserFilter{}, Page{Offset: 0, Limit: 100, Sort: "created_at", Order: "ASC"})
 
 	assert.NoError(t, err)
 	assert.NotNil(t, users)
@@ -140,7 +183,7 @@ func Test_GetAllUsers_Success(t *testing.T) {
 func Test_GetAllUsers_Failure(t *testing.T) {
 	mock.ExpectQuery(`SELECT id, created_at, updated_at`).WillReturnError(sql.ErrConnDone)
 
-	users, err := repo.GetAllUsers(context.Background(), 0, 100, "created_at", "ASC")
+	users, _, err := repo.GetAllUsers(context.Background(), UserFilter{}, Page{Offset: 0, Limit: 100, Sort: "created_at", Order: "ASC"})
 
 	assert.Error(t, err)
 	assert.Nil(t, users)
@@ -151,17 +194,17 @@ func Test_GetAllUsers_Failure(t *testing.T) {
 func Test_GetAllUsers_InvalidSorting(t *testing.T) {
 	rows := sqlmock.NewRows([]string{
 		"id", "created_at", "updated_at", "first_name", "last_name", "salutation", "title", "birth_date",
-		"birth_city", "birth_country", "birth_name", "nationalities", "postal_address", "address", "status",
+		"birth_city", "birth_country", "birth_name", "nationalities", "postal_address", "address", "tax_residencies", "status",
 	}).AddRow("1", "2025-01-01T00:00:00Z", "2025-01-01T00:00:00Z", "Jason", "Schmidt", "", "", "2000-01-01",
-		"Berlin", "DE", "", `["DE"]`, `{"address_line1":"123 Main St"}`, `{"address_line1":"456 High St"}`, "ACTIVE")
+		"Berlin", "DE", "", `["DE"]`, `{"address_line1":"123 Main St"}`, `{"address_line1":"456 High St"}`, `[]`, "ACTIVE")
 
-	mock.ExpectQuery(`SELECT id, created_at, updated_at, first_name, last_name, salutation, title, birth_date, 
-		       birth_city, birth_country, birth_name, nationalities, postal_address, address, status 
+	mock.ExpectQuery(`SELECT id, created_at, updated_at, first_name, last_name, salutation, title,
+		       to_char\(birth_date, 'YYYY-MM-DD'\), birth_city, birth_country, birth_name, nationalities, postal_address, address, tax_residencies, status 
 		FROM users ORDER BY created_at ASC LIMIT \$1 OFFSET \$2`).
 		WithArgs(100, 0).
 		WillReturnRows(rows)
 
-	users, err := repo.GetAllUsers(context.Background(), 0, 100, "invalid_field", "invalid_order")
+	users, _, err := repo.GetAllUsers(context.Background(), UserFilter{}, Page{Offset: 0, Limit: 100, Sort: "invalid_field", Order: "invalid_order"})
 
 	assert.NoError(t, err)
 	assert.NotNil(t, users)
@@ -173,17 +216,17 @@ func Test_GetAllUsers_InvalidSorting(t *testing.T) {
 func Test_GetAllUsers_InvalidPagination(t *testing.T) {
 	rows := sqlmock.NewRows([]string{
 		"id", "created_at", "updated_at", "first_name", "last_name", "salutation", "title", "birth_date",
-		"birth_city", "birth_country", "birth_name", "nationalities", "postal_address", "address", "status",
+		"birth_city", "birth_country", "birth_name", "nationalities", "postal_address", "address", "tax_residencies", "status",
 	}).AddRow("1", "2025-01-01T00:00:00Z", "2025-01-01T00:00:00Z", "Mark", "Smith", "", "", "1985-01-01",
-		"Berlin", "DE", "", `["DE"]`, `{"address_line1":"789 Main St"}`, `{"address_line1":"123 Side St"}`, "ACTIVE")
+		"Berlin", "DE", "", `["DE"]`, `{"address_line1":"789 Main St"}`, `{"address_line1":"123 Side St"}`, `[]`, "ACTIVE")
 
-	mock.ExpectQuery(`SELECT id, created_at, updated_at, first_name, last_name, salutation, title, birth_date, 
-		       birth_city, birth_country, birth_name, nationalities, postal_address, address, status 
+	mock.ExpectQuery(`SELECT id, created_at, updated_at, first_name, last_name, salutation, title,
+		       to_char\(birth_date, 'YYYY-MM-DD'\), birth_city, birth_country, birth_name, nationalities, postal_address, address, tax_residencies, status 
 		FROM users ORDER BY created_at ASC LIMIT \$1 OFFSET \$2`).
 		WithArgs(200, 0).
 		WillReturnRows(rows)
 
-	users, err := repo.GetAllUsers(context.Background(), 0, 200, "created_at", "ASC")
+	users, _, err := repo.GetAllUsers(context.Background(), UserFilter{}, Page{Offset: 0, Limit: 200, Sort: "created_at", Order: "ASC"})
 
 	assert.NoError(t, err)
 	assert.NotNil(t, users)
@@ -191,13 +234,123 @@ func Test_GetAllUsers_InvalidPagination(t *testing.T) {
 	assert.Equal(t, "Mark", users[0].FirstName)
 }
 
+// Test_GetAllUsers_Cursor tests keyset pagination after a cursor
+func Test_GetAllUsers_Cursor(t *testing.T) {
+	rows := sqlmock.NewRows([]string{
+		"id", "created_at", "updated_at", "first_name", "last_name", "salutation", "title", "birth_date",
+		"birth_city", "birth_country", "birth_name", "nationalities", "postal_address", "address", "tax_residencies", "status",
+	}).
+		AddRow("2", "2025-01-02T00:00:00Z", "2025-01-02T00:00:00Z", "Jane", "Schmidt", "", "", "1999-01-01",
+			"Munich", "DE", "", `["DE"]`, nil, `{"address_line1":"123 High St"}`, `[]`, "ACTIVE").
+		AddRow("3", "2025-01-03T00:00:00Z", "2025-01-03T00:00:00Z", "Mark", "Smith", "", "", "1985-01-01",
+			"Berlin", "DE", "", `["DE"]`, nil, `{"address_line1":"789 Main St"}`, `[]`, "ACTIVE")
+
+	mock.ExpectQuery(`FROM users WHERE \(created_at, id\) > \(\$1::TIMESTAMP, \$2::UUID\) ORDER BY created_at ASC, id ASC LIMIT \$3`).
+		WithArgs("2025-01-01T00:00:00Z", "1", 2).
+		WillReturnRows(rows)
+
+	users, pageInfo, err := repo.GetAllUsers(context.Background(), UserFilter{}, Page{
+		Limit:  1,
+		Sort:   "created_at",
+		Order:  "ASC",
+		Cursor: &Cursor{SortValue: "2025-01-01T00:00:00Z", ID: "1"},
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, users, 1)
+	assert.Equal(t, "Jane", users[0].FirstName)
+	assert.True(t, pageInfo.HasNext)
+	assert.True(t, pageInfo.HasPrev)
+}
+
+// Test_GetAllUsers_CursorBackward tests that a backward page is read in reverse and returned in list order
+func Test_GetAllUsers_CursorBackward(t *testing.T) {
+	rows := sqlmock.NewRows([]string{
+		"id", "created_at", "updated_at", "first_name", "last_name", "salutation", "title", "birth_date",
+		"birth_city", "birth_country", "birth_name", "nationalities", "postal_address", "address", "tax_residencies", "status",
+	}).
+		AddRow("2", "2025-01-02T00:00:00Z", "2025-01-02T00:00:00Z", "Jane", "Schmidt", "", "", "1999-01-01",
+			"Munich", "DE", "", `["DE"]`, nil, `{"address_line1":"123 High St"}`, `[]`, "ACTIVE").
+		AddRow("1", "2025-01-01T00:00:00Z", "2025-01-01T00:00:00Z", "John", "Schmidt", "", "", "1998-01-01",
+			"Berlin", "DE", "", `["DE"]`, nil, `{"address_line1":"456 High St"}`, `[]`, "ACTIVE")
+
+	mock.ExpectQuery(`FROM users WHERE \(updated_at, id\) < \(\$1::TIMESTAMP, \$2::UUID\) ORDER BY updated_at DESC, id DESC LIMIT \$3`).
+		WithArgs("2025-01-03T00:00:00Z", "3", 3).
+		WillReturnRows(rows)
+
+	users, pageInfo, err := repo.GetAllUsers(context.Background(), UserFilter{}, Page{
+		Limit:  2,
+		Sort:   "updated_at",
+		Order:  "ASC",
+		Cursor: &Cursor{SortValue: "2025-01-03T00:00:00Z", ID: "3", Backward: true},
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, users, 2)
+	assert.Equal(t, "1", users[0].ID)
+	assert.Equal(t, "2", users[1].ID)
+	assert.True(t, pageInfo.HasNext)
+	assert.False(t, pageInfo.HasPrev)
+}
+
+// Test_GetAllUsers_Filter tests that filter conditions precede the paging placeholders
+func Test_GetAllUsers_Filter(t *testing.T) {
+	rows := sqlmock.NewRows([]string{
+		"id", "created_at", "updated_at", "first_name", "last_name", "salutation", "title", "birth_date",
+		"birth_city", "birth_country", "birth_name", "nationalities", "postal_address", "address", "tax_residencies", "status",
+	}).AddRow("1", "2025-01-01T00:00:00Z", "2025-01-01T00:00:00Z", "John", "Schmidt", "", "", "1998-01-01",
+		"Berlin", "DE", "", `["DE"]`, nil, `{"address_line1":"456 High St"}`, `[]`, "ACTIVE")
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`FROM users WHERE status IN \(\$1, \$2\) AND birth_country = \$3 AND nationalities @> \$4::JSONB `+
+		`AND created_at >= \$5::TIMESTAMP AND \(first_name \|\| ' ' \|\| last_name\) ILIKE \$6 `+
+		`ORDER BY created_at ASC LIMIT \$7 OFFSET \$8`).
+		WithArgs("ACTIVE", "INACTIVE", "DE", `["DE"]`, "2025-01-01 00:00:00", `%100\%\_sch%`, 100, 0).
+		WillReturnRows(rows)
+
+	users, _, err := repo.GetAllUsers(context.Background(), UserFilter{
+		Statuses:     []string{"ACTIVE", "INACTIVE"},
+		BirthCountry: "DE",
+		Nationality:  "DE",
+		CreatedAfter: &from,
+		Name:         "100%_sch",
+	}, Page{Limit: 100, Sort: "created_at", Order: "ASC"})
+
+	assert.NoError(t, err)
+	assert.Len(t, users, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Test_GetAllUsers_FilterWithCursor tests that the keyset condition follows the filter conditions
+func Test_GetAllUsers_FilterWithCursor(t *testing.T) {
+	rows := sqlmock.NewRows([]string{
+		"id", "created_at", "updated_at", "first_name", "last_name", "salutation", "title", "birth_date",
+		"birth_city", "birth_country", "birth_name", "nationalities", "postal_address", "address", "tax_residencies", "status",
+	})
+
+	mock.ExpectQuery(`FROM users WHERE status IN \(\$1\) AND \(created_at, id\) > \(\$2::TIMESTAMP, \$3::UUID\) `+
+		`ORDER BY created_at ASC, id ASC LIMIT \$4`).
+		WithArgs("INACTIVE", "2025-01-01T00:00:00Z", "1", 11).
+		WillReturnRows(rows)
+
+	users, pageInfo, err := repo.GetAllUsers(context.Background(), UserFilter{Statuses: []string{"INACTIVE"}}, Page{
+		Limit:  10,
+		Cursor: &Cursor{SortValue: "2025-01-01T00:00:00Z", ID: "1"},
+	})
+
+	assert.NoError(t, err)
+	assert.Empty(t, users)
+	assert.False(t, pageInfo.HasNext)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func Test_GetUserByID_Success(t *testing.T) {
 	row := sqlmock.NewRows([]string{
 		"id", "created_at", "updated_at", "first_name", "last_name", "salutation", "title", "birth_date",
-		"birth_city", "birth_country", "birth_name", "nationalities", "postal_address", "address", "status",
+		"birth_city", "birth_country", "birth_name", "nationalities", "postal_address", "address", "tax_residencies", "status",
 	}).AddRow("1", "2025-01-01T00:00:00Z", "2025-01-01T00:00:00Z", "Jason", "Schmidt", "SALUTATION_MALE", "DR",
 		"2001-01-01", "Berlin", "DE", "Schmidt", `["DE"]`, `{"address_line1":"123 Main St"}`,
-		`{"address_line1":"123 Main St"}`, "ACTIVE")
+		`{"address_line1":"123 Main St"}`, `[{"country":"US","tin":"123-45-6789"}]`, "ACTIVE")
 
 	mock.ExpectQuery(`SELECT id, created_at, updated_at`).WithArgs("1").WillReturnRows(row)
 
@@ -207,6 +360,25 @@ func Test_GetUserByID_Success(t *testing.T) {
 	assert.NotNil(t, user)
 	assert.Equal(t, "Jason", user.FirstName)
 	assert.Equal(t, "Schmidt", user.LastName)
+	assert.Equal(t, []domain.TaxResidency{{Country: "US", TIN: "123-45-6789"}}, user.TaxResidencies)
+	assert.True(t, user.FATCA)
+}
+
+func Test_GetUserByID_FormatsBirthDate(t *testing.T) {
+	row := sqlmock.NewRows([]string{
+		"id", "created_at", "updated_at", "first_name", "last_name", "salutation", "title", "birth_date",
+		"birth_city", "birth_country", "birth_name", "nationalities", "postal_address", "address", "tax_residencies", "status",
+	}).AddRow("1", "2025-01-01T00:00:00Z", "2025-01-01T00:00:00Z", "Jason", "Schmidt", "", "",
+		time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC), "Berlin", "DE", "", `["DE"]`, `null`,
+		`{"address_line1":"1 Main St","postcode":"10115","city":"Berlin","country":"DE"}`, `[]`, "ACTIVE")
+
+	mock.ExpectQuery(`to_char\(birth_date, 'YYYY-MM-DD'\)`).WithArgs("1").WillReturnRows(row)
+
+	user, err := repo.GetUserByID(context.Background(), "1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1990-01-01", user.BirthDate)
+	assert.NoError(t, user.Validate())
 }
 
 func Test_GetUserByID_NotFound(t *testing.T) {
@@ -223,22 +395,36 @@ func Test_OffboardUser_Success(t *testing.T) {
 	setup()
 	defer teardown()
 
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status FROM users WHERE id = \$1 FOR UPDATE`).
+		WithArgs("123").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("ACTIVE"))
+	mock.ExpectQuery(`SELECT currency FROM ledger_postings`).
+		WithArgs("123").
+		WillReturnRows(sqlmock.NewRows([]string{"currency"}))
 	mock.ExpectExec(`UPDATE users SET status = \$1, updated_at = NOW\(\) WHERE id = \$2`).
-		WithArgs("OFFBOARDED", "123").
+		WithArgs("OFFBOARDING", "123").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`INSERT INTO outbox`).
+		WithArgs("user", "123", "USER_OFFBOARDING", sqlmock.AnyArg(), 2, "").
 		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
 
 	err := repo.OffboardUser(context.Background(), "123")
 
 	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func Test_OffboardUser_NotFound(t *testing.T) {
 	setup()
 	defer teardown()
 
-	mock.ExpectExec(`UPDATE users SET status = \$1, updated_at = NOW\(\) WHERE id = \$2`).
-		WithArgs("OFFBOARDED", "123").
-		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status FROM users WHERE id = \$1 FOR UPDATE`).
+		WithArgs("123").
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
 
 	err := repo.OffboardUser(context.Background(), "123")
 
@@ -246,13 +432,37 @@ func Test_OffboardUser_NotFound(t *testing.T) {
 	assert.EqualError(t, err, sql.ErrNoRows.Error())
 }
 
+func Test_OffboardUser_IllegalTransition(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status FROM users WHERE id = \$1 FOR UPDATE`).
+		WithArgs("123").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("OFFBOARDED"))
+	mock.ExpectRollback()
+
+	err := repo.OffboardUser(context.Background(), "123")
+
+	assert.True(t, errors.Is(err, domain.ErrIllegalStatusTransition))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func Test_OffboardUser_DatabaseError(t *testing.T) {
 	setup()
 	defer teardown()
 
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status FROM users WHERE id = \$1 FOR UPDATE`).
+		WithArgs("123").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("ACTIVE"))
+	mock.ExpectQuery(`SELECT currency FROM ledger_postings`).
+		WithArgs("123").
+		WillReturnRows(sqlmock.NewRows([]string{"currency"}))
 	mock.ExpectExec(`UPDATE users SET status = \$1, updated_at = NOW\(\) WHERE id = \$2`).
-		WithArgs("OFFBOARDED", "123").
+		WithArgs("OFFBOARDING", "123").
 		WillReturnError(fmt.Errorf("database error"))
+	mock.ExpectRollback()
 
 	err := repo.OffboardUser(context.Background(), "123")
 
@@ -260,3 +470,194 @@ func Test_OffboardUser_DatabaseError(t *testing.T) {
 	assert.Contains(t, err.Error(), "failed to update user status: database error")
 }
 
+func Test_OffboardUser_NonZeroBalance(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status FROM users WHERE id = \$1 FOR UPDATE`).
+		WithArgs("123").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("ACTIVE"))
+	mock.ExpectQuery(`SELECT currency FROM ledger_postings`).
+		WithArgs("123").
+		WillReturnRows(sqlmock.NewRows([]string{"currency"}).AddRow("EUR"))
+	mock.ExpectRollback()
+
+	err := repo.OffboardUser(context.Background(), "123")
+
+	assert.True(t, errors.Is(err, domain.ErrNonZeroBalance))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_CompleteOffboarding_Success(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status FROM users WHERE id = \$1 FOR UPDATE`).
+		WithArgs("123").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("OFFBOARDING"))
+	mock.ExpectQuery(`SELECT currency FROM ledger_postings`).
+		WithArgs("123").
+		WillReturnRows(sqlmock.NewRows([]string{"currency"}))
+	mock.ExpectExec(`UPDATE users SET status = \$1, updated_at = NOW\(\) WHERE id = \$2`).
+		WithArgs("OFFBOARDED", "123").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`INSERT INTO outbox`).
+		WithArgs("user", "123", "USER_OFFBOARDED", sqlmock.AnyArg(), 2, "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err := repo.CompleteOffboarding(context.Background(), "123")
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_CompleteOffboarding_NonZeroBalance(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status FROM users WHERE id = \$1 FOR UPDATE`).
+		WithArgs("123").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("OFFBOARDING"))
+	mock.ExpectQuery(`SELECT currency FROM ledger_postings`).
+		WithArgs("123").
+		WillReturnRows(sqlmock.NewRows([]string{"currency"}).AddRow("EUR"))
+	mock.ExpectRollback()
+
+	err := repo.CompleteOffboarding(context.Background(), "123")
+
+	assert.True(t, errors.Is(err, domain.ErrNonZeroBalance))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_UpdateUser_Success(t *testing.T) {
+	setup()
+	defer teardown()
+
+	user := &domain.User{
+		ID:            "123",
+		UpdatedAt:     "2025-01-01T00:00:00Z",
+		FirstName:     "Rob",
+		LastName:      "Meyer",
+		BirthDate:     "1990-01-01",
+		BirthCity:     "Berlin",
+		BirthCountry:  "DE",
+		Nationalities: []string{"DE"},
+		Address:       domain.Address{AddressLine1: "1 New St", Postcode: "20095", City: "Hamburg", Country: "DE"},
+	}
+	address, _ := json.Marshal(user.Address)
+
+	row := sqlmock.NewRows([]string{
+		"id", "created_at", "updated_at", "first_name", "last_name", "salutation", "title", "birth_date",
+		"birth_city", "birth_country", "birth_name", "nationalities", "postal_address", "address", "tax_residencies", "status",
+	}).AddRow("123", "2025-01-01T00:00:00Z", "2025-01-02T00:00:00Z", "Rob", "Meyer", "", "", "1990-01-01",
+		"Berlin", "DE", "", `["DE"]`, `null`, string(address), `[]`, "ACTIVE")
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status, updated_at FROM users WHERE id = \$1 FOR UPDATE`).
+		WithArgs("123").
+		WillReturnRows(sqlmock.NewRows([]string{"status", "updated_at"}).AddRow("ACTIVE", "2025-01-01T00:00:00Z"))
+	mock.ExpectQuery(`UPDATE users SET address = \$1, last_name = \$2, updated_at = NOW\(\) WHERE id = \$3`).
+		WithArgs(address, "Meyer", "123").
+		WillReturnRows(row)
+	mock.ExpectExec(`INSERT INTO outbox`).
+		WithArgs("user", "123", "USER_UPDATED", sqlmock.AnyArg(), 2, "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	updated, err := repo.UpdateUser(context.Background(), user, []string{"address", "last_name"}, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Meyer", updated.LastName)
+	assert.Equal(t, "Hamburg", updated.Address.City)
+	assert.Nil(t, updated.PostalAddress)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_UpdateUser_BirthDateUpdatesMinor(t *testing.T) {
+	setup()
+	defer teardown()
+	users := &userRepo{db: db, now: func() time.Time { return time.Date(2025, 4, 13, 0, 0, 0, 0, time.UTC) }}
+
+	row := sqlmock.NewRows([]string{
+		"id", "created_at", "updated_at", "first_name", "last_name", "salutation", "title", "birth_date",
+		"birth_city", "birth_country", "birth_name", "nationalities", "postal_address", "address", "tax_residencies", "status",
+	}).AddRow("123", "2025-01-01T00:00:00Z", "2025-01-02T00:00:00Z", "Rob", "Smith", "", "", "2010-05-01",
+		"Berlin", "DE", "", `["DE"]`, `null`, `{}`, `[]`, "ACTIVE")
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status, updated_at FROM users WHERE id = \$1 FOR UPDATE`).
+		WithArgs("123").
+		WillReturnRows(sqlmock.NewRows([]string{"status", "updated_at"}).AddRow("ACTIVE", "2025-01-01T00:00:00Z"))
+	mock.ExpectQuery(`UPDATE users SET birth_date = \$1, minor = \$2, updated_at = NOW\(\) WHERE id = \$3`).
+		WithArgs("2010-05-01", true, "123").
+		WillReturnRows(row)
+	mock.ExpectExec(`INSERT INTO outbox`).
+		WithArgs("user", "123", "USER_UPDATED", sqlmock.AnyArg(), 2, "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	_, err := users.UpdateUser(context.Background(), &domain.User{ID: "123", UpdatedAt: "2025-01-01T00:00:00Z", BirthDate: "2010-05-01"}, []string{"birth_date"}, nil)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_UpdateUser_NotFound(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status, updated_at FROM users WHERE id = \$1 FOR UPDATE`).
+		WithArgs("123").
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+
+	_, err := repo.UpdateUser(context.Background(), &domain.User{ID: "123", LastName: "Meyer"}, []string{"last_name"}, nil)
+
+	assert.True(t, errors.Is(err, sql.ErrNoRows))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_UpdateUser_ModifiedConcurrently(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status, updated_at FROM users WHERE id = \$1 FOR UPDATE`).
+		WithArgs("123").
+		WillReturnRows(sqlmock.NewRows([]string{"status", "updated_at"}).AddRow("ACTIVE", "2025-01-03T00:00:00Z"))
+	mock.ExpectRollback()
+
+	user := &domain.User{ID: "123", UpdatedAt: "2025-01-01T00:00:00Z", LastName: "Meyer"}
+	_, err := repo.UpdateUser(context.Background(), user, []string{"last_name"}, nil)
+
+	assert.True(t, errors.Is(err, domain.ErrUserModified))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_UpdateUser_RejectsOffboardingUser(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status, updated_at FROM users WHERE id = \$1 FOR UPDATE`).
+		WithArgs("123").
+		WillReturnRows(sqlmock.NewRows([]string{"status", "updated_at"}).AddRow("OFFBOARDING", "2025-01-01T00:00:00Z"))
+	mock.ExpectRollback()
+
+	user := &domain.User{ID: "123", UpdatedAt: "2025-01-01T00:00:00Z", LastName: "Meyer"}
+	_, err := repo.UpdateUser(context.Background(), user, []string{"last_name"}, nil)
+
+	assert.True(t, errors.Is(err, domain.ErrUserNotActive))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_UpdateUser_UnknownField(t *testing.T) {
+	_, err := repo.UpdateUser(context.Background(), &domain.User{ID: "123"}, []string{"status"}, nil)
+
+	assert.EqualError(t, err, `field "status" cannot be updated`)
+}
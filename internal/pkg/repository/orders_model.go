@@ -0,0 +1,320 @@
+//go:generate mockery --name=OrderRepository --output=../../util/mocks --outpkg=mocks
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+)
+
+const (
+	orderCancelReasonRequested  = "USER_REQUESTED"
+	orderCancelReasonOffboarded = "USER_OFFBOARDED"
+)
+
+type OrderRepository interface {
+	CreateOrder(ctx context.Context, order *domain.Order) (*domain.Order, error)
+	GetAllOrders(ctx context.Context, filter OrderFilter, page Page) ([]domain.Order, PageInfo, error)
+	GetOrderByID(ctx context.Context, orderID string) (*domain.Order, error)
+	CancelOrder(ctx context.Context, orderID string) (*domain.Order, error)
+	CancelUserOrders(ctx context.Context, userID string) error
+}
+
+// OrderFilter narrows an order list. Zero-valued fields do not filter.
+type OrderFilter struct {
+	UserID    string   `json:"user_id,omitempty"`
+	AccountID string   `json:"account_id,omitempty"`
+	ISIN      string   `json:"isin,omitempty"`
+	Statuses  []string `json:"status,omitempty"`
+}
+
+func (f OrderFilter) conditions(argPos int) ([]string, []interface{}) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+	placeholder := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", argPos+len(args)-1)
+	}
+
+	if f.UserID != "" {
+		conditions = append(conditions, "user_id = "+placeholder(f.UserID))
+	}
+	if f.AccountID != "" {
+		conditions = append(conditions, "account_id = "+placeholder(f.AccountID))
+	}
+	if f.ISIN != "" {
+		conditions = append(conditions, "isin = "+placeholder(f.ISIN))
+	}
+	if len(f.Statuses) > 0 {
+		placeholders := make([]string, 0, len(f.Statuses))
+		for _, status := range f.Statuses {
+			placeholders = append(placeholders, placeholder(status))
+		}
+		conditions = append(conditions, "status IN ("+strings.Join(placeholders, ", ")+")")
+	}
+
+	return conditions, args
+}
+
+type orderRepo struct {
+	db *sql.DB
+}
+
+func NewOrderRepository(db *sql.DB) OrderRepository {
+	return &orderRepo{db: db}
+}
+
+// CreateOrder places an order for an ACTIVE user in one of the user's open
+// accounts. The instrument must be tradable and quoted in the order currency.
+func (r *orderRepo) CreateOrder(ctx context.Context, order *domain.Order) (*domain.Order, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := checkTradable(ctx, tx, order.UserID, order.AccountID, order.ISIN, order.Currency,
+		domain.ErrOrderNotAccepted); err != nil {
+		return nil, err
+	}
+
+	err = tx.QueryRowContext(ctx, queryCreateOrder,
+		order.UserID, order.AccountID, order.ISIN, order.Side, order.Type,
+		order.Quantity, order.CashAmount, order.LimitPrice, order.Currency, domain.OrderStatusNew,
+	).Scan(&order.ID, &order.CreatedAt, &order.UpdatedAt, &order.FilledQuantity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create order: %w", err)
+	}
+	order.Status = domain.OrderStatusNew
+
+	if err := insertOutboxEvent(ctx, tx, aggregateOrder, order.ID, eventOrderCreated, map[string]interface{}{
+		"action": eventOrderCreated,
+		"order":  order,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return order, nil
+}
+
+func (r *orderRepo) GetAllOrders(ctx context.Context, filter OrderFilter, page Page) ([]domain.Order, PageInfo, error) {
+	page = page.normalize()
+
+	conditions, args := filter.conditions(1)
+
+	if page.Cursor != nil {
+		condition, orderBy, keysetArgs := page.keyset(len(args) + 1)
+		conditions = append(conditions, condition)
+		args = append(args, keysetArgs...)
+		args = append(args, page.Limit+1)
+		query := fmt.Sprintf(queryReadOrdersByCursor, whereClause(conditions), orderBy, len(args))
+
+		orders, err := r.queryOrders(ctx, query, args...)
+		if err != nil {
+			return nil, PageInfo{}, err
+		}
+		orders, info := trimPage(orders, page)
+		return orders, info, nil
+	}
+
+	args = append(args, page.Limit, page.Offset)
+	query := fmt.Sprintf(queryReadOrders, whereClause(conditions), page.Sort, page.Order, len(args)-1, len(args))
+
+	orders, err := r.queryOrders(ctx, query, args...)
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+
+	return orders, PageInfo{HasNext: len(orders) == page.Limit, HasPrev: page.Offset > 0}, nil
+}
+
+func (r *orderRepo) queryOrders(ctx context.Context, query string, args ...interface{}) ([]domain.Order, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	var orders []domain.Order
+	for rows.Next() {
+		order, err := scanOrder(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		orders = append(orders, *order)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return orders, nil
+}
+
+func (r *orderRepo) GetOrderByID(ctx context.Context, orderID string) (*domain.Order, error) {
+	order, err := scanOrder(r.db.QueryRowContext(ctx, queryReadOrderByID, orderID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("order not found: %w", err)
+	} else if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	return order, nil
+}
+
+// CancelOrder cancels an open order and records an ORDER_CANCELLED event.
+func (r *orderRepo) CancelOrder(ctx context.Context, orderID string) (*domain.Order, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var current string
+	err = tx.QueryRowContext(ctx, queryLockOrderStatus, orderID).Scan(&current)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, sql.ErrNoRows
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read order status: %w", err)
+	}
+
+	if err := domain.ValidateOrderStatusTransition(current, domain.OrderStatusCancelled); err != nil {
+		return nil, err
+	}
+
+	order, err := scanOrder(tx.QueryRowContext(ctx, queryUpdateOrderStatus, domain.OrderStatusCancelled, orderID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel order: %w", err)
+	}
+
+	if err := insertOrderCancelledEvent(ctx, tx, order, orderCancelReasonRequested); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return order, nil
+}
+
+// CancelUserOrders cancels all open orders of a user. It is idempotent, so it
+// can run as an offboarding step.
+func (r *orderRepo) CancelUserOrders(ctx context.Context, userID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, queryCancelUserOrders, userID)
+	if err != nil {
+		return fmt.Errorf("failed to cancel orders: %w", err)
+	}
+	var cancelled []*domain.Order
+	for rows.Next() {
+		order, err := scanOrder(rows)
+		if err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan row: %w", err)
+		}
+		cancelled = append(cancelled, order)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("row iteration error: %w", err)
+	}
+
+	for _, order := range cancelled {
+		if err := insertOrderCancelledEvent(ctx, tx, order, orderCancelReasonOffboarded); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// checkTradable verifies within tx that an ACTIVE user can trade an instrument
+// in the given currency from one of the user's open accounts. Reasons the
+// account or instrument cannot be used are wrapped in notAccepted.
+func checkTradable(ctx context.Context, tx *sql.Tx, userID, accountID, isin, currency string, notAccepted error) error {
+	var userStatus string
+	err := tx.QueryRowContext(ctx, queryLockUserStatus, userID).Scan(&userStatus)
+	if errors.Is(err, sql.ErrNoRows) {
+		return sql.ErrNoRows
+	} else if err != nil {
+		return fmt.Errorf("failed to read user status: %w", err)
+	}
+	if userStatus != domain.UserStatusActive {
+		return fmt.Errorf("%w: user %s is %s", domain.ErrUserNotActive, userID, userStatus)
+	}
+
+	var accountUserID, accountStatus string
+	err = tx.QueryRowContext(ctx, queryLockAccountForOrder, accountID).Scan(&accountUserID, &accountStatus)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && accountUserID != userID) {
+		return fmt.Errorf("%w: account %s does not belong to user %s", notAccepted, accountID, userID)
+	} else if err != nil {
+		return fmt.Errorf("failed to read account status: %w", err)
+	}
+	if accountStatus != domain.AccountStatusActive {
+		return fmt.Errorf("%w: account %s is %s", notAccepted, accountID, accountStatus)
+	}
+
+	var tradingStatus, instrumentCurrency string
+	err = tx.QueryRowContext(ctx, queryReadInstrumentTrading, isin).Scan(&tradingStatus, &instrumentCurrency)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: instrument %s is not in the catalogue", notAccepted, isin)
+	} else if err != nil {
+		return fmt.Errorf("failed to read instrument: %w", err)
+	}
+	if tradingStatus != domain.TradingStatusActive {
+		return fmt.Errorf("%w: instrument %s is %s", notAccepted, isin, tradingStatus)
+	}
+	if instrumentCurrency != currency {
+		return fmt.Errorf("%w: instrument %s trades in %s", notAccepted, isin, instrumentCurrency)
+	}
+	return nil
+}
+
+func insertOrderCancelledEvent(ctx context.Context, tx *sql.Tx, order *domain.Order, reason string) error {
+	return insertOutboxEvent(ctx, tx, aggregateOrder, order.ID, eventOrderCancelled, map[string]interface{}{
+		"action": eventOrderCancelled,
+		"order":  order,
+		"reason": reason,
+	})
+}
+
+// scanOrder reads an order row selected in the column order of queryReadOrders.
+func scanOrder(row rowScanner) (*domain.Order, error) {
+	var (
+		order                            domain.Order
+		quantity, cashAmount, limitPrice sql.NullString
+	)
+
+	if err := row.Scan(
+		&order.ID, &order.CreatedAt, &order.UpdatedAt, &order.UserID, &order.AccountID, &order.ISIN,
+		&order.Side, &order.Type, &quantity, &cashAmount, &limitPrice, &order.Currency,
+		&order.FilledQuantity, &order.Status,
+	); err != nil {
+		return nil, err
+	}
+	order.Quantity = quantity.String
+	order.CashAmount = cashAmount.String
+	order.LimitPrice = limitPrice.String
+
+	return &order, nil
+}
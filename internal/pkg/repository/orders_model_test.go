@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+var orderColumns = []string{
+	"id", "created_at", "updated_at", "user_id", "account_id", "isin", "side", "type", "quantity", "cash_amount",
+	"limit_price", "currency", "filled_quantity", "status",
+}
+
+func newTestOrder() *domain.Order {
+	return &domain.Order{
+		UserID:     "u1",
+		AccountID:  "a1",
+		ISIN:       "DE0007164600",
+		Side:       "BUY",
+		Type:       "MARKET",
+		CashAmount: "100.00",
+		Currency:   "EUR",
+	}
+}
+
+func expectOrderPreconditions(userStatus, accountUserID, accountStatus, tradingStatus string) {
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status FROM users WHERE id = \$1 FOR UPDATE`).
+		WithArgs("u1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(userStatus))
+	if userStatus != "ACTIVE" {
+		return
+	}
+	mock.ExpectQuery(`SELECT user_id, status FROM accounts WHERE id = \$1 FOR SHARE`).
+		WithArgs("a1").
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "status"}).AddRow(accountUserID, accountStatus))
+	if accountUserID != "u1" || accountStatus != "ACTIVE" {
+		return
+	}
+	mock.ExpectQuery(`SELECT trading_status, currency FROM instruments WHERE isin = \$1`).
+		WithArgs("DE0007164600").
+		WillReturnRows(sqlmock.NewRows([]string{"trading_status", "currency"}).AddRow(tradingStatus, "EUR"))
+}
+
+func Test_CreateOrder_Success(t *testing.T) {
+	setup()
+	defer teardown()
+
+	orders := NewOrderRepository(db)
+
+	expectOrderPreconditions("ACTIVE", "u1", "ACTIVE", "ACTIVE")
+	mock.ExpectQuery(`INSERT INTO orders`).
+		WithArgs("u1", "a1", "DE0007164600", "BUY", "MARKET", "", "100.00", "", "EUR", "NEW").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "filled_quantity"}).
+			AddRow("o1", "2025-01-01T00:00:00Z", "2025-01-01T00:00:00Z", "0"))
+	mock.ExpectExec(`INSERT INTO outbox`).
+		WithArgs("order", "o1", "ORDER_CREATED", sqlmock.AnyArg(), 1, "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	order, err := orders.CreateOrder(context.Background(), newTestOrder())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "o1", order.ID)
+	assert.Equal(t, "NEW", order.Status)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_CreateOrder_Rejections(t *testing.T) {
+	setup()
+	defer teardown()
+
+	orders := NewOrderRepository(db)
+
+	tests := []struct {
+		name                                                         string
+		userStatus, accountUserID, accountStatus, tradingStatus, err string
+	}{
+		{"user offboarding", "OFFBOARDING", "", "", "", "user is not active: user u1 is OFFBOARDING"},
+		{"foreign account", "ACTIVE", "u2", "ACTIVE", "", "order not accepted: account a1 does not belong to user u1"},
+		{"closed account", "ACTIVE", "u1", "CLOSED", "", "order not accepted: account a1 is CLOSED"},
+		{"suspended instrument", "ACTIVE", "u1", "ACTIVE", "SUSPENDED", "order not accepted: instrument DE0007164600 is SUSPENDED"},
+	}
+
+	for _, tt := range tests {
+		expectOrderPreconditions(tt.userStatus, tt.accountUserID, tt.accountStatus, tt.tradingStatus)
+		mock.ExpectRollback()
+
+		order, err := orders.CreateOrder(context.Background(), newTestOrder())
+
+		assert.Nil(t, order, tt.name)
+		assert.EqualError(t, err, tt.err, tt.name)
+		assert.NoError(t, mock.ExpectationsWereMet(), tt.name)
+	}
+}
+
+func Test_CancelOrder_Success(t *testing.T) {
+	setup()
+	defer teardown()
+
+	orders := NewOrderRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status FROM orders WHERE id = \$1 FOR UPDATE`).
+		WithArgs("o1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("NEW"))
+	mock.ExpectQuery(`UPDATE orders`).
+		WithArgs("CANCELLED", "o1").
+		WillReturnRows(sqlmock.NewRows(orderColumns).AddRow("o1", "2025-01-01T00:00:00Z", "2025-01-02T00:00:00Z",
+			"u1", "a1", "DE0007164600", "BUY", "LIMIT", "1.5", nil, "120.5", "EUR", "0", "CANCELLED"))
+	mock.ExpectExec(`INSERT INTO outbox`).
+		WithArgs("order", "o1", "ORDER_CANCELLED", sqlmock.AnyArg(), 1, "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	order, err := orders.CancelOrder(context.Background(), "o1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "CANCELLED", order.Status)
+	assert.Equal(t, "1.5", order.Quantity)
+	assert.Empty(t, order.CashAmount)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_CancelOrder_IllegalTransition(t *testing.T) {
+	setup()
+	defer teardown()
+
+	orders := NewOrderRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status FROM orders WHERE id = \$1 FOR UPDATE`).
+		WithArgs("o1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("FILLED"))
+	mock.ExpectRollback()
+
+	_, err := orders.CancelOrder(context.Background(), "o1")
+
+	assert.ErrorIs(t, err, domain.ErrIllegalOrderTransition)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_CancelOrder_NotFound(t *testing.T) {
+	setup()
+	defer teardown()
+
+	orders := NewOrderRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status FROM orders WHERE id = \$1 FOR UPDATE`).
+		WithArgs("o1").
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+
+	_, err := orders.CancelOrder(context.Background(), "o1")
+
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_CancelUserOrders(t *testing.T) {
+	setup()
+	defer teardown()
+
+	orders := NewOrderRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`UPDATE orders`).
+		WithArgs("u1").
+		WillReturnRows(sqlmock.NewRows(orderColumns).AddRow("o1", "2025-01-01T00:00:00Z", "2025-01-02T00:00:00Z",
+			"u1", "a1", "DE0007164600", "BUY", "MARKET", nil, "100", nil, "EUR", "0", "CANCELLED"))
+	mock.ExpectExec(`INSERT INTO outbox`).
+		WithArgs("order", "o1", "ORDER_CANCELLED", sqlmock.AnyArg(), 1, "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err := orders.CancelUserOrders(context.Background(), "u1")
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
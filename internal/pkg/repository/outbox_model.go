@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/event"
+)
+
+type outboxRepo struct {
+	db *sql.DB
+}
+
+func NewOutboxRepository(db *sql.DB) event.OutboxStore {
+	return &outboxRepo{db: db}
+}
+
+// insertOutboxEvent stores an event within the caller's transaction, so that it
+// is relayed to Kafka if and only if the surrounding change commits. The payload
+// is version 1 of eventType; typed events are stored with insertEvent.
+func insertOutboxEvent(ctx context.Context, tx *sql.Tx, aggregateType, aggregateID, eventType string, payload interface{}) error {
+	return insertOutboxRow(ctx, tx, aggregateType, aggregateID, eventType, 1, payload)
+}
+
+// insertEvent stores a typed event like insertOutboxEvent, with the schema
+// version of its payload.
+func insertEvent(ctx context.Context, tx *sql.Tx, aggregateType, aggregateID string, payload event.Payload) error {
+	return insertOutboxRow(ctx, tx, aggregateType, aggregateID, payload.EventType(), payload.SchemaVersion(), payload)
+}
+
+// insertOutboxRow tags the event with the correlation ID of ctx, if any.
+func insertOutboxRow(ctx context.Context, tx *sql.Tx, aggregateType, aggregateID, eventType string, version int, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s event: %w", eventType, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, queryInsertOutbox, aggregateType, aggregateID, eventType, body,
+		version, event.CorrelationID(ctx)); err != nil {
+		return fmt.Errorf("failed to insert outbox event: %w", err)
+	}
+	return nil
+}
+
+func (r *outboxRepo) ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]event.OutboxMessage, error) {
+	rows, err := r.db.QueryContext(ctx, queryClaimOutbox, limit, lease.Milliseconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim outbox messages: %w", err)
+	}
+	defer rows.Close()
+
+	type claimed struct {
+		seq int64
+		msg event.OutboxMessage
+	}
+
+	var batch []claimed
+	for rows.Next() {
+		var (
+			c   claimed
+			key string
+		)
+		if err := rows.Scan(&c.msg.ID, &c.seq, &key, &c.msg.Type, &c.msg.SchemaVersion, &c.msg.CreatedAt,
+			&c.msg.CorrelationID, &c.msg.Payload, &c.msg.Attempts); err != nil {
+			return nil, fmt.Errorf("failed to scan outbox row: %w", err)
+		}
+		c.msg.Key = []byte(key)
+		batch = append(batch, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	// RETURNING does not preserve the order of the sub-select.
+	sort.Slice(batch, func(i, j int) bool { return batch[i].seq < batch[j].seq })
+
+	messages := make([]event.OutboxMessage, 0, len(batch))
+	for _, c := range batch {
+		messages = append(messages, c.msg)
+	}
+	return messages, nil
+}
+
+func (r *outboxRepo) MarkSent(ctx context.Context, id string) error {
+	if _, err := r.db.ExecContext(ctx, queryMarkOutboxSent, id); err != nil {
+		return fmt.Errorf("failed to mark outbox message as sent: %w", err)
+	}
+	return nil
+}
+
+func (r *outboxRepo) MarkFailed(ctx context.Context, id string, cause error, retryAfter time.Duration) error {
+	if _, err := r.db.ExecContext(ctx, queryMarkOutboxFailed, id, cause.Error(), retryAfter.Milliseconds()); err != nil {
+		return fmt.Errorf("failed to mark outbox message as failed: %w", err)
+	}
+	return nil
+}
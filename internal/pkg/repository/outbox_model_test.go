@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ashwingopalsamy/upvest-api/internal/event"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_InsertEvent_StoresTypedPayloadWithCorrelationID(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status FROM users WHERE id = \$1 FOR UPDATE`).
+		WithArgs("123").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("OFFBOARDING"))
+	mock.ExpectQuery(`SELECT currency FROM ledger_postings`).
+		WithArgs("123").
+		WillReturnRows(sqlmock.NewRows([]string{"currency"}))
+	mock.ExpectExec(`UPDATE users SET status = \$1, updated_at = NOW\(\) WHERE id = \$2`).
+		WithArgs("OFFBOARDED", "123").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`INSERT INTO outbox`).
+		WithArgs("user", "123", "USER_OFFBOARDED",
+			[]byte(`{"user_id":"123","previous_status":"OFFBOARDING","status":"OFFBOARDED"}`), 2, "corr-1").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err := repo.CompleteOffboarding(event.WithCorrelationID(context.Background(), "corr-1"), "123")
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_ClaimPending_ReturnsEnvelopeFields(t *testing.T) {
+	setup()
+	defer teardown()
+	outbox := NewOutboxRepository(db)
+	createdAt := time.Date(2025, 5, 11, 9, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`UPDATE outbox`).
+		WithArgs(100, int64(30000)).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "seq", "aggregate_id", "event_type", "schema_version", "created_at", "correlation_id", "payload", "attempts",
+		}).
+			AddRow("o2", 2, "123", "USER_OFFBOARDED", 2, createdAt, "", []byte(`{}`), 1).
+			AddRow("o1", 1, "123", "USER_OFFBOARDING", 2, createdAt, "corr-1", []byte(`{}`), 1))
+
+	messages, err := outbox.ClaimPending(context.Background(), 100, 30*time.Second)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []event.OutboxMessage{
+		{ID: "o1", Key: []byte("123"), Type: "USER_OFFBOARDING", SchemaVersion: 2, CreatedAt: createdAt,
+			CorrelationID: "corr-1", Payload: []byte(`{}`), Attempts: 1},
+		{ID: "o2", Key: []byte("123"), Type: "USER_OFFBOARDED", SchemaVersion: 2, CreatedAt: createdAt,
+			Payload: []byte(`{}`), Attempts: 1},
+	}, messages)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
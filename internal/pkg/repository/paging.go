@@ -0,0 +1,90 @@
+package repository
+
+import "fmt"
+
+// Page selects a window of a list. Without a Cursor the window is addressed by
+// Offset; with a Cursor it starts right after (or, when Backward, right before)
+// the cursor position.
+type Page struct {
+	Offset int
+	Limit  int
+	Sort   string
+	Order  string
+	Cursor *Cursor
+}
+
+// Cursor is a position in a list ordered by a sort column, with the row ID as
+// tie-breaker so that every position is unique.
+type Cursor struct {
+	SortValue string
+	ID        string
+	Backward  bool
+}
+
+// PageInfo reports whether rows exist beyond either end of a returned page.
+type PageInfo struct {
+	HasNext bool
+	HasPrev bool
+}
+
+// normalize applies the default sort column and order.
+func (p Page) normalize() Page {
+	if p.Sort != "created_at" && p.Sort != "updated_at" {
+		p.Sort = "created_at"
+	}
+	if p.Order != "ASC" && p.Order != "DESC" {
+		p.Order = "ASC"
+	}
+	return p
+}
+
+// keyset returns the condition and ORDER BY clause selecting the rows of a
+// cursor page, together with the condition's arguments. Placeholders are
+// numbered from argPos. A backward page is read in reverse order and must be
+// flipped by the caller.
+func (p Page) keyset(argPos int) (string, string, []interface{}) {
+	return p.keysetOn(argPos, "id", "UUID")
+}
+
+// keysetOn is keyset for tables whose tie-breaking key is keyColumn of SQL type
+// keyType rather than a UUID id.
+func (p Page) keysetOn(argPos int, keyColumn, keyType string) (string, string, []interface{}) {
+	order := p.Order
+	if p.Cursor.Backward {
+		order = reverseOrder(order)
+	}
+
+	comparison := ">"
+	if order == "DESC" {
+		comparison = "<"
+	}
+
+	condition := fmt.Sprintf("(%s, %s) %s ($%d::TIMESTAMP, $%d::%s)", p.Sort, keyColumn, comparison, argPos, argPos+1, keyType)
+	orderBy := fmt.Sprintf("%s %s, %s %s", p.Sort, order, keyColumn, order)
+	return condition, orderBy, []interface{}{p.Cursor.SortValue, p.Cursor.ID}
+}
+
+func reverseOrder(order string) string {
+	if order == "ASC" {
+		return "DESC"
+	}
+	return "ASC"
+}
+
+// trimPage cuts rows read for a cursor page, which include one row beyond the
+// limit, down to the page and reports whether the list continues on either
+// side. Rows of a backward page are flipped back into list order.
+func trimPage[T any](rows []T, page Page) ([]T, PageInfo) {
+	more := len(rows) > page.Limit
+	if more {
+		rows = rows[:page.Limit]
+	}
+
+	if page.Cursor.Backward {
+		for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+			rows[i], rows[j] = rows[j], rows[i]
+		}
+		return rows, PageInfo{HasNext: true, HasPrev: more}
+	}
+	return rows, PageInfo{HasNext: more, HasPrev: true}
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/event"
+)
+
+type processedMessageRepo struct {
+	db *sql.DB
+}
+
+func NewProcessedMessageRepository(db *sql.DB) event.ProcessedStore {
+	return &processedMessageRepo{db: db}
+}
+
+func (r *processedMessageRepo) IsProcessed(ctx context.Context, groupID, topic string, partition int, offset int64) (bool, error) {
+	var processed bool
+	if err := r.db.QueryRowContext(ctx, queryIsMessageProcessed, groupID, topic, partition, offset).Scan(&processed); err != nil {
+		return false, fmt.Errorf("failed to look up processed message: %w", err)
+	}
+	return processed, nil
+}
+
+func (r *processedMessageRepo) MarkProcessed(ctx context.Context, groupID, topic string, partition int, offset int64) error {
+	if _, err := r.db.ExecContext(ctx, queryMarkMessageProcessed, groupID, topic, partition, offset); err != nil {
+		return fmt.Errorf("failed to mark message as processed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_IsProcessed(t *testing.T) {
+	setup()
+	defer teardown()
+	processed := NewProcessedMessageRepository(db)
+
+	mock.ExpectQuery(`SELECT EXISTS \(SELECT 1 FROM processed_messages`).
+		WithArgs("user-subscriber-group", "user-events", 2, int64(42)).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	ok, err := processed.IsProcessed(context.Background(), "user-subscriber-group", "user-events", 2, 42)
+
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_MarkProcessed(t *testing.T) {
+	setup()
+	defer teardown()
+	processed := NewProcessedMessageRepository(db)
+
+	mock.ExpectExec(`INSERT INTO processed_messages`).
+		WithArgs("user-subscriber-group", "user-events", 2, int64(42)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := processed.MarkProcessed(context.Background(), "user-subscriber-group", "user-events", 2, 42)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
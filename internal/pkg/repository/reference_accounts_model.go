@@ -0,0 +1,103 @@
+//go:generate mockery --name=ReferenceAccountRepository --output=../../util/mocks --outpkg=mocks
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+)
+
+type ReferenceAccountRepository interface {
+	AddReferenceAccount(ctx context.Context, account *domain.ReferenceAccount) (*domain.ReferenceAccount, error)
+	GetReferenceAccounts(ctx context.Context, userID string) ([]domain.ReferenceAccount, error)
+}
+
+type referenceAccountRepo struct {
+	db *sql.DB
+}
+
+func NewReferenceAccountRepository(db *sql.DB) ReferenceAccountRepository {
+	return &referenceAccountRepo{db: db}
+}
+
+// AddReferenceAccount stores a reference account of an ACTIVE user whose name
+// matches the account holder.
+func (r *referenceAccountRepo) AddReferenceAccount(ctx context.Context, account *domain.ReferenceAccount) (*domain.ReferenceAccount, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var status, firstName, lastName string
+	err = tx.QueryRowContext(ctx, queryLockUserName, account.UserID).Scan(&status, &firstName, &lastName)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, sql.ErrNoRows
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read user: %w", err)
+	}
+	if status != domain.UserStatusActive {
+		return nil, fmt.Errorf("%w: user %s is %s", domain.ErrUserNotActive, account.UserID, status)
+	}
+	if !domain.HolderNameMatches(account.AccountHolder, firstName, lastName) {
+		return nil, domain.ErrHolderNameMismatch
+	}
+
+	err = tx.QueryRowContext(ctx, queryUpsertReferenceAccount,
+		account.UserID, account.IBAN, account.BIC, account.AccountHolder,
+	).Scan(&account.ID, &account.CreatedAt, &account.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add reference account: %w", err)
+	}
+
+	if err := insertOutboxEvent(ctx, tx, aggregateReferenceAccount, account.ID, eventReferenceAccountAdded, map[string]interface{}{
+		"action":            eventReferenceAccountAdded,
+		"reference_account": account,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return account, nil
+}
+
+// GetReferenceAccounts returns the reference accounts of a user, oldest first.
+func (r *referenceAccountRepo) GetReferenceAccounts(ctx context.Context, userID string) ([]domain.ReferenceAccount, error) {
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, queryUserExists, userID).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("user not found: %w", sql.ErrNoRows)
+	}
+
+	rows, err := r.db.QueryContext(ctx, queryReadReferenceAccounts, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	accounts := []domain.ReferenceAccount{}
+	for rows.Next() {
+		var account domain.ReferenceAccount
+		if err := rows.Scan(
+			&account.ID, &account.CreatedAt, &account.UpdatedAt, &account.UserID,
+			&account.IBAN, &account.BIC, &account.AccountHolder,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		accounts = append(accounts, account)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return accounts, nil
+}
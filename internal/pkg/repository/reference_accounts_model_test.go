@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestReferenceAccount(holder string) *domain.ReferenceAccount {
+	return &domain.ReferenceAccount{
+		UserID:        "u1",
+		IBAN:          "DE89370400440532013000",
+		BIC:           "COBADEFFXXX",
+		AccountHolder: holder,
+	}
+}
+
+func Test_AddReferenceAccount_Success(t *testing.T) {
+	setup()
+	defer teardown()
+
+	accounts := NewReferenceAccountRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status, first_name, last_name FROM users WHERE id = \$1 FOR SHARE`).
+		WithArgs("u1").
+		WillReturnRows(sqlmock.NewRows([]string{"status", "first_name", "last_name"}).AddRow("ACTIVE", "Rob", "Smith"))
+	mock.ExpectQuery(`INSERT INTO reference_accounts`).
+		WithArgs("u1", "DE89370400440532013000", "COBADEFFXXX", "SMITH ROB").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).
+			AddRow("r1", "2025-01-01T00:00:00Z", "2025-01-01T00:00:00Z"))
+	mock.ExpectExec(`INSERT INTO outbox`).
+		WithArgs("reference_account", "r1", "REFERENCE_ACCOUNT_ADDED", sqlmock.AnyArg(), 1, "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	account, err := accounts.AddReferenceAccount(context.Background(), newTestReferenceAccount("SMITH ROB"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "r1", account.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_AddReferenceAccount_HolderMismatch(t *testing.T) {
+	setup()
+	defer teardown()
+
+	accounts := NewReferenceAccountRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status, first_name, last_name FROM users WHERE id = \$1 FOR SHARE`).
+		WithArgs("u1").
+		WillReturnRows(sqlmock.NewRows([]string{"status", "first_name", "last_name"}).AddRow("ACTIVE", "Rob", "Smith"))
+	mock.ExpectRollback()
+
+	_, err := accounts.AddReferenceAccount(context.Background(), newTestReferenceAccount("Jane Smith"))
+
+	assert.ErrorIs(t, err, domain.ErrHolderNameMismatch)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,358 @@
+//go:generate mockery --name=SavingsPlanRepository --output=../../util/mocks --outpkg=mocks
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+)
+
+type SavingsPlanRepository interface {
+	CreateSavingsPlan(ctx context.Context, plan *domain.SavingsPlan) (*domain.SavingsPlan, error)
+	GetSavingsPlans(ctx context.Context, userID string) ([]domain.SavingsPlan, error)
+	GetSavingsPlanByID(ctx context.Context, planID string) (*domain.SavingsPlan, error)
+	PauseSavingsPlan(ctx context.Context, planID string) (*domain.SavingsPlan, error)
+	ResumeSavingsPlan(ctx context.Context, planID string) (*domain.SavingsPlan, error)
+	CancelSavingsPlan(ctx context.Context, planID string) (*domain.SavingsPlan, error)
+	CancelUserSavingsPlans(ctx context.Context, userID string) error
+	GetDueSavingsPlans(ctx context.Context, through time.Time) ([]domain.SavingsPlan, error)
+	RecordExecution(ctx context.Context, planID string, scheduled, executed time.Time) (*domain.SavingsPlanExecution, error)
+	GetSavingsPlanExecutions(ctx context.Context, planID string) ([]domain.SavingsPlanExecution, error)
+}
+
+type savingsPlanRepo struct {
+	db  *sql.DB
+	now func() time.Time
+}
+
+func NewSavingsPlanRepository(db *sql.DB) SavingsPlanRepository {
+	return &savingsPlanRepo{db: db, now: time.Now}
+}
+
+func (r *savingsPlanRepo) today() time.Time {
+	now := r.now().UTC()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// CreateSavingsPlan sets up a plan for an ACTIVE user in one of the user's open
+// accounts. The plan first runs on the next date of its schedule from today.
+func (r *savingsPlanRepo) CreateSavingsPlan(ctx context.Context, plan *domain.SavingsPlan) (*domain.SavingsPlan, error) {
+	next, err := plan.NextScheduledDate(r.today())
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := checkTradable(ctx, tx, plan.UserID, plan.AccountID, plan.ISIN, plan.Currency,
+		domain.ErrSavingsPlanNotAccepted); err != nil {
+		return nil, err
+	}
+
+	plan.NextExecutionDate = next.Format(domain.DateLayout)
+	plan.Status = domain.SavingsPlanStatusActive
+	err = tx.QueryRowContext(ctx, queryCreateSavingsPlan,
+		plan.UserID, plan.AccountID, plan.ISIN, plan.Amount, plan.Currency, plan.Cadence,
+		plan.StartDate, plan.NextExecutionDate, plan.Status,
+	).Scan(&plan.ID, &plan.CreatedAt, &plan.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create savings plan: %w", err)
+	}
+
+	if err := insertSavingsPlanEvent(ctx, tx, plan, eventSavingsPlanCreated); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return plan, nil
+}
+
+// GetSavingsPlans returns the savings plans of a user, oldest first.
+func (r *savingsPlanRepo) GetSavingsPlans(ctx context.Context, userID string) ([]domain.SavingsPlan, error) {
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, queryUserExists, userID).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("user not found: %w", sql.ErrNoRows)
+	}
+
+	return r.querySavingsPlans(ctx, queryReadSavingsPlans, userID)
+}
+
+func (r *savingsPlanRepo) GetSavingsPlanByID(ctx context.Context, planID string) (*domain.SavingsPlan, error) {
+	plan, err := scanSavingsPlan(r.db.QueryRowContext(ctx, queryReadSavingsPlanByID, planID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("savings plan not found: %w", err)
+	} else if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	return plan, nil
+}
+
+func (r *savingsPlanRepo) PauseSavingsPlan(ctx context.Context, planID string) (*domain.SavingsPlan, error) {
+	return r.changeSavingsPlanStatus(ctx, planID, domain.SavingsPlanStatusPaused, eventSavingsPlanPaused)
+}
+
+// ResumeSavingsPlan reactivates a paused plan. Dates missed while the plan was
+// paused are not caught up; the plan runs next on the first date of its
+// schedule from today.
+func (r *savingsPlanRepo) ResumeSavingsPlan(ctx context.Context, planID string) (*domain.SavingsPlan, error) {
+	return r.changeSavingsPlanStatus(ctx, planID, domain.SavingsPlanStatusActive, eventSavingsPlanResumed)
+}
+
+func (r *savingsPlanRepo) CancelSavingsPlan(ctx context.Context, planID string) (*domain.SavingsPlan, error) {
+	return r.changeSavingsPlanStatus(ctx, planID, domain.SavingsPlanStatusCancelled, eventSavingsPlanCancelled)
+}
+
+func (r *savingsPlanRepo) changeSavingsPlanStatus(ctx context.Context, planID, status, eventType string) (*domain.SavingsPlan, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	plan, err := scanSavingsPlan(tx.QueryRowContext(ctx, queryLockSavingsPlan, planID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, sql.ErrNoRows
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read savings plan: %w", err)
+	}
+
+	if err := domain.ValidateSavingsPlanStatusTransition(plan.Status, status); err != nil {
+		return nil, err
+	}
+
+	next := plan.NextExecutionDate
+	if status == domain.SavingsPlanStatusActive {
+		// Never move back before the stored date, which may already have run today.
+		from := r.today()
+		if stored, err := time.Parse(domain.DateLayout, next); err == nil && stored.After(from) {
+			from = stored
+		}
+		date, err := plan.NextScheduledDate(from)
+		if err != nil {
+			return nil, err
+		}
+		next = date.Format(domain.DateLayout)
+	}
+
+	plan, err = scanSavingsPlan(tx.QueryRowContext(ctx, queryUpdateSavingsPlanStatus, status, next, planID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to update savings plan: %w", err)
+	}
+
+	if err := insertSavingsPlanEvent(ctx, tx, plan, eventType); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return plan, nil
+}
+
+// CancelUserSavingsPlans cancels all plans of a user. It is idempotent, so it
+// can run as an offboarding step.
+func (r *savingsPlanRepo) CancelUserSavingsPlans(ctx context.Context, userID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, queryCancelUserSavingsPlans, userID)
+	if err != nil {
+		return fmt.Errorf("failed to cancel savings plans: %w", err)
+	}
+	var cancelled []*domain.SavingsPlan
+	for rows.Next() {
+		plan, err := scanSavingsPlan(rows)
+		if err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan row: %w", err)
+		}
+		cancelled = append(cancelled, plan)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("row iteration error: %w", err)
+	}
+
+	for _, plan := range cancelled {
+		if err := insertSavingsPlanEvent(ctx, tx, plan, eventSavingsPlanCancelled); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetDueSavingsPlans returns the active plans scheduled on or before through,
+// leaving out those of users that are not ACTIVE, e.g. held for screening.
+func (r *savingsPlanRepo) GetDueSavingsPlans(ctx context.Context, through time.Time) ([]domain.SavingsPlan, error) {
+	return r.querySavingsPlans(ctx, queryReadDueSavingsPlans, through.Format(domain.DateLayout))
+}
+
+// RecordExecution records the run of a plan for its scheduled date, moves the
+// plan on to the next date of its schedule and emits a
+// SAVINGS_PLAN_EXECUTION_DUE event. It returns ErrSavingsPlanNotDue if the
+// plan is no longer active or scheduled for that date, or if its user is no
+// longer ACTIVE.
+func (r *savingsPlanRepo) RecordExecution(ctx context.Context, planID string, scheduled, executed time.Time) (*domain.SavingsPlanExecution, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	plan, err := scanSavingsPlan(tx.QueryRowContext(ctx, queryLockSavingsPlan, planID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, sql.ErrNoRows
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read savings plan: %w", err)
+	}
+
+	execution := domain.SavingsPlanExecution{
+		SavingsPlanID: planID,
+		ScheduledDate: scheduled.Format(domain.DateLayout),
+		ExecutionDate: executed.Format(domain.DateLayout),
+	}
+	if plan.Status != domain.SavingsPlanStatusActive || plan.NextExecutionDate != execution.ScheduledDate {
+		return nil, fmt.Errorf("%w: plan %s is %s and scheduled for %s", domain.ErrSavingsPlanNotDue,
+			planID, plan.Status, plan.NextExecutionDate)
+	}
+
+	// The user is locked, so that no screening hit holds them while the
+	// execution is recorded.
+	var userStatus string
+	if err := tx.QueryRowContext(ctx, queryLockUserForPosting, plan.UserID).Scan(&userStatus); err != nil {
+		return nil, fmt.Errorf("failed to read user status: %w", err)
+	}
+	if userStatus != domain.UserStatusActive {
+		return nil, fmt.Errorf("%w: user %s of plan %s is %s", domain.ErrSavingsPlanNotDue,
+			plan.UserID, planID, userStatus)
+	}
+
+	next, err := plan.NextScheduledDate(scheduled.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.QueryRowContext(ctx, queryCreateSavingsPlanExecution,
+		planID, execution.ScheduledDate, execution.ExecutionDate,
+	).Scan(&execution.ID, &execution.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record execution: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, queryAdvanceSavingsPlan, next.Format(domain.DateLayout), planID); err != nil {
+		return nil, fmt.Errorf("failed to advance savings plan: %w", err)
+	}
+
+	if err := insertOutboxEvent(ctx, tx, aggregateSavingsPlan, planID, eventSavingsPlanExecutionDue, map[string]interface{}{
+		"action":       eventSavingsPlanExecutionDue,
+		"savings_plan": plan,
+		"execution":    execution,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &execution, nil
+}
+
+// GetSavingsPlanExecutions returns the runs of a plan, most recent first.
+func (r *savingsPlanRepo) GetSavingsPlanExecutions(ctx context.Context, planID string) ([]domain.SavingsPlanExecution, error) {
+	if _, err := r.GetSavingsPlanByID(ctx, planID); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, queryReadSavingsPlanExecutions, planID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	executions := []domain.SavingsPlanExecution{}
+	for rows.Next() {
+		var execution domain.SavingsPlanExecution
+		if err := rows.Scan(
+			&execution.ID, &execution.CreatedAt, &execution.SavingsPlanID,
+			&execution.ScheduledDate, &execution.ExecutionDate,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		executions = append(executions, execution)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return executions, nil
+}
+
+func (r *savingsPlanRepo) querySavingsPlans(ctx context.Context, query string, args ...interface{}) ([]domain.SavingsPlan, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	plans := []domain.SavingsPlan{}
+	for rows.Next() {
+		plan, err := scanSavingsPlan(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		plans = append(plans, *plan)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return plans, nil
+}
+
+func insertSavingsPlanEvent(ctx context.Context, tx *sql.Tx, plan *domain.SavingsPlan, eventType string) error {
+	return insertOutboxEvent(ctx, tx, aggregateSavingsPlan, plan.ID, eventType, map[string]interface{}{
+		"action":       eventType,
+		"savings_plan": plan,
+	})
+}
+
+// scanSavingsPlan reads a savings plan row selected with savingsPlanColumns.
+func scanSavingsPlan(row rowScanner) (*domain.SavingsPlan, error) {
+	var plan domain.SavingsPlan
+
+	if err := row.Scan(
+		&plan.ID, &plan.CreatedAt, &plan.UpdatedAt, &plan.UserID, &plan.AccountID, &plan.ISIN,
+		&plan.Amount, &plan.Currency, &plan.Cadence, &plan.StartDate, &plan.NextExecutionDate, &plan.Status,
+	); err != nil {
+		return nil, err
+	}
+
+	return &plan, nil
+}
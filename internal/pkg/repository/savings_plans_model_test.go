@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+var savingsPlanColumnNames = []string{
+	"id", "created_at", "updated_at", "user_id", "account_id", "isin", "amount", "currency", "cadence",
+	"start_date", "next_execution_date", "status",
+}
+
+func newTestSavingsPlanRepo(today string) *savingsPlanRepo {
+	now, _ := time.Parse(domain.DateLayout, today)
+	return &savingsPlanRepo{db: db, now: func() time.Time { return now.Add(15 * time.Hour) }}
+}
+
+func savingsPlanRow(next, status string) *sqlmock.Rows {
+	return sqlmock.NewRows(savingsPlanColumnNames).AddRow("sp1", "2025-01-01T00:00:00Z", "2025-01-01T00:00:00Z",
+		"u1", "a1", "DE0007164600", "50.00", "EUR", "MONTHLY", "2025-01-31", next, status)
+}
+
+func Test_CreateSavingsPlan_Success(t *testing.T) {
+	setup()
+	defer teardown()
+
+	plans := newTestSavingsPlanRepo("2025-03-10")
+
+	expectOrderPreconditions("ACTIVE", "u1", "ACTIVE", "ACTIVE")
+	mock.ExpectQuery(`INSERT INTO savings_plans`).
+		WithArgs("u1", "a1", "DE0007164600", "50.00", "EUR", "MONTHLY", "2025-01-31", "2025-03-31", "ACTIVE").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).
+			AddRow("sp1", "2025-03-10T00:00:00Z", "2025-03-10T00:00:00Z"))
+	mock.ExpectExec(`INSERT INTO outbox`).
+		WithArgs("savings_plan", "sp1", "SAVINGS_PLAN_CREATED", sqlmock.AnyArg(), 1, "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	plan, err := plans.CreateSavingsPlan(context.Background(), &domain.SavingsPlan{
+		UserID: "u1", AccountID: "a1", ISIN: "DE0007164600", Amount: "50.00", Currency: "EUR",
+		Cadence: "MONTHLY", StartDate: "2025-01-31",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2025-03-31", plan.NextExecutionDate)
+	assert.Equal(t, "ACTIVE", plan.Status)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_CreateSavingsPlan_InstrumentNotTradable(t *testing.T) {
+	setup()
+	defer teardown()
+
+	plans := newTestSavingsPlanRepo("2025-03-10")
+
+	expectOrderPreconditions("ACTIVE", "u1", "ACTIVE", "SUSPENDED")
+	mock.ExpectRollback()
+
+	_, err := plans.CreateSavingsPlan(context.Background(), &domain.SavingsPlan{
+		UserID: "u1", AccountID: "a1", ISIN: "DE0007164600", Amount: "50.00", Currency: "EUR",
+		Cadence: "MONTHLY", StartDate: "2025-01-31",
+	})
+
+	assert.ErrorIs(t, err, domain.ErrSavingsPlanNotAccepted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_ResumeSavingsPlan(t *testing.T) {
+	setup()
+	defer teardown()
+
+	plans := newTestSavingsPlanRepo("2025-05-02")
+
+	// Paused since February, so the dates in March and April are not caught up.
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT (.+) FROM savings_plans WHERE id = \$1 FOR UPDATE`).
+		WithArgs("sp1").
+		WillReturnRows(savingsPlanRow("2025-02-28", "PAUSED"))
+	mock.ExpectQuery(`UPDATE savings_plans`).
+		WithArgs("ACTIVE", "2025-05-31", "sp1").
+		WillReturnRows(savingsPlanRow("2025-05-31", "ACTIVE"))
+	mock.ExpectExec(`INSERT INTO outbox`).
+		WithArgs("savings_plan", "sp1", "SAVINGS_PLAN_RESUMED", sqlmock.AnyArg(), 1, "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	plan, err := plans.ResumeSavingsPlan(context.Background(), "sp1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2025-05-31", plan.NextExecutionDate)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_CancelSavingsPlan_AlreadyCancelled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	plans := newTestSavingsPlanRepo("2025-05-02")
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT (.+) FROM savings_plans WHERE id = \$1 FOR UPDATE`).
+		WithArgs("sp1").
+		WillReturnRows(savingsPlanRow("2025-05-31", "CANCELLED"))
+	mock.ExpectRollback()
+
+	_, err := plans.CancelSavingsPlan(context.Background(), "sp1")
+
+	assert.ErrorIs(t, err, domain.ErrIllegalSavingsPlanTransition)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_RecordExecution(t *testing.T) {
+	setup()
+	defer teardown()
+
+	plans := newTestSavingsPlanRepo("2025-06-02")
+	scheduled, _ := time.Parse(domain.DateLayout, "2025-05-31")
+	executed, _ := time.Parse(domain.DateLayout, "2025-06-02")
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT (.+) FROM savings_plans WHERE id = \$1 FOR UPDATE`).
+		WithArgs("sp1").
+		WillReturnRows(savingsPlanRow("2025-05-31", "ACTIVE"))
+	mock.ExpectQuery(`SELECT status FROM users WHERE id = \$1 FOR SHARE`).
+		WithArgs("u1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("ACTIVE"))
+	mock.ExpectQuery(`INSERT INTO savings_plan_executions`).
+		WithArgs("sp1", "2025-05-31", "2025-06-02").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow("e1", "2025-06-02T00:00:00Z"))
+	mock.ExpectExec(`UPDATE savings_plans`).
+		WithArgs("2025-06-30", "sp1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO outbox`).
+		WithArgs("savings_plan", "sp1", "SAVINGS_PLAN_EXECUTION_DUE", sqlmock.AnyArg(), 1, "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	execution, err := plans.RecordExecution(context.Background(), "sp1", scheduled, executed)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "e1", execution.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// A second scheduler picking up the same date finds the plan moved on.
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT (.+) FROM savings_plans WHERE id = \$1 FOR UPDATE`).
+		WithArgs("sp1").
+		WillReturnRows(savingsPlanRow("2025-06-30", "ACTIVE"))
+	mock.ExpectRollback()
+
+	_, err = plans.RecordExecution(context.Background(), "sp1", scheduled, executed)
+
+	assert.ErrorIs(t, err, domain.ErrSavingsPlanNotDue)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_RecordExecution_UserHeld(t *testing.T) {
+	setup()
+	defer teardown()
+
+	plans := newTestSavingsPlanRepo("2025-06-02")
+	scheduled, _ := time.Parse(domain.DateLayout, "2025-05-31")
+	executed, _ := time.Parse(domain.DateLayout, "2025-06-02")
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT (.+) FROM savings_plans WHERE id = \$1 FOR UPDATE`).
+		WithArgs("sp1").
+		WillReturnRows(savingsPlanRow("2025-05-31", "ACTIVE"))
+	mock.ExpectQuery(`SELECT status FROM users WHERE id = \$1 FOR SHARE`).
+		WithArgs("u1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("HELD"))
+	mock.ExpectRollback()
+
+	_, err := plans.RecordExecution(context.Background(), "sp1", scheduled, executed)
+
+	assert.ErrorIs(t, err, domain.ErrSavingsPlanNotDue)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_CancelUserSavingsPlans(t *testing.T) {
+	setup()
+	defer teardown()
+
+	plans := NewSavingsPlanRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`UPDATE savings_plans`).
+		WithArgs("u1").
+		WillReturnRows(savingsPlanRow("2025-05-31", "CANCELLED"))
+	mock.ExpectExec(`INSERT INTO outbox`).
+		WithArgs("savings_plan", "sp1", "SAVINGS_PLAN_CANCELLED", sqlmock.AnyArg(), 1, "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	assert.NoError(t, plans.CancelUserSavingsPlans(context.Background(), "u1"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
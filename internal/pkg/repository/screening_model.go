@@ -0,0 +1,234 @@
+//go:generate mockery --name=ScreeningRepository --output=../../util/mocks --outpkg=mocks
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/ashwingopalsamy/upvest-api/internal/event"
+)
+
+type ScreeningRepository interface {
+	RecordHits(ctx context.Context, userID string, hits []domain.ScreeningHit) (int, error)
+	GetHits(ctx context.Context, userID string) ([]domain.ScreeningHit, error)
+	ReviewHit(ctx context.Context, hitID, decision string) (*domain.ScreeningHit, error)
+	GetUsersToScreen(ctx context.Context, afterID string, limit int) ([]domain.User, error)
+}
+
+type screeningRepo struct {
+	db *sql.DB
+}
+
+func NewScreeningRepository(db *sql.DB) ScreeningRepository {
+	return &screeningRepo{db: db}
+}
+
+// RecordHits stores the hits of a re-screen and holds the user if any of them
+// is new. It returns the number of new hits.
+func (r *screeningRepo) RecordHits(ctx context.Context, userID string, hits []domain.ScreeningHit) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var status string
+	err = tx.QueryRowContext(ctx, queryLockUserStatus, userID).Scan(&status)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, sql.ErrNoRows
+	} else if err != nil {
+		return 0, fmt.Errorf("failed to read user status: %w", err)
+	}
+
+	created, _, err := recordScreeningHits(ctx, tx, userID, status, hits)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return len(created), nil
+}
+
+// GetHits returns the screening hits of a user, the latest first.
+func (r *screeningRepo) GetHits(ctx context.Context, userID string) ([]domain.ScreeningHit, error) {
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, queryUserExists, userID).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("user not found: %w", sql.ErrNoRows)
+	}
+
+	rows, err := r.db.QueryContext(ctx, queryReadScreeningHits, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	hits := []domain.ScreeningHit{}
+	for rows.Next() {
+		hit, err := scanScreeningHit(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		hits = append(hits, *hit)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return hits, nil
+}
+
+// ReviewHit confirms or dismisses a pending hit and records a
+// SCREENING_HIT_REVIEWED event. A HELD user is released to ACTIVE, with a
+// USER_RELEASED event, once none of the user's hits is pending or confirmed.
+func (r *screeningRepo) ReviewHit(ctx context.Context, hitID, decision string) (*domain.ScreeningHit, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// The user is locked before the hit, in the order re-screens lock them.
+	var userID string
+	err = tx.QueryRowContext(ctx, queryReadScreeningHitUserID, hitID).Scan(&userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("screening hit not found: %w", err)
+	} else if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	var status string
+	if err := tx.QueryRowContext(ctx, queryLockUserStatus, userID).Scan(&status); err != nil {
+		return nil, fmt.Errorf("failed to read user status: %w", err)
+	}
+
+	hit, err := scanScreeningHit(tx.QueryRowContext(ctx, queryReviewScreeningHit, hitID, decision))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", domain.ErrScreeningHitReviewed, hitID)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to review screening hit: %w", err)
+	}
+
+	if err := insertEvent(ctx, tx, aggregateUser, userID, event.ScreeningHitReviewed{UserID: userID, ScreeningHit: hit}); err != nil {
+		return nil, err
+	}
+
+	if status == domain.UserStatusHeld {
+		var unresolved int
+		if err := tx.QueryRowContext(ctx, queryUnresolvedScreeningHits, userID).Scan(&unresolved); err != nil {
+			return nil, fmt.Errorf("failed to count screening hits: %w", err)
+		}
+		if unresolved == 0 {
+			if err := updateUserStatus(ctx, tx, userID, status, domain.UserStatusActive, func(change event.UserStatusChange) event.Payload {
+				return event.UserReleased{UserStatusChange: change}
+			}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return hit, nil
+}
+
+// GetUsersToScreen returns the names and birth dates of up to limit users that
+// are not offboarded, ordered by ID and starting after afterID.
+func (r *screeningRepo) GetUsersToScreen(ctx context.Context, afterID string, limit int) ([]domain.User, error) {
+	rows, err := r.db.QueryContext(ctx, queryReadUsersToScreen, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	var users []domain.User
+	for rows.Next() {
+		var user domain.User
+		if err := rows.Scan(&user.ID, &user.FirstName, &user.LastName, &user.BirthName, &user.BirthDate); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return users, nil
+}
+
+// recordScreeningHits stores the hits the user is not known for yet. If there
+// are any, it moves the user to HELD where the user's status allows it and
+// records a USER_SCREENING_HIT event. It returns the new hits and the user's
+// resulting status.
+func recordScreeningHits(ctx context.Context, tx *sql.Tx, userID, status string, hits []domain.ScreeningHit) ([]domain.ScreeningHit, string, error) {
+	var created []domain.ScreeningHit
+	for _, hit := range hits {
+		hit.UserID = userID
+		err := tx.QueryRowContext(ctx, queryCreateScreeningHit,
+			hit.UserID, hit.ListName, hit.EntryID, hit.EntryName, hit.Category, hit.MatchedName, hit.Score, hit.BirthDateMatched,
+		).Scan(&hit.ID, &hit.CreatedAt, &hit.Status)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		} else if err != nil {
+			return nil, "", fmt.Errorf("failed to create screening hit: %w", err)
+		}
+		created = append(created, hit)
+	}
+	if len(created) == 0 {
+		return nil, status, nil
+	}
+
+	held := status
+	if domain.ValidateUserStatusTransition(status, domain.UserStatusHeld) == nil {
+		held = domain.UserStatusHeld
+	}
+	if err := updateUserStatus(ctx, tx, userID, status, held, func(change event.UserStatusChange) event.Payload {
+		return event.UserScreeningHit{UserStatusChange: change, ScreeningHits: created}
+	}); err != nil {
+		return nil, "", err
+	}
+
+	return created, held, nil
+}
+
+// statusEvent builds the event recorded for a change of a user's status.
+type statusEvent func(change event.UserStatusChange) event.Payload
+
+// updateUserStatus sets the status of a locked user, unless it is unchanged, and
+// records the event of newEvent.
+func updateUserStatus(ctx context.Context, tx *sql.Tx, userID, current, status string, newEvent statusEvent) error {
+	if status != current {
+		if _, err := tx.ExecContext(ctx, queryUpdateUserStatus, status, userID); err != nil {
+			return fmt.Errorf("failed to update user status: %w", err)
+		}
+	}
+
+	return insertEvent(ctx, tx, aggregateUser, userID, newEvent(event.UserStatusChange{
+		UserID:         userID,
+		PreviousStatus: current,
+		Status:         status,
+	}))
+}
+
+func scanScreeningHit(row rowScanner) (*domain.ScreeningHit, error) {
+	var hit domain.ScreeningHit
+	err := row.Scan(
+		&hit.ID, &hit.CreatedAt, &hit.UserID, &hit.ListName, &hit.EntryID, &hit.EntryName, &hit.Category,
+		&hit.MatchedName, &hit.Score, &hit.BirthDateMatched, &hit.Status, &hit.ReviewedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &hit, nil
+}
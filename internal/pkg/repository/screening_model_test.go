@@ -0,0 +1,300 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+var screeningHitColumns = []string{
+	"id", "created_at", "user_id", "list_name", "entry_id", "entry_name", "category",
+	"matched_name", "score", "birth_date_matched", "status", "reviewed_at",
+}
+
+func newTestScreeningHit() domain.ScreeningHit {
+	return domain.ScreeningHit{
+		ListName:         "eu_consolidated",
+		EntryID:          "13",
+		EntryName:        "Saddam Hussein Al-Tikriti",
+		Category:         "SANCTIONS",
+		MatchedName:      "Saddam Hussein",
+		Score:            0.95,
+		BirthDateMatched: true,
+		Status:           "PENDING",
+	}
+}
+
+func expectCreateScreeningHit(id string) {
+	mock.ExpectQuery(`INSERT INTO screening_hits`).
+		WithArgs("123", "eu_consolidated", "13", "Saddam Hussein Al-Tikriti", "SANCTIONS", "Saddam Hussein", 0.95, true).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "status"}).AddRow(id, "2025-04-27T09:00:00Z", "PENDING"))
+}
+
+func Test_CreateUser_ScreeningHitHoldsUser(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO users`).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+			sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), "HELD", false).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow("123", "2025-01-01T00:00:00Z", "2025-01-01T00:00:00Z"))
+	mock.ExpectExec(`INSERT INTO outbox`).
+		WithArgs("user", "123", "USER_CREATED", sqlmock.AnyArg(), 2, "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectCreateScreeningHit("h1")
+	mock.ExpectExec(`INSERT INTO outbox`).
+		WithArgs("user", "123", "USER_SCREENING_HIT", sqlmock.AnyArg(), 2, "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	user := &domain.User{FirstName: "Saddam", LastName: "Hussein", BirthDate: "1937-04-28", Nationalities: []string{"IQ"}}
+	created, err := repo.CreateUser(context.Background(), user, []domain.ScreeningHit{newTestScreeningHit()})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "HELD", created.Status)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_UpdateUser_ScreeningHitHoldsUser(t *testing.T) {
+	setup()
+	defer teardown()
+
+	row := sqlmock.NewRows([]string{
+		"id", "created_at", "updated_at", "first_name", "last_name", "salutation", "title", "birth_date",
+		"birth_city", "birth_country", "birth_name", "nationalities", "postal_address", "address", "tax_residencies", "status",
+	}).AddRow("123", "2025-01-01T00:00:00Z", "2025-01-02T00:00:00Z", "Saddam", "Hussein", "", "", "1937-04-28",
+		"Tikrit", "IQ", "", `["IQ"]`, `null`, `{}`, `[]`, "ACTIVE")
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status, updated_at FROM users WHERE id = \$1 FOR UPDATE`).
+		WithArgs("123").
+		WillReturnRows(sqlmock.NewRows([]string{"status", "updated_at"}).AddRow("ACTIVE", "2025-01-01T00:00:00Z"))
+	mock.ExpectQuery(`UPDATE users SET last_name = \$1`).WillReturnRows(row)
+	mock.ExpectExec(`INSERT INTO outbox`).
+		WithArgs("user", "123", "USER_UPDATED", sqlmock.AnyArg(), 2, "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectCreateScreeningHit("h1")
+	mock.ExpectExec(`UPDATE users SET status = \$1`).
+		WithArgs("HELD", "123").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO outbox`).
+		WithArgs("user", "123", "USER_SCREENING_HIT", sqlmock.AnyArg(), 2, "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	user := &domain.User{ID: "123", UpdatedAt: "2025-01-01T00:00:00Z", LastName: "Hussein"}
+	updated, err := repo.UpdateUser(context.Background(), user, []string{"last_name"}, []domain.ScreeningHit{newTestScreeningHit()})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "HELD", updated.Status)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_RecordHits_KnownHitsAreSkipped(t *testing.T) {
+	setup()
+	defer teardown()
+	screening := NewScreeningRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status FROM users WHERE id = \$1 FOR UPDATE`).
+		WithArgs("123").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("HELD"))
+	mock.ExpectQuery(`INSERT INTO screening_hits`).WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "status"}))
+	mock.ExpectCommit()
+
+	created, err := screening.RecordHits(context.Background(), "123", []domain.ScreeningHit{newTestScreeningHit()})
+
+	assert.NoError(t, err)
+	assert.Zero(t, created)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_RecordHits_OffboardedUserIsNotHeld(t *testing.T) {
+	setup()
+	defer teardown()
+	screening := NewScreeningRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status FROM users WHERE id = \$1 FOR UPDATE`).
+		WithArgs("123").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("OFFBOARDING"))
+	expectCreateScreeningHit("h1")
+	mock.ExpectExec(`INSERT INTO outbox`).
+		WithArgs("user", "123", "USER_SCREENING_HIT", sqlmock.AnyArg(), 2, "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	created, err := screening.RecordHits(context.Background(), "123", []domain.ScreeningHit{newTestScreeningHit()})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, created)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_RecordHits_UserNotFound(t *testing.T) {
+	setup()
+	defer teardown()
+	screening := NewScreeningRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status FROM users`).WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+
+	_, err := screening.RecordHits(context.Background(), "123", []domain.ScreeningHit{newTestScreeningHit()})
+
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_GetHits_Success(t *testing.T) {
+	setup()
+	defer teardown()
+	screening := NewScreeningRepository(db)
+
+	mock.ExpectQuery(`SELECT EXISTS`).WithArgs("123").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery(`SELECT id, created_at, user_id, list_name`).
+		WithArgs("123").
+		WillReturnRows(sqlmock.NewRows(screeningHitColumns).AddRow(
+			"h1", "2025-04-27T09:00:00Z", "123", "eu_consolidated", "13", "Saddam Hussein Al-Tikriti", "SANCTIONS",
+			"Saddam Hussein", 0.95, true, "PENDING", ""))
+
+	hits, err := screening.GetHits(context.Background(), "123")
+
+	assert.NoError(t, err)
+	assert.Len(t, hits, 1)
+	assert.Equal(t, "h1", hits[0].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_GetHits_UserNotFound(t *testing.T) {
+	setup()
+	defer teardown()
+	screening := NewScreeningRepository(db)
+
+	mock.ExpectQuery(`SELECT EXISTS`).WithArgs("123").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+	_, err := screening.GetHits(context.Background(), "123")
+
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func Test_ReviewHit_ReleasesUser(t *testing.T) {
+	setup()
+	defer teardown()
+	screening := NewScreeningRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT user_id FROM screening_hits`).
+		WithArgs("h1").
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow("123"))
+	mock.ExpectQuery(`SELECT status FROM users WHERE id = \$1 FOR UPDATE`).
+		WithArgs("123").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("HELD"))
+	mock.ExpectQuery(`UPDATE screening_hits SET status = \$2`).
+		WithArgs("h1", "DISMISSED").
+		WillReturnRows(sqlmock.NewRows(screeningHitColumns).AddRow(
+			"h1", "2025-04-27T09:00:00Z", "123", "eu_consolidated", "13", "Saddam Hussein Al-Tikriti", "SANCTIONS",
+			"Saddam Hussein", 0.95, true, "DISMISSED", "2025-04-28T09:00:00Z"))
+	mock.ExpectExec(`INSERT INTO outbox`).
+		WithArgs("user", "123", "SCREENING_HIT_REVIEWED", sqlmock.AnyArg(), 2, "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM screening_hits`).
+		WithArgs("123").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectExec(`UPDATE users SET status = \$1`).
+		WithArgs("ACTIVE", "123").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO outbox`).
+		WithArgs("user", "123", "USER_RELEASED", sqlmock.AnyArg(), 2, "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	hit, err := screening.ReviewHit(context.Background(), "h1", "DISMISSED")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "DISMISSED", hit.Status)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_ReviewHit_ConfirmedHitKeepsUserHeld(t *testing.T) {
+	setup()
+	defer teardown()
+	screening := NewScreeningRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT user_id FROM screening_hits`).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow("123"))
+	mock.ExpectQuery(`SELECT status FROM users WHERE id = \$1 FOR UPDATE`).
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("HELD"))
+	mock.ExpectQuery(`UPDATE screening_hits SET status = \$2`).
+		WithArgs("h1", "CONFIRMED").
+		WillReturnRows(sqlmock.NewRows(screeningHitColumns).AddRow(
+			"h1", "2025-04-27T09:00:00Z", "123", "eu_consolidated", "13", "Saddam Hussein Al-Tikriti", "SANCTIONS",
+			"Saddam Hussein", 0.95, true, "CONFIRMED", "2025-04-28T09:00:00Z"))
+	mock.ExpectExec(`INSERT INTO outbox`).
+		WithArgs("user", "123", "SCREENING_HIT_REVIEWED", sqlmock.AnyArg(), 2, "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM screening_hits`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectCommit()
+
+	_, err := screening.ReviewHit(context.Background(), "h1", "CONFIRMED")
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_ReviewHit_AlreadyReviewed(t *testing.T) {
+	setup()
+	defer teardown()
+	screening := NewScreeningRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT user_id FROM screening_hits`).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow("123"))
+	mock.ExpectQuery(`SELECT status FROM users WHERE id = \$1 FOR UPDATE`).
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("ACTIVE"))
+	mock.ExpectQuery(`UPDATE screening_hits SET status = \$2`).WillReturnRows(sqlmock.NewRows(screeningHitColumns))
+	mock.ExpectRollback()
+
+	_, err := screening.ReviewHit(context.Background(), "h1", "DISMISSED")
+
+	assert.ErrorIs(t, err, domain.ErrScreeningHitReviewed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_ReviewHit_NotFound(t *testing.T) {
+	setup()
+	defer teardown()
+	screening := NewScreeningRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT user_id FROM screening_hits`).WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+
+	_, err := screening.ReviewHit(context.Background(), "h1", "DISMISSED")
+
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func Test_GetUsersToScreen(t *testing.T) {
+	setup()
+	defer teardown()
+	screening := NewScreeningRepository(db)
+
+	mock.ExpectQuery(`SELECT id, first_name, last_name`).
+		WithArgs("00000000-0000-0000-0000-000000000000", 100).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "first_name", "last_name", "birth_name", "birth_date"}).
+			AddRow("123", "Rob", "Smith", "", "1990-01-01"))
+
+	users, err := screening.GetUsersToScreen(context.Background(), "00000000-0000-0000-0000-000000000000", 100)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.User{{ID: "123", FirstName: "Rob", LastName: "Smith", BirthDate: "1990-01-01"}}, users)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -1,18 +1,573 @@
 package repository
 
-const fieldStatusActive = "ACTIVE"
+const (
+	// The events of users are typed in the event package.
+	aggregateUser = "user"
+
+	aggregateAccount = "account"
+
+	eventAccountOpened = "ACCOUNT_OPENED"
+	eventAccountClosed = "ACCOUNT_CLOSED"
+
+	aggregateOrder = "order"
+
+	eventOrderCreated   = "ORDER_CREATED"
+	eventOrderCancelled = "ORDER_CANCELLED"
+
+	aggregateJournalEntry = "journal_entry"
+
+	eventLedgerPostingCreated = "LEDGER_POSTING_CREATED"
+
+	aggregateReferenceAccount = "reference_account"
+
+	eventReferenceAccountAdded = "REFERENCE_ACCOUNT_ADDED"
+
+	aggregateWithdrawal = "withdrawal"
+
+	eventWithdrawalCreated = "WITHDRAWAL_CREATED"
+	eventWithdrawalBooked  = "WITHDRAWAL_BOOKED"
+	eventWithdrawalFailed  = "WITHDRAWAL_FAILED"
+
+	aggregateSavingsPlan = "savings_plan"
+
+	eventSavingsPlanCreated      = "SAVINGS_PLAN_CREATED"
+	eventSavingsPlanPaused       = "SAVINGS_PLAN_PAUSED"
+	eventSavingsPlanResumed      = "SAVINGS_PLAN_RESUMED"
+	eventSavingsPlanCancelled    = "SAVINGS_PLAN_CANCELLED"
+	eventSavingsPlanExecutionDue = "SAVINGS_PLAN_EXECUTION_DUE"
+
+	aggregateFeeSchedule = "fee_schedule"
+
+	eventFeeScheduleSet = "FEE_SCHEDULE_SET"
+
+	aggregateFee = "fee"
+
+	eventFeeCharged = "FEE_CHARGED"
+
+	aggregateAppropriatenessAssessment = "appropriateness_assessment"
+
+	eventAppropriatenessAssessed = "APPROPRIATENESS_ASSESSED"
+
+	aggregateBusiness = "business"
+
+	eventBusinessCreated = "BUSINESS_CREATED"
+
+	aggregateDocument = "document"
+
+	eventDocumentUploaded = "DOCUMENT_UPLOADED"
+	eventDocumentExpired  = "DOCUMENT_EXPIRED"
+)
 
 var queryCreateUsers = `INSERT INTO users (first_name, last_name, salutation, title, birth_date, birth_city, birth_country,
-                   birth_name, nationalities, postal_address, address, status)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9::JSONB, $10::JSONB, $11::JSONB, $12)
+                   birth_name, nationalities, postal_address, address, tax_residencies, status, minor)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9::JSONB, $10::JSONB, $11::JSONB, $12::JSONB, $13, $14)
 RETURNING id, created_at, updated_at;`
 
-var queryReadUsers = `SELECT id, created_at, updated_at, first_name, last_name, salutation, title, birth_date,
-		       birth_city, birth_country, birth_name, nationalities, postal_address, address, status
+// queryReadUsers is completed with the WHERE clause of the filter (possibly
+// empty), the sort column and order, and the placeholder indexes of the limit
+// and offset.
+var queryReadUsers = `SELECT id, created_at, updated_at, first_name, last_name, salutation, title,
+		       to_char(birth_date, 'YYYY-MM-DD'), birth_city, birth_country, birth_name, nationalities,
+		       postal_address, address, tax_residencies, status
 FROM users
+%s
 ORDER BY %s %s
-LIMIT $1 OFFSET $2`
+LIMIT $%d OFFSET $%d`
+
+// queryReadUsersByCursor is completed with the WHERE clause combining the
+// filter and the keyset condition, the ORDER BY clause and the placeholder
+// index of the limit.
+var queryReadUsersByCursor = `SELECT id, created_at, updated_at, first_name, last_name, salutation, title,
+		       to_char(birth_date, 'YYYY-MM-DD'), birth_city, birth_country, birth_name, nationalities,
+		       postal_address, address, tax_residencies, status
+FROM users
+%s
+ORDER BY %s
+LIMIT $%d`
+
+var queryReadUserByID = `SELECT id, created_at, updated_at, first_name, last_name, salutation, title,
+		to_char(birth_date, 'YYYY-MM-DD'), birth_city, birth_country, birth_name, nationalities,
+		postal_address, address, tax_residencies, status
+		FROM users WHERE id = $1`
 
-var queryOffboardUser = `UPDATE users 
+// queryUpdateUser is completed with the SET assignments and the placeholder index of the user ID.
+var queryUpdateUser = `UPDATE users
+		SET %s, updated_at = NOW()
+		WHERE id = $%d
+		RETURNING id, created_at, updated_at, first_name, last_name, salutation, title,
+		to_char(birth_date, 'YYYY-MM-DD'), birth_city, birth_country, birth_name, nationalities,
+		postal_address, address, tax_residencies, status`
+
+var queryLockUserStatus = `SELECT status FROM users WHERE id = $1 FOR UPDATE`
+
+var queryLockUserForUpdate = `SELECT status, updated_at FROM users WHERE id = $1 FOR UPDATE`
+
+var queryUpdateUserStatus = `UPDATE users 
 		SET status = $1, updated_at = NOW()
 		WHERE id = $2`
+
+// queryUpsertAccountGroup returns the user's open account group, creating it
+// if the user has none.
+var queryUpsertAccountGroup = `INSERT INTO account_groups (user_id)
+VALUES ($1)
+ON CONFLICT (user_id) WHERE status = 'ACTIVE' DO UPDATE SET updated_at = account_groups.updated_at
+RETURNING id`
+
+var queryCreateAccount = `INSERT INTO accounts (user_id, account_group_id, type, name, status)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, created_at, updated_at`
+
+// queryReadAccounts is completed like queryReadUsers.
+var queryReadAccounts = `SELECT id, created_at, updated_at, user_id, account_group_id, type, name, status, closed_at
+FROM accounts
+%s
+ORDER BY %s %s
+LIMIT $%d OFFSET $%d`
+
+// queryReadAccountsByCursor is completed like queryReadUsersByCursor.
+var queryReadAccountsByCursor = `SELECT id, created_at, updated_at, user_id, account_group_id, type, name, status, closed_at
+FROM accounts
+%s
+ORDER BY %s
+LIMIT $%d`
+
+var queryReadAccountByID = `SELECT id, created_at, updated_at, user_id, account_group_id, type, name, status, closed_at
+		FROM accounts WHERE id = $1`
+
+var queryLockAccountStatus = `SELECT user_id, status FROM accounts WHERE id = $1 FOR UPDATE`
+
+var queryCloseAccount = `UPDATE accounts
+		SET status = 'CLOSED', closed_at = NOW(), updated_at = NOW()
+		WHERE id = $1`
+
+var queryCloseUserAccounts = `UPDATE accounts
+		SET status = 'CLOSED', closed_at = NOW(), updated_at = NOW()
+		WHERE user_id = $1 AND status = 'ACTIVE'
+RETURNING id`
+
+var queryCloseUserAccountGroups = `UPDATE account_groups
+		SET status = 'CLOSED', updated_at = NOW()
+		WHERE user_id = $1 AND status = 'ACTIVE'`
+
+// queryUpsertInstrument inserts an instrument or updates it if any of its
+// reference data changed.
+var queryUpsertInstrument = `INSERT INTO instruments (isin, wkn, name, type, currency, trading_status)
+VALUES ($1, NULLIF($2, ''), $3, $4, $5, $6)
+ON CONFLICT (isin) DO UPDATE
+		SET wkn = EXCLUDED.wkn, name = EXCLUDED.name, type = EXCLUDED.type, currency = EXCLUDED.currency,
+		    trading_status = EXCLUDED.trading_status, updated_at = NOW()
+		WHERE (instruments.wkn, instruments.name, instruments.type, instruments.currency, instruments.trading_status)
+		      IS DISTINCT FROM (EXCLUDED.wkn, EXCLUDED.name, EXCLUDED.type, EXCLUDED.currency, EXCLUDED.trading_status)`
+
+// queryReadInstruments is completed like queryReadUsers.
+var queryReadInstruments = `SELECT isin, created_at, updated_at, wkn, name, type, currency, trading_status
+FROM instruments
+%s
+ORDER BY %s %s
+LIMIT $%d OFFSET $%d`
+
+// queryReadInstrumentsByCursor is completed like queryReadUsersByCursor.
+var queryReadInstrumentsByCursor = `SELECT isin, created_at, updated_at, wkn, name, type, currency, trading_status
+FROM instruments
+%s
+ORDER BY %s
+LIMIT $%d`
+
+var queryReadInstrumentByISIN = `SELECT isin, created_at, updated_at, wkn, name, type, currency, trading_status
+		FROM instruments WHERE isin = $1`
+
+var queryLockAccountForOrder = `SELECT user_id, status FROM accounts WHERE id = $1 FOR SHARE`
+
+var queryReadInstrumentTrading = `SELECT trading_status, currency FROM instruments WHERE isin = $1`
+
+var queryCreateOrder = `INSERT INTO orders (user_id, account_id, isin, side, type, quantity, cash_amount, limit_price,
+                    currency, status)
+VALUES ($1, $2, $3, $4, $5, NULLIF($6, '')::NUMERIC, NULLIF($7, '')::NUMERIC, NULLIF($8, '')::NUMERIC, $9, $10)
+RETURNING id, created_at, updated_at, filled_quantity`
+
+// queryReadOrders is completed like queryReadUsers.
+var queryReadOrders = `SELECT id, created_at, updated_at, user_id, account_id, isin, side, type, quantity, cash_amount,
+		       limit_price, currency, filled_quantity, status
+FROM orders
+%s
+ORDER BY %s %s
+LIMIT $%d OFFSET $%d`
+
+// queryReadOrdersByCursor is completed like queryReadUsersByCursor.
+var queryReadOrdersByCursor = `SELECT id, created_at, updated_at, user_id, account_id, isin, side, type, quantity, cash_amount,
+		       limit_price, currency, filled_quantity, status
+FROM orders
+%s
+ORDER BY %s
+LIMIT $%d`
+
+var queryReadOrderByID = `SELECT id, created_at, updated_at, user_id, account_id, isin, side, type, quantity, cash_amount,
+		limit_price, currency, filled_quantity, status
+		FROM orders WHERE id = $1`
+
+var queryLockOrderStatus = `SELECT status FROM orders WHERE id = $1 FOR UPDATE`
+
+var queryUpdateOrderStatus = `UPDATE orders
+		SET status = $1, updated_at = NOW()
+		WHERE id = $2
+RETURNING id, created_at, updated_at, user_id, account_id, isin, side, type, quantity, cash_amount,
+		limit_price, currency, filled_quantity, status`
+
+// queryCancelUserOrders cancels the orders of a user that are still open.
+var queryCancelUserOrders = `UPDATE orders
+		SET status = 'CANCELLED', updated_at = NOW()
+		WHERE user_id = $1 AND status IN ('NEW', 'PROCESSING', 'PARTIALLY_FILLED')
+RETURNING id, created_at, updated_at, user_id, account_id, isin, side, type, quantity, cash_amount,
+		limit_price, currency, filled_quantity, status`
+
+// queryLockUserForPosting keeps a user from being offboarded while cash is
+// booked to the user's ledger accounts.
+var queryLockUserForPosting = `SELECT status FROM users WHERE id = $1 FOR SHARE`
+
+var queryCreateJournalEntry = `INSERT INTO journal_entries (description, reference)
+VALUES ($1, NULLIF($2, ''))
+RETURNING id, created_at`
+
+var queryCreatePosting = `INSERT INTO ledger_postings (journal_entry_id, ledger_account, user_id, currency, amount)
+VALUES ($1, $2, NULLIF($3, '')::UUID, $4, $5)
+RETURNING id, created_at`
+
+var queryUserExists = `SELECT EXISTS (SELECT 1 FROM users WHERE id = $1)`
+
+var queryReadUserBalances = `SELECT currency,
+		       COALESCE(SUM(amount) FILTER (WHERE ledger_account = 'USER_AVAILABLE'), 0),
+		       COALESCE(SUM(amount) FILTER (WHERE ledger_account = 'USER_RESERVED'), 0)
+FROM ledger_postings
+WHERE user_id = $1
+GROUP BY currency
+ORDER BY currency`
+
+// queryReadNonZeroBalance returns the currency of any ledger account of a user
+// that does not balance to zero.
+var queryReadNonZeroBalance = `SELECT currency FROM ledger_postings
+		WHERE user_id = $1
+		GROUP BY ledger_account, currency
+		HAVING SUM(amount) <> 0
+		LIMIT 1`
+
+var queryLockUserName = `SELECT status, first_name, last_name FROM users WHERE id = $1 FOR SHARE`
+
+// queryUpsertReferenceAccount adds a reference account, or updates BIC and
+// holder when the user registers the same IBAN again.
+var queryUpsertReferenceAccount = `INSERT INTO reference_accounts (user_id, iban, bic, account_holder)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (user_id, iban) DO UPDATE
+		SET bic = EXCLUDED.bic, account_holder = EXCLUDED.account_holder, updated_at = NOW()
+RETURNING id, created_at, updated_at`
+
+var queryReadReferenceAccounts = `SELECT id, created_at, updated_at, user_id, iban, bic, account_holder
+		FROM reference_accounts WHERE user_id = $1 ORDER BY created_at, id`
+
+var queryReadReferenceAccountOwner = `SELECT user_id FROM reference_accounts WHERE id = $1`
+
+var queryReadAvailableCash = `SELECT COALESCE(SUM(amount), 0) FROM ledger_postings
+		WHERE user_id = $1 AND currency = $2 AND ledger_account = 'USER_AVAILABLE'`
+
+var queryReadLedgerBalance = `SELECT COALESCE(SUM(amount), 0) FROM ledger_postings
+		WHERE user_id = $1 AND ledger_account = $2 AND currency = $3`
+
+var queryCreateWithdrawal = `INSERT INTO withdrawals (user_id, reference_account_id, amount, currency, status)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, created_at, updated_at`
+
+var queryReadWithdrawalByID = `SELECT id, created_at, updated_at, user_id, reference_account_id, amount, currency,
+		status, failure_reason
+		FROM withdrawals WHERE id = $1`
+
+var queryLockWithdrawalStatus = `SELECT status FROM withdrawals WHERE id = $1 FOR UPDATE`
+
+var queryUpdateWithdrawalStatus = `UPDATE withdrawals
+		SET status = $1, failure_reason = NULLIF($2, ''), updated_at = NOW()
+		WHERE id = $3
+RETURNING id, created_at, updated_at, user_id, reference_account_id, amount, currency, status, failure_reason`
+
+// queryReadPendingWithdrawals lists pending withdrawals that were not exported
+// yet with the account they are paid out to, oldest first.
+var queryReadPendingWithdrawals = `SELECT w.id, w.created_at, w.updated_at, w.user_id, w.reference_account_id, w.amount,
+		       w.currency, w.status, w.failure_reason,
+		       r.id, r.created_at, r.updated_at, r.user_id, r.iban, r.bic, r.account_holder
+FROM withdrawals w
+JOIN reference_accounts r ON r.id = w.reference_account_id
+WHERE w.status = 'PENDING' AND w.export_id IS NULL
+ORDER BY w.created_at, w.id`
+
+// queryCreateWithdrawalExport returns no row if the message ID was used before.
+var queryCreateWithdrawalExport = `INSERT INTO withdrawal_exports (message_id, file)
+VALUES ($1, $2)
+ON CONFLICT (message_id) DO NOTHING
+RETURNING id, created_at`
+
+var queryMarkWithdrawalExported = `UPDATE withdrawals
+		SET export_id = $1, updated_at = NOW()
+		WHERE id = $2 AND status = 'PENDING' AND export_id IS NULL`
+
+var queryReadWithdrawalExport = `SELECT id, created_at, message_id, file FROM withdrawal_exports WHERE id = $1`
+
+var queryReadExportedWithdrawalIDs = `SELECT id FROM withdrawals WHERE export_id = $1 ORDER BY created_at, id`
+
+var queryCreateSavingsPlan = `INSERT INTO savings_plans (user_id, account_id, isin, amount, currency, cadence, start_date,
+                           next_execution_date, status)
+VALUES ($1, $2, $3, $4::NUMERIC, $5, $6, $7::DATE, $8::DATE, $9)
+RETURNING id, created_at, updated_at`
+
+// savingsPlanColumns selects a savings plan in the column order of
+// scanSavingsPlan, with dates formatted as YYYY-MM-DD.
+const savingsPlanColumns = `id, created_at, updated_at, user_id, account_id, isin, amount, currency, cadence,
+		to_char(start_date, 'YYYY-MM-DD'), to_char(next_execution_date, 'YYYY-MM-DD'), status`
+
+var queryReadSavingsPlans = `SELECT ` + savingsPlanColumns + `
+		FROM savings_plans WHERE user_id = $1 ORDER BY created_at, id`
+
+var queryReadSavingsPlanByID = `SELECT ` + savingsPlanColumns + `
+		FROM savings_plans WHERE id = $1`
+
+var queryLockSavingsPlan = `SELECT ` + savingsPlanColumns + `
+		FROM savings_plans WHERE id = $1 FOR UPDATE`
+
+var queryUpdateSavingsPlanStatus = `UPDATE savings_plans
+		SET status = $1, next_execution_date = $2::DATE, updated_at = NOW()
+		WHERE id = $3
+RETURNING ` + savingsPlanColumns
+
+// queryCancelUserSavingsPlans cancels the plans of a user that are not
+// cancelled yet.
+var queryCancelUserSavingsPlans = `UPDATE savings_plans
+		SET status = 'CANCELLED', updated_at = NOW()
+		WHERE user_id = $1 AND status IN ('ACTIVE', 'PAUSED')
+RETURNING ` + savingsPlanColumns
+
+// queryReadDueSavingsPlans lists the active plans of active users scheduled on
+// or before a date, oldest schedule first.
+var queryReadDueSavingsPlans = `SELECT ` + savingsPlanColumns + `
+		FROM savings_plans
+		WHERE status = 'ACTIVE' AND next_execution_date <= $1::DATE
+		  AND user_id IN (SELECT id FROM users WHERE status = 'ACTIVE')
+		ORDER BY next_execution_date, id`
+
+var queryCreateSavingsPlanExecution = `INSERT INTO savings_plan_executions (savings_plan_id, scheduled_date, execution_date)
+VALUES ($1, $2::DATE, $3::DATE)
+RETURNING id, created_at`
+
+var queryAdvanceSavingsPlan = `UPDATE savings_plans
+		SET next_execution_date = $1::DATE, updated_at = NOW()
+		WHERE id = $2`
+
+var queryReadSavingsPlanExecutions = `SELECT id, created_at, savings_plan_id, to_char(scheduled_date, 'YYYY-MM-DD'),
+		to_char(execution_date, 'YYYY-MM-DD')
+		FROM savings_plan_executions WHERE savings_plan_id = $1 ORDER BY scheduled_date DESC`
+
+// queryUpsertFeeSchedule sets the schedule of a fee for a segment, replacing
+// the previous one.
+var queryUpsertFeeSchedule = `INSERT INTO fee_schedules (segment, fee_type, method, currency, flat_amount, rate,
+                           min_amount, max_amount)
+VALUES ($1, $2, $3, $4, $5, NULLIF($6, '')::NUMERIC, $7, $8)
+ON CONFLICT (segment, fee_type, currency) DO UPDATE
+		SET method = EXCLUDED.method, flat_amount = EXCLUDED.flat_amount, rate = EXCLUDED.rate,
+		    min_amount = EXCLUDED.min_amount, max_amount = EXCLUDED.max_amount, updated_at = NOW()
+RETURNING id, created_at, updated_at`
+
+const feeScheduleColumns = `id, created_at, updated_at, segment, fee_type, method, currency, flat_amount, rate,
+		min_amount, max_amount`
+
+var queryReadFeeSchedules = `SELECT ` + feeScheduleColumns + `
+		FROM fee_schedules ORDER BY segment, fee_type, currency`
+
+// queryReadUserFeeSchedule picks the schedule of the user's segment, falling
+// back to the DEFAULT segment.
+var queryReadUserFeeSchedule = `SELECT ` + feeScheduleColumns + `
+		FROM fee_schedules
+		WHERE fee_type = $2 AND currency = $3
+		  AND segment IN (COALESCE((SELECT segment FROM user_fee_segments WHERE user_id = $1), 'DEFAULT'), 'DEFAULT')
+		ORDER BY segment = 'DEFAULT'
+		LIMIT 1`
+
+var queryUpsertUserFeeSegment = `INSERT INTO user_fee_segments (user_id, segment)
+VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE
+		SET segment = EXCLUDED.segment, updated_at = NOW()`
+
+// queryCreateFee records a fee unless one was charged for the same reference
+// already, in which case no row is returned.
+var queryCreateFee = `INSERT INTO fees (user_id, fee_schedule_id, fee_type, reference, basis_amount, amount, currency,
+                  journal_entry_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, '')::UUID)
+ON CONFLICT (user_id, fee_type, reference) DO NOTHING
+RETURNING id, created_at`
+
+var queryReadUserFees = `SELECT id, created_at, user_id, fee_schedule_id, fee_type, reference, basis_amount, amount,
+		currency, journal_entry_id
+		FROM fees WHERE user_id = $1 ORDER BY created_at DESC, id`
+
+var queryCreateAppropriatenessAssessment = `INSERT INTO appropriateness_assessments (user_id, questionnaire_version, answers,
+                   scores, allowed_categories)
+VALUES ($1, $2, $3::JSONB, $4::JSONB, $5::JSONB)
+RETURNING id, created_at`
+
+var queryReadAppropriatenessAssessments = `SELECT id, created_at, user_id, questionnaire_version, answers, scores,
+		       allowed_categories
+		FROM appropriateness_assessments WHERE user_id = $1 ORDER BY created_at DESC, id DESC`
+
+// queryCreateBusiness returns no row if a business with the LEI exists.
+var queryCreateBusiness = `INSERT INTO businesses (name, registration_number, legal_form, registered_address, lei)
+VALUES ($1, $2, $3, $4::JSONB, $5)
+ON CONFLICT (lei) DO NOTHING
+RETURNING id, created_at, updated_at`
+
+var queryCreateBusinessPerson = `INSERT INTO business_persons (business_id, user_id, role, ownership_percentage)
+VALUES ($1, $2, $3, NULLIF($4, '')::NUMERIC)`
+
+var queryReadBusinessByID = `SELECT id, created_at, updated_at, name, registration_number, legal_form, registered_address, lei
+		FROM businesses WHERE id = $1`
+
+var queryReadBusinessPersons = `SELECT user_id, role, COALESCE(ownership_percentage::TEXT, '')
+		FROM business_persons WHERE business_id = $1 ORDER BY role, user_id`
+
+// queryMinorWithoutGuardian reports whether a user is a minor who has no
+// guardian to act for them.
+var queryMinorWithoutGuardian = `SELECT minor AND NOT EXISTS (
+		       SELECT 1 FROM user_guardians WHERE user_guardians.user_id = users.id AND ended_at IS NULL)
+		FROM users WHERE id = $1`
+
+var queryLockMinor = `SELECT status, to_char(birth_date, 'YYYY-MM-DD') FROM users WHERE id = $1 FOR UPDATE`
+
+var queryLockGuardian = `SELECT status, to_char(birth_date, 'YYYY-MM-DD') FROM users WHERE id = $1 FOR SHARE`
+
+var queryReadActiveGuardianIDs = `SELECT guardian_id FROM user_guardians WHERE user_id = $1 AND ended_at IS NULL`
+
+var queryCreateGuardianship = `INSERT INTO user_guardians (user_id, guardian_id)
+VALUES ($1, $2)
+RETURNING created_at`
+
+var queryReadGuardianships = `SELECT user_id, guardian_id, created_at, ended_at
+		FROM user_guardians WHERE user_id = $1 ORDER BY created_at, guardian_id`
+
+// queryLockComingOfAge selects the minors born on or before the cutoff, skipping
+// those locked by a concurrent run.
+var queryLockComingOfAge = `SELECT id, to_char(birth_date, 'YYYY-MM-DD') FROM users
+		WHERE minor AND birth_date <= $1
+		ORDER BY birth_date, id
+		FOR UPDATE SKIP LOCKED`
+
+var queryEndMinority = `UPDATE users SET minor = FALSE, updated_at = NOW() WHERE id = $1`
+
+var queryEndGuardianships = `UPDATE user_guardians SET ended_at = NOW()
+		WHERE user_id = $1 AND ended_at IS NULL
+		RETURNING guardian_id`
+
+var queryCreateDocument = `INSERT INTO documents (user_id, type, issuing_country, expiry_date, file_name, content_type,
+                       size, sha256, storage_key)
+VALUES ($1, $2, $3, NULLIF($4, '')::DATE, $5, $6, $7, $8, $9)
+RETURNING id, created_at, status`
+
+var queryReadDocuments = `SELECT id, created_at, user_id, type, issuing_country, COALESCE(to_char(expiry_date, 'YYYY-MM-DD'), ''),
+		       file_name, content_type, size, sha256, status, storage_key
+		FROM documents WHERE user_id = $1 ORDER BY created_at DESC, id`
+
+var queryReadDocument = `SELECT id, created_at, user_id, type, issuing_country, COALESCE(to_char(expiry_date, 'YYYY-MM-DD'), ''),
+		       file_name, content_type, size, sha256, status, storage_key
+		FROM documents WHERE user_id = $1 AND id = $2`
+
+// queryExpireDocuments marks the valid documents whose expiry date lies before
+// the given day as expired.
+var queryExpireDocuments = `UPDATE documents SET status = 'EXPIRED'
+		WHERE status = 'VALID' AND expiry_date < $1
+		RETURNING id, user_id, type, to_char(expiry_date, 'YYYY-MM-DD')`
+
+// queryCreateScreeningHit returns no row for a hit the user is known for.
+var queryCreateScreeningHit = `INSERT INTO screening_hits (user_id, list_name, entry_id, entry_name, category, matched_name,
+                            score, birth_date_matched)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT (user_id, list_name, entry_id) DO NOTHING
+RETURNING id, created_at, status`
+
+var queryReadScreeningHits = `SELECT id, created_at, user_id, list_name, entry_id, entry_name, category, matched_name,
+		       score, birth_date_matched, status, COALESCE(reviewed_at::TEXT, '')
+		FROM screening_hits WHERE user_id = $1 ORDER BY created_at DESC, id`
+
+var queryReadScreeningHitUserID = `SELECT user_id FROM screening_hits WHERE id = $1`
+
+var queryReviewScreeningHit = `UPDATE screening_hits SET status = $2, reviewed_at = NOW()
+		WHERE id = $1 AND status = 'PENDING'
+		RETURNING id, created_at, user_id, list_name, entry_id, entry_name, category, matched_name,
+		          score, birth_date_matched, status, COALESCE(reviewed_at::TEXT, '')`
+
+// queryUnresolvedScreeningHits counts the hits that keep a user held.
+var queryUnresolvedScreeningHits = `SELECT COUNT(*) FROM screening_hits
+		WHERE user_id = $1 AND status IN ('PENDING', 'CONFIRMED')`
+
+var queryReadUsersToScreen = `SELECT id, first_name, last_name, COALESCE(birth_name, ''), to_char(birth_date, 'YYYY-MM-DD')
+		FROM users WHERE status <> 'OFFBOARDED' AND id > $1::UUID
+		ORDER BY id LIMIT $2`
+
+var queryInsertOutbox = `INSERT INTO outbox (aggregate_type, aggregate_id, event_type, payload, schema_version, correlation_id)
+VALUES ($1, $2, $3, $4::JSONB, $5, NULLIF($6, ''))`
+
+// queryClaimOutbox leases the oldest pending messages. A message is skipped while
+// an older message for the same aggregate is still unsent, which keeps events of
+// one aggregate in commit order even across retries.
+var queryClaimOutbox = `UPDATE outbox
+		SET attempts = attempts + 1, next_attempt_at = NOW() + $2 * INTERVAL '1 millisecond'
+		WHERE id IN (
+			SELECT o.id FROM outbox o
+			WHERE o.sent_at IS NULL AND o.next_attempt_at <= NOW()
+			  AND NOT EXISTS (
+				SELECT 1 FROM outbox p
+				WHERE p.aggregate_id = o.aggregate_id AND p.sent_at IS NULL AND p.seq < o.seq)
+			ORDER BY o.seq
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED)
+RETURNING id, seq, aggregate_id, event_type, schema_version, created_at, COALESCE(correlation_id, ''), payload, attempts`
+
+var queryMarkOutboxSent = `UPDATE outbox
+		SET sent_at = NOW(), last_error = NULL
+		WHERE id = $1`
+
+var queryMarkOutboxFailed = `UPDATE outbox
+		SET last_error = $2, next_attempt_at = NOW() + $3 * INTERVAL '1 millisecond'
+		WHERE id = $1`
+
+var queryIsMessageProcessed = `SELECT EXISTS (SELECT 1 FROM processed_messages
+		WHERE consumer_group = $1 AND topic = $2 AND partition = $3 AND "offset" = $4)`
+
+var queryMarkMessageProcessed = `INSERT INTO processed_messages (consumer_group, topic, partition, "offset")
+VALUES ($1, $2, $3, $4)
+ON CONFLICT DO NOTHING`
+
+// queryReserveIdempotencyKey inserts a new key leased for $3 milliseconds. An
+// existing key is taken over only once it has expired, or when the same request
+// was abandoned mid-flight and its lease ran out.
+var queryReserveIdempotencyKey = `INSERT INTO idempotency_keys (key, fingerprint, locked_until)
+VALUES ($1, $2, NOW() + $3 * INTERVAL '1 millisecond')
+ON CONFLICT (key) DO UPDATE
+		SET fingerprint = EXCLUDED.fingerprint, created_at = NOW(), locked_until = EXCLUDED.locked_until,
+		    completed_at = NULL, status_code = NULL, response_headers = NULL, response_body = NULL
+		WHERE (idempotency_keys.created_at < NOW() - INTERVAL '24 hours'
+		       AND (idempotency_keys.completed_at IS NOT NULL OR idempotency_keys.locked_until < NOW()))
+		   OR (idempotency_keys.completed_at IS NULL
+		       AND idempotency_keys.fingerprint = EXCLUDED.fingerprint
+		       AND idempotency_keys.locked_until < NOW())
+RETURNING key`
+
+var queryRenewIdempotencyKey = `UPDATE idempotency_keys
+		SET locked_until = NOW() + $2 * INTERVAL '1 millisecond'
+		WHERE key = $1 AND completed_at IS NULL`
+
+// queryDeleteExpiredIdempotencyKeys deletes the keys reserved more than 24
+// hours before $1 that no request holds anymore.
+var queryDeleteExpiredIdempotencyKeys = `DELETE FROM idempotency_keys
+		WHERE created_at < $1::TIMESTAMP - INTERVAL '24 hours'
+		  AND (completed_at IS NOT NULL OR locked_until < NOW())`
+
+var queryReadIdempotencyKey = `SELECT fingerprint, status_code, response_headers, response_body, completed_at IS NOT NULL
+		FROM idempotency_keys WHERE key = $1`
+
+var queryCompleteIdempotencyKey = `UPDATE idempotency_keys
+		SET status_code = $2, response_headers = $3::JSONB, response_body = $4, completed_at = NOW()
+		WHERE key = $1`
+
+var queryReleaseIdempotencyKey = `DELETE FROM idempotency_keys WHERE key = $1 AND completed_at IS NULL`
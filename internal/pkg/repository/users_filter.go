@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// UserFilter narrows a user list. Zero-valued fields do not filter.
+type UserFilter struct {
+	Statuses      []string   `json:"status,omitempty"`
+	BirthCountry  string     `json:"birth_country,omitempty"`
+	Nationality   string     `json:"nationality,omitempty"`
+	CreatedAfter  *time.Time `json:"created_from,omitempty"`
+	CreatedBefore *time.Time `json:"created_to,omitempty"`
+	Name          string     `json:"name,omitempty"`
+}
+
+// IsEmpty reports whether the filter matches every user.
+func (f UserFilter) IsEmpty() bool {
+	return len(f.Statuses) == 0 && f.BirthCountry == "" && f.Nationality == "" &&
+		f.CreatedAfter == nil && f.CreatedBefore == nil && f.Name == ""
+}
+
+// conditions returns the SQL conditions of the filter and their arguments, with
+// placeholders numbered from argPos.
+func (f UserFilter) conditions(argPos int) ([]string, []interface{}) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+	placeholder := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", argPos+len(args)-1)
+	}
+
+	if len(f.Statuses) > 0 {
+		placeholders := make([]string, 0, len(f.Statuses))
+		for _, status := range f.Statuses {
+			placeholders = append(placeholders, placeholder(status))
+		}
+		conditions = append(conditions, "status IN ("+strings.Join(placeholders, ", ")+")")
+	}
+	if f.BirthCountry != "" {
+		conditions = append(conditions, "birth_country = "+placeholder(f.BirthCountry))
+	}
+	if f.Nationality != "" {
+		nationality, _ := json.Marshal([]string{f.Nationality})
+		conditions = append(conditions, "nationalities @> "+placeholder(string(nationality))+"::JSONB")
+	}
+	if f.CreatedAfter != nil {
+		conditions = append(conditions, "created_at >= "+placeholder(sqlTimestamp(*f.CreatedAfter))+"::TIMESTAMP")
+	}
+	if f.CreatedBefore != nil {
+		conditions = append(conditions, "created_at < "+placeholder(sqlTimestamp(*f.CreatedBefore))+"::TIMESTAMP")
+	}
+	if f.Name != "" {
+		conditions = append(conditions, "(first_name || ' ' || last_name) ILIKE "+placeholder("%"+escapeLike(f.Name)+"%"))
+	}
+
+	return conditions, args
+}
+
+// whereClause joins conditions into a WHERE clause, or returns an empty string.
+func whereClause(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return "WHERE " + strings.Join(conditions, " AND ")
+}
+
+// sqlTimestamp formats t for comparison with a TIMESTAMP column holding UTC times.
+func sqlTimestamp(t time.Time) string {
+	return t.UTC().Format("2006-01-02 15:04:05.999999")
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// escapeLike makes user input match literally inside a LIKE pattern.
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
@@ -0,0 +1,313 @@
+//go:generate mockery --name=WithdrawalRepository --output=../../util/mocks --outpkg=mocks
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+)
+
+type WithdrawalRepository interface {
+	CreateWithdrawal(ctx context.Context, withdrawal *domain.Withdrawal) (*domain.Withdrawal, error)
+	GetWithdrawalByID(ctx context.Context, withdrawalID string) (*domain.Withdrawal, error)
+	BookWithdrawal(ctx context.Context, withdrawalID string) (*domain.Withdrawal, error)
+	FailWithdrawal(ctx context.Context, withdrawalID, reason string) (*domain.Withdrawal, error)
+	GetPendingPaymentInstructions(ctx context.Context) ([]domain.PaymentInstruction, error)
+	CreateWithdrawalExport(ctx context.Context, export *domain.WithdrawalExport) (*domain.WithdrawalExport, error)
+	GetWithdrawalExport(ctx context.Context, exportID string) (*domain.WithdrawalExport, error)
+}
+
+type withdrawalRepo struct {
+	db *sql.DB
+}
+
+func NewWithdrawalRepository(db *sql.DB) WithdrawalRepository {
+	return &withdrawalRepo{db: db}
+}
+
+// CreateWithdrawal requests a payout of an ACTIVE user's available cash to one
+// of the user's reference accounts and reserves the amount until the
+// withdrawal is booked or fails.
+func (r *withdrawalRepo) CreateWithdrawal(ctx context.Context, withdrawal *domain.Withdrawal) (*domain.Withdrawal, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// The exclusive lock serialises withdrawals of the same user, so that
+	// available cash is not paid out twice.
+	var userStatus string
+	err = tx.QueryRowContext(ctx, queryLockUserStatus, withdrawal.UserID).Scan(&userStatus)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, sql.ErrNoRows
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read user status: %w", err)
+	}
+	if userStatus != domain.UserStatusActive {
+		return nil, fmt.Errorf("%w: user %s is %s", domain.ErrUserNotActive, withdrawal.UserID, userStatus)
+	}
+
+	var ownerID string
+	err = tx.QueryRowContext(ctx, queryReadReferenceAccountOwner, withdrawal.ReferenceAccountID).Scan(&ownerID)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && ownerID != withdrawal.UserID) {
+		return nil, fmt.Errorf("%w: reference account %s does not belong to user %s",
+			domain.ErrWithdrawalNotAccepted, withdrawal.ReferenceAccountID, withdrawal.UserID)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read reference account: %w", err)
+	}
+
+	var available int64
+	err = tx.QueryRowContext(ctx, queryReadAvailableCash, withdrawal.UserID, withdrawal.Currency).Scan(&available)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read available cash: %w", err)
+	}
+	if available < withdrawal.Amount {
+		return nil, fmt.Errorf("%w: %d %s available", domain.ErrInsufficientFunds, available, withdrawal.Currency)
+	}
+
+	err = tx.QueryRowContext(ctx, queryCreateWithdrawal,
+		withdrawal.UserID, withdrawal.ReferenceAccountID, withdrawal.Amount, withdrawal.Currency, domain.WithdrawalStatusPending,
+	).Scan(&withdrawal.ID, &withdrawal.CreatedAt, &withdrawal.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create withdrawal: %w", err)
+	}
+	withdrawal.Status = domain.WithdrawalStatusPending
+
+	if err := insertJournalEntry(ctx, tx, withdrawalEntry(withdrawal, "Withdrawal reserved",
+		domain.LedgerAccountUserAvailable, domain.LedgerAccountUserReserved)); err != nil {
+		return nil, err
+	}
+
+	if err := insertOutboxEvent(ctx, tx, aggregateWithdrawal, withdrawal.ID, eventWithdrawalCreated, map[string]interface{}{
+		"action":     eventWithdrawalCreated,
+		"withdrawal": withdrawal,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return withdrawal, nil
+}
+
+func (r *withdrawalRepo) GetWithdrawalByID(ctx context.Context, withdrawalID string) (*domain.Withdrawal, error) {
+	withdrawal, err := scanWithdrawal(r.db.QueryRowContext(ctx, queryReadWithdrawalByID, withdrawalID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("withdrawal not found: %w", err)
+	} else if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	return withdrawal, nil
+}
+
+// BookWithdrawal records that the bank executed a pending withdrawal; the
+// reserved cash leaves the platform.
+func (r *withdrawalRepo) BookWithdrawal(ctx context.Context, withdrawalID string) (*domain.Withdrawal, error) {
+	return r.completeWithdrawal(ctx, withdrawalID, domain.WithdrawalStatusBooked, "", eventWithdrawalBooked)
+}
+
+// FailWithdrawal records that a pending withdrawal could not be executed; the
+// reserved cash becomes available again.
+func (r *withdrawalRepo) FailWithdrawal(ctx context.Context, withdrawalID, reason string) (*domain.Withdrawal, error) {
+	return r.completeWithdrawal(ctx, withdrawalID, domain.WithdrawalStatusFailed, reason, eventWithdrawalFailed)
+}
+
+// completeWithdrawal moves a pending withdrawal to its final status, releases
+// its reservation and records eventType in the same transaction.
+func (r *withdrawalRepo) completeWithdrawal(ctx context.Context, withdrawalID, status, reason, eventType string) (*domain.Withdrawal, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var current string
+	err = tx.QueryRowContext(ctx, queryLockWithdrawalStatus, withdrawalID).Scan(&current)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, sql.ErrNoRows
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read withdrawal status: %w", err)
+	}
+
+	if err := domain.ValidateWithdrawalStatusTransition(current, status); err != nil {
+		return nil, err
+	}
+
+	withdrawal, err := scanWithdrawal(tx.QueryRowContext(ctx, queryUpdateWithdrawalStatus, status, reason, withdrawalID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to update withdrawal: %w", err)
+	}
+
+	entry := withdrawalEntry(withdrawal, "Withdrawal booked", domain.LedgerAccountUserReserved, domain.LedgerAccountClearing)
+	if status == domain.WithdrawalStatusFailed {
+		entry = withdrawalEntry(withdrawal, "Withdrawal failed", domain.LedgerAccountUserReserved, domain.LedgerAccountUserAvailable)
+	}
+	if err := insertJournalEntry(ctx, tx, entry); err != nil {
+		return nil, err
+	}
+
+	if err := insertOutboxEvent(ctx, tx, aggregateWithdrawal, withdrawal.ID, eventType, map[string]interface{}{
+		"action":     eventType,
+		"withdrawal": withdrawal,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return withdrawal, nil
+}
+
+// GetPendingPaymentInstructions returns the pending withdrawals that were not
+// exported yet with their reference accounts, oldest first.
+func (r *withdrawalRepo) GetPendingPaymentInstructions(ctx context.Context) ([]domain.PaymentInstruction, error) {
+	rows, err := r.db.QueryContext(ctx, queryReadPendingWithdrawals)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	var instructions []domain.PaymentInstruction
+	for rows.Next() {
+		var (
+			instruction   domain.PaymentInstruction
+			w             = &instruction.Withdrawal
+			c             = &instruction.Creditor
+			failureReason sql.NullString
+		)
+		if err := rows.Scan(
+			&w.ID, &w.CreatedAt, &w.UpdatedAt, &w.UserID, &w.ReferenceAccountID, &w.Amount, &w.Currency,
+			&w.Status, &failureReason,
+			&c.ID, &c.CreatedAt, &c.UpdatedAt, &c.UserID, &c.IBAN, &c.BIC, &c.AccountHolder,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		instructions = append(instructions, instruction)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return instructions, nil
+}
+
+// CreateWithdrawalExport stores the file of an export and marks its withdrawals
+// as exported. It returns ErrWithdrawalExportConflict, and stores nothing, if
+// any of them is no longer pending or was exported in the meantime, or if the
+// message ID was used before.
+func (r *withdrawalRepo) CreateWithdrawalExport(ctx context.Context, export *domain.WithdrawalExport) (*domain.WithdrawalExport, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, queryCreateWithdrawalExport, export.MessageID, export.File).
+		Scan(&export.ID, &export.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: message ID %s was used before", domain.ErrWithdrawalExportConflict, export.MessageID)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to create withdrawal export: %w", err)
+	}
+
+	for _, withdrawalID := range export.WithdrawalIDs {
+		result, err := tx.ExecContext(ctx, queryMarkWithdrawalExported, export.ID, withdrawalID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to mark withdrawal exported: %w", err)
+		}
+		if n, err := result.RowsAffected(); err != nil {
+			return nil, fmt.Errorf("failed to mark withdrawal exported: %w", err)
+		} else if n == 0 {
+			return nil, fmt.Errorf("%w: withdrawal %s is no longer pending export", domain.ErrWithdrawalExportConflict, withdrawalID)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return export, nil
+}
+
+// GetWithdrawalExport returns a stored export with its file and the
+// withdrawals it paid out.
+func (r *withdrawalRepo) GetWithdrawalExport(ctx context.Context, exportID string) (*domain.WithdrawalExport, error) {
+	var export domain.WithdrawalExport
+	err := r.db.QueryRowContext(ctx, queryReadWithdrawalExport, exportID).
+		Scan(&export.ID, &export.CreatedAt, &export.MessageID, &export.File)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("withdrawal export not found: %w", err)
+	} else if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, queryReadExportedWithdrawalIDs, exportID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var withdrawalID string
+		if err := rows.Scan(&withdrawalID); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		export.WithdrawalIDs = append(export.WithdrawalIDs, withdrawalID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+
+	return &export, nil
+}
+
+// withdrawalEntry moves the amount of a withdrawal from one ledger account to
+// another. Only USER_* accounts carry the user's ID.
+func withdrawalEntry(withdrawal *domain.Withdrawal, description, from, to string) *domain.JournalEntry {
+	posting := func(account string, amount int64) domain.Posting {
+		p := domain.Posting{LedgerAccount: account, Currency: withdrawal.Currency, Amount: amount}
+		if domain.IsUserLedgerAccount(account) {
+			p.UserID = withdrawal.UserID
+		}
+		return p
+	}
+
+	return &domain.JournalEntry{
+		Description: description,
+		Reference:   withdrawal.ID,
+		Postings: []domain.Posting{
+			posting(from, -withdrawal.Amount),
+			posting(to, withdrawal.Amount),
+		},
+	}
+}
+
+// scanWithdrawal reads a withdrawal row selected in the column order of
+// queryReadWithdrawalByID.
+func scanWithdrawal(row rowScanner) (*domain.Withdrawal, error) {
+	var (
+		withdrawal    domain.Withdrawal
+		failureReason sql.NullString
+	)
+
+	if err := row.Scan(
+		&withdrawal.ID, &withdrawal.CreatedAt, &withdrawal.UpdatedAt, &withdrawal.UserID, &withdrawal.ReferenceAccountID,
+		&withdrawal.Amount, &withdrawal.Currency, &withdrawal.Status, &failureReason,
+	); err != nil {
+		return nil, err
+	}
+	withdrawal.FailureReason = failureReason.String
+
+	return &withdrawal, nil
+}
@@ -0,0 +1,257 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+var withdrawalColumns = []string{
+	"id", "created_at", "updated_at", "user_id", "reference_account_id", "amount", "currency", "status", "failure_reason",
+}
+
+func expectWithdrawalEntry(from, fromUserID, to, toUserID string) {
+	mock.ExpectQuery(`INSERT INTO journal_entries`).
+		WithArgs(sqlmock.AnyArg(), "w1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow("j1", "2025-01-01T00:00:00Z"))
+	mock.ExpectQuery(`INSERT INTO ledger_postings`).
+		WithArgs("j1", from, fromUserID, "EUR", int64(-2500)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow("p1", "2025-01-01T00:00:00Z"))
+	mock.ExpectExec(`INSERT INTO outbox`).
+		WithArgs("journal_entry", "j1", "LEDGER_POSTING_CREATED", sqlmock.AnyArg(), 1, "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(`INSERT INTO ledger_postings`).
+		WithArgs("j1", to, toUserID, "EUR", int64(2500)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow("p2", "2025-01-01T00:00:00Z"))
+	mock.ExpectExec(`INSERT INTO outbox`).
+		WithArgs("journal_entry", "j1", "LEDGER_POSTING_CREATED", sqlmock.AnyArg(), 1, "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+}
+
+func expectWithdrawalPreconditions(ownerID string, available int64) {
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status FROM users WHERE id = \$1 FOR UPDATE`).
+		WithArgs("u1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("ACTIVE"))
+	mock.ExpectQuery(`SELECT user_id FROM reference_accounts WHERE id = \$1`).
+		WithArgs("r1").
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(ownerID))
+	if ownerID != "u1" {
+		return
+	}
+	mock.ExpectQuery(`SELECT COALESCE\(SUM\(amount\), 0\) FROM ledger_postings`).
+		WithArgs("u1", "EUR").
+		WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(available))
+}
+
+func newTestWithdrawal() *domain.Withdrawal {
+	return &domain.Withdrawal{UserID: "u1", ReferenceAccountID: "r1", Amount: 2500, Currency: "EUR"}
+}
+
+func Test_CreateWithdrawal_Success(t *testing.T) {
+	setup()
+	defer teardown()
+
+	withdrawals := NewWithdrawalRepository(db)
+
+	expectWithdrawalPreconditions("u1", 10000)
+	mock.ExpectQuery(`INSERT INTO withdrawals`).
+		WithArgs("u1", "r1", int64(2500), "EUR", "PENDING").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).
+			AddRow("w1", "2025-01-01T00:00:00Z", "2025-01-01T00:00:00Z"))
+	expectWithdrawalEntry("USER_AVAILABLE", "u1", "USER_RESERVED", "u1")
+	mock.ExpectExec(`INSERT INTO outbox`).
+		WithArgs("withdrawal", "w1", "WITHDRAWAL_CREATED", sqlmock.AnyArg(), 1, "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	withdrawal, err := withdrawals.CreateWithdrawal(context.Background(), newTestWithdrawal())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "PENDING", withdrawal.Status)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_CreateWithdrawal_Rejections(t *testing.T) {
+	setup()
+	defer teardown()
+
+	withdrawals := NewWithdrawalRepository(db)
+
+	expectWithdrawalPreconditions("u2", 0)
+	mock.ExpectRollback()
+	_, err := withdrawals.CreateWithdrawal(context.Background(), newTestWithdrawal())
+	assert.ErrorIs(t, err, domain.ErrWithdrawalNotAccepted)
+
+	expectWithdrawalPreconditions("u1", 2499)
+	mock.ExpectRollback()
+	_, err = withdrawals.CreateWithdrawal(context.Background(), newTestWithdrawal())
+	assert.ErrorIs(t, err, domain.ErrInsufficientFunds)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_CompleteWithdrawal(t *testing.T) {
+	setup()
+	defer teardown()
+
+	withdrawals := NewWithdrawalRepository(db)
+
+	tests := []struct {
+		status, reason, event string
+		to, toUserID          string
+		complete              func() (*domain.Withdrawal, error)
+	}{
+		{"BOOKED", "", "WITHDRAWAL_BOOKED", "CLEARING", "", func() (*domain.Withdrawal, error) {
+			return withdrawals.BookWithdrawal(context.Background(), "w1")
+		}},
+		{"FAILED", "account closed", "WITHDRAWAL_FAILED", "USER_AVAILABLE", "u1", func() (*domain.Withdrawal, error) {
+			return withdrawals.FailWithdrawal(context.Background(), "w1", "account closed")
+		}},
+	}
+
+	for _, tt := range tests {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT status FROM withdrawals WHERE id = \$1 FOR UPDATE`).
+			WithArgs("w1").
+			WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("PENDING"))
+		mock.ExpectQuery(`UPDATE withdrawals`).
+			WithArgs(tt.status, tt.reason, "w1").
+			WillReturnRows(sqlmock.NewRows(withdrawalColumns).AddRow("w1", "2025-01-01T00:00:00Z",
+				"2025-01-02T00:00:00Z", "u1", "r1", 2500, "EUR", tt.status, tt.reason))
+		expectWithdrawalEntry("USER_RESERVED", "u1", tt.to, tt.toUserID)
+		mock.ExpectExec(`INSERT INTO outbox`).
+			WithArgs("withdrawal", "w1", tt.event, sqlmock.AnyArg(), 1, "").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		withdrawal, err := tt.complete()
+
+		assert.NoError(t, err, tt.status)
+		assert.Equal(t, tt.status, withdrawal.Status)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_CompleteWithdrawal_AlreadyBooked(t *testing.T) {
+	setup()
+	defer teardown()
+
+	withdrawals := NewWithdrawalRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status FROM withdrawals WHERE id = \$1 FOR UPDATE`).
+		WithArgs("w1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("BOOKED"))
+	mock.ExpectRollback()
+
+	_, err := withdrawals.FailWithdrawal(context.Background(), "w1", "returned")
+
+	assert.ErrorIs(t, err, domain.ErrIllegalWithdrawalTransition)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_CreateWithdrawalExport(t *testing.T) {
+	setup()
+	defer teardown()
+
+	withdrawals := NewWithdrawalRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO withdrawal_exports`).
+		WithArgs("WDR-20250601090000", []byte("<xml/>")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow("x1", "2025-06-01T09:00:00Z"))
+	for _, id := range []string{"w1", "w2"} {
+		mock.ExpectExec(`UPDATE withdrawals\s+SET export_id = \$1`).
+			WithArgs("x1", id).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	mock.ExpectCommit()
+
+	export, err := withdrawals.CreateWithdrawalExport(context.Background(), &domain.WithdrawalExport{
+		MessageID:     "WDR-20250601090000",
+		WithdrawalIDs: []string{"w1", "w2"},
+		File:          []byte("<xml/>"),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "x1", export.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_CreateWithdrawalExport_AlreadyExported(t *testing.T) {
+	setup()
+	defer teardown()
+
+	withdrawals := NewWithdrawalRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO withdrawal_exports`).
+		WithArgs("WDR-20250601090000", []byte("<xml/>")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow("x2", "2025-06-01T09:00:00Z"))
+	mock.ExpectExec(`UPDATE withdrawals\s+SET export_id = \$1`).
+		WithArgs("x2", "w1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	_, err := withdrawals.CreateWithdrawalExport(context.Background(), &domain.WithdrawalExport{
+		MessageID:     "WDR-20250601090000",
+		WithdrawalIDs: []string{"w1"},
+		File:          []byte("<xml/>"),
+	})
+
+	assert.ErrorIs(t, err, domain.ErrWithdrawalExportConflict)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_CreateWithdrawalExport_MessageIDUsed(t *testing.T) {
+	setup()
+	defer teardown()
+
+	withdrawals := NewWithdrawalRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO withdrawal_exports`).
+		WithArgs("WDR-20250601090000", []byte("<xml/>")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}))
+	mock.ExpectRollback()
+
+	_, err := withdrawals.CreateWithdrawalExport(context.Background(), &domain.WithdrawalExport{
+		MessageID:     "WDR-20250601090000",
+		WithdrawalIDs: []string{"w1"},
+		File:          []byte("<xml/>"),
+	})
+
+	assert.ErrorIs(t, err, domain.ErrWithdrawalExportConflict)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_GetWithdrawalExport(t *testing.T) {
+	setup()
+	defer teardown()
+
+	withdrawals := NewWithdrawalRepository(db)
+
+	mock.ExpectQuery(`SELECT id, created_at, message_id, file FROM withdrawal_exports WHERE id = \$1`).
+		WithArgs("x1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "message_id", "file"}).
+			AddRow("x1", "2025-06-01T09:00:00Z", "WDR-20250601090000", []byte("<xml/>")))
+	mock.ExpectQuery(`SELECT id FROM withdrawals WHERE export_id = \$1`).
+		WithArgs("x1").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("w1").AddRow("w2"))
+
+	export, err := withdrawals.GetWithdrawalExport(context.Background(), "x1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.WithdrawalExport{
+		ID:            "x1",
+		CreatedAt:     "2025-06-01T09:00:00Z",
+		MessageID:     "WDR-20250601090000",
+		WithdrawalIDs: []string{"w1", "w2"},
+		File:          []byte("<xml/>"),
+	}, export)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
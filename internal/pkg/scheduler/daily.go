@@ -0,0 +1,60 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Task processes the records that are due on the given day and returns how
+// many it processed. It must find its records by their state rather than by the
+// day alone, so that a missed day is caught up by the next run.
+type Task func(ctx context.Context, today time.Time) (int, error)
+
+// DailyJob runs a task for the current UTC day, at startup and then at a fixed
+// interval.
+type DailyJob struct {
+	name     string
+	task     Task
+	interval time.Duration
+	now      func() time.Time
+}
+
+func NewDailyJob(name string, task Task, interval time.Duration) *DailyJob {
+	return &DailyJob{
+		name:     name,
+		task:     task,
+		interval: interval,
+		now:      time.Now,
+	}
+}
+
+// Run runs the task until ctx is cancelled. A run in progress when ctx is
+// cancelled is finished.
+func (j *DailyJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		if n, err := j.RunOnce(context.WithoutCancel(ctx)); err != nil {
+			log.Errorf("%s failed: %v", j.name, err)
+		} else if n > 0 {
+			log.Infof("%s processed %d records", j.name, n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce runs the task for today.
+func (j *DailyJob) RunOnce(ctx context.Context) (int, error) {
+	now := j.now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	return j.task(ctx, today)
+}
@@ -0,0 +1,25 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/util/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_DailyJob_RunOnce(t *testing.T) {
+	guardians := new(mocks.GuardianRepository)
+	j := NewDailyJob("coming of age job", guardians.RecordComingOfAge, 24*time.Hour)
+	j.now = func() time.Time { return date("2025-03-01").Add(23 * time.Hour) }
+
+	guardians.On("RecordComingOfAge", mock.Anything, date("2025-03-01")).Return(2, nil)
+
+	n, err := j.RunOnce(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	guardians.AssertExpectations(t)
+}
@@ -0,0 +1,138 @@
+package scheduler
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/ashwingopalsamy/upvest-api/internal/pkg/repository"
+	log "github.com/sirupsen/logrus"
+)
+
+// Scheduler runs the savings plans that are due. A plan scheduled on a weekend
+// or holiday runs on the next business day of the calendar.
+type Scheduler struct {
+	plans    repository.SavingsPlanRepository
+	calendar *domain.Calendar
+	interval time.Duration
+	now      func() time.Time
+}
+
+func New(plans repository.SavingsPlanRepository, calendar *domain.Calendar, interval time.Duration) *Scheduler {
+	return &Scheduler{
+		plans:    plans,
+		calendar: calendar,
+		interval: interval,
+		now:      time.Now,
+	}
+}
+
+// Run checks for due plans until ctx is cancelled.
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if n, err := s.RunOnce(ctx); err != nil {
+			log.Errorf("savings plan scheduler failed: %v", err)
+		} else if n > 0 {
+			log.Infof("executed %d savings plans", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce executes every plan whose execution date has been reached and returns
+// how many were executed. A plan that missed several dates runs once per call,
+// so it catches up over the following runs. When ctx is cancelled the plan in
+// hand is still recorded and the remaining plans are left for the next run.
+// No plan is executed once today is past the end of the holiday calendar.
+func (s *Scheduler) RunOnce(ctx context.Context) (int, error) {
+	now := s.now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if !s.calendar.Covers(today) {
+		return 0, fmt.Errorf("holiday calendar ends on %s, extend it to execute savings plans",
+			s.calendar.LastDay().Format(domain.DateLayout))
+	}
+
+	due, err := s.plans.GetDueSavingsPlans(ctx, today)
+	if err != nil {
+		return 0, err
+	}
+
+	executed := 0
+	for _, plan := range due {
+		if ctx.Err() != nil {
+			break
+		}
+
+		scheduled, err := time.Parse(domain.DateLayout, plan.NextExecutionDate)
+		if err != nil {
+			log.Errorf("skipping savings plan %s: invalid execution date %q", plan.ID, plan.NextExecutionDate)
+			continue
+		}
+
+		executionDate := s.calendar.NextBusinessDay(scheduled)
+		if executionDate.After(today) {
+			continue
+		}
+
+		_, err = s.plans.RecordExecution(context.WithoutCancel(ctx), plan.ID, scheduled, executionDate)
+		if errors.Is(err, domain.ErrSavingsPlanNotDue) {
+			// Paused, cancelled, run by another scheduler or its user held since
+			// it was listed.
+			log.Warnf("skipping savings plan %s: %v", plan.ID, err)
+			continue
+		} else if err != nil {
+			log.Errorf("failed to execute savings plan %s: %v", plan.ID, err)
+			continue
+		}
+		executed++
+	}
+
+	return executed, nil
+}
+
+// LoadCalendar reads holidays from r, one YYYY-MM-DD date per line. Blank lines
+// and lines starting with # are ignored. The calendar covers the years up to
+// the last one a holiday is listed for.
+func LoadCalendar(r io.Reader) (*domain.Calendar, error) {
+	var holidays []time.Time
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		holiday, err := time.Parse(domain.DateLayout, text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %q is not a YYYY-MM-DD date", line, text)
+		}
+		holidays = append(holidays, holiday)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read calendar: %w", err)
+	}
+	if len(holidays) == 0 {
+		return nil, errors.New("calendar lists no holidays")
+	}
+
+	lastYear := holidays[0].Year()
+	for _, holiday := range holidays[1:] {
+		if holiday.Year() > lastYear {
+			lastYear = holiday.Year()
+		}
+	}
+	return domain.NewCalendarUntil(time.Date(lastYear, time.December, 31, 0, 0, 0, 0, time.UTC), holidays...), nil
+}
@@ -0,0 +1,117 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/ashwingopalsamy/upvest-api/internal/util/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func date(s string) time.Time {
+	d, _ := time.Parse(domain.DateLayout, s)
+	return d
+}
+
+func Test_RunOnce(t *testing.T) {
+	plans := new(mocks.SavingsPlanRepository)
+	calendar := domain.NewCalendar(date("2025-04-18"), date("2025-04-21"))
+	s := New(plans, calendar, time.Hour)
+	s.now = func() time.Time { return date("2025-04-18").Add(9 * time.Hour) } // Good Friday
+
+	plans.On("GetDueSavingsPlans", mock.Anything, date("2025-04-18")).Return([]domain.SavingsPlan{
+		{ID: "weekday", NextExecutionDate: "2025-04-17"},
+		{ID: "holiday", NextExecutionDate: "2025-04-18"},
+		{ID: "cancelled", NextExecutionDate: "2025-04-15"},
+		{ID: "failing", NextExecutionDate: "2025-04-16"},
+	}, nil)
+	plans.On("RecordExecution", mock.Anything, "weekday", date("2025-04-17"), date("2025-04-17")).
+		Return(&domain.SavingsPlanExecution{ID: "e1"}, nil)
+	plans.On("RecordExecution", mock.Anything, "cancelled", date("2025-04-15"), date("2025-04-15")).
+		Return(nil, fmt.Errorf("%w: plan cancelled is CANCELLED", domain.ErrSavingsPlanNotDue))
+	plans.On("RecordExecution", mock.Anything, "failing", date("2025-04-16"), date("2025-04-16")).
+		Return(nil, fmt.Errorf("connection reset"))
+
+	n, err := s.RunOnce(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	plans.AssertExpectations(t)
+	// The plan due on the holiday waits for Tuesday after Easter.
+	plans.AssertNotCalled(t, "RecordExecution", mock.Anything, "holiday", mock.Anything, mock.Anything)
+}
+
+func Test_RunOnce_FinishesPlanInHandWhenCancelled(t *testing.T) {
+	plans := new(mocks.SavingsPlanRepository)
+	s := New(plans, domain.NewCalendar(), time.Hour)
+	s.now = func() time.Time { return date("2025-04-17").Add(9 * time.Hour) }
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	plans.On("GetDueSavingsPlans", mock.Anything, date("2025-04-17")).Return([]domain.SavingsPlan{
+		{ID: "first", NextExecutionDate: "2025-04-16"},
+		{ID: "second", NextExecutionDate: "2025-04-17"},
+	}, nil)
+	plans.On("RecordExecution", mock.Anything, "first", date("2025-04-16"), date("2025-04-16")).
+		Run(func(args mock.Arguments) {
+			cancel()
+			assert.NoError(t, args.Get(0).(context.Context).Err())
+		}).
+		Return(&domain.SavingsPlanExecution{ID: "e1"}, nil)
+
+	n, err := s.RunOnce(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	plans.AssertExpectations(t)
+	plans.AssertNotCalled(t, "RecordExecution", mock.Anything, "second", mock.Anything, mock.Anything)
+}
+
+func Test_RunOnce_RefusesPastEndOfCalendar(t *testing.T) {
+	plans := new(mocks.SavingsPlanRepository)
+	s := New(plans, domain.NewCalendarUntil(date("2026-12-31"), date("2026-12-25")), time.Hour)
+	s.now = func() time.Time { return date("2027-01-01").Add(9 * time.Hour) }
+
+	n, err := s.RunOnce(context.Background())
+
+	assert.EqualError(t, err, "holiday calendar ends on 2026-12-31, extend it to execute savings plans")
+	assert.Equal(t, 0, n)
+	plans.AssertNotCalled(t, "GetDueSavingsPlans", mock.Anything, mock.Anything)
+}
+
+func Test_LoadCalendar(t *testing.T) {
+	calendar, err := LoadCalendar(strings.NewReader("# TARGET2\n2025-04-18\n\n2025-04-21\n"))
+
+	assert.NoError(t, err)
+	assert.False(t, calendar.IsBusinessDay(date("2025-04-21")))
+	assert.True(t, calendar.IsBusinessDay(date("2025-04-22")))
+	assert.Equal(t, date("2025-12-31"), calendar.LastDay())
+
+	_, err = LoadCalendar(strings.NewReader("2025-04-18\n18.04.2025\n"))
+
+	assert.EqualError(t, err, `line 2: "18.04.2025" is not a YYYY-MM-DD date`)
+
+	_, err = LoadCalendar(strings.NewReader("# TARGET2\n"))
+
+	assert.EqualError(t, err, "calendar lists no holidays")
+}
+
+func Test_LoadCalendar_TARGET2(t *testing.T) {
+	file, err := os.Open("../../../schema/calendars/target2.txt")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer file.Close()
+
+	calendar, err := LoadCalendar(file)
+
+	assert.NoError(t, err)
+	assert.True(t, calendar.Covers(date("2027-12-31")))
+	assert.False(t, calendar.IsBusinessDay(date("2027-03-26")), "Good Friday 2027")
+}
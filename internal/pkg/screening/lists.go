@@ -0,0 +1,223 @@
+// Package screening matches users against sanctions and PEP lists.
+package screening
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+)
+
+// List is a sanctions or PEP list, named after the file it was loaded from.
+type List struct {
+	Name    string
+	Entries []Entry
+}
+
+// Entry is a listed person. BirthDates hold full YYYY-MM-DD dates or, where
+// only the year is known, YYYY.
+type Entry struct {
+	ID         string
+	Names      []string
+	BirthDates []string
+	Category   string
+}
+
+var listBirthDateRegex = regexp.MustCompile(`^[0-9]{4}(-[0-9]{2}-[0-9]{2})?$`)
+
+// LoadList reads a list file: the EU consolidated financial sanctions list as
+// .xml, or a .csv file in the format of ReadCSVList.
+func LoadList(path string) (List, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return List{}, err
+	}
+	defer file.Close()
+
+	extension := strings.ToLower(filepath.Ext(path))
+	list := List{Name: strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))}
+	switch extension {
+	case ".xml":
+		list.Entries, err = ReadEUConsolidatedList(file)
+	case ".csv":
+		list.Entries, err = ReadCSVList(file)
+	default:
+		return List{}, fmt.Errorf("%s: unsupported list format %q", path, extension)
+	}
+	if err != nil {
+		return List{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return list, nil
+}
+
+type euSanctionEntity struct {
+	LogicalID   string `xml:"logicalId,attr"`
+	SubjectType struct {
+		Code string `xml:"code,attr"`
+	} `xml:"subjectType"`
+	NameAliases []struct {
+		WholeName string `xml:"wholeName,attr"`
+	} `xml:"nameAlias"`
+	BirthDates []struct {
+		BirthDate string `xml:"birthdate,attr"`
+		Year      string `xml:"year,attr"`
+	} `xml:"birthdate"`
+}
+
+// ReadEUConsolidatedList reads the persons of the EU consolidated financial
+// sanctions list in its XML export format. Entities other than persons are
+// left out.
+func ReadEUConsolidatedList(r io.Reader) ([]Entry, error) {
+	decoder := xml.NewDecoder(r)
+
+	var entries []Entry
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read XML: %w", err)
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "sanctionEntity" {
+			continue
+		}
+		var entity euSanctionEntity
+		if err := decoder.DecodeElement(&entity, &start); err != nil {
+			return nil, fmt.Errorf("failed to read sanctionEntity: %w", err)
+		}
+		if entity.SubjectType.Code != "person" {
+			continue
+		}
+
+		entry := Entry{ID: entity.LogicalID, Category: domain.ScreeningCategorySanctions}
+		for _, alias := range entity.NameAliases {
+			if name := strings.TrimSpace(alias.WholeName); name != "" {
+				entry.Names = append(entry.Names, name)
+			}
+		}
+		for _, birthDate := range entity.BirthDates {
+			if listBirthDateRegex.MatchString(birthDate.BirthDate) {
+				entry.BirthDates = append(entry.BirthDates, birthDate.BirthDate)
+			} else if listBirthDateRegex.MatchString(birthDate.Year) {
+				entry.BirthDates = append(entry.BirthDates, birthDate.Year)
+			}
+		}
+		if entry.ID == "" || len(entry.Names) == 0 {
+			return nil, fmt.Errorf("sanctionEntity %q has no logicalId or name", entity.LogicalID)
+		}
+		entries = append(entries, entry)
+	}
+
+	if len(entries) == 0 {
+		return nil, errors.New("list has no persons")
+	}
+	return entries, nil
+}
+
+var requiredListColumns = []string{"id", "name", "category"}
+
+// ReadCSVList reads a list from CSV with a header row. Columns are matched by
+// name, case-insensitively: id, name and category (SANCTIONS or PEP) are
+// required; aliases and birth_dates are optional and separate several values
+// with semicolons. Unlike reference data, an invalid row fails the whole list.
+func ReadCSVList(r io.Reader) ([]Entry, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("file is empty")
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range requiredListColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("missing required column %q", name)
+		}
+	}
+	reader.FieldsPerRecord = -1
+
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var entries []Entry
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read row: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		entry := Entry{
+			ID:         field(record, "id"),
+			Names:      append([]string{field(record, "name")}, splitValues(field(record, "aliases"))...),
+			BirthDates: splitValues(field(record, "birth_dates")),
+			Category:   strings.ToUpper(field(record, "category")),
+		}
+		if entry.ID == "" || entry.Names[0] == "" {
+			return nil, fmt.Errorf("line %d: id and name are required", line)
+		}
+		if entry.Category != domain.ScreeningCategorySanctions && entry.Category != domain.ScreeningCategoryPEP {
+			return nil, fmt.Errorf("line %d: category must be SANCTIONS or PEP", line)
+		}
+		for _, birthDate := range entry.BirthDates {
+			if !listBirthDateRegex.MatchString(birthDate) {
+				return nil, fmt.Errorf("line %d: birth date %q must be YYYY-MM-DD or YYYY", line, birthDate)
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	if len(entries) == 0 {
+		return nil, errors.New("list has no entries")
+	}
+	return entries, nil
+}
+
+func splitValues(field string) []string {
+	var values []string
+	for _, value := range strings.Split(field, ";") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// LoadScreener loads the lists named by paths, a comma-separated list of files
+// as in SCREENING_LISTS. Empty paths give a screener without lists.
+func LoadScreener(paths string) (*Screener, error) {
+	var lists []List
+	for _, path := range strings.Split(paths, ",") {
+		if path = strings.TrimSpace(path); path == "" {
+			continue
+		}
+		list, err := LoadList(path)
+		if err != nil {
+			return nil, err
+		}
+		lists = append(lists, list)
+	}
+	return NewScreener(lists...), nil
+}
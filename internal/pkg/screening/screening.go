@@ -0,0 +1,191 @@
+package screening
+
+import (
+	"math"
+	"strings"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+)
+
+const (
+	// MinScore is the similarity from which a name is taken as a match.
+	MinScore = 0.9
+	// minPartScore is the similarity every part of the shorter name needs to
+	// reach with a part of the longer one.
+	minPartScore = 0.85
+)
+
+// Screener matches users against the entries of its lists. It is safe for
+// concurrent use.
+type Screener struct {
+	entries []screenedEntry
+	lists   []string
+}
+
+type screenedEntry struct {
+	list  string
+	entry Entry
+	names [][]string
+}
+
+// NewScreener prepares the lists for matching.
+func NewScreener(lists ...List) *Screener {
+	s := &Screener{}
+	for _, list := range lists {
+		s.lists = append(s.lists, list.Name)
+		for _, entry := range list.Entries {
+			screened := screenedEntry{list: list.Name, entry: entry}
+			for _, name := range entry.Names {
+				screened.names = append(screened.names, domain.NameParts(name))
+			}
+			s.entries = append(s.entries, screened)
+		}
+	}
+	return s
+}
+
+// Lists returns the names of the lists users are screened against.
+func (s *Screener) Lists() []string {
+	return s.lists
+}
+
+// Screen returns a pending hit for every entry the user matches. A name matches
+// when it is similar to one of the entry's names, with the parts in any order;
+// the birth date must match as well unless the entry has none. The user's birth
+// name is screened as a last name, too.
+func (s *Screener) Screen(user *domain.User) []domain.ScreeningHit {
+	userNames := []string{user.FirstName + " " + user.LastName}
+	if user.BirthName != "" {
+		userNames = append(userNames, user.FirstName+" "+user.BirthName)
+	}
+
+	var hits []domain.ScreeningHit
+	for _, screened := range s.entries {
+		birthDateMatched := birthDateMatches(screened.entry.BirthDates, user.BirthDate)
+		if len(screened.entry.BirthDates) > 0 && !birthDateMatched {
+			continue
+		}
+
+		var (
+			best        float64
+			bestEntry   string
+			matchedName string
+		)
+		for _, userName := range userNames {
+			userParts := domain.NameParts(userName)
+			for i, entryParts := range screened.names {
+				if score := nameScore(userParts, entryParts); score > best {
+					best, bestEntry, matchedName = score, screened.entry.Names[i], userName
+				}
+			}
+		}
+		if best < MinScore {
+			continue
+		}
+
+		hits = append(hits, domain.ScreeningHit{
+			UserID:           user.ID,
+			ListName:         screened.list,
+			EntryID:          screened.entry.ID,
+			EntryName:        bestEntry,
+			Category:         screened.entry.Category,
+			MatchedName:      matchedName,
+			Score:            math.Round(best*1000) / 1000,
+			BirthDateMatched: birthDateMatched,
+			Status:           domain.ScreeningHitStatusPending,
+		})
+	}
+	return hits
+}
+
+func birthDateMatches(listed []string, birthDate string) bool {
+	for _, date := range listed {
+		if date == birthDate || (len(date) == 4 && strings.HasPrefix(birthDate, date+"-")) {
+			return true
+		}
+	}
+	return false
+}
+
+// nameScore compares two names part by part. Every part of the shorter name is
+// paired with its most similar unpaired part of the longer one, so middle names
+// on either side do not prevent a match. Names of a single part are not
+// compared, as they match too broadly.
+func nameScore(a, b []string) float64 {
+	shorter, longer := a, b
+	if len(shorter) > len(longer) {
+		shorter, longer = longer, shorter
+	}
+	if len(shorter) < 2 {
+		return 0
+	}
+
+	paired := make([]bool, len(longer))
+	total := 0.0
+	for _, part := range shorter {
+		best, bestIndex := 0.0, -1
+		for i, candidate := range longer {
+			if paired[i] {
+				continue
+			}
+			if score := jaroWinkler(part, candidate); score > best {
+				best, bestIndex = score, i
+			}
+		}
+		if best < minPartScore {
+			return 0
+		}
+		paired[bestIndex] = true
+		total += best
+	}
+	return total / float64(len(shorter))
+}
+
+// jaroWinkler returns the Jaro-Winkler similarity of a and b, between 0 and 1.
+func jaroWinkler(a, b string) float64 {
+	s, t := []rune(a), []rune(b)
+	if len(s) == 0 || len(t) == 0 {
+		return 0
+	}
+
+	window := max(len(s), len(t))/2 - 1
+	window = max(window, 0)
+	sMatched := make([]bool, len(s))
+	tMatched := make([]bool, len(t))
+	matches := 0
+	for i := range s {
+		for j := max(0, i-window); j < min(len(t), i+window+1); j++ {
+			if !tMatched[j] && s[i] == t[j] {
+				sMatched[i], tMatched[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	transpositions, j := 0, 0
+	for i := range s {
+		if !sMatched[i] {
+			continue
+		}
+		for !tMatched[j] {
+			j++
+		}
+		if s[i] != t[j] {
+			transpositions++
+		}
+		j++
+	}
+
+	m := float64(matches)
+	jaro := (m/float64(len(s)) + m/float64(len(t)) + (m-float64(transpositions)/2)/m) / 3
+
+	prefix := 0
+	for prefix < min(4, len(s), len(t)) && s[prefix] == t[prefix] {
+		prefix++
+	}
+	return jaro + float64(prefix)*0.1*(1-jaro)
+}
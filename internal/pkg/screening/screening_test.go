@@ -0,0 +1,103 @@
+package screening
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+const testEUList = `<?xml version="1.0" encoding="UTF-8"?>
+<export xmlns="http://eu.europa.ec/fpi/fsd/export" generationDate="2025-04-25T10:00:00.000+02:00">
+  <sanctionEntity logicalId="13" euReferenceNumber="EU.27.28">
+    <subjectType code="person" classificationCode="P"/>
+    <nameAlias firstName="Saddam" lastName="Hussein Al-Tikriti" wholeName="Saddam Hussein Al-Tikriti" logicalId="17"/>
+    <nameAlias wholeName="Abu Ali" logicalId="18"/>
+    <birthdate birthdate="1937-04-28" year="1937" logicalId="19"/>
+  </sanctionEntity>
+  <sanctionEntity logicalId="20">
+    <subjectType code="enterprise" classificationCode="E"/>
+    <nameAlias wholeName="Example Trading LLC" logicalId="21"/>
+  </sanctionEntity>
+  <sanctionEntity logicalId="30">
+    <subjectType code="person" classificationCode="P"/>
+    <nameAlias wholeName="Ivan Petrov" logicalId="31"/>
+    <birthdate year="1965" logicalId="32"/>
+  </sanctionEntity>
+</export>`
+
+const testPEPList = `id,name,aliases,birth_dates,category
+P1,Jürgen Müller,Juergen Mueller,,PEP
+P2,Maria Gonzalez Lopez,,1970-01-15;1971,PEP
+`
+
+func Test_ReadEUConsolidatedList(t *testing.T) {
+	entries, err := ReadEUConsolidatedList(strings.NewReader(testEUList))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Entry{
+		{ID: "13", Names: []string{"Saddam Hussein Al-Tikriti", "Abu Ali"}, BirthDates: []string{"1937-04-28"}, Category: "SANCTIONS"},
+		{ID: "30", Names: []string{"Ivan Petrov"}, BirthDates: []string{"1965"}, Category: "SANCTIONS"},
+	}, entries)
+}
+
+func Test_ReadCSVList(t *testing.T) {
+	entries, err := ReadCSVList(strings.NewReader(testPEPList))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Entry{
+		{ID: "P1", Names: []string{"Jürgen Müller", "Juergen Mueller"}, Category: "PEP"},
+		{ID: "P2", Names: []string{"Maria Gonzalez Lopez"}, BirthDates: []string{"1970-01-15", "1971"}, Category: "PEP"},
+	}, entries)
+
+	_, err = ReadCSVList(strings.NewReader("id,name,category\nP1,Jane Doe,OTHER\n"))
+	assert.EqualError(t, err, "line 2: category must be SANCTIONS or PEP")
+
+	_, err = ReadCSVList(strings.NewReader("id,name,birth_dates,category\nP1,Jane Doe,15.01.1970,PEP\n"))
+	assert.EqualError(t, err, `line 2: birth date "15.01.1970" must be YYYY-MM-DD or YYYY`)
+
+	_, err = ReadCSVList(strings.NewReader("id,name\nP1,Jane Doe\n"))
+	assert.EqualError(t, err, `missing required column "category"`)
+}
+
+func Test_Screen(t *testing.T) {
+	sanctions, _ := ReadEUConsolidatedList(strings.NewReader(testEUList))
+	peps, _ := ReadCSVList(strings.NewReader(testPEPList))
+	screener := NewScreener(List{Name: "eu_consolidated", Entries: sanctions}, List{Name: "peps", Entries: peps})
+
+	tests := []struct {
+		name    string
+		user    domain.User
+		entries []string
+	}{
+		{"exact name and birth date", domain.User{FirstName: "Saddam", LastName: "Hussein Al-Tikriti", BirthDate: "1937-04-28"}, []string{"13"}},
+		{"misspelt name in any order", domain.User{FirstName: "Hussain", LastName: "Saddam", BirthDate: "1937-04-28"}, []string{"13"}},
+		{"other birth date", domain.User{FirstName: "Saddam", LastName: "Hussein", BirthDate: "1980-04-28"}, nil},
+		{"birth year only", domain.User{FirstName: "Ivan", LastName: "Petrov", BirthDate: "1965-11-02"}, []string{"30"}},
+		{"entry without birth date", domain.User{FirstName: "Jurgen", LastName: "Muller", BirthDate: "1990-01-01"}, []string{"P1"}},
+		{"birth name", domain.User{FirstName: "Maria", LastName: "Schmidt", BirthName: "Gonzalez", BirthDate: "1971-06-01"}, []string{"P2"}},
+		{"name variant", domain.User{FirstName: "Ivana", LastName: "Petrova", BirthDate: "1965-11-02"}, []string{"30"}},
+		{"other last name", domain.User{FirstName: "Ivan", LastName: "Popov", BirthDate: "1965-11-02"}, nil},
+		{"alias", domain.User{FirstName: "Ali", LastName: "Abu", BirthDate: "1937-04-28"}, []string{"13"}},
+		{"no match", domain.User{FirstName: "Rob", LastName: "Smith", BirthDate: "1965-11-02"}, nil},
+	}
+
+	for _, tt := range tests {
+		var entries []string
+		for _, hit := range screener.Screen(&tt.user) {
+			entries = append(entries, hit.EntryID)
+			assert.Equal(t, domain.ScreeningHitStatusPending, hit.Status, tt.name)
+			assert.GreaterOrEqual(t, hit.Score, MinScore, tt.name)
+		}
+		assert.Equal(t, tt.entries, entries, tt.name)
+	}
+}
+
+func Test_JaroWinkler(t *testing.T) {
+	assert.InDelta(t, 0.961, jaroWinkler("martha", "marhta"), 0.001)
+	assert.InDelta(t, 0.813, jaroWinkler("dixon", "dicksonx"), 0.001)
+	assert.InDelta(t, 0.840, jaroWinkler("dwayne", "duane"), 0.001)
+	assert.Equal(t, 1.0, jaroWinkler("petrov", "petrov"))
+	assert.Equal(t, 0.0, jaroWinkler("", "petrov"))
+}
@@ -0,0 +1,168 @@
+// Package sepa writes SEPA payment files for the bank.
+package sepa
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+)
+
+const pain001Namespace = "urn:iso:std:iso:20022:tech:xsd:pain.001.001.03"
+
+var ErrNoInstructions = errors.New("no payment instructions to export")
+
+// Debtor is the platform's bank account that payouts are debited from.
+type Debtor struct {
+	Name string
+	IBAN string
+	BIC  string
+}
+
+// Validate checks that the debtor account is configured and well-formed.
+func (d Debtor) Validate() error {
+	if strings.TrimSpace(d.Name) == "" {
+		return errors.New("debtor name is required")
+	}
+	if !domain.IsValidIBAN(d.IBAN) {
+		return errors.New("debtor IBAN must be a valid IBAN")
+	}
+	if !domain.IsValidBIC(d.BIC) {
+		return errors.New("debtor BIC must be a valid BIC")
+	}
+	return nil
+}
+
+type document struct {
+	XMLName    xml.Name   `xml:"Document"`
+	Namespace  string     `xml:"xmlns,attr"`
+	Initiation initiation `xml:"CstmrCdtTrfInitn"`
+}
+
+type initiation struct {
+	GroupHeader groupHeader `xml:"GrpHdr"`
+	PaymentInfo paymentInfo `xml:"PmtInf"`
+}
+
+type groupHeader struct {
+	MessageID       string `xml:"MsgId"`
+	CreatedAt       string `xml:"CreDtTm"`
+	NumberOfTxs     int    `xml:"NbOfTxs"`
+	ControlSum      string `xml:"CtrlSum"`
+	InitiatingParty party  `xml:"InitgPty"`
+}
+
+type paymentInfo struct {
+	PaymentInfoID   string        `xml:"PmtInfId"`
+	PaymentMethod   string        `xml:"PmtMtd"`
+	NumberOfTxs     int           `xml:"NbOfTxs"`
+	ControlSum      string        `xml:"CtrlSum"`
+	ServiceLevel    string        `xml:"PmtTpInf>SvcLvl>Cd"`
+	ExecutionDate   string        `xml:"ReqdExctnDt"`
+	Debtor          party         `xml:"Dbtr"`
+	DebtorIBAN      string        `xml:"DbtrAcct>Id>IBAN"`
+	DebtorBIC       string        `xml:"DbtrAgt>FinInstnId>BIC"`
+	ChargeBearer    string        `xml:"ChrgBr"`
+	CreditTransfers []transaction `xml:"CdtTrfTxInf"`
+}
+
+type party struct {
+	Name string `xml:"Nm"`
+}
+
+type transaction struct {
+	EndToEndID   string `xml:"PmtId>EndToEndId"`
+	Amount       amount `xml:"Amt>InstdAmt"`
+	CreditorBIC  string `xml:"CdtrAgt>FinInstnId>BIC"`
+	Creditor     party  `xml:"Cdtr"`
+	CreditorIBAN string `xml:"CdtrAcct>Id>IBAN"`
+	Remittance   string `xml:"RmtInf>Ustrd"`
+}
+
+type amount struct {
+	Currency string `xml:"Ccy,attr"`
+	Value    string `xml:",chardata"`
+}
+
+// WriteCreditTransfers writes the instructions as one pain.001.001.03 SEPA
+// credit transfer initiation, to be executed on the day it is created. The
+// withdrawal IDs serve as end-to-end IDs, so bank statements can be matched
+// back to withdrawals.
+func WriteCreditTransfers(w io.Writer, debtor Debtor, createdAt time.Time, instructions []domain.PaymentInstruction) error {
+	if len(instructions) == 0 {
+		return ErrNoInstructions
+	}
+
+	var total int64
+	transactions := make([]transaction, 0, len(instructions))
+	for _, instruction := range instructions {
+		withdrawal := instruction.Withdrawal
+		if withdrawal.Currency != "EUR" {
+			return fmt.Errorf("withdrawal %s: SEPA credit transfers must be in EUR", withdrawal.ID)
+		}
+		total += withdrawal.Amount
+		transactions = append(transactions, transaction{
+			EndToEndID:   endToEndID(withdrawal.ID),
+			Amount:       amount{Currency: withdrawal.Currency, Value: domain.FormatMinorUnits(withdrawal.Amount, withdrawal.Currency)},
+			CreditorBIC:  instruction.Creditor.BIC,
+			Creditor:     party{Name: instruction.Creditor.AccountHolder},
+			CreditorIBAN: instruction.Creditor.IBAN,
+			Remittance:   "Withdrawal " + withdrawal.ID,
+		})
+	}
+
+	createdAt = createdAt.UTC()
+	messageID := MessageID(createdAt)
+	controlSum := domain.FormatMinorUnits(total, "EUR")
+
+	doc := document{
+		Namespace: pain001Namespace,
+		Initiation: initiation{
+			GroupHeader: groupHeader{
+				MessageID:       messageID,
+				CreatedAt:       createdAt.Format("2006-01-02T15:04:05"),
+				NumberOfTxs:     len(transactions),
+				ControlSum:      controlSum,
+				InitiatingParty: party{Name: debtor.Name},
+			},
+			PaymentInfo: paymentInfo{
+				PaymentInfoID:   messageID,
+				PaymentMethod:   "TRF",
+				NumberOfTxs:     len(transactions),
+				ControlSum:      controlSum,
+				ServiceLevel:    "SEPA",
+				ExecutionDate:   createdAt.Format("2006-01-02"),
+				Debtor:          party{Name: debtor.Name},
+				DebtorIBAN:      debtor.IBAN,
+				DebtorBIC:       debtor.BIC,
+				ChargeBearer:    "SLEV",
+				CreditTransfers: transactions,
+			},
+		},
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return fmt.Errorf("failed to encode pain.001: %w", err)
+	}
+	return encoder.Close()
+}
+
+// MessageID returns the message ID of the file created at createdAt. Banks
+// reject a message ID they have seen before.
+func MessageID(createdAt time.Time) string {
+	return "WDR-" + createdAt.UTC().Format("20060102150405")
+}
+
+// endToEndID fits a UUID into the 35 characters allowed for end-to-end IDs.
+func endToEndID(id string) string {
+	return strings.ReplaceAll(id, "-", "")
+}
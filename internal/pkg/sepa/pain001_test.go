@@ -0,0 +1,54 @@
+package sepa
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/ashwingopalsamy/upvest-api/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testDebtor = Debtor{Name: "Upvest GmbH", IBAN: "DE89370400440532013000", BIC: "COBADEFFXXX"}
+
+func Test_WriteCreditTransfers(t *testing.T) {
+	instructions := []domain.PaymentInstruction{
+		{
+			Withdrawal: domain.Withdrawal{ID: "9f1c2d3e-4b5a-6978-8a9b-0c1d2e3f4a5b", Amount: 1050, Currency: "EUR"},
+			Creditor:   domain.ReferenceAccount{IBAN: "FR1420041010050500013M02606", BIC: "PSSTFRPPXXX", AccountHolder: "Rob Smith"},
+		},
+		{
+			Withdrawal: domain.Withdrawal{ID: "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d", Amount: 5, Currency: "EUR"},
+			Creditor:   domain.ReferenceAccount{IBAN: "AT611904300234573201", BIC: "BKAUATWW", AccountHolder: "Jane Doe"},
+		},
+	}
+
+	var buf bytes.Buffer
+	err := WriteCreditTransfers(&buf, testDebtor, time.Date(2025, 3, 2, 10, 15, 0, 0, time.UTC), instructions)
+	require.NoError(t, err)
+
+	var doc document
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &doc))
+
+	header := doc.Initiation.GroupHeader
+	assert.Equal(t, "WDR-20250302101500", header.MessageID)
+	assert.Equal(t, 2, header.NumberOfTxs)
+	assert.Equal(t, "10.55", header.ControlSum)
+
+	payment := doc.Initiation.PaymentInfo
+	assert.Equal(t, "2025-03-02", payment.ExecutionDate)
+	assert.Equal(t, "DE89370400440532013000", payment.DebtorIBAN)
+	require.Len(t, payment.CreditTransfers, 2)
+	assert.Equal(t, "9f1c2d3e4b5a69788a9b0c1d2e3f4a5b", payment.CreditTransfers[0].EndToEndID)
+	assert.Equal(t, amount{Currency: "EUR", Value: "10.50"}, payment.CreditTransfers[0].Amount)
+	assert.Equal(t, "Jane Doe", payment.CreditTransfers[1].Creditor.Name)
+	assert.Contains(t, buf.String(), `xmlns="urn:iso:std:iso:20022:tech:xsd:pain.001.001.03"`)
+}
+
+func Test_WriteCreditTransfers_Empty(t *testing.T) {
+	err := WriteCreditTransfers(&bytes.Buffer{}, testDebtor, time.Now(), nil)
+
+	assert.ErrorIs(t, err, ErrNoInstructions)
+}
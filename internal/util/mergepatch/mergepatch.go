@@ -0,0 +1,48 @@
+package mergepatch
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+var ErrInvalidPatch = errors.New("merge patch must be a valid JSON document")
+
+// Apply applies a JSON Merge Patch (RFC 7386) to the original document and
+// returns the merged document.
+func Apply(original, patch []byte) ([]byte, error) {
+	var patchDoc interface{}
+	if err := json.Unmarshal(patch, &patchDoc); err != nil {
+		return nil, ErrInvalidPatch
+	}
+
+	var originalDoc interface{}
+	if len(original) > 0 {
+		if err := json.Unmarshal(original, &originalDoc); err != nil {
+			return nil, err
+		}
+	}
+
+	return json.Marshal(merge(originalDoc, patchDoc))
+}
+
+func merge(target, patch interface{}) interface{} {
+	patchObj, ok := patch.(map[string]interface{})
+	if !ok {
+		// A non-object patch replaces the target entirely.
+		return patch
+	}
+
+	targetObj, ok := target.(map[string]interface{})
+	if !ok {
+		targetObj = map[string]interface{}{}
+	}
+
+	for key, value := range patchObj {
+		if value == nil {
+			delete(targetObj, key)
+			continue
+		}
+		targetObj[key] = merge(targetObj[key], value)
+	}
+	return targetObj
+}
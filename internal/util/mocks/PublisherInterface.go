@@ -5,6 +5,7 @@ package mocks
 import (
 	context "context"
 
+	event "github.com/ashwingopalsamy/upvest-api/internal/event"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -31,17 +32,17 @@ func (_m *PublisherInterface) Close() error {
 	return r0
 }
 
-// Publish provides a mock function with given fields: ctx, key, value
-func (_m *PublisherInterface) Publish(ctx context.Context, key []byte, value []byte) error {
-	ret := _m.Called(ctx, key, value)
+// Publish provides a mock function with given fields: ctx, key, envelope
+func (_m *PublisherInterface) Publish(ctx context.Context, key []byte, envelope event.Envelope) error {
+	ret := _m.Called(ctx, key, envelope)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Publish")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, []byte, []byte) error); ok {
-		r0 = rf(ctx, key, value)
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, event.Envelope) error); ok {
+		r0 = rf(ctx, key, envelope)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -7,6 +7,8 @@ import (
 
 	domain "github.com/ashwingopalsamy/upvest-api/internal/domain"
 	mock "github.com/stretchr/testify/mock"
+
+	repository "github.com/ashwingopalsamy/upvest-api/internal/pkg/repository"
 )
 
 // UserRepository is an autogenerated mock type for the UserRepository type
@@ -14,9 +16,27 @@ type UserRepository struct {
 	mock.Mock
 }
 
-// CreateUser provides a mock function with given fields: ctx, user
-func (_m *UserRepository) CreateUser(ctx context.Context, user *domain.User) (*domain.User, error) {
-	ret := _m.Called(ctx, user)
+// CompleteOffboarding provides a mock function with given fields: ctx, userID
+func (_m *UserRepository) CompleteOffboarding(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompleteOffboarding")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateUser provides a mock function with given fields: ctx, user, hits
+func (_m *UserRepository) CreateUser(ctx context.Context, user *domain.User, hits []domain.ScreeningHit) (*domain.User, error) {
+	ret := _m.Called(ctx, user, hits)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateUser")
@@ -24,19 +44,19 @@ func (_m *UserRepository) CreateUser(ctx context.Context, user *domain.User) (*d
 
 	var r0 *domain.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *domain.User) (*domain.User, error)); ok {
-		return rf(ctx, user)
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.User, []domain.ScreeningHit) (*domain.User, error)); ok {
+		return rf(ctx, user, hits)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *domain.User) *domain.User); ok {
-		r0 = rf(ctx, user)
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.User, []domain.ScreeningHit) *domain.User); ok {
+		r0 = rf(ctx, user, hits)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *domain.User) error); ok {
-		r1 = rf(ctx, user)
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.User, []domain.ScreeningHit) error); ok {
+		r1 = rf(ctx, user, hits)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -44,34 +64,41 @@ func (_m *UserRepository) CreateUser(ctx context.Context, user *domain.User) (*d
 	return r0, r1
 }
 
-// GetAllUsers provides a mock function with given fields: ctx, offset, limit, sort, order
-func (_m *UserRepository) GetAllUsers(ctx context.Context, offset int, limit int, sort string, order string) ([]domain.User, error) {
-	ret := _m.Called(ctx, offset, limit, sort, order)
+// GetAllUsers provides a mock function with given fields: ctx, filter, page
+func (_m *UserRepository) GetAllUsers(ctx context.Context, filter repository.UserFilter, page repository.Page) ([]domain.User, repository.PageInfo, error) {
+	ret := _m.Called(ctx, filter, page)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAllUsers")
 	}
 
 	var r0 []domain.User
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int, int, string, string) ([]domain.User, error)); ok {
-		return rf(ctx, offset, limit, sort, order)
+	var r1 repository.PageInfo
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.UserFilter, repository.Page) ([]domain.User, repository.PageInfo, error)); ok {
+		return rf(ctx, filter, page)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int, int, string, string) []domain.User); ok {
-		r0 = rf(ctx, offset, limit, sort, order)
+	if rf, ok := ret.Get(0).(func(context.Context, repository.UserFilter, repository.Page) []domain.User); ok {
+		r0 = rf(ctx, filter, page)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]domain.User)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int, int, string, string) error); ok {
-		r1 = rf(ctx, offset, limit, sort, order)
+	if rf, ok := ret.Get(1).(func(context.Context, repository.UserFilter, repository.Page) repository.PageInfo); ok {
+		r1 = rf(ctx, filter, page)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(repository.PageInfo)
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(context.Context, repository.UserFilter, repository.Page) error); ok {
+		r2 = rf(ctx, filter, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
 // GetUserByID provides a mock function with given fields: ctx, userID
@@ -122,6 +149,36 @@ func (_m *UserRepository) OffboardUser(ctx context.Context, userID string) error
 	return r0
 }
 
+// UpdateUser provides a mock function with given fields: ctx, user, fields, hits
+func (_m *UserRepository) UpdateUser(ctx context.Context, user *domain.User, fields []string, hits []domain.ScreeningHit) (*domain.User, error) {
+	ret := _m.Called(ctx, user, fields, hits)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUser")
+	}
+
+	var r0 *domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.User, []string, []domain.ScreeningHit) (*domain.User, error)); ok {
+		return rf(ctx, user, fields, hits)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.User, []string, []domain.ScreeningHit) *domain.User); ok {
+		r0 = rf(ctx, user, fields, hits)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.User, []string, []domain.ScreeningHit) error); ok {
+		r1 = rf(ctx, user, fields, hits)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewUserRepository creates a new instance of UserRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserRepository(t interface {
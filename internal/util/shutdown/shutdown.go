@@ -0,0 +1,54 @@
+// Package shutdown lets services stop on SIGINT or SIGTERM without dropping the
+// work they have in flight.
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// OnSignal returns a context that is cancelled on SIGINT or SIGTERM. A second
+// signal after stop was called terminates the process as usual.
+func OnSignal() (ctx context.Context, stop context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+}
+
+// Serve runs server on its address until ctx is cancelled. It then stops
+// accepting connections and waits up to drainTimeout for the requests in
+// flight to complete. Serve returns early if the server fails.
+func Serve(ctx context.Context, server *http.Server, drainTimeout time.Duration) error {
+	listener, err := net.Listen("tcp", server.Addr)
+	if err != nil {
+		return err
+	}
+	return serve(ctx, server, listener, drainTimeout)
+}
+
+func serve(ctx context.Context, server *http.Server, listener net.Listener, drainTimeout time.Duration) error {
+	errs := make(chan error, 1)
+	go func() {
+		errs <- server.Serve(listener)
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	drainCtx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+	defer cancel()
+	if err := server.Shutdown(drainCtx); err != nil {
+		return fmt.Errorf("failed to drain HTTP server: %w", err)
+	}
+	if err := <-errs; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
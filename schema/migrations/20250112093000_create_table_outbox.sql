@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE outbox (
+   id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+   seq BIGSERIAL NOT NULL,
+   created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+   aggregate_type VARCHAR(50) NOT NULL,
+   aggregate_id VARCHAR(100) NOT NULL,
+   event_type VARCHAR(100) NOT NULL,
+   payload JSONB NOT NULL,
+   attempts INT NOT NULL DEFAULT 0,
+   next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+   last_error TEXT,
+   sent_at TIMESTAMP
+);
+
+-- Only unsent rows are ever scanned by the relay.
+CREATE INDEX idx_outbox_pending ON outbox (next_attempt_at, seq) WHERE sent_at IS NULL;
+CREATE INDEX idx_outbox_aggregate_pending ON outbox (aggregate_id, seq) WHERE sent_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS outbox;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Keyset pagination orders by (sort column, id).
+CREATE INDEX idx_users_created_at_id ON users (created_at, id);
+CREATE INDEX idx_users_updated_at_id ON users (updated_at, id);
+
+CREATE INDEX idx_users_status ON users (status);
+CREATE INDEX idx_users_birth_country ON users (birth_country);
+-- Supports containment queries such as nationalities @> '["DE"]'.
+CREATE INDEX idx_users_nationalities ON users USING GIN (nationalities jsonb_path_ops);
+-- Supports case-insensitive substring search on the full name.
+CREATE INDEX idx_users_full_name_trgm ON users USING GIN ((first_name || ' ' || last_name) gin_trgm_ops);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_users_full_name_trgm;
+DROP INDEX IF EXISTS idx_users_nationalities;
+DROP INDEX IF EXISTS idx_users_birth_country;
+DROP INDEX IF EXISTS idx_users_status;
+DROP INDEX IF EXISTS idx_users_updated_at_id;
+DROP INDEX IF EXISTS idx_users_created_at_id;
+-- +goose StatementEnd
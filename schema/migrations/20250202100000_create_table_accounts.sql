@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE account_groups (
+   id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+   created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+   updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+   user_id UUID NOT NULL REFERENCES users (id),
+   status VARCHAR(20) CHECK (status IN ('ACTIVE', 'CLOSED')) DEFAULT 'ACTIVE'
+);
+
+-- A user has at most one open account group.
+CREATE UNIQUE INDEX ux_account_groups_user_active ON account_groups (user_id) WHERE status = 'ACTIVE';
+
+CREATE TABLE accounts (
+   id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+   created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+   updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+   user_id UUID NOT NULL REFERENCES users (id),
+   account_group_id UUID NOT NULL REFERENCES account_groups (id),
+   type VARCHAR(20) NOT NULL CHECK (type IN ('TRADING', 'RETIREMENT')),
+   name VARCHAR(100) DEFAULT '',
+   status VARCHAR(20) CHECK (status IN ('ACTIVE', 'CLOSED')) DEFAULT 'ACTIVE',
+   closed_at TIMESTAMP
+);
+
+CREATE INDEX idx_accounts_user_id ON accounts (user_id, status);
+CREATE INDEX idx_accounts_created_at_id ON accounts (created_at, id);
+CREATE INDEX idx_accounts_updated_at_id ON accounts (updated_at, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS accounts;
+DROP TABLE IF EXISTS account_groups;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE instruments (
+   isin CHAR(12) PRIMARY KEY,
+   created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+   updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+   wkn CHAR(6),
+   name VARCHAR(255) NOT NULL,
+   type VARCHAR(20) NOT NULL CHECK (type IN ('EQUITY', 'ETF', 'FUND', 'BOND')),
+   currency CHAR(3) NOT NULL,
+   trading_status VARCHAR(20) NOT NULL CHECK (trading_status IN ('ACTIVE', 'SUSPENDED', 'DELISTED')) DEFAULT 'ACTIVE'
+);
+
+CREATE UNIQUE INDEX ux_instruments_wkn ON instruments (wkn) WHERE wkn IS NOT NULL;
+CREATE INDEX idx_instruments_created_at_isin ON instruments (created_at, isin);
+CREATE INDEX idx_instruments_updated_at_isin ON instruments (updated_at, isin);
+-- Supports case-insensitive substring search on the name; pg_trgm is created
+-- by the users filter migration.
+CREATE INDEX idx_instruments_name_trgm ON instruments USING GIN (name gin_trgm_ops);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS instruments;
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE orders (
+   id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+   created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+   updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+   user_id UUID NOT NULL REFERENCES users (id),
+   account_id UUID NOT NULL REFERENCES accounts (id),
+   isin CHAR(12) NOT NULL REFERENCES instruments (isin),
+   side VARCHAR(4) NOT NULL CHECK (side IN ('BUY', 'SELL')),
+   type VARCHAR(10) NOT NULL CHECK (type IN ('MARKET', 'LIMIT')),
+   quantity NUMERIC CHECK (quantity > 0),
+   cash_amount NUMERIC CHECK (cash_amount > 0),
+   limit_price NUMERIC CHECK (limit_price > 0),
+   currency CHAR(3) NOT NULL,
+   filled_quantity NUMERIC NOT NULL DEFAULT 0,
+   status VARCHAR(20) NOT NULL CHECK (status IN ('NEW', 'PROCESSING', 'PARTIALLY_FILLED', 'FILLED', 'CANCELLED', 'REJECTED')) DEFAULT 'NEW',
+   CHECK ((quantity IS NULL) <> (cash_amount IS NULL)),
+   CHECK ((type = 'LIMIT') = (limit_price IS NOT NULL))
+);
+
+CREATE INDEX idx_orders_user_id_status ON orders (user_id, status);
+CREATE INDEX idx_orders_account_id ON orders (account_id);
+CREATE INDEX idx_orders_created_at_id ON orders (created_at, id);
+CREATE INDEX idx_orders_updated_at_id ON orders (updated_at, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS orders;
+-- +goose StatementEnd
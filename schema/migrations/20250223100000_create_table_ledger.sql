@@ -0,0 +1,60 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE journal_entries (
+   id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+   created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+   description VARCHAR(200) NOT NULL,
+   reference VARCHAR(100)
+);
+
+CREATE TABLE ledger_postings (
+   id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+   journal_entry_id UUID NOT NULL REFERENCES journal_entries (id),
+   created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+   ledger_account VARCHAR(30) NOT NULL CHECK (ledger_account IN ('USER_AVAILABLE', 'USER_RESERVED', 'CLEARING')),
+   user_id UUID REFERENCES users (id),
+   currency CHAR(3) NOT NULL,
+   amount BIGINT NOT NULL CHECK (amount <> 0),
+   CHECK ((ledger_account LIKE 'USER\_%') = (user_id IS NOT NULL))
+);
+
+CREATE INDEX idx_ledger_postings_journal_entry_id ON ledger_postings (journal_entry_id);
+CREATE INDEX idx_ledger_postings_user_id_currency ON ledger_postings (user_id, currency) WHERE user_id IS NOT NULL;
+
+-- The ledger is append-only: corrections are booked as new journal entries.
+CREATE FUNCTION ledger_reject_change() RETURNS TRIGGER AS $$
+BEGIN
+   RAISE EXCEPTION '% is append-only', TG_TABLE_NAME;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER journal_entries_append_only BEFORE UPDATE OR DELETE ON journal_entries
+   FOR EACH ROW EXECUTE FUNCTION ledger_reject_change();
+CREATE TRIGGER ledger_postings_append_only BEFORE UPDATE OR DELETE ON ledger_postings
+   FOR EACH ROW EXECUTE FUNCTION ledger_reject_change();
+
+-- Every journal entry must balance per currency once its transaction commits.
+CREATE FUNCTION ledger_check_balanced() RETURNS TRIGGER AS $$
+BEGIN
+   IF EXISTS (SELECT 1 FROM ledger_postings
+              WHERE journal_entry_id = NEW.journal_entry_id
+              GROUP BY currency
+              HAVING SUM(amount) <> 0) THEN
+      RAISE EXCEPTION 'journal entry % is not balanced', NEW.journal_entry_id;
+   END IF;
+   RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE CONSTRAINT TRIGGER ledger_postings_balanced AFTER INSERT ON ledger_postings
+   DEFERRABLE INITIALLY DEFERRED
+   FOR EACH ROW EXECUTE FUNCTION ledger_check_balanced();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS ledger_postings;
+DROP TABLE IF EXISTS journal_entries;
+DROP FUNCTION IF EXISTS ledger_check_balanced();
+DROP FUNCTION IF EXISTS ledger_reject_change();
+-- +goose StatementEnd
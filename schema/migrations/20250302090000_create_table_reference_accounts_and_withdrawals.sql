@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE reference_accounts (
+   id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+   created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+   updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+   user_id UUID NOT NULL REFERENCES users (id),
+   iban VARCHAR(34) NOT NULL,
+   bic VARCHAR(11) NOT NULL,
+   account_holder VARCHAR(70) NOT NULL,
+   UNIQUE (user_id, iban)
+);
+
+CREATE TABLE withdrawals (
+   id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+   created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+   updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+   user_id UUID NOT NULL REFERENCES users (id),
+   reference_account_id UUID NOT NULL REFERENCES reference_accounts (id),
+   amount BIGINT NOT NULL CHECK (amount > 0),
+   currency CHAR(3) NOT NULL,
+   status VARCHAR(10) NOT NULL CHECK (status IN ('PENDING', 'BOOKED', 'FAILED')) DEFAULT 'PENDING',
+   failure_reason VARCHAR(200)
+);
+
+CREATE INDEX idx_withdrawals_user_id ON withdrawals (user_id);
+CREATE INDEX idx_withdrawals_pending ON withdrawals (created_at) WHERE status = 'PENDING';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS withdrawals;
+DROP TABLE IF EXISTS reference_accounts;
+-- +goose StatementEnd
@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE savings_plans (
+   id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+   created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+   updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+   user_id UUID NOT NULL REFERENCES users (id),
+   account_id UUID NOT NULL REFERENCES accounts (id),
+   isin CHAR(12) NOT NULL REFERENCES instruments (isin),
+   amount NUMERIC NOT NULL CHECK (amount > 0),
+   currency CHAR(3) NOT NULL,
+   cadence VARCHAR(11) NOT NULL CHECK (cadence IN ('MONTHLY', 'QUARTERLY', 'HALF_YEARLY', 'YEARLY')),
+   start_date DATE NOT NULL,
+   next_execution_date DATE NOT NULL,
+   status VARCHAR(9) NOT NULL CHECK (status IN ('ACTIVE', 'PAUSED', 'CANCELLED')) DEFAULT 'ACTIVE'
+);
+
+CREATE INDEX idx_savings_plans_user_id ON savings_plans (user_id);
+CREATE INDEX idx_savings_plans_due ON savings_plans (next_execution_date) WHERE status = 'ACTIVE';
+
+-- One row per run of a plan. The unique scheduled date keeps a plan from
+-- running twice for the same date when schedulers overlap.
+CREATE TABLE savings_plan_executions (
+   id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+   created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+   savings_plan_id UUID NOT NULL REFERENCES savings_plans (id),
+   scheduled_date DATE NOT NULL,
+   execution_date DATE NOT NULL,
+   UNIQUE (savings_plan_id, scheduled_date)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS savings_plan_executions;
+DROP TABLE IF EXISTS savings_plans;
+-- +goose StatementEnd
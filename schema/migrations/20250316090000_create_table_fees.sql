@@ -0,0 +1,55 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE fee_schedules (
+   id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+   created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+   updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+   segment VARCHAR(30) NOT NULL,
+   fee_type VARCHAR(10) NOT NULL CHECK (fee_type IN ('TRADE', 'CUSTODY')),
+   method VARCHAR(15) NOT NULL CHECK (method IN ('FLAT', 'PERCENTAGE', 'BASIS_POINTS')),
+   currency CHAR(3) NOT NULL,
+   flat_amount BIGINT NOT NULL DEFAULT 0 CHECK (flat_amount >= 0),
+   rate NUMERIC CHECK (rate > 0),
+   min_amount BIGINT NOT NULL DEFAULT 0 CHECK (min_amount >= 0),
+   max_amount BIGINT NOT NULL DEFAULT 0 CHECK (max_amount >= 0),
+   UNIQUE (segment, fee_type, currency)
+);
+
+-- Users without a row here belong to the DEFAULT segment.
+CREATE TABLE user_fee_segments (
+   user_id UUID PRIMARY KEY REFERENCES users (id),
+   updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+   segment VARCHAR(30) NOT NULL
+);
+
+CREATE TABLE fees (
+   id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+   created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+   user_id UUID NOT NULL REFERENCES users (id),
+   fee_schedule_id UUID NOT NULL REFERENCES fee_schedules (id),
+   fee_type VARCHAR(10) NOT NULL,
+   reference VARCHAR(100) NOT NULL,
+   basis_amount BIGINT NOT NULL CHECK (basis_amount >= 0),
+   amount BIGINT NOT NULL CHECK (amount >= 0),
+   currency CHAR(3) NOT NULL,
+   journal_entry_id UUID REFERENCES journal_entries (id),
+   UNIQUE (user_id, fee_type, reference)
+);
+
+CREATE INDEX idx_fees_user_id_created_at ON fees (user_id, created_at);
+
+ALTER TABLE ledger_postings DROP CONSTRAINT ledger_postings_ledger_account_check;
+ALTER TABLE ledger_postings ADD CONSTRAINT ledger_postings_ledger_account_check
+   CHECK (ledger_account IN ('USER_AVAILABLE', 'USER_RESERVED', 'CLEARING', 'FEE_INCOME'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE ledger_postings DROP CONSTRAINT ledger_postings_ledger_account_check;
+ALTER TABLE ledger_postings ADD CONSTRAINT ledger_postings_ledger_account_check
+   CHECK (ledger_account IN ('USER_AVAILABLE', 'USER_RESERVED', 'CLEARING'));
+
+DROP TABLE IF EXISTS fees;
+DROP TABLE IF EXISTS user_fee_segments;
+DROP TABLE IF EXISTS fee_schedules;
+-- +goose StatementEnd
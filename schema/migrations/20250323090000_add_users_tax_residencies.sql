@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- A list of {"country": ..., "tin": ...} objects, one per country.
+ALTER TABLE users ADD COLUMN tax_residencies JSONB NOT NULL DEFAULT '[]';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users DROP COLUMN IF EXISTS tax_residencies;
+-- +goose StatementEnd
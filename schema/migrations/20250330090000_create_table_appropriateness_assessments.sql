@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Assessments are append-only; the latest one of a user is the current one.
+CREATE TABLE appropriateness_assessments (
+   id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+   created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+   user_id UUID NOT NULL REFERENCES users (id),
+   questionnaire_version INTEGER NOT NULL,
+   answers JSONB NOT NULL,
+   scores JSONB NOT NULL,
+   allowed_categories JSONB NOT NULL
+);
+
+CREATE INDEX idx_appropriateness_assessments_user_id ON appropriateness_assessments (user_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS appropriateness_assessments;
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE businesses (
+   id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+   created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+   updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+   name VARCHAR(200) NOT NULL,
+   registration_number VARCHAR(50) NOT NULL,
+   legal_form VARCHAR(20) NOT NULL,
+   registered_address JSONB NOT NULL,
+   lei CHAR(20) NOT NULL UNIQUE
+);
+
+-- Natural-person users acting for or owning a business.
+CREATE TABLE business_persons (
+   business_id UUID NOT NULL REFERENCES businesses (id),
+   user_id UUID NOT NULL REFERENCES users (id),
+   role VARCHAR(30) NOT NULL CHECK (role IN ('UBO', 'LEGAL_REPRESENTATIVE')),
+   ownership_percentage NUMERIC CHECK (ownership_percentage > 0 AND ownership_percentage <= 100),
+   PRIMARY KEY (business_id, user_id, role),
+   CHECK ((role = 'UBO') = (ownership_percentage IS NOT NULL))
+);
+
+CREATE INDEX idx_business_persons_user_id ON business_persons (user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS business_persons;
+DROP TABLE IF EXISTS businesses;
+-- +goose StatementEnd
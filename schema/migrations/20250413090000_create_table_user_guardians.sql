@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Set while a user is under 18; the scheduler clears it when the user comes of age.
+ALTER TABLE users ADD COLUMN minor BOOLEAN NOT NULL DEFAULT FALSE;
+UPDATE users SET minor = TRUE WHERE birth_date > CURRENT_DATE - INTERVAL '18 years';
+
+CREATE INDEX idx_users_minor_birth_date ON users (birth_date) WHERE minor;
+
+CREATE TABLE user_guardians (
+   user_id UUID NOT NULL REFERENCES users (id),
+   guardian_id UUID NOT NULL REFERENCES users (id) CHECK (guardian_id <> user_id),
+   created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+   ended_at TIMESTAMP,
+   PRIMARY KEY (user_id, guardian_id)
+);
+
+CREATE INDEX idx_user_guardians_guardian_id ON user_guardians (guardian_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS user_guardians;
+DROP INDEX IF EXISTS idx_users_minor_birth_date;
+ALTER TABLE users DROP COLUMN IF EXISTS minor;
+-- +goose StatementEnd
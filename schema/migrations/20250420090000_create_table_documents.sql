@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+-- KYC documents of users. The files are kept in blob storage under storage_key.
+CREATE TABLE documents (
+   id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+   created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+   user_id UUID NOT NULL REFERENCES users (id),
+   type VARCHAR(20) NOT NULL CHECK (type IN ('ID_CARD', 'PASSPORT', 'PROOF_OF_ADDRESS')),
+   issuing_country CHAR(2) NOT NULL,
+   expiry_date DATE,
+   file_name VARCHAR(255) NOT NULL,
+   content_type VARCHAR(100) NOT NULL,
+   size BIGINT NOT NULL CHECK (size > 0),
+   sha256 CHAR(64) NOT NULL,
+   storage_key VARCHAR(255) NOT NULL UNIQUE,
+   status VARCHAR(20) NOT NULL DEFAULT 'VALID' CHECK (status IN ('VALID', 'EXPIRED')),
+   CHECK (type = 'PROOF_OF_ADDRESS' OR expiry_date IS NOT NULL)
+);
+
+CREATE INDEX idx_documents_user_id ON documents (user_id, created_at);
+CREATE INDEX idx_documents_valid_expiry_date ON documents (expiry_date) WHERE status = 'VALID';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS documents;
+-- +goose StatementEnd
@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users DROP CONSTRAINT users_status_check;
+ALTER TABLE users ADD CONSTRAINT users_status_check
+   CHECK (status IN ('ACTIVE', 'INACTIVE', 'HELD', 'OFFBOARDING', 'OFFBOARDED'));
+
+-- Matches of users against sanctions and PEP lists, kept once per list entry so
+-- that a dismissed hit is not raised again by a re-screen.
+CREATE TABLE screening_hits (
+   id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+   created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+   user_id UUID NOT NULL REFERENCES users (id),
+   list_name VARCHAR(100) NOT NULL,
+   entry_id VARCHAR(100) NOT NULL,
+   entry_name VARCHAR(300) NOT NULL,
+   category VARCHAR(20) NOT NULL CHECK (category IN ('SANCTIONS', 'PEP')),
+   matched_name VARCHAR(300) NOT NULL,
+   score NUMERIC(4, 3) NOT NULL,
+   birth_date_matched BOOLEAN NOT NULL,
+   status VARCHAR(20) NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'CONFIRMED', 'DISMISSED')),
+   reviewed_at TIMESTAMP,
+   UNIQUE (user_id, list_name, entry_id)
+);
+
+CREATE INDEX idx_screening_hits_pending ON screening_hits (created_at) WHERE status = 'PENDING';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS screening_hits;
+
+UPDATE users SET status = 'INACTIVE' WHERE status = 'HELD';
+ALTER TABLE users DROP CONSTRAINT users_status_check;
+ALTER TABLE users ADD CONSTRAINT users_status_check
+   CHECK (status IN ('ACTIVE', 'INACTIVE', 'OFFBOARDING', 'OFFBOARDED'));
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Kafka messages a consumer group has processed, so that redeliveries after a
+-- crash or a rebalance are skipped.
+CREATE TABLE processed_messages (
+   consumer_group VARCHAR(255) NOT NULL,
+   topic VARCHAR(255) NOT NULL,
+   partition INT NOT NULL,
+   "offset" BIGINT NOT NULL,
+   processed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+   PRIMARY KEY (consumer_group, topic, partition, "offset")
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS processed_messages;
+-- +goose StatementEnd
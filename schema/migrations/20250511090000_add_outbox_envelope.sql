@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The relay wraps every payload in an event envelope. Rows written before carry
+-- version 1 of their event type.
+ALTER TABLE outbox ADD COLUMN schema_version INT NOT NULL DEFAULT 1;
+ALTER TABLE outbox ADD COLUMN correlation_id VARCHAR(100);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE outbox DROP COLUMN IF EXISTS correlation_id;
+ALTER TABLE outbox DROP COLUMN IF EXISTS schema_version;
+-- +goose StatementEnd
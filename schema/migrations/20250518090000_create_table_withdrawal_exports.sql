@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Every pain.001 file handed to the bank is kept, and the withdrawals it pays
+-- out point to it, so that no withdrawal is exported twice.
+CREATE TABLE withdrawal_exports (
+   id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+   created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+   message_id VARCHAR(35) NOT NULL UNIQUE,
+   file BYTEA NOT NULL
+);
+
+ALTER TABLE withdrawals ADD COLUMN export_id UUID REFERENCES withdrawal_exports (id);
+
+DROP INDEX IF EXISTS idx_withdrawals_pending;
+CREATE INDEX idx_withdrawals_pending ON withdrawals (created_at) WHERE status = 'PENDING' AND export_id IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_withdrawals_pending;
+CREATE INDEX idx_withdrawals_pending ON withdrawals (created_at) WHERE status = 'PENDING';
+ALTER TABLE withdrawals DROP COLUMN IF EXISTS export_id;
+DROP TABLE IF EXISTS withdrawal_exports;
+-- +goose StatementEnd